@@ -28,6 +28,8 @@ const (
 	ActivityPipelineTaskStatementUpdate ActivityType = "bb.pipeline.task.statement.update"
 	// ActivityPipelineTaskEarliestAllowedTimeUpdate is the type for updating pipeline task the earliest allowed time.
 	ActivityPipelineTaskEarliestAllowedTimeUpdate ActivityType = "bb.pipeline.task.general.earliest-allowed-time.update"
+	// ActivityPipelineTaskApprove is the type for approving a step of the pipeline task approval workflow.
+	ActivityPipelineTaskApprove ActivityType = "bb.pipeline.task.approve"
 
 	// Member related
 
@@ -39,6 +41,10 @@ const (
 	ActivityMemberActivate ActivityType = "bb.member.activate"
 	// ActivityMemberDeactivate is the type for deactivating members.
 	ActivityMemberDeactivate ActivityType = "bb.member.deactivate"
+	// ActivityMemberAPITokenCreate is the type for creating API tokens of members.
+	ActivityMemberAPITokenCreate ActivityType = "bb.member.api-token.create"
+	// ActivityMemberAPITokenRevoke is the type for revoking API tokens of members.
+	ActivityMemberAPITokenRevoke ActivityType = "bb.member.api-token.revoke"
 
 	// Project related
 
@@ -83,6 +89,10 @@ func (e ActivityType) String() string {
 		return "bb.member.activate"
 	case ActivityMemberDeactivate:
 		return "bb.member.deactivate"
+	case ActivityMemberAPITokenCreate:
+		return "bb.member.api-token.create"
+	case ActivityMemberAPITokenRevoke:
+		return "bb.member.api-token.revoke"
 	case ActivityProjectRepositoryPush:
 		return "bb.project.repository.push"
 	case ActivityProjectDatabaseTransfer:
@@ -196,6 +206,21 @@ type ActivityPipelineTaskEarliestAllowedTimeUpdatePayload struct {
 	TaskName  string `json:"taskName"`
 }
 
+// ActivityPipelineTaskApprovePayload is the API message payloads for approving pipeline tasks.
+type ActivityPipelineTaskApprovePayload struct {
+	TaskID int `json:"taskId"`
+	// Step is the 1-based approval step.
+	Step      int `json:"step"`
+	StepCount int `json:"stepCount"`
+	// ApprovalCount is the approvals the step has got, and RequiredApprovalCount is the approvals the step requires.
+	ApprovalCount         int    `json:"approvalCount"`
+	RequiredApprovalCount int    `json:"requiredApprovalCount"`
+	Approver              string `json:"approver"`
+	// Used by inbox to display info without paying the join cost
+	IssueName string `json:"issueName"`
+	TaskName  string `json:"taskName"`
+}
+
 // ActivityMemberCreatePayload is the API message payloads for creating members.
 type ActivityMemberCreatePayload struct {
 	PrincipalID    int          `json:"principalId"`
@@ -222,6 +247,16 @@ type ActivityMemberActivateDeactivatePayload struct {
 	Role           Role   `json:"role"`
 }
 
+// ActivityMemberAPITokenCreateRevokePayload is the API message payloads for creating or revoking API tokens of members.
+type ActivityMemberAPITokenCreateRevokePayload struct {
+	PrincipalID    int           `json:"principalId"`
+	PrincipalName  string        `json:"principalName"`
+	PrincipalEmail string        `json:"principalEmail"`
+	TokenID        int           `json:"tokenId"`
+	TokenName      string        `json:"tokenName"`
+	Scope          APITokenScope `json:"scope"`
+}
+
 // ActivityProjectRepositoryPushPayload is the API message payloads for pushing repositories.
 type ActivityProjectRepositoryPushPayload struct {
 	VCSPushEvent vcs.PushEvent `json:"pushEvent"`
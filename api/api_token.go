@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// APITokenPrefix is the prefix of the API tokens, which tells the API tokens from the other bearer tokens.
+const APITokenPrefix = "bbp_"
+
+// APITokenScope is the scope of an API token.
+type APITokenScope string
+
+const (
+	// APITokenScopeReadOnly is the API token scope for READ_ONLY, which only allows the GET requests.
+	APITokenScopeReadOnly APITokenScope = "READ_ONLY"
+	// APITokenScopeReadWrite is the API token scope for READ_WRITE, which allows all the requests permitted by the role of the principal.
+	APITokenScopeReadWrite APITokenScope = "READ_WRITE"
+)
+
+func (e APITokenScope) String() string {
+	switch e {
+	case APITokenScopeReadOnly:
+		return "READ_ONLY"
+	case APITokenScopeReadWrite:
+		return "READ_WRITE"
+	}
+	return ""
+}
+
+// APIToken is the API message for an API token.
+type APIToken struct {
+	ID int `jsonapi:"primary,apiToken"`
+
+	// Standard fields
+	RowStatus RowStatus `jsonapi:"attr,rowStatus"`
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	PrincipalID int `jsonapi:"attr,principalId"`
+
+	// Domain specific fields
+	Name  string        `jsonapi:"attr,name"`
+	Scope APITokenScope `jsonapi:"attr,scope"`
+	// Do not return to the client
+	TokenHash string
+	// Token is the plaintext token, which is only returned once when the token is created.
+	Token string `jsonapi:"attr,token,omitempty"`
+	// ExpiresTs is 0 if the token never expires.
+	ExpiresTs  int64 `jsonapi:"attr,expiresTs"`
+	LastUsedTs int64 `jsonapi:"attr,lastUsedTs"`
+}
+
+// APITokenCreate is the API message for creating an API token.
+type APITokenCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	PrincipalID int
+
+	// Domain specific fields
+	Name      string        `jsonapi:"attr,name"`
+	Scope     APITokenScope `jsonapi:"attr,scope"`
+	ExpiresTs int64         `jsonapi:"attr,expiresTs"`
+	TokenHash string
+}
+
+// APITokenFind is the API message for finding API tokens.
+type APITokenFind struct {
+	ID *int
+
+	// Standard fields
+	RowStatus *RowStatus
+
+	// Related fields
+	PrincipalID *int
+
+	// Domain specific fields
+	TokenHash *string
+}
+
+func (find *APITokenFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// APITokenPatch is the API message for patching an API token.
+type APITokenPatch struct {
+	ID int
+
+	// Standard fields
+	RowStatus *string
+	// Value is assigned from the jwt subject field passed by the client.
+	UpdaterID int
+
+	// Domain specific fields
+	LastUsedTs *int64
+}
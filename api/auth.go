@@ -38,3 +38,36 @@ type SignUp struct {
 	Email    string `jsonapi:"attr,email"`
 	Password string `jsonapi:"attr,password"`
 }
+
+// OIDCConfig is the config of the OpenID Connect auth provider, which is stored as the JSON value of SettingAuthOIDC.
+// The OpenID Connect auth provider is disabled if the setting value is empty.
+type OIDCConfig struct {
+	// DiscoveryURL is either the issuer URL or the URL of the discovery document.
+	DiscoveryURL string   `json:"discoveryUrl"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	Scopes       []string `json:"scopes"`
+	// EmailClaim is the claim carrying the email of the user, defaults to "email".
+	EmailClaim string `json:"emailClaim"`
+	// NameClaim is the claim carrying the name of the user, defaults to "name".
+	NameClaim string `json:"nameClaim"`
+	// GroupClaim is the claim carrying the groups of the user.
+	GroupClaim string `json:"groupClaim"`
+	// GroupRoleMapping maps the groups to the role of the user provisioned on the first login.
+	// If the user is in several mapped groups, the highest role wins.
+	GroupRoleMapping map[string]Role `json:"groupRoleMapping"`
+}
+
+// OIDCAuthProvider is the OpenID Connect auth provider returned to the client to start the authorization code flow.
+type OIDCAuthProvider struct {
+	AuthorizationEndpoint string `jsonapi:"attr,authorizationEndpoint"`
+	ClientID              string `jsonapi:"attr,clientId"`
+	Scope                 string `jsonapi:"attr,scope"`
+}
+
+// OIDCLogin is the API message for logins via OpenID Connect.
+type OIDCLogin struct {
+	// Code is the authorization code granted by the identity provider,
+	// we will use this code to exchange the ID token.
+	Code string `jsonapi:"attr,code"`
+}
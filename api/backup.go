@@ -57,7 +57,7 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 or S3-compatible storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
 	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
@@ -105,6 +105,7 @@ type Backup struct {
 	ID int `jsonapi:"primary,backup"`
 
 	// Standard fields
+	RowStatus RowStatus `jsonapi:"attr,rowStatus"`
 	CreatorID int
 	Creator   *Principal `jsonapi:"relation,creator"`
 	CreatedTs int64      `jsonapi:"attr,createdTs"`
@@ -166,6 +167,9 @@ type BackupCreate struct {
 type BackupFind struct {
 	ID *int
 
+	// Standard fields
+	RowStatus *RowStatus
+
 	// Related fields
 	DatabaseID *int
 
@@ -189,11 +193,12 @@ type BackupPatch struct {
 	// Standard fields
 	// Value is assigned from the jwt subject field passed by the client.
 	UpdaterID int
+	RowStatus *string
 
 	// Domain specific fields
-	Status  string
-	Comment string
-	Payload string
+	Status  *string
+	Comment *string
+	Payload *string
 }
 
 // BackupSetting is the backup setting for a database.
@@ -75,6 +75,13 @@ type DataSource struct {
 	Username string         `jsonapi:"attr,username"`
 	// Do not return the password to client
 	Password string
+	// SSH tunnel through the bastion host, empty SSHHost means connecting the database directly.
+	SSHHost string `jsonapi:"attr,sshHost"`
+	SSHPort string `jsonapi:"attr,sshPort"`
+	SSHUser string `jsonapi:"attr,sshUser"`
+	// Do not return the SSH password and private key to client
+	SSHPassword   string
+	SSHPrivateKey string
 }
 
 // DataSourceCreate is the API message for creating a data source.
@@ -92,6 +99,12 @@ type DataSourceCreate struct {
 	Type     DataSourceType `jsonapi:"attr,type"`
 	Username string         `jsonapi:"attr,username"`
 	Password string         `jsonapi:"attr,password"`
+	// SSH tunnel through the bastion host, empty SSHHost means connecting the database directly.
+	SSHHost       string `jsonapi:"attr,sshHost"`
+	SSHPort       string `jsonapi:"attr,sshPort"`
+	SSHUser       string `jsonapi:"attr,sshUser"`
+	SSHPassword   string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey string `jsonapi:"attr,sshPrivateKey"`
 	// If true, syncs the schema after creating the data source. The client
 	// may set to false if the target data source's instance contains too many databases
 	// to avoid the request timeout.
@@ -130,6 +143,12 @@ type DataSourcePatch struct {
 	Username         *string `jsonapi:"attr,username"`
 	Password         *string `jsonapi:"attr,password"`
 	UseEmptyPassword *bool   `jsonapi:"attr,useEmptyPassword"`
+	// Set SSHHost to empty to connect the database directly.
+	SSHHost       *string `jsonapi:"attr,sshHost"`
+	SSHPort       *string `jsonapi:"attr,sshPort"`
+	SSHUser       *string `jsonapi:"attr,sshUser"`
+	SSHPassword   *string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey *string `jsonapi:"attr,sshPrivateKey"`
 	// If true, syncs the schema after patching the data source. The client
 	// may set to false if the target data source's instance contains too many databases
 	// to avoid the request timeout.
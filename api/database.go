@@ -135,3 +135,14 @@ type DatabasePatch struct {
 	SyncStatus           *SyncStatus
 	LastSuccessfulSyncTs *int64
 }
+
+// DatabaseSchemaDiff is the API message for the schema diff between two databases.
+type DatabaseSchemaDiff struct {
+	// Related fields
+	SourceDatabaseID int `jsonapi:"attr,sourceDatabaseId"`
+	TargetDatabaseID int `jsonapi:"attr,targetDatabaseId"`
+
+	// Domain specific fields
+	// Statement is the DDL script that migrates the source database schema to the target database schema.
+	Statement string `jsonapi:"attr,statement"`
+}
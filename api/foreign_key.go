@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// ForeignKey is the API message for a foreign key.
+type ForeignKey struct {
+	ID int `jsonapi:"primary,foreignKey"`
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64 `json:"createdTs"`
+	UpdaterID int
+	UpdatedTs int64 `json:"updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	TableID    int
+
+	// Domain specific fields
+	Name                 string   `json:"name"`
+	ColumnList           []string `json:"columnList"`
+	ReferencedSchema     string   `json:"referencedSchema"`
+	ReferencedTable      string   `json:"referencedTable"`
+	ReferencedColumnList []string `json:"referencedColumnList"`
+	OnDelete             string   `json:"onDelete"`
+	OnUpdate             string   `json:"onUpdate"`
+}
+
+// ForeignKeyCreate is the API message for creating a foreign key.
+type ForeignKeyCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	DatabaseID int
+	TableID    int
+
+	// Domain specific fields
+	Name                 string
+	ColumnList           []string
+	ReferencedSchema     string
+	ReferencedTable      string
+	ReferencedColumnList []string
+	OnDelete             string
+	OnUpdate             string
+}
+
+// ForeignKeyFind is the API message for finding foreign keys.
+type ForeignKeyFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+	TableID    *int
+
+	// Domain specific fields
+	Name *string
+}
+
+func (find *ForeignKeyFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
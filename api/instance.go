@@ -36,6 +36,8 @@ type Instance struct {
 	Username      string  `jsonapi:"attr,username"`
 	// Password is not returned to the client
 	Password string
+	// Capabilities is the features supported by the engine, which is only returned when fetching a single instance.
+	Capabilities *db.Capabilities `jsonapi:"attr,capabilities,omitempty"`
 }
 
 // InstanceCreate is the API message for creating an instance.
@@ -55,6 +57,12 @@ type InstanceCreate struct {
 	Port         string  `jsonapi:"attr,port"`
 	Username     string  `jsonapi:"attr,username"`
 	Password     string  `jsonapi:"attr,password"`
+	// SSH tunnel through the bastion host for the admin data source, empty SSHHost means connecting the instance directly.
+	SSHHost       string `jsonapi:"attr,sshHost"`
+	SSHPort       string `jsonapi:"attr,sshPort"`
+	SSHUser       string `jsonapi:"attr,sshUser"`
+	SSHPassword   string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey string `jsonapi:"attr,sshPrivateKey"`
 	// If true, syncs the schema after adding the instance. The client
 	// may set to false if the target instance contains too many databases
 	// to avoid the request timeout.
@@ -147,6 +147,9 @@ type UpdateSchemaDetail struct {
 	Statement string `json:"statement"`
 	// EarliestAllowedTs the earliest execution time of the change at system local Unix timestamp in seconds.
 	EarliestAllowedTs int64 `jsonapi:"attr,earliestAllowedTs"`
+	// Idempotent is true if the data update statement can be applied more than once.
+	// It's only applicable to the data update, and allows the task retry policy to retry the failed task.
+	Idempotent bool `json:"idempotent"`
 }
 
 // UpdateSchemaContext is the issue create context for updating database schema.
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maintenanceWindowDateFormat is the format of the blackout dates.
+	maintenanceWindowDateFormat = "2006-01-02"
+	// maintenanceWindowMaxDuration is the maximum duration of a maintenance window.
+	maintenanceWindowMaxDuration = 7 * 24 * time.Hour
+	// maintenanceWindowSearchRange is how far we search for the next maintenance window.
+	maintenanceWindowSearchRange = 366 * 24 * time.Hour
+)
+
+// windowSchedule is the parsed cron expression of a maintenance window, each field is the bit set of the allowed values.
+type windowSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// dayOfMonthAny and dayOfWeekAny are true if the fields start with "*", e.g. "*" and "*/2".
+	// Like cron, a day matches either field if both are restricted.
+	dayOfMonthAny bool
+	dayOfWeekAny  bool
+}
+
+// parseWindowSchedule parses the cron expression "minute hour day-of-month month day-of-week".
+// Each field is "*" or a list of values, ranges and steps, e.g. "1,3-5,*/10". Sunday is 0 or 7 in the day-of-week field.
+func parseWindowSchedule(schedule string) (*windowSchedule, error) {
+	fieldList := strings.Fields(schedule)
+	if len(fieldList) != 5 {
+		return nil, fmt.Errorf("schedule %q should have 5 fields", schedule)
+	}
+	bounds := []struct {
+		name     string
+		min, max int
+	}{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day-of-month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12},
+		{name: "day-of-week", min: 0, max: 7},
+	}
+	var bitsList []uint64
+	for i, field := range fieldList {
+		bits, err := parseScheduleField(field, bounds[i].min, bounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field of schedule %q: %w", bounds[i].name, schedule, err)
+		}
+		bitsList = append(bitsList, bits)
+	}
+	dayOfWeek := bitsList[4]
+	if dayOfWeek&(1<<7) != 0 {
+		dayOfWeek |= 1
+	}
+	return &windowSchedule{
+		minute:        bitsList[0],
+		hour:          bitsList[1],
+		dayOfMonth:    bitsList[2],
+		month:         bitsList[3],
+		dayOfWeek:     dayOfWeek,
+		dayOfMonthAny: strings.HasPrefix(fieldList[2], "*"),
+		dayOfWeekAny:  strings.HasPrefix(fieldList[4], "*"),
+	}, nil
+}
+
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			rangePart, step = part[:i], s
+		}
+		start, end := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			v, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start, end = v, v
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				// "a/n" means from a to the max.
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value %q out of range [%d, %d]", part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// match returns true if the schedule starts a window at the minute of t in UTC.
+func (s *windowSchedule) match(t time.Time) bool {
+	t = t.UTC()
+	return s.matchDay(t) && s.hour&(1<<uint(t.Hour())) != 0 && s.minute&(1<<uint(t.Minute())) != 0
+}
+
+// matchDay returns true if the schedule may start a window on the day of t in UTC.
+func (s *windowSchedule) matchDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthAny || s.dayOfWeekAny {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// next returns the earliest window start at or after t and before the limit.
+// It skips the whole day or hour if the fields can't match.
+func (s *windowSchedule) next(t, limit time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// prev returns the latest window start at or before t and after the limit.
+// It skips the whole day or hour if the fields can't match.
+func (s *windowSchedule) prev(t, limit time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute)
+	for t.After(limit) {
+		switch {
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Minute)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(-time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parsedMaintenanceWindow is a maintenance window with the parsed schedule.
+type parsedMaintenanceWindow struct {
+	schedule *windowSchedule
+	duration time.Duration
+}
+
+// parse parses the window schedules once for the policy.
+func (mw *MaintenanceWindowPolicy) parse() ([]*parsedMaintenanceWindow, error) {
+	if mw.parsedWindowList != nil || len(mw.WindowList) == 0 {
+		return mw.parsedWindowList, nil
+	}
+	var parsedWindowList []*parsedMaintenanceWindow
+	for _, window := range mw.WindowList {
+		schedule, err := parseWindowSchedule(window.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		parsedWindowList = append(parsedWindowList, &parsedMaintenanceWindow{
+			schedule: schedule,
+			duration: time.Duration(window.DurationMinutes) * time.Minute,
+		})
+	}
+	mw.parsedWindowList = parsedWindowList
+	return parsedWindowList, nil
+}
+
+// Validate validates the maintenance window policy.
+func (mw *MaintenanceWindowPolicy) Validate() error {
+	for _, window := range mw.WindowList {
+		if _, err := parseWindowSchedule(window.Schedule); err != nil {
+			return err
+		}
+		duration := time.Duration(window.DurationMinutes) * time.Minute
+		if duration <= 0 || duration > maintenanceWindowMaxDuration {
+			return fmt.Errorf("window duration should be between 1 minute and %v, got %d minutes", maintenanceWindowMaxDuration, window.DurationMinutes)
+		}
+	}
+	for _, date := range mw.BlackoutDateList {
+		if _, err := time.Parse(maintenanceWindowDateFormat, date); err != nil {
+			return fmt.Errorf("invalid blackout date %q, should be in the YYYY-MM-DD format", date)
+		}
+	}
+	return nil
+}
+
+// IsOpen returns true if the tasks can start running at t, and the end of the current window if any.
+func (mw *MaintenanceWindowPolicy) IsOpen(t time.Time) (bool, time.Time, error) {
+	if len(mw.WindowList) == 0 {
+		return true, time.Time{}, nil
+	}
+	windowList, err := mw.parse()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	open, end := mw.isOpen(windowList, t.UTC().Truncate(time.Minute))
+	return open, end, nil
+}
+
+func (mw *MaintenanceWindowPolicy) isOpen(windowList []*parsedMaintenanceWindow, t time.Time) (bool, time.Time) {
+	for _, date := range mw.BlackoutDateList {
+		if date == t.Format(maintenanceWindowDateFormat) {
+			return false, time.Time{}
+		}
+	}
+	open := false
+	var end time.Time
+	for _, window := range windowList {
+		// Find the latest window start within the duration before t.
+		if start, ok := window.schedule.prev(t, t.Add(-window.duration)); ok {
+			open = true
+			if start.Add(window.duration).After(end) {
+				end = start.Add(window.duration)
+			}
+		}
+	}
+	return open, end
+}
+
+// NextWindow returns the earliest time from t when the tasks can start running, and the end of that window.
+// It returns false if there is no window in the next year.
+func (mw *MaintenanceWindowPolicy) NextWindow(t time.Time) (time.Time, time.Time, bool, error) {
+	t = t.UTC().Truncate(time.Minute)
+	if len(mw.WindowList) == 0 {
+		return t, time.Time{}, true, nil
+	}
+	windowList, err := mw.parse()
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	// The tasks can start running either at t, at a window start, or at a midnight after a blackout date.
+	limit := t.Add(maintenanceWindowSearchRange)
+	for candidate := t; candidate.Before(limit); {
+		if open, end := mw.isOpen(windowList, candidate); open {
+			return candidate, end, true, nil
+		}
+		next := time.Date(candidate.Year(), candidate.Month(), candidate.Day()+1, 0, 0, 0, 0, time.UTC)
+		for _, window := range windowList {
+			if start, ok := window.schedule.next(candidate.Add(time.Minute), next); ok {
+				next = start
+			}
+		}
+		candidate = next
+	}
+	return time.Time{}, time.Time{}, false, nil
+}
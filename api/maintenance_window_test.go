@@ -0,0 +1,204 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindowPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *MaintenanceWindowPolicy
+		errPart string
+	}{
+		{
+			"empty",
+			&MaintenanceWindowPolicy{},
+			"",
+		},
+		{
+			"valid",
+			&MaintenanceWindowPolicy{
+				WindowList: []*MaintenanceWindow{
+					{Schedule: "0 2 * * 2,4", DurationMinutes: 120},
+					{Schedule: "*/30 0-6/2 1,15 * 7", DurationMinutes: 10},
+				},
+				BlackoutDateList: []string{"2022-12-25"},
+			},
+			"",
+		},
+		{
+			"missingField",
+			&MaintenanceWindowPolicy{
+				WindowList: []*MaintenanceWindow{{Schedule: "0 2 * *", DurationMinutes: 120}},
+			},
+			"should have 5 fields",
+		},
+		{
+			"outOfRange",
+			&MaintenanceWindowPolicy{
+				WindowList: []*MaintenanceWindow{{Schedule: "0 24 * * *", DurationMinutes: 120}},
+			},
+			"invalid hour field",
+		},
+		{
+			"invalidDuration",
+			&MaintenanceWindowPolicy{
+				WindowList: []*MaintenanceWindow{{Schedule: "0 2 * * *", DurationMinutes: 0}},
+			},
+			"window duration",
+		},
+		{
+			"invalidBlackoutDate",
+			&MaintenanceWindowPolicy{
+				BlackoutDateList: []string{"12/25/2022"},
+			},
+			"invalid blackout date",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Validate()
+			if test.errPart == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.errPart)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowPolicyNextWindow(t *testing.T) {
+	// Every Tuesday and Thursday from 02:00 to 04:00 UTC, except 2022-06-09.
+	policy := &MaintenanceWindowPolicy{
+		WindowList:       []*MaintenanceWindow{{Schedule: "0 2 * * 2,4", DurationMinutes: 120}},
+		BlackoutDateList: []string{"2022-06-09"},
+	}
+	date := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return v
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantOpen  bool
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			"beforeWindow",
+			date("2022-06-07 01:30"),
+			false,
+			date("2022-06-07 02:00"),
+			date("2022-06-07 04:00"),
+		},
+		{
+			"withinWindow",
+			date("2022-06-07 03:15"),
+			true,
+			date("2022-06-07 03:15"),
+			date("2022-06-07 04:00"),
+		},
+		{
+			"windowEnd",
+			date("2022-06-07 04:00"),
+			false,
+			date("2022-06-14 02:00"),
+			date("2022-06-14 04:00"),
+		},
+		{
+			// 2022-06-09 is a blackout date, so the next window is the next Tuesday.
+			"skipBlackoutDate",
+			date("2022-06-08 12:00"),
+			false,
+			date("2022-06-14 02:00"),
+			date("2022-06-14 04:00"),
+		},
+		{
+			"nonUTC",
+			date("2022-06-07 02:30").In(time.FixedZone("UTC+8", 8*60*60)),
+			true,
+			date("2022-06-07 02:30"),
+			date("2022-06-07 04:00"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			open, _, err := policy.IsOpen(test.now)
+			require.NoError(t, err)
+			require.Equal(t, test.wantOpen, open)
+
+			start, end, ok, err := policy.NextWindow(test.now)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, test.wantStart, start)
+			require.Equal(t, test.wantEnd, end)
+		})
+	}
+}
+
+func TestMaintenanceWindowPolicyBlackoutMidnight(t *testing.T) {
+	// A daily window from 23:00 to 01:00 UTC is closed during the blackout date, and reopens at the next midnight.
+	policy := &MaintenanceWindowPolicy{
+		WindowList:       []*MaintenanceWindow{{Schedule: "0 23 * * *", DurationMinutes: 120}},
+		BlackoutDateList: []string{"2022-06-07"},
+	}
+	now := time.Date(2022, 6, 7, 0, 30, 0, 0, time.UTC)
+	open, _, err := policy.IsOpen(now)
+	require.NoError(t, err)
+	require.False(t, open)
+
+	start, end, ok, err := policy.NextWindow(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2022, 6, 8, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2022, 6, 8, 1, 0, 0, 0, time.UTC), end)
+}
+
+func TestMaintenanceWindowPolicyDayStep(t *testing.T) {
+	// Like cron, the day-of-week step "*/2" doesn't restrict the days by itself,
+	// so the window starts on the 1st of the months falling on Sunday, Tuesday, Thursday or Saturday.
+	policy := &MaintenanceWindowPolicy{
+		WindowList: []*MaintenanceWindow{{Schedule: "0 2 1 * */2", DurationMinutes: 60}},
+	}
+	// 2022-06-02 is a Thursday but not the 1st.
+	open, _, err := policy.IsOpen(time.Date(2022, 6, 2, 2, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, open)
+
+	// 2022-06-01 is a Wednesday, and 2022-09-01 is a Thursday.
+	start, end, ok, err := policy.NextWindow(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2022, 9, 1, 2, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2022, 9, 1, 3, 0, 0, 0, time.UTC), end)
+}
+
+func TestMaintenanceWindowPolicyRareWindow(t *testing.T) {
+	// The window only starts on leap days.
+	policy := &MaintenanceWindowPolicy{
+		WindowList: []*MaintenanceWindow{{Schedule: "0 0 29 2 *", DurationMinutes: 7 * 24 * 60}},
+	}
+	start, end, ok, err := policy.NextWindow(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), end)
+
+	open, end, err := policy.IsOpen(time.Date(2024, 3, 6, 23, 59, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, open)
+	require.Equal(t, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), end)
+
+	// There is no window in the next year.
+	_, _, ok, err = policy.NextWindow(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -91,7 +91,7 @@ const (
 
 	// Feature3rdPartyAuth allows user to authenticate (login) and authorize (sync project member)
 	//
-	// Currently, we only support GitLab EE/CE auth.
+	// Currently, we support GitLab EE/CE auth and OpenID Connect auth.
 	Feature3rdPartyAuth FeatureType = "bb.feature.3rd-party-auth"
 
 	// Branding
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/plugin/advisor"
 )
 
@@ -13,6 +14,9 @@ type PolicyType string
 // PipelineApprovalValue is value for approval policy.
 type PipelineApprovalValue string
 
+// ApprovalStepType is the type of the approvers of an approval step.
+type ApprovalStepType string
+
 // BackupPlanPolicySchedule is value for backup plan policy.
 type BackupPlanPolicySchedule string
 
@@ -26,12 +30,29 @@ const (
 	PolicyTypeBackupPlan PolicyType = "bb.policy.backup-plan"
 	// PolicyTypeSchemaReview is the schema review policy type.
 	PolicyTypeSchemaReview PolicyType = "bb.policy.schema-review"
+	// PolicyTypeBackupStorage is the backup storage policy type.
+	PolicyTypeBackupStorage PolicyType = "bb.policy.backup-storage"
+	// PolicyTypeAffectedRowLimit is the affected row limit policy type.
+	PolicyTypeAffectedRowLimit PolicyType = "bb.policy.affected-row-limit"
+	// PolicyTypeMaintenanceWindow is the maintenance window policy type.
+	PolicyTypeMaintenanceWindow PolicyType = "bb.policy.maintenance-window"
+	// PolicyTypeTaskConcurrency is the task concurrency policy type.
+	PolicyTypeTaskConcurrency PolicyType = "bb.policy.task-concurrency"
+	// PolicyTypeTaskRetry is the task retry policy type.
+	PolicyTypeTaskRetry PolicyType = "bb.policy.task-retry"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
 	// PipelineApprovalValueManualAlways means the pipeline should be manually approved by user to proceed.
 	PipelineApprovalValueManualAlways PipelineApprovalValue = "MANUAL_APPROVAL_ALWAYS"
 
+	// ApprovalStepTypeWorkspaceRole means the approvers are the members with the workspace role.
+	ApprovalStepTypeWorkspaceRole ApprovalStepType = "WORKSPACE_ROLE"
+	// ApprovalStepTypeProjectRole means the approvers are the members with the role in the project of the issue.
+	ApprovalStepTypeProjectRole ApprovalStepType = "PROJECT_ROLE"
+	// ApprovalStepTypeGroup means the approvers are the members of the named group.
+	ApprovalStepTypeGroup ApprovalStepType = "GROUP"
+
 	// BackupPlanPolicyScheduleUnset is NEVER backup plan policy value.
 	BackupPlanPolicyScheduleUnset BackupPlanPolicySchedule = "UNSET"
 	// BackupPlanPolicyScheduleDaily is DAILY backup plan policy value.
@@ -43,9 +64,14 @@ const (
 var (
 	// PolicyTypes is a set of all policy types.
 	PolicyTypes = map[PolicyType]bool{
-		PolicyTypePipelineApproval: true,
-		PolicyTypeBackupPlan:       true,
-		PolicyTypeSchemaReview:     true,
+		PolicyTypePipelineApproval:  true,
+		PolicyTypeBackupPlan:        true,
+		PolicyTypeSchemaReview:      true,
+		PolicyTypeBackupStorage:     true,
+		PolicyTypeAffectedRowLimit:  true,
+		PolicyTypeMaintenanceWindow: true,
+		PolicyTypeTaskConcurrency:   true,
+		PolicyTypeTaskRetry:         true,
 	}
 )
 
@@ -117,6 +143,68 @@ type PolicyDelete struct {
 // PipelineApprovalPolicy is the policy configuration for pipeline approval
 type PipelineApprovalPolicy struct {
 	Value PipelineApprovalValue `json:"value"`
+	// StepList is the ordered approval steps for MANUAL_APPROVAL_ALWAYS, the task is approved after all steps get the required approvals.
+	// The task is approved by the issue assignee if it's empty.
+	StepList []*ApprovalStep `json:"stepList,omitempty"`
+}
+
+// ApprovalStep is a step of the approval workflow.
+type ApprovalStep struct {
+	Type ApprovalStepType `json:"type"`
+	// Role is the workspace role for WORKSPACE_ROLE, or the project role for PROJECT_ROLE.
+	Role string `json:"role,omitempty"`
+	// GroupName and MemberIDList are the name and the principal IDs of the approver group for GROUP.
+	GroupName    string `json:"groupName,omitempty"`
+	MemberIDList []int  `json:"memberIdList,omitempty"`
+	// ApprovalCount is the number of the approvals the step requires.
+	ApprovalCount int `json:"approvalCount"`
+}
+
+// ApproverName returns the name of the approvers of the step.
+func (step *ApprovalStep) ApproverName() string {
+	switch step.Type {
+	case ApprovalStepTypeWorkspaceRole:
+		return fmt.Sprintf("workspace %s", step.Role)
+	case ApprovalStepTypeProjectRole:
+		return fmt.Sprintf("project %s", step.Role)
+	case ApprovalStepTypeGroup:
+		return fmt.Sprintf("group %q", step.GroupName)
+	}
+	return string(step.Type)
+}
+
+// Validate validates the approval step.
+func (step *ApprovalStep) Validate() error {
+	if step.ApprovalCount <= 0 {
+		return fmt.Errorf("the approval count should be positive, got %d", step.ApprovalCount)
+	}
+	switch step.Type {
+	case ApprovalStepTypeWorkspaceRole:
+		if role := Role(step.Role); role != Owner && role != DBA && role != Developer {
+			return fmt.Errorf("invalid workspace role %q", step.Role)
+		}
+	case ApprovalStepTypeProjectRole:
+		if role := common.ProjectRole(step.Role); role != common.ProjectOwner && role != common.ProjectDeveloper {
+			return fmt.Errorf("invalid project role %q", step.Role)
+		}
+	case ApprovalStepTypeGroup:
+		if step.GroupName == "" {
+			return fmt.Errorf("the group name is required")
+		}
+		memberIDSet := make(map[int]bool)
+		for _, memberID := range step.MemberIDList {
+			if memberIDSet[memberID] {
+				return fmt.Errorf("duplicate member %d in group %q", memberID, step.GroupName)
+			}
+			memberIDSet[memberID] = true
+		}
+		if len(memberIDSet) < step.ApprovalCount {
+			return fmt.Errorf("group %q has %d members, fewer than the approval count %d", step.GroupName, len(memberIDSet), step.ApprovalCount)
+		}
+	default:
+		return fmt.Errorf("invalid approval step type %q", step.Type)
+	}
+	return nil
 }
 
 func (pa PipelineApprovalPolicy) String() (string, error) {
@@ -139,6 +227,21 @@ func UnmarshalPipelineApprovalPolicy(payload string) (*PipelineApprovalPolicy, e
 // BackupPlanPolicy is the policy configuration for backup plan.
 type BackupPlanPolicy struct {
 	Schedule BackupPlanPolicySchedule `json:"schedule"`
+	// Retention is the rule of which backups are kept. The backups are kept forever if it's nil.
+	Retention *BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupRetention is the retention rule of the backups, in the grandfather-father-son style.
+// A backup is kept if any of the rules keeps it, and a rule with zero value keeps nothing.
+type BackupRetention struct {
+	// KeepLast keeps the last N backups.
+	KeepLast int `json:"keepLast"`
+	// KeepDays keeps the backups taken in the last D days.
+	KeepDays int `json:"keepDays"`
+	// KeepWeekly keeps the last backup of each of the last N weeks which have backups.
+	KeepWeekly int `json:"keepWeekly"`
+	// KeepMonthly keeps the last backup of each of the last N months which have backups.
+	KeepMonthly int `json:"keepMonthly"`
 }
 
 func (bp BackupPlanPolicy) String() (string, error) {
@@ -158,6 +261,164 @@ func UnmarshalBackupPlanPolicy(payload string) (*BackupPlanPolicy, error) {
 	return &bp, nil
 }
 
+// BackupStoragePolicy is the policy configuration for where the backups of an environment are stored.
+type BackupStoragePolicy struct {
+	StorageBackend BackupStorageBackend `json:"storageBackend"`
+	// S3 is the configuration of the S3-compatible storage, required if StorageBackend is S3.
+	S3 *BackupStorageS3Config `json:"s3,omitempty"`
+}
+
+// BackupStorageS3Config is the configuration of an S3-compatible backup storage.
+type BackupStorageS3Config struct {
+	// Endpoint is the URL of the S3-compatible service. The AWS S3 endpoint is used if it's empty.
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	// UsePathStyle addresses the bucket by path instead of subdomain, which is required by most self-hosted services.
+	UsePathStyle bool `json:"usePathStyle"`
+}
+
+func (bs BackupStoragePolicy) String() (string, error) {
+	s, err := json.Marshal(bs)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalBackupStoragePolicy will unmarshal payload to backup storage policy.
+func UnmarshalBackupStoragePolicy(payload string) (*BackupStoragePolicy, error) {
+	var bs BackupStoragePolicy
+	if err := json.Unmarshal([]byte(payload), &bs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup storage policy %q: %q", payload, err)
+	}
+	return &bs, nil
+}
+
+// AffectedRowLimitPolicy is the policy configuration for the maximum rows affected by a DML statement of the data update tasks.
+type AffectedRowLimitPolicy struct {
+	// MaxRowCount is the maximum affected row count of a statement, the tasks exceeding it are blocked.
+	// There is no limit if it's 0.
+	MaxRowCount int64 `json:"maxRowCount"`
+}
+
+func (ar AffectedRowLimitPolicy) String() (string, error) {
+	s, err := json.Marshal(ar)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalAffectedRowLimitPolicy will unmarshal payload to affected row limit policy.
+func UnmarshalAffectedRowLimitPolicy(payload string) (*AffectedRowLimitPolicy, error) {
+	var ar AffectedRowLimitPolicy
+	if err := json.Unmarshal([]byte(payload), &ar); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal affected row limit policy %q: %q", payload, err)
+	}
+	return &ar, nil
+}
+
+// MaintenanceWindowPolicy is the policy configuration for the maintenance windows of an environment.
+// The tasks of the environment can only start running within the windows and not on the blackout dates.
+// There is no restriction if the window list is empty.
+type MaintenanceWindowPolicy struct {
+	WindowList []*MaintenanceWindow `json:"windowList"`
+	// BlackoutDateList is the dates in the "YYYY-MM-DD" format in UTC, on which no task can start running.
+	BlackoutDateList []string `json:"blackoutDateList"`
+
+	// parsedWindowList caches the parsed windows of WindowList.
+	parsedWindowList []*parsedMaintenanceWindow
+}
+
+// MaintenanceWindow is a recurring maintenance window.
+type MaintenanceWindow struct {
+	// Schedule is the cron expression "minute hour day-of-month month day-of-week" of the window start in UTC,
+	// e.g. "0 2 * * 2,4" starts at 02:00 UTC every Tuesday and Thursday.
+	Schedule string `json:"schedule"`
+	// DurationMinutes is the length of the window in minutes.
+	DurationMinutes int `json:"durationMinutes"`
+}
+
+func (mw MaintenanceWindowPolicy) String() (string, error) {
+	s, err := json.Marshal(mw)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalMaintenanceWindowPolicy will unmarshal payload to maintenance window policy.
+func UnmarshalMaintenanceWindowPolicy(payload string) (*MaintenanceWindowPolicy, error) {
+	var mw MaintenanceWindowPolicy
+	if err := json.Unmarshal([]byte(payload), &mw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal maintenance window policy %q: %q", payload, err)
+	}
+	return &mw, nil
+}
+
+// TaskConcurrencyPolicy is the policy configuration for the maximum running tasks of an environment.
+// The tasks exceeding the limits wait in the task queue. There is no limit if it's 0.
+type TaskConcurrencyPolicy struct {
+	// MaxRunningTaskCount is the maximum running tasks in the environment.
+	MaxRunningTaskCount int `json:"maxRunningTaskCount"`
+	// MaxRunningTaskCountPerInstance is the maximum running tasks on each instance of the environment.
+	MaxRunningTaskCountPerInstance int `json:"maxRunningTaskCountPerInstance"`
+}
+
+func (tc TaskConcurrencyPolicy) String() (string, error) {
+	s, err := json.Marshal(tc)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalTaskConcurrencyPolicy will unmarshal payload to task concurrency policy.
+func UnmarshalTaskConcurrencyPolicy(payload string) (*TaskConcurrencyPolicy, error) {
+	var tc TaskConcurrencyPolicy
+	if err := json.Unmarshal([]byte(payload), &tc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task concurrency policy %q: %q", payload, err)
+	}
+	return &tc, nil
+}
+
+// MaxTaskRetryAttempts is the upper bound of the max attempts in the task retry policy.
+const MaxTaskRetryAttempts = 10
+
+// TaskRetryPolicy is the policy configuration for automatically retrying the failed idempotent tasks of an environment.
+// The tasks failing with the transient database errors, e.g. connection reset, lock wait timeout and deadlock, are retried.
+type TaskRetryPolicy struct {
+	// MaxAttempts is the maximum number of runs of a task including the first run. The task isn't retried if it's 0 or 1.
+	MaxAttempts int `json:"maxAttempts"`
+	// BackoffSeconds is the delay before the first retry, the delay doubles on each of the following retries.
+	BackoffSeconds int `json:"backoffSeconds"`
+	// MaxBackoffSeconds caps the delay between the retries. There is no cap if it's 0.
+	MaxBackoffSeconds int `json:"maxBackoffSeconds"`
+	// RetryableCodeList is the error codes to retry in addition to the transient database errors.
+	RetryableCodeList []common.Code `json:"retryableCodeList"`
+}
+
+func (tr TaskRetryPolicy) String() (string, error) {
+	s, err := json.Marshal(tr)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalTaskRetryPolicy will unmarshal payload to task retry policy.
+func UnmarshalTaskRetryPolicy(payload string) (*TaskRetryPolicy, error) {
+	var tr TaskRetryPolicy
+	if err := json.Unmarshal([]byte(payload), &tr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task retry policy %q: %q", payload, err)
+	}
+	return &tr, nil
+}
+
 // UnmarshalSchemaReviewPolicy will unmarshal payload to schema review policy.
 func UnmarshalSchemaReviewPolicy(payload string) (*advisor.SchemaReviewPolicy, error) {
 	var sr advisor.SchemaReviewPolicy
@@ -185,6 +446,14 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if pa.Value != PipelineApprovalValueManualNever && pa.Value != PipelineApprovalValueManualAlways {
 			return fmt.Errorf("invalid approval policy value: %q", payload)
 		}
+		if pa.Value == PipelineApprovalValueManualNever && len(pa.StepList) > 0 {
+			return fmt.Errorf("invalid approval policy, approval steps require %s: %q", PipelineApprovalValueManualAlways, payload)
+		}
+		for i, step := range pa.StepList {
+			if err := step.Validate(); err != nil {
+				return fmt.Errorf("invalid approval policy step %d: %w", i+1, err)
+			}
+		}
 	case PolicyTypeBackupPlan:
 		bp, err := UnmarshalBackupPlanPolicy(payload)
 		if err != nil {
@@ -193,6 +462,15 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if bp.Schedule != BackupPlanPolicyScheduleUnset && bp.Schedule != BackupPlanPolicyScheduleDaily && bp.Schedule != BackupPlanPolicyScheduleWeekly {
 			return fmt.Errorf("invalid backup plan policy schedule: %q", bp.Schedule)
 		}
+		if r := bp.Retention; r != nil {
+			if r.KeepLast < 0 || r.KeepDays < 0 || r.KeepWeekly < 0 || r.KeepMonthly < 0 {
+				return fmt.Errorf("invalid backup plan policy retention, the values should not be negative: %q", payload)
+			}
+			// Otherwise all the backups would be pruned.
+			if r.KeepLast == 0 && r.KeepDays == 0 && r.KeepWeekly == 0 && r.KeepMonthly == 0 {
+				return fmt.Errorf("invalid backup plan policy retention, at least one rule should be set: %q", payload)
+			}
+		}
 	case PolicyTypeSchemaReview:
 		sr, err := UnmarshalSchemaReviewPolicy(payload)
 		if err != nil {
@@ -201,6 +479,58 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if err := sr.Validate(); err != nil {
 			return fmt.Errorf("invalid schema review policy: %w", err)
 		}
+	case PolicyTypeBackupStorage:
+		bs, err := UnmarshalBackupStoragePolicy(payload)
+		if err != nil {
+			return err
+		}
+		switch bs.StorageBackend {
+		case BackupStorageBackendLocal:
+		case BackupStorageBackendS3:
+			if bs.S3 == nil || bs.S3.Bucket == "" {
+				return fmt.Errorf("invalid backup storage policy, S3 bucket is required")
+			}
+			if bs.S3.AccessKeyID == "" || bs.S3.SecretAccessKey == "" {
+				return fmt.Errorf("invalid backup storage policy, S3 access key ID and secret access key are required")
+			}
+		default:
+			return fmt.Errorf("invalid backup storage policy storage backend: %q", bs.StorageBackend)
+		}
+	case PolicyTypeAffectedRowLimit:
+		ar, err := UnmarshalAffectedRowLimitPolicy(payload)
+		if err != nil {
+			return err
+		}
+		if ar.MaxRowCount < 0 {
+			return fmt.Errorf("invalid affected row limit policy, the max row count should not be negative: %q", payload)
+		}
+	case PolicyTypeMaintenanceWindow:
+		mw, err := UnmarshalMaintenanceWindowPolicy(payload)
+		if err != nil {
+			return err
+		}
+		if err := mw.Validate(); err != nil {
+			return fmt.Errorf("invalid maintenance window policy: %w", err)
+		}
+	case PolicyTypeTaskConcurrency:
+		tc, err := UnmarshalTaskConcurrencyPolicy(payload)
+		if err != nil {
+			return err
+		}
+		if tc.MaxRunningTaskCount < 0 || tc.MaxRunningTaskCountPerInstance < 0 {
+			return fmt.Errorf("invalid task concurrency policy, the max running task counts should not be negative: %q", payload)
+		}
+	case PolicyTypeTaskRetry:
+		tr, err := UnmarshalTaskRetryPolicy(payload)
+		if err != nil {
+			return err
+		}
+		if tr.MaxAttempts < 0 || tr.BackoffSeconds < 0 || tr.MaxBackoffSeconds < 0 {
+			return fmt.Errorf("invalid task retry policy, the values should not be negative: %q", payload)
+		}
+		if tr.MaxAttempts > MaxTaskRetryAttempts {
+			return fmt.Errorf("invalid task retry policy, the max attempts should not exceed %d: %q", MaxTaskRetryAttempts, payload)
+		}
 	}
 	return nil
 }
@@ -220,6 +550,31 @@ func GetDefaultPolicy(pType PolicyType) (string, error) {
 	case PolicyTypeSchemaReview:
 		// TODO(ed): we may need to define the default schema review policy payload in the PR of policy data migration.
 		return "{}", nil
+	case PolicyTypeBackupStorage:
+		return BackupStoragePolicy{
+			StorageBackend: BackupStorageBackendLocal,
+		}.String()
+	case PolicyTypeAffectedRowLimit:
+		return AffectedRowLimitPolicy{
+			MaxRowCount: 0,
+		}.String()
+	case PolicyTypeMaintenanceWindow:
+		return MaintenanceWindowPolicy{
+			WindowList:       []*MaintenanceWindow{},
+			BlackoutDateList: []string{},
+		}.String()
+	case PolicyTypeTaskConcurrency:
+		return TaskConcurrencyPolicy{
+			MaxRunningTaskCount:            0,
+			MaxRunningTaskCountPerInstance: 0,
+		}.String()
+	case PolicyTypeTaskRetry:
+		return TaskRetryPolicy{
+			MaxAttempts:       1,
+			BackoffSeconds:    30,
+			MaxBackoffSeconds: 600,
+			RetryableCodeList: []common.Code{},
+		}.String()
 	}
 	return "", nil
 }
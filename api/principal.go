@@ -15,6 +15,8 @@ const (
 	EndUser PrincipalType = "END_USER"
 	// BOT is the principal type for BOT.
 	BOT PrincipalType = "BOT"
+	// ServiceAccount is the principal type for SERVICE_ACCOUNT, which is used by automations such as CI and can only authenticate with API tokens.
+	ServiceAccount PrincipalType = "SERVICE_ACCOUNT"
 )
 
 func (e PrincipalType) String() string {
@@ -23,6 +25,8 @@ func (e PrincipalType) String() string {
 		return "END_USER"
 	case BOT:
 		return "BOT"
+	case ServiceAccount:
+		return "SERVICE_ACCOUNT"
 	}
 	return ""
 }
@@ -35,6 +39,8 @@ const (
 	PrincipalAuthProviderBytebase PrincipalAuthProvider = "BYTEBASE"
 	// PrincipalAuthProviderGitlabSelfHost is the self-hosted GitLab authentication provider.
 	PrincipalAuthProviderGitlabSelfHost PrincipalAuthProvider = "GITLAB_SELF_HOST"
+	// PrincipalAuthProviderOIDC is the OpenID Connect authentication provider.
+	PrincipalAuthProviderOIDC PrincipalAuthProvider = "OIDC"
 )
 
 // Principal is the API message for principals.
@@ -92,10 +98,11 @@ type PrincipalCreate struct {
 	CreatorID int
 
 	// Domain specific fields
-	Type         PrincipalType
-	Name         string `jsonapi:"attr,name"`
-	Email        string `jsonapi:"attr,email"`
-	Password     string `jsonapi:"attr,password"`
+	// Type is END_USER if not specified.
+	Type         PrincipalType `jsonapi:"attr,type"`
+	Name         string        `jsonapi:"attr,name"`
+	Email        string        `jsonapi:"attr,email"`
+	Password     string        `jsonapi:"attr,password"`
 	PasswordHash string
 }
 
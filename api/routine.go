@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// Routine is the API message for a stored procedure or function.
+type Routine struct {
+	ID int `jsonapi:"primary,routine"`
+
+	// Standard fields
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	Database   *Database `jsonapi:"relation,database"`
+
+	// Domain specific fields
+	Name string `jsonapi:"attr,name"`
+	// Type is "PROCEDURE" or "FUNCTION".
+	Type       string `jsonapi:"attr,type"`
+	Arguments  string `jsonapi:"attr,arguments"`
+	ReturnType string `jsonapi:"attr,returnType"`
+	Definition string `jsonapi:"attr,definition"`
+	Comment    string `jsonapi:"attr,comment"`
+}
+
+// RoutineCreate is the API message for creating a stored procedure or function.
+type RoutineCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	Type       string
+	Arguments  string
+	ReturnType string
+	Definition string
+	Comment    string
+}
+
+// RoutineFind is the API message for finding stored procedures and functions.
+type RoutineFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+
+	// Domain specific fields
+	Name *string
+}
+
+func (find *RoutineFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// RoutineDelete is the API message for deleting stored procedures and functions.
+type RoutineDelete struct {
+	// Related fields
+	DatabaseID int
+}
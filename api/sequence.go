@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// Sequence is the API message for a sequence.
+type Sequence struct {
+	ID int `jsonapi:"primary,sequence"`
+
+	// Standard fields
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	Database   *Database `jsonapi:"relation,database"`
+
+	// Domain specific fields
+	Name       string `jsonapi:"attr,name"`
+	DataType   string `jsonapi:"attr,dataType"`
+	StartValue int64  `jsonapi:"attr,startValue"`
+	MinValue   int64  `jsonapi:"attr,minValue"`
+	MaxValue   int64  `jsonapi:"attr,maxValue"`
+	Increment  int64  `jsonapi:"attr,increment"`
+	Cycle      bool   `jsonapi:"attr,cycle"`
+}
+
+// SequenceCreate is the API message for creating a sequence.
+type SequenceCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+}
+
+// SequenceFind is the API message for finding sequences.
+type SequenceFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+
+	// Domain specific fields
+	Name *string
+}
+
+func (find *SequenceFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// SequenceDelete is the API message for deleting sequences.
+type SequenceDelete struct {
+	// Related fields
+	DatabaseID int
+}
@@ -16,6 +16,11 @@ const (
 	SettingWorkspaceID SettingName = "bb.workspace.id"
 	// SettingEnterpriseLicense is the setting name for enterprise license.
 	SettingEnterpriseLicense SettingName = "bb.enterprise.license"
+	// SettingAuthOIDC is the setting name for the OpenID Connect auth provider, whose value is the JSON of OIDCConfig.
+	SettingAuthOIDC SettingName = "bb.auth.oidc"
+	// SettingSecurityDataKey is the setting name for the data key encrypting the credentials, which is wrapped by the master key.
+	// Empty value means the credentials are stored in plaintext.
+	SettingSecurityDataKey SettingName = "bb.security.data-key"
 )
 
 // Setting is the API message for a setting.
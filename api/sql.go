@@ -15,6 +15,13 @@ type ConnectionInfo struct {
 	Password         string  `jsonapi:"attr,password"`
 	UseEmptyPassword bool    `jsonapi:"attr,useEmptyPassword"`
 	InstanceID       *int    `jsonapi:"attr,instanceId"`
+	// SSH tunnel through the bastion host, empty SSHHost means connecting directly.
+	// If both SSHPassword and SSHPrivateKey are empty, the server uses the ones of the admin data source of InstanceID.
+	SSHHost       string `jsonapi:"attr,sshHost"`
+	SSHPort       string `jsonapi:"attr,sshPort"`
+	SSHUser       string `jsonapi:"attr,sshUser"`
+	SSHPassword   string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey string `jsonapi:"attr,sshPrivateKey"`
 }
 
 // SQLSyncSchema is the API message for sync schemas.
@@ -44,6 +51,70 @@ type SQLResultSet struct {
 	Error string `jsonapi:"attr,error"`
 }
 
+// SQLExplain is the API message for explaining a statement.
+type SQLExplain struct {
+	InstanceID int `jsonapi:"attr,instanceId"`
+	// For engines like MySQL, databaseName can be empty.
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+}
+
+// SQLExplainResult is the API message for the query plan of a statement.
+type SQLExplainResult struct {
+	// The normalized plan tree marshalled into a JSON.
+	Plan string `jsonapi:"attr,plan"`
+	// Explaining may fail for connection issue and there is no proper http status code for it, so we return error in the response body.
+	Error string `jsonapi:"attr,error"`
+}
+
+// SQLQuerySessionCreate is the API message for starting a query session.
+// Unlike SQLExecute, the result of a query session is fetched page by page and the query can be cancelled.
+// For now, we only support readonly / SELECT.
+type SQLQuerySessionCreate struct {
+	InstanceID int `jsonapi:"attr,instanceId"`
+	// For engines like MySQL, databaseName can be empty.
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+}
+
+// SQLQuerySession is the API message for a query session.
+type SQLQuerySession struct {
+	ID string `jsonapi:"primary,sqlQuerySession"`
+
+	// Related fields
+	InstanceID int `jsonapi:"attr,instanceId"`
+
+	// Domain specific fields
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+	// The names of the result columns.
+	ColumnNames []string `jsonapi:"attr,columnNames"`
+	// The database system names of the result column types, e.g. "VARCHAR", "INT".
+	ColumnTypeNames []string `jsonapi:"attr,columnTypeNames"`
+	// SQL operation may fail for connection issue and there is no proper http status code for it, so we return error in the response body.
+	Error string `jsonapi:"attr,error"`
+}
+
+// SQLQueryPage is the API message for a page of the query session result.
+type SQLQueryPage struct {
+	// ID is the query session ID.
+	ID string `jsonapi:"primary,sqlQueryPage"`
+
+	// Domain specific fields
+	// Cursor is the offset of the first row in the page.
+	Cursor int `jsonapi:"attr,cursor"`
+	// NextCursor is the cursor to fetch the next page.
+	NextCursor int `jsonapi:"attr,nextCursor"`
+	// Done is true if there are no more rows after this page.
+	Done            bool     `jsonapi:"attr,done"`
+	ColumnNames     []string `jsonapi:"attr,columnNames"`
+	ColumnTypeNames []string `jsonapi:"attr,columnTypeNames"`
+	// A list of rows marshalled into a JSON.
+	Data string `jsonapi:"attr,data"`
+	// SQL operation may fail for connection issue and there is no proper http status code for it, so we return error in the response body.
+	Error string `jsonapi:"attr,error"`
+}
+
 // SQLService is the service for SQL.
 type SQLService interface {
 	Ping(ctx context.Context, config *ConnectionInfo) (*SQLResultSet, error)
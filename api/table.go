@@ -21,18 +21,21 @@ type Table struct {
 	Database   *Database `jsonapi:"relation,database"`
 
 	// Domain specific fields
-	Name          string    `jsonapi:"attr,name"`
-	Type          string    `jsonapi:"attr,type"`
-	Engine        string    `jsonapi:"attr,engine"`
-	Collation     string    `jsonapi:"attr,collation"`
-	RowCount      int64     `jsonapi:"attr,rowCount"`
-	DataSize      int64     `jsonapi:"attr,dataSize"`
-	IndexSize     int64     `jsonapi:"attr,indexSize"`
-	DataFree      int64     `jsonapi:"attr,dataFree"`
-	CreateOptions string    `jsonapi:"attr,createOptions"`
-	Comment       string    `jsonapi:"attr,comment"`
-	ColumnList    []*Column `jsonapi:"attr,columnList"`
-	IndexList     []*Index  `jsonapi:"attr,indexList"`
+	Name                string             `jsonapi:"attr,name"`
+	Type                string             `jsonapi:"attr,type"`
+	Engine              string             `jsonapi:"attr,engine"`
+	Collation           string             `jsonapi:"attr,collation"`
+	RowCount            int64              `jsonapi:"attr,rowCount"`
+	DataSize            int64              `jsonapi:"attr,dataSize"`
+	IndexSize           int64              `jsonapi:"attr,indexSize"`
+	DataFree            int64              `jsonapi:"attr,dataFree"`
+	CreateOptions       string             `jsonapi:"attr,createOptions"`
+	Comment             string             `jsonapi:"attr,comment"`
+	ColumnList          []*Column          `jsonapi:"attr,columnList"`
+	IndexList           []*Index           `jsonapi:"attr,indexList"`
+	ForeignKeyList      []*ForeignKey      `jsonapi:"attr,foreignKeyList"`
+	CheckConstraintList []*CheckConstraint `jsonapi:"attr,checkConstraintList"`
+	TriggerList         []*Trigger         `jsonapi:"attr,triggerList"`
 }
 
 // TableCreate is the API message for creating a table.
@@ -144,6 +144,8 @@ type TaskDatabaseDataUpdatePayload struct {
 	Statement     string         `json:"statement,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
 	VCSPushEvent  *vcs.PushEvent `json:"pushEvent,omitempty"`
+	// Idempotent is true if the statement can be applied more than once, so the task can be retried automatically on the transient errors.
+	Idempotent bool `json:"idempotent,omitempty"`
 }
 
 // TaskDatabaseBackupPayload is the task payload for database backup.
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// TaskApproval is the API message for an approval of a task.
+// The approvals are recorded if the pipeline approval policy of the environment has approval steps.
+type TaskApproval struct {
+	ID int `jsonapi:"primary,taskApproval"`
+
+	// Standard fields
+	// CreatorID is the ID of the approver.
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	TaskID  int `jsonapi:"attr,taskId"`
+	StageID int `jsonapi:"attr,stageId"`
+
+	// Domain specific fields
+	// Step is the 0-based index of the approval step in the pipeline approval policy.
+	Step int `jsonapi:"attr,step"`
+}
+
+// TaskApprovalCreate is the API message for creating a task approval.
+type TaskApprovalCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	TaskID  int
+	StageID int
+
+	// Domain specific fields
+	Step int
+}
+
+// TaskApprovalFind is the API message for finding task approvals.
+type TaskApprovalFind struct {
+	ID *int
+
+	// Related fields
+	TaskID  *int
+	StageID *int
+}
+
+func (find *TaskApprovalFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// TaskApprovalDelete is the API message for deleting the approvals of a task.
+type TaskApprovalDelete struct {
+	// Related fields
+	TaskID int
+}
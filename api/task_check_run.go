@@ -73,12 +73,18 @@ const (
 	TaskCheckDatabaseStatementCompatibility TaskCheckType = "bb.task-check.database.statement.compatibility"
 	// TaskCheckDatabaseStatementAdvise is the task check type for schema system review policy.
 	TaskCheckDatabaseStatementAdvise TaskCheckType = "bb.task-check.database.statement.advise"
+	// TaskCheckDatabaseStatementFullTableScan is the task check type for the full table scans on large tables by the query plans.
+	TaskCheckDatabaseStatementFullTableScan TaskCheckType = "bb.task-check.database.statement.full-table-scan"
+	// TaskCheckDatabaseStatementAffectedRows is the task check type for the rows affected by the DML statements.
+	TaskCheckDatabaseStatementAffectedRows TaskCheckType = "bb.task-check.database.statement.affected-rows"
 	// TaskCheckDatabaseConnect is the task check type for database connection.
 	TaskCheckDatabaseConnect TaskCheckType = "bb.task-check.database.connect"
 	// TaskCheckInstanceMigrationSchema is the task check type for migrating schemas.
 	TaskCheckInstanceMigrationSchema TaskCheckType = "bb.task-check.instance.migration-schema"
 	// TaskCheckGeneralEarliestAllowedTime is the task check type for earliest allowed time.
 	TaskCheckGeneralEarliestAllowedTime TaskCheckType = "bb.task-check.general.earliest-allowed-time"
+	// TaskCheckGeneralMaintenanceWindow is the task check type for the maintenance window.
+	TaskCheckGeneralMaintenanceWindow TaskCheckType = "bb.task-check.general.maintenance-window"
 )
 
 // TaskCheckEarliestAllowedTimePayload is the task check payload for earliest allowed time.
@@ -0,0 +1,21 @@
+package api
+
+// TaskQueueItem is the API message for a task waiting in the task queue.
+// The tasks passing all checks wait in the queue if the running tasks reach the task concurrency policy limits.
+type TaskQueueItem struct {
+	// ID is the task ID.
+	ID int `jsonapi:"primary,taskQueueItem"`
+
+	// Related fields
+	PipelineID    int `jsonapi:"attr,pipelineId"`
+	InstanceID    int `jsonapi:"attr,instanceId"`
+	EnvironmentID int `jsonapi:"attr,environmentId"`
+
+	// Domain specific fields
+	Name string `jsonapi:"attr,name"`
+	// Position is the 1-based position in the queue.
+	Position   int   `jsonapi:"attr,position"`
+	EnqueuedTs int64 `jsonapi:"attr,enqueuedTs"`
+	// Reason is why the task is waiting, it's empty if the task starts running in the next scheduling round.
+	Reason string `jsonapi:"attr,reason"`
+}
@@ -41,6 +41,10 @@ type TaskRunResultPayload struct {
 	Detail      string `json:"detail,omitempty"`
 	MigrationID int64  `json:"migrationId,omitempty"`
 	Version     string `json:"version,omitempty"`
+	// RollbackStatement is the statement reverting the data update.
+	RollbackStatement string `json:"rollbackStatement,omitempty"`
+	// RollbackError is the reason why the rollback statement isn't generated.
+	RollbackError string `json:"rollbackError,omitempty"`
 }
 
 // TaskRun is the API message for a task run.
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// Trigger is the API message for a table trigger.
+type Trigger struct {
+	ID int `jsonapi:"primary,trigger"`
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64 `json:"createdTs"`
+	UpdaterID int
+	UpdatedTs int64 `json:"updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	TableID    int
+
+	// Domain specific fields
+	Name   string `json:"name"`
+	Timing string `json:"timing"`
+	Event  string `json:"event"`
+	Body   string `json:"body"`
+}
+
+// TriggerCreate is the API message for creating a table trigger.
+type TriggerCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	DatabaseID int
+	TableID    int
+
+	// Domain specific fields
+	Name   string
+	Timing string
+	Event  string
+	Body   string
+}
+
+// TriggerFind is the API message for finding table triggers.
+type TriggerFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+	TableID    *int
+
+	// Domain specific fields
+	Name *string
+}
+
+func (find *TriggerFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
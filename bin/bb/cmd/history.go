@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+)
+
+func newHistoryCmd() *cobra.Command {
+	var (
+		dsn    string
+		limit  int
+		output string
+	)
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Print the migration history of a database, most recent first.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			u, err := dburl.Parse(dsn)
+			if err != nil {
+				return fmt.Errorf("failed to parse dsn, got error: %w", err)
+			}
+			if output != "table" && output != "json" {
+				return fmt.Errorf("invalid output format %q, must be one of table and json", output)
+			}
+			historyList, err := findMigrationHistory(context.Background(), u, limit)
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				return printMigrationHistoryJSON(cmd.OutOrStdout(), historyList)
+			}
+			return printMigrationHistoryTable(cmd.OutOrStdout(), historyList)
+		},
+	}
+
+	historyCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
+	historyCmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of the most recent migration histories to print. Print all if unspecified.")
+	historyCmd.Flags().StringVarP(&output, "output", "o", "table", "Output format, one of table and json.")
+	return historyCmd
+}
+
+// findMigrationHistory finds the migration history of the database in the dsn, or of all databases if the dsn doesn't specify one.
+func findMigrationHistory(ctx context.Context, u *dburl.URL, limit int) ([]*db.MigrationHistory, error) {
+	driver, err := open(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	needsSetup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check migration setup, got error: %w", err)
+	}
+	// There is no migration history if the migration schema hasn't been set up.
+	if needsSetup {
+		return nil, nil
+	}
+
+	find := &db.MigrationHistoryFind{}
+	if database := getDatabase(u); database != "" {
+		find.Database = &database
+	}
+	if limit > 0 {
+		find.Limit = &limit
+	}
+	historyList, err := driver.FindMigrationHistoryList(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find migration history, got error: %w", err)
+	}
+	return historyList, nil
+}
+
+// migrationHistory is the JSON output of a migration history.
+// The schema before and after the migration are omitted since they are usually too large to read in the terminal.
+type migrationHistory struct {
+	ID                  int                `json:"id"`
+	Creator             string             `json:"creator"`
+	CreatedTs           int64              `json:"createdTs"`
+	ReleaseVersion      string             `json:"releaseVersion"`
+	Database            string             `json:"database"`
+	Sequence            int                `json:"sequence"`
+	Source              db.MigrationSource `json:"source"`
+	Type                db.MigrationType   `json:"type"`
+	Status              db.MigrationStatus `json:"status"`
+	Version             string             `json:"version"`
+	UseSemanticVersion  bool               `json:"useSemanticVersion"`
+	Description         string             `json:"description"`
+	Statement           string             `json:"statement"`
+	ExecutionDurationNs int64              `json:"executionDurationNs"`
+	IssueID             string             `json:"issueId"`
+}
+
+func printMigrationHistoryJSON(out io.Writer, historyList []*db.MigrationHistory) error {
+	list := []*migrationHistory{}
+	for _, history := range historyList {
+		list = append(list, &migrationHistory{
+			ID:                  history.ID,
+			Creator:             history.Creator,
+			CreatedTs:           history.CreatedTs,
+			ReleaseVersion:      history.ReleaseVersion,
+			Database:            history.Namespace,
+			Sequence:            history.Sequence,
+			Source:              history.Source,
+			Type:                history.Type,
+			Status:              history.Status,
+			Version:             history.Version,
+			UseSemanticVersion:  history.UseSemanticVersion,
+			Description:         history.Description,
+			Statement:           history.Statement,
+			ExecutionDurationNs: history.ExecutionDurationNs,
+			IssueID:             history.IssueID,
+		})
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(list); err != nil {
+		return fmt.Errorf("failed to marshal migration history, got error: %w", err)
+	}
+	return nil
+}
+
+func printMigrationHistoryTable(out io.Writer, historyList []*db.MigrationHistory) error {
+	w := tabwriter.NewWriter(out, 0 /* minwidth */, 8 /* tabwidth */, 2 /* padding */, ' ', 0 /* flags */)
+	fmt.Fprintln(w, "ID\tDATABASE\tVERSION\tTYPE\tSTATUS\tSOURCE\tCREATOR\tCREATED\tDURATION\tDESCRIPTION")
+	for _, history := range historyList {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			history.ID,
+			history.Namespace,
+			history.Version,
+			history.Type,
+			history.Status,
+			history.Source,
+			history.Creator,
+			time.Unix(history.CreatedTs, 0).UTC().Format(time.RFC3339),
+			time.Duration(history.ExecutionDurationNs).Round(time.Millisecond),
+			history.Description,
+		)
+	}
+	return w.Flush()
+}
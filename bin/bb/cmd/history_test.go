@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+var testMigrationHistoryList = []*db.MigrationHistory{
+	{
+		ID:                  2,
+		Creator:             "ci",
+		CreatedTs:           1654012800,
+		Namespace:           "bytebase_test_todo",
+		Sequence:            2,
+		Source:              db.LIBRARY,
+		Type:                db.Migrate,
+		Status:              db.Done,
+		Version:             "1.1.0",
+		UseSemanticVersion:  true,
+		Description:         "Add book",
+		Statement:           "CREATE TABLE book (id INTEGER PRIMARY KEY);",
+		Schema:              "CREATE TABLE book (id INTEGER PRIMARY KEY);",
+		ExecutionDurationNs: 1500000000,
+	},
+	{
+		ID:          1,
+		Creator:     "ci",
+		CreatedTs:   1654009200,
+		Namespace:   "bytebase_test_todo",
+		Sequence:    1,
+		Source:      db.LIBRARY,
+		Type:        db.Baseline,
+		Status:      db.Done,
+		Version:     "20220531150000",
+		Description: "Baseline",
+	},
+}
+
+func TestPrintMigrationHistoryTable(t *testing.T) {
+	a := require.New(t)
+	var buf bytes.Buffer
+	err := printMigrationHistoryTable(&buf, testMigrationHistoryList)
+	a.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	a.Len(lines, 3)
+	a.Equal([]string{"ID", "DATABASE", "VERSION", "TYPE", "STATUS", "SOURCE", "CREATOR", "CREATED", "DURATION", "DESCRIPTION"}, strings.Fields(lines[0]))
+	a.Equal([]string{"2", "bytebase_test_todo", "1.1.0", "MIGRATE", "DONE", "LIBRARY", "ci", "2022-05-31T16:00:00Z", "1.5s", "Add", "book"}, strings.Fields(lines[1]))
+	a.Equal([]string{"1", "bytebase_test_todo", "20220531150000", "BASELINE", "DONE", "LIBRARY", "ci", "2022-05-31T15:00:00Z", "0s", "Baseline"}, strings.Fields(lines[2]))
+}
+
+func TestPrintMigrationHistoryJSON(t *testing.T) {
+	a := require.New(t)
+	var buf bytes.Buffer
+	err := printMigrationHistoryJSON(&buf, testMigrationHistoryList)
+	a.NoError(err)
+
+	var list []map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &list)
+	a.NoError(err)
+	a.Len(list, 2)
+	a.Equal("1.1.0", list[0]["version"])
+	a.Equal(true, list[0]["useSemanticVersion"])
+	a.Equal("bytebase_test_todo", list[0]["database"])
+	a.Equal("MIGRATE", list[0]["type"])
+	// The schema is omitted.
+	a.NotContains(list[0], "schema")
+
+	// An empty history prints an empty list rather than null.
+	buf.Reset()
+	err = printMigrationHistoryJSON(&buf, nil)
+	a.NoError(err)
+	a.Equal("[]\n", buf.String())
+}
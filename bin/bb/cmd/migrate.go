@@ -11,22 +11,27 @@ import (
 
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
 	"github.com/spf13/cobra"
 	"github.com/xo/dburl"
 )
 
 func newMigrateCmd() *cobra.Command {
 	var (
-		dsn         string
-		fileList    []string
-		commandList []string
-		description string
-		issueID     string
+		dsn              string
+		fileList         []string
+		commandList      []string
+		description      string
+		issueID          string
+		migrationVersion string
+		semantic         bool
+		migrationType    string
+		dryRun           bool
 	)
 	migrateCmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Migrate the database schema.",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			u, err := dburl.Parse(dsn)
 			if err != nil {
 				return fmt.Errorf("failed to parse dsn, got error: %w", err)
@@ -48,8 +53,15 @@ func newMigrateCmd() *cobra.Command {
 				sqlReaders = append(sqlReaders, strings.NewReader(command))
 			}
 
+			m, err := getMigrationInfo(u, migrationVersion, semantic, migrationType, description, issueID)
+			if err != nil {
+				return err
+			}
 			sqlReader := io.MultiReader(sqlReaders...)
-			return migrateDatabase(context.Background(), u, description, issueID, false /*createDatabase*/, sqlReader)
+			if dryRun {
+				return dryRunMigration(context.Background(), u, m, sqlReader, cmd.OutOrStdout())
+			}
+			return migrateDatabase(context.Background(), u, m, sqlReader)
 		}}
 
 	migrateCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
@@ -57,10 +69,57 @@ func newMigrateCmd() *cobra.Command {
 	migrateCmd.Flags().StringSliceVarP(&commandList, "command", "c", []string{}, "SQL command to execute.")
 	migrateCmd.Flags().StringVar(&description, "description", "", "Description of migration.")
 	migrateCmd.Flags().StringVar(&issueID, "issue-id", "", "Issue ID of migration.")
+	migrateCmd.Flags().StringVar(&migrationVersion, "version", "", "Version of migration. Use the current time in the format of 20060102150405 if unspecified.")
+	migrateCmd.Flags().BoolVar(&semantic, "semantic", false, "Whether the version is a semantic version (https://semver.org/), e.g. 1.2.0.")
+	migrateCmd.Flags().StringVar(&migrationType, "type", "migrate", "Type of migration, one of baseline, migrate and data.")
+	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migration to be applied without executing it.")
 	return migrateCmd
 }
 
-func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID string, createDatabase bool, sqlReader io.Reader) error {
+// getMigrationInfo validates the migrate flags and returns the migration info.
+func getMigrationInfo(u *dburl.URL, migrationVersion string, semantic bool, migrationType, description, issueID string) (*db.MigrationInfo, error) {
+	m := &db.MigrationInfo{
+		ReleaseVersion: version,
+		Version:        migrationVersion,
+		Namespace:      getDatabase(u),
+		Database:       getDatabase(u),
+		Source:         db.LIBRARY,
+		Description:    description,
+		Creator:        "bb-unknown-creator",
+		IssueID:        issueID,
+	}
+	if currentUser, err := user.Current(); err == nil {
+		m.Creator = currentUser.Username
+	}
+
+	switch migrationType {
+	case "baseline":
+		m.Type = db.Baseline
+	case "migrate":
+		m.Type = db.Migrate
+	case "data":
+		m.Type = db.Data
+	default:
+		return nil, fmt.Errorf("invalid migration type %q, must be one of baseline, migrate and data", migrationType)
+	}
+
+	if semantic {
+		if migrationVersion == "" {
+			return nil, fmt.Errorf("--version is required for semantic versioning")
+		}
+		m.UseSemanticVersion = true
+		// Since stored version should be unique, we have to append a suffix if we allow users to baseline to the same semantic version for fixing schema drift.
+		m.SemanticVersionSuffix = common.DefaultMigrationVersion()
+	} else if migrationVersion == "" {
+		m.Version = common.DefaultMigrationVersion()
+	}
+	if _, err := util.ToStoredVersion(m.UseSemanticVersion, m.Version, m.SemanticVersionSuffix); err != nil {
+		return nil, fmt.Errorf("invalid version %q, got error: %w", m.Version, err)
+	}
+	return m, nil
+}
+
+func migrateDatabase(ctx context.Context, u *dburl.URL, m *db.MigrationInfo, sqlReader io.Reader) error {
 	driver, err := open(ctx, u)
 	if err != nil {
 		return err
@@ -71,28 +130,58 @@ func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID str
 		return fmt.Errorf("failed to setup migration, got error: %w", err)
 	}
 
-	migrationCreator := "bb-unknown-creator"
-	if currentUser, err := user.Current(); err == nil {
-		migrationCreator = currentUser.Username
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, sqlReader); err != nil {
+		return fmt.Errorf("failed to read sql file, got error: %w", err)
 	}
+	if _, _, err := driver.ExecuteMigration(ctx, m, buf.String()); err != nil {
+		return fmt.Errorf("failed to migrate database, got error: %w", err)
+	}
+	return nil
+}
+
+// dryRunMigration prints the migration to be applied and checks whether the version has been applied, without changing the database.
+func dryRunMigration(ctx context.Context, u *dburl.URL, m *db.MigrationInfo, sqlReader io.Reader, out io.Writer) error {
+	driver, err := open(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
 
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, sqlReader); err != nil {
 		return fmt.Errorf("failed to read sql file, got error: %w", err)
 	}
-	// TODO(d): support semantic versioning.
-	if _, _, err := driver.ExecuteMigration(ctx, &db.MigrationInfo{
-		ReleaseVersion: version,
-		Version:        common.DefaultMigrationVersion(),
-		Database:       getDatabase(u),
-		Source:         db.LIBRARY,
-		Type:           db.Migrate,
-		Description:    description,
-		Creator:        migrationCreator,
-		IssueID:        issueID,
-		CreateDatabase: createDatabase,
-	}, buf.String()); err != nil {
-		return fmt.Errorf("failed to migrate database, got error: %w", err)
+
+	needsSetup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check migration setup, got error: %w", err)
+	}
+	// Same as the migration, only the non-semantic version is checked for duplicates since the semantic version has a unique suffix.
+	if !needsSetup && !m.UseSemanticVersion {
+		historyList, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{
+			Database: &m.Namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find migration history, got error: %w", err)
+		}
+		for _, history := range historyList {
+			if !history.UseSemanticVersion && history.Version == m.Version {
+				return fmt.Errorf("database %q has already applied version %s with status %s", m.Database, m.Version, history.Status)
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "Dry run, the database is not changed.\n")
+	if needsSetup {
+		fmt.Fprintf(out, "Migration schema: will be set up\n")
 	}
+	fmt.Fprintf(out, "Database: %s\n", m.Database)
+	fmt.Fprintf(out, "Version: %s\n", m.Version)
+	fmt.Fprintf(out, "Semantic: %t\n", m.UseSemanticVersion)
+	fmt.Fprintf(out, "Type: %s\n", m.Type)
+	fmt.Fprintf(out, "Description: %s\n", m.Description)
+	fmt.Fprintf(out, "Issue ID: %s\n", m.IssueID)
+	fmt.Fprintf(out, "Statement:\n%s\n", buf.String())
 	return nil
 }
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetMigrationInfo(t *testing.T) {
+	a := require.New(t)
+	u, err := dburl.Parse("mysql://root@localhost:3306/bytebase_test_todo")
+	a.NoError(err)
+
+	m, err := getMigrationInfo(u, "" /* migrationVersion */, false /* semantic */, "migrate", "create table", "101")
+	a.NoError(err)
+	a.Equal("bytebase_test_todo", m.Database)
+	a.Equal("bytebase_test_todo", m.Namespace)
+	a.Equal(db.Migrate, m.Type)
+	a.Equal(db.LIBRARY, m.Source)
+	a.Len(m.Version, len("20060102150405"))
+	a.False(m.UseSemanticVersion)
+	a.Equal("create table", m.Description)
+	a.Equal("101", m.IssueID)
+
+	m, err = getMigrationInfo(u, "1.2.0", true /* semantic */, "baseline", "", "")
+	a.NoError(err)
+	a.Equal(db.Baseline, m.Type)
+	a.Equal("1.2.0", m.Version)
+	a.True(m.UseSemanticVersion)
+	a.Len(m.SemanticVersionSuffix, len("20060102150405"))
+
+	m, err = getMigrationInfo(u, "v2-add-index", false /* semantic */, "data", "", "")
+	a.NoError(err)
+	a.Equal(db.Data, m.Type)
+	a.Equal("v2-add-index", m.Version)
+
+	// The semantic version is required and must be valid.
+	_, err = getMigrationInfo(u, "", true /* semantic */, "migrate", "", "")
+	a.Error(err)
+	_, err = getMigrationInfo(u, "v1", true /* semantic */, "migrate", "", "")
+	a.Error(err)
+	_, err = getMigrationInfo(u, "10000.0.0", true /* semantic */, "migrate", "", "")
+	a.Error(err)
+	// The type must be valid.
+	_, err = getMigrationInfo(u, "", false /* semantic */, "branch", "", "")
+	a.Error(err)
+}
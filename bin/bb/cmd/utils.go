@@ -8,10 +8,14 @@ import (
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/resources/postgres"
 
+	// install clickhouse driver.
+	_ "github.com/bytebase/bytebase/plugin/db/clickhouse"
 	// install mysql driver.
 	_ "github.com/bytebase/bytebase/plugin/db/mysql"
 	// install pg driver.
 	_ "github.com/bytebase/bytebase/plugin/db/pg"
+	// install snowflake driver.
+	_ "github.com/bytebase/bytebase/plugin/db/snowflake"
 	"github.com/xo/dburl"
 )
 
@@ -28,6 +32,18 @@ func open(ctx context.Context, u *dburl.URL) (db.Driver, error) {
 	switch u.Driver {
 	case "mysql":
 		dbType = db.MySQL
+		// dburl.Parse() parses 'tidb' to the 'mysql' driver since TiDB is compatible with the MySQL protocol.
+		if u.Scheme == "tidb" {
+			dbType = db.TiDB
+		}
+		// dburl.Parse() parses 'mariadb' and 'maria' to the 'mysql' driver as well.
+		if u.Scheme == "mariadb" || u.Scheme == "maria" {
+			dbType = db.MariaDB
+		}
+	case "clickhouse":
+		dbType = db.ClickHouse
+	case "snowflake":
+		dbType = db.Snowflake
 	// dburl.Parse() do the job of parsing 'pg', 'postgresql' and 'pgsql' to 'postgres'.
 	// https://pkg.go.dev/github.com/xo/dburl@v0.9.1#hdr-Protocol_Schemes_and_Aliases
 	case "postgres":
@@ -38,7 +54,7 @@ func open(ctx context.Context, u *dburl.URL) (db.Driver, error) {
 		}
 		pgInstanceDir = pgInstance.BaseDir
 	default:
-		return nil, fmt.Errorf("database type %q not supported; supported types: mysql, pg", u.Driver)
+		return nil, fmt.Errorf("database type %q not supported; supported types: mysql, tidb, pg, clickhouse, snowflake", u.Driver)
 	}
 	passwd, _ := u.User.Password()
 	driver, err := db.Open(
@@ -0,0 +1,9 @@
+//go:build duckdb
+// +build duckdb
+
+package cmd
+
+import (
+	// Register duckdb driver.
+	_ "github.com/bytebase/bytebase/plugin/db/duckdb"
+)
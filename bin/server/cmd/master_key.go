@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/crypto"
+	"github.com/bytebase/bytebase/server"
+)
+
+// masterKeyEnv is the environment variable for the base64 encoded master key.
+const masterKeyEnv = "BB_MASTER_KEY"
+
+var (
+	rotateFlags struct {
+		newMasterKey     string
+		newMasterKeyFile string
+	}
+	rotateMasterKeyCmd = &cobra.Command{
+		Use:   "rotate-master-key",
+		Short: "Re-encrypt the data key of the credentials with a new master key. Bytebase must be stopped if using the embedded PostgreSQL",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := rotateMasterKey(); err != nil {
+				log.Error(err.Error())
+				os.Exit(1)
+			}
+			fmt.Println("The master key is rotated, please restart Bytebase with the new master key.")
+		},
+	}
+)
+
+func init() {
+	rotateMasterKeyCmd.Flags().StringVar(&rotateFlags.newMasterKey, "new-master-key", "", "the new base64 encoded 32-byte master key")
+	rotateMasterKeyCmd.Flags().StringVar(&rotateFlags.newMasterKeyFile, "new-master-key-file", "", "file containing the new base64 encoded 32-byte master key")
+	rootCmd.AddCommand(rotateMasterKeyCmd)
+}
+
+func rotateMasterKey() error {
+	defer log.Sync()
+	if err := checkDataDir(); err != nil {
+		return err
+	}
+	masterKey, err := loadMasterKey(flags.masterKey, flags.masterKeyFile, masterKeyEnv)
+	if err != nil {
+		return err
+	}
+	if len(masterKey) == 0 {
+		return fmt.Errorf("the current master key must be supplied by --master-key, --master-key-file or the %s environment variable", masterKeyEnv)
+	}
+	newMasterKey, err := loadMasterKey(rotateFlags.newMasterKey, rotateFlags.newMasterKeyFile, "" /* env */)
+	if err != nil {
+		return err
+	}
+	if len(newMasterKey) == 0 {
+		return fmt.Errorf("the new master key must be supplied by --new-master-key or --new-master-key-file")
+	}
+
+	profile := activeProfile(flags.dataDir)
+	profile.MasterKey = masterKey
+	return server.RotateMasterKey(context.Background(), profile, newMasterKey)
+}
+
+// loadMasterKey loads the master key from the flag value, the file or the environment variable in order.
+// It returns nil if none of them is supplied.
+func loadMasterKey(value, file, env string) ([]byte, error) {
+	if value != "" && file != "" {
+		return nil, fmt.Errorf("the master key and the master key file can't be both supplied")
+	}
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read master key file %q, error: %w", file, err)
+		}
+		value = string(content)
+	}
+	if value == "" && env != "" {
+		value = os.Getenv(env)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	key, err := crypto.ParseKey(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key, error: %w", err)
+	}
+	return key, nil
+}
@@ -22,6 +22,7 @@ const (
 	DbConnectionFailure    Code = 101
 	DbStatementSyntaxError Code = 102
 	DbExecutionError       Code = 103
+	DbTransientError       Code = 104
 
 	// 201 db migration error
 	// Db migration is a core feature, so we separate it from the db error
@@ -33,10 +34,15 @@ const (
 	MigrationFailed          Code = 206
 
 	// 301 task error
-	TaskTimingNotAllowed Code = 301
+	TaskTimingNotAllowed         Code = 301
+	TaskOutsideMaintenanceWindow Code = 302
 
 	// 401 task check error
-	TaskCheckEmptySchemaReviewPolicy Code = 401
+	TaskCheckEmptySchemaReviewPolicy  Code = 401
+	TaskCheckNonTransactionalDDL      Code = 402
+	TaskCheckFullTableScan            Code = 403
+	TaskCheckAffectedRowLimitExceeded Code = 404
+	TaskCheckAffectedRowsUnknown      Code = 405
 
 	// 10001 advisor error code
 	CompatibilityDropDatabase  Code = 10001
@@ -96,6 +102,11 @@ func (e *Error) Error() string {
 	return e.Err.Error()
 }
 
+// Unwrap returns the embedded error, so that the callers can inspect the underlying driver error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
 // ErrorCode unwraps an application error and returns its code.
 // Non-application errors always return EINTERNAL.
 func ErrorCode(err error) Code {
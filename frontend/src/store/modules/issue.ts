@@ -11,12 +11,14 @@ import {
   IssueStatus,
   IssueStatusPatch,
   Pipeline,
+  PipelineId,
   Principal,
   PrincipalId,
   Project,
   ProjectId,
   ResourceIdentifier,
   ResourceObject,
+  TaskId,
   unknown,
 } from "@/types";
 import { getPrincipalFromIncludedList } from "./principal";
@@ -194,6 +196,27 @@ export const useIssueStore = defineStore("issue", {
 
       return createdIssue;
     },
+    async createRollbackIssue({
+      pipelineId,
+      taskId,
+    }: {
+      pipelineId: PipelineId;
+      taskId: TaskId;
+    }) {
+      const data = (
+        await axios.post(
+          `/api/pipeline/${pipelineId}/task/${taskId}/rollback-issue`
+        )
+      ).data;
+      const createdIssue = convert(data.data, data.included);
+
+      this.setIssueById({
+        issueId: createdIssue.id,
+        issue: createdIssue,
+      });
+
+      return createdIssue;
+    },
     async validateIssue(newIssue: IssueCreate) {
       const data = (
         await axios.post(`/api/issue`, {
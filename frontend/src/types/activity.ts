@@ -13,6 +13,7 @@ export type IssueActivityType =
   | "bb.issue.field.update"
   | "bb.issue.status.update"
   | "bb.pipeline.task.status.update"
+  | "bb.pipeline.task.approve"
   | "bb.pipeline.task.file.commit"
   | "bb.pipeline.task.statement.update"
   | "bb.pipeline.task.general.earliest-allowed-time.update";
@@ -47,6 +48,8 @@ export function activityName(type: ActivityType): string {
       return t("activity.type.issue-status-update");
     case "bb.pipeline.task.status.update":
       return t("activity.type.pipeline-task-status-update");
+    case "bb.pipeline.task.approve":
+      return t("activity.type.pipeline-task-approve");
     case "bb.pipeline.task.file.commit":
       return t("activity.type.pipeline-task-file-commit");
     case "bb.pipeline.task.statement.update":
@@ -105,6 +108,18 @@ export type ActivityTaskStatusUpdatePayload = {
   taskName: string;
 };
 
+export type ActivityTaskApprovePayload = {
+  taskId: TaskId;
+  // The 1-based index of the approval step.
+  step: number;
+  stepCount: number;
+  approvalCount: number;
+  requiredApprovalCount: number;
+  approver: string;
+  issueName: string;
+  taskName: string;
+};
+
 export type ActivityTaskFileCommitPayload = {
   taskId: TaskId;
   vcsInstanceUrl: string;
@@ -170,6 +185,7 @@ export type ActionPayloadType =
   | ActivityIssueFieldUpdatePayload
   | ActivityIssueStatusUpdatePayload
   | ActivityTaskStatusUpdatePayload
+  | ActivityTaskApprovePayload
   | ActivityTaskFileCommitPayload
   | ActivityTaskStatementUpdatePayload
   | ActivityTaskEarliestAllowedTimeUpdatePayload
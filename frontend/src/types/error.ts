@@ -12,6 +12,7 @@ export enum DBErrorCode {
   CONNECTION_ERROR = 101,
   SYNTAX_ERROR = 102,
   EXECUTION_ERROR = 103,
+  TRANSIENT_ERROR = 104,
 }
 
 export enum MigrationErrorCode {
@@ -21,6 +22,13 @@ export enum MigrationErrorCode {
   MIGRATION_BASELINE_MISSING = 204,
 }
 
+export enum TaskCheckErrorCode {
+  NON_TRANSACTIONAL_DDL = 402,
+  FULL_TABLE_SCAN = 403,
+  AFFECTED_ROW_LIMIT_EXCEEDED = 404,
+  AFFECTED_ROWS_UNKNOWN = 405,
+}
+
 export enum SchemaReviewPolicyErrorCode {
   EMPTY_POLICY = 401,
   STATEMENT_NO_WHERE = 10101,
@@ -64,6 +64,29 @@ export type Instance = {
   externalLink?: string;
   host: string;
   port?: string;
+  // Only returned when fetching a single instance.
+  capabilities?: InstanceCapabilities;
+};
+
+export type SchemaObjectKind =
+  | "TABLE"
+  | "VIEW"
+  | "INDEX"
+  | "FOREIGN_KEY"
+  | "CHECK_CONSTRAINT"
+  | "TRIGGER"
+  | "ROUTINE"
+  | "SEQUENCE"
+  | "EXTENSION";
+
+export type InstanceCapabilities = {
+  transactionalDDL: boolean;
+  pitr: boolean;
+  ghOst: boolean;
+  readOnlyConnection: boolean;
+  schemaObjectKindList: SchemaObjectKind[];
+  dumpFormat: "SQL";
+  explain: boolean;
 };
 
 export type InstanceCreate = {
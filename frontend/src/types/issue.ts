@@ -47,6 +47,8 @@ export type UpdateSchemaDetail = {
   databaseName: string;
   statement: string;
   earliestAllowedTs: number;
+  // Only applicable to the data update, allowing the task retry policy to retry the failed task.
+  idempotent?: boolean;
 };
 
 export type UpdateSchemaGhostDetail = UpdateSchemaDetail & {
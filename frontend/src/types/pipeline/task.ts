@@ -90,6 +90,7 @@ export type TaskDatabasePITRDeletePayload = {
 export type TaskDatabaseDataUpdatePayload = {
   statement: string;
   pushEvent?: VCSPushEvent;
+  idempotent?: boolean;
 };
 
 export type TaskDatabaseRestorePayload = {
@@ -174,6 +175,8 @@ export type TaskRunResultPayload = {
   detail: string;
   migrationId?: MigrationHistoryId;
   version?: string;
+  rollbackStatement?: string;
+  rollbackError?: string;
 };
 
 export type TaskRun = {
@@ -202,9 +205,12 @@ export type TaskCheckType =
   | "bb.task-check.database.statement.syntax"
   | "bb.task-check.database.statement.compatibility"
   | "bb.task-check.database.statement.advise"
+  | "bb.task-check.database.statement.full-table-scan"
+  | "bb.task-check.database.statement.affected-rows"
   | "bb.task-check.database.connect"
   | "bb.task-check.instance.migration-schema"
   | "bb.task-check.general.earliest-allowed-time"
+  | "bb.task-check.general.maintenance-window"
   | "bb.task-check.database.schema.update.ghost"
   | "bb.task-check.database.schema.update.ghost.cutover";
 
@@ -16,8 +16,19 @@ export type PipelineApprovalPolicyValue =
   | "MANUAL_APPROVAL_NEVER"
   | "MANUAL_APPROVAL_ALWAYS";
 
+export type ApprovalStepType = "WORKSPACE_ROLE" | "PROJECT_ROLE" | "GROUP";
+
+export type ApprovalStep = {
+  type: ApprovalStepType;
+  role?: string;
+  groupName?: string;
+  memberIdList?: number[];
+  approvalCount: number;
+};
+
 export type PipelineApporvalPolicyPayload = {
   value: PipelineApprovalPolicyValue;
+  stepList?: ApprovalStep[];
 };
 
 export const DefaultApporvalPolicy: PipelineApprovalPolicyValue =
@@ -27,3 +27,32 @@ export type SQLResultSet = {
   data: string;
   error: string;
 };
+
+export type ExplainInfo = {
+  instanceId: InstanceId;
+  databaseName?: string;
+  statement: string;
+};
+
+export type PlanNode = {
+  operation:
+    | "TABLE_SCAN"
+    | "INDEX_SCAN"
+    | "JOIN"
+    | "SORT"
+    | "AGGREGATE"
+    | "MODIFY"
+    | "OTHER";
+  detail: string;
+  table?: string;
+  index?: string;
+  estimatedRows?: number;
+  cost?: number;
+  children?: PlanNode[];
+};
+
+export type SQLExplainResult = {
+  // JSON of the PlanNode tree.
+  plan: string;
+  error: string;
+};
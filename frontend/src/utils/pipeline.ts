@@ -78,9 +78,10 @@ export function activeStage(pipeline: Pipeline): Stage {
         task.status == "PENDING" ||
         task.status == "PENDING_APPROVAL" ||
         task.status == "RUNNING" ||
-        // "FAILED" is also a transient task status, which requires user
-        // to take further action (e.g. Skip, Retry)
-        task.status == "FAILED"
+        // "FAILED" and "CANCELED" are also transient task statuses, which
+        // require user to take further action (e.g. Skip, Retry)
+        task.status == "FAILED" ||
+        task.status == "CANCELED"
       ) {
         return stage;
       }
@@ -100,9 +101,10 @@ export function activeTask(pipeline: Pipeline): Task {
         task.status == "PENDING" ||
         task.status == "PENDING_APPROVAL" ||
         task.status == "RUNNING" ||
-        // "FAILED" is also a transient task status, which requires user
-        // to take further action (e.g. Skip, Retry)
-        task.status == "FAILED"
+        // "FAILED" and "CANCELED" are also transient task statuses, which
+        // require user to take further action (e.g. Skip, Retry)
+        task.status == "FAILED" ||
+        task.status == "CANCELED"
       ) {
         return task;
       }
@@ -123,9 +125,10 @@ export function activeTaskInStage(stage: Stage): Task {
       task.status == "PENDING" ||
       task.status == "PENDING_APPROVAL" ||
       task.status == "RUNNING" ||
-      // "FAILED" is also a transient task status, which requires user
-      // to take further action (e.g. Skip, Retry)
-      task.status == "FAILED"
+      // "FAILED" and "CANCELED" are also transient task statuses, which
+      // require user to take further action (e.g. Skip, Retry)
+      task.status == "FAILED" ||
+      task.status == "CANCELED"
     ) {
       return task;
     }
@@ -203,7 +206,7 @@ const TASK_STATUS_TRANSITION_LIST: Map<
     "CANCEL",
     {
       type: "CANCEL",
-      to: "PENDING",
+      to: "CANCELED",
       buttonName: "common.cancel",
       buttonClass: "btn-primary",
     },
@@ -220,6 +223,7 @@ const APPLICABLE_TASK_TRANSITION_LIST: Map<
   ["RUNNING", ["CANCEL"]],
   ["DONE", []],
   ["FAILED", ["RETRY"]],
+  ["CANCELED", ["RETRY"]],
 ]);
 
 export function applicableTaskTransition(
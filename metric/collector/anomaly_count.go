@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"context"
+
+	metricAPI "github.com/bytebase/bytebase/metric"
+	"github.com/bytebase/bytebase/plugin/metric"
+	"github.com/bytebase/bytebase/store"
+)
+
+var _ metric.Collector = (*anomalyCountCollector)(nil)
+
+// anomalyCountCollector is the metric data collector for anomaly.
+type anomalyCountCollector struct {
+	store *store.Store
+}
+
+// NewAnomalyCountCollector creates a new instance of anomalyCountCollector
+func NewAnomalyCountCollector(store *store.Store) metric.Collector {
+	return &anomalyCountCollector{
+		store: store,
+	}
+}
+
+// Collect will collect the metric for the active anomalies
+func (c *anomalyCountCollector) Collect(ctx context.Context) ([]*metric.Metric, error) {
+	var res []*metric.Metric
+
+	anomalyCountMetricList, err := c.store.CountAnomalyGroupByType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, anomalyCountMetric := range anomalyCountMetricList {
+		res = append(res, &metric.Metric{
+			Name:  metricAPI.AnomalyCountMetricName,
+			Value: anomalyCountMetric.Count,
+			Labels: map[string]string{
+				"type": string(anomalyCountMetric.Type),
+			},
+		})
+	}
+
+	return res, nil
+}
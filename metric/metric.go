@@ -23,6 +23,8 @@ const (
 	SheetCountMetricName metric.Name = "bb.sheet.count"
 	// MemberCountMetricName is the metric name for member count
 	MemberCountMetricName metric.Name = "bb.member.count"
+	// AnomalyCountMetricName is the metric name for anomaly count
+	AnomalyCountMetricName metric.Name = "bb.anomaly.count"
 )
 
 // InstanceCountMetric is the API message for bb.instance.count
@@ -86,3 +88,9 @@ type MemberCountMetric struct {
 	Status    api.MemberStatus
 	RowStatus api.RowStatus
 }
+
+// AnomalyCountMetric is the API message for anomaly count metric
+type AnomalyCountMetric struct {
+	Type  api.AnomalyType
+	Count int
+}
@@ -97,6 +97,45 @@ const (
 
 	// MySQLTableRequirePK is an advisor type for MySQL table require primary key.
 	MySQLTableRequirePK Type = "bb.plugin.advisor.mysql.table.require-pk"
+
+	// PostgreSQLSyntax is an advisor type for PostgreSQL syntax.
+	PostgreSQLSyntax Type = "bb.plugin.advisor.postgresql.syntax"
+
+	// PostgreSQLMigrationCompatibility is an advisor type for PostgreSQL migration compatibility.
+	PostgreSQLMigrationCompatibility Type = "bb.plugin.advisor.postgresql.migration-compatibility"
+
+	// PostgreSQLWhereRequirement is an advisor type for PostgreSQL WHERE clause requirement.
+	PostgreSQLWhereRequirement Type = "bb.plugin.advisor.postgresql.where.require"
+
+	// PostgreSQLNoLeadingWildcardLike is an advisor type for PostgreSQL no leading wildcard LIKE.
+	PostgreSQLNoLeadingWildcardLike Type = "bb.plugin.advisor.postgresql.where.no-leading-wildcard-like"
+
+	// PostgreSQLNamingTableConvention is an advisor type for PostgreSQL table naming convention.
+	PostgreSQLNamingTableConvention Type = "bb.plugin.advisor.postgresql.naming.table"
+
+	// PostgreSQLNamingIndexConvention is an advisor type for PostgreSQL index naming convention.
+	PostgreSQLNamingIndexConvention Type = "bb.plugin.advisor.postgresql.naming.index"
+
+	// PostgreSQLNamingUKConvention is an advisor type for PostgreSQL unique key naming convention.
+	PostgreSQLNamingUKConvention Type = "bb.plugin.advisor.postgresql.naming.uk"
+
+	// PostgreSQLNamingFKConvention is an advisor type for PostgreSQL foreign key naming convention.
+	PostgreSQLNamingFKConvention Type = "bb.plugin.advisor.postgresql.naming.fk"
+
+	// PostgreSQLNamingColumnConvention is an advisor type for PostgreSQL column naming convention.
+	PostgreSQLNamingColumnConvention Type = "bb.plugin.advisor.postgresql.naming.column"
+
+	// PostgreSQLColumnRequirement is an advisor type for PostgreSQL column requirement.
+	PostgreSQLColumnRequirement Type = "bb.plugin.advisor.postgresql.column.require"
+
+	// PostgreSQLColumnNoNull is an advisor type for PostgreSQL column no NULL value.
+	PostgreSQLColumnNoNull Type = "bb.plugin.advisor.postgresql.column.no-null"
+
+	// PostgreSQLNoSelectAll is an advisor type for PostgreSQL no select all.
+	PostgreSQLNoSelectAll Type = "bb.plugin.advisor.postgresql.select.no-select-all"
+
+	// PostgreSQLTableRequirePK is an advisor type for PostgreSQL table require primary key.
+	PostgreSQLTableRequirePK Type = "bb.plugin.advisor.postgresql.table.require-pk"
 )
 
 // Advice is the result of an advisor.
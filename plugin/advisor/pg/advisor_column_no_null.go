@@ -0,0 +1,118 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnNoNullAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLColumnNoNull, &ColumnNoNullAdvisor{})
+}
+
+// ColumnNoNullAdvisor is the advisor checking for column no NULL value.
+type ColumnNoNullAdvisor struct {
+}
+
+// Check checks for column no NULL value.
+func (adv *ColumnNoNullAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnNoNullChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt.AST)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnNoNullChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+}
+
+type columnName struct {
+	tableName  string
+	columnName string
+}
+
+func (v *columnNoNullChecker) check(stmt *pgquery.Node) {
+	var columns []columnName
+	switch node := stmt.Node.(type) {
+	// CREATE TABLE
+	case *pgquery.Node_CreateStmt:
+		for _, elt := range node.CreateStmt.TableElts {
+			if column := elt.GetColumnDef(); column != nil && canNull(column) {
+				columns = append(columns, columnName{
+					tableName:  node.CreateStmt.Relation.Relname,
+					columnName: column.Colname,
+				})
+			}
+		}
+	// ALTER TABLE
+	case *pgquery.Node_AlterTableStmt:
+		table := node.AlterTableStmt.Relation.Relname
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			cmd := cmd.GetAlterTableCmd()
+			if cmd == nil {
+				continue
+			}
+			switch cmd.Subtype {
+			// ADD COLUMN
+			case pgquery.AlterTableType_AT_AddColumn:
+				if column := cmd.Def.GetColumnDef(); column != nil && canNull(column) {
+					columns = append(columns, columnName{
+						tableName:  table,
+						columnName: column.Colname,
+					})
+				}
+			// ALTER COLUMN DROP NOT NULL
+			case pgquery.AlterTableType_AT_DropNotNull:
+				columns = append(columns, columnName{
+					tableName:  table,
+					columnName: cmd.Name,
+				})
+			}
+		}
+	}
+
+	for _, column := range columns {
+		v.adviceList = append(v.adviceList, advisor.Advice{
+			Status:  v.level,
+			Code:    common.ColumnCanNotNull,
+			Title:   v.title,
+			Content: fmt.Sprintf("`%s`.`%s` can not have NULL value", column.tableName, column.columnName),
+		})
+	}
+}
+
+func canNull(column *pgquery.ColumnDef) bool {
+	return len(columnConstraintList(column, pgquery.ConstrType_CONSTR_NOTNULL)) == 0 &&
+		len(columnConstraintList(column, pgquery.ConstrType_CONSTR_PRIMARY)) == 0
+}
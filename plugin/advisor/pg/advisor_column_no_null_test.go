@@ -0,0 +1,69 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestColumnNoNull(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE TABLE book(id int PRIMARY KEY, name text NOT NULL)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE book(id int PRIMARY KEY, name text, author text NULL)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.ColumnCanNotNull,
+					Title:   "column.no-null",
+					Content: "`book`.`name` can not have NULL value",
+				},
+				{
+					Status:  advisor.Warn,
+					Code:    common.ColumnCanNotNull,
+					Title:   "column.no-null",
+					Content: "`book`.`author` can not have NULL value",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE book ADD COLUMN author text",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.ColumnCanNotNull,
+					Title:   "column.no-null",
+					Content: "`book`.`author` can not have NULL value",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE book ALTER COLUMN name DROP NOT NULL",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.ColumnCanNotNull,
+					Title:   "column.no-null",
+					Content: "`book`.`name` can not have NULL value",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &ColumnNoNullAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnNotNull,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &MockCatalogService{})
+}
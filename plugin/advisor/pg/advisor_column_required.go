@@ -0,0 +1,209 @@
+package pg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnRequirementAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLColumnRequirement, &ColumnRequirementAdvisor{})
+}
+
+// ColumnRequirementAdvisor is the advisor checking for column requirement.
+type ColumnRequirementAdvisor struct {
+}
+
+// Check checks for the column requirement.
+func (adv *ColumnRequirementAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalRequiredColumnRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	requiredColumns := make(columnSet)
+	for _, column := range payload.ColumnList {
+		requiredColumns[column] = true
+	}
+	checker := &columnRequirementChecker{
+		level:           level,
+		title:           string(ctx.Rule.Type),
+		requiredColumns: requiredColumns,
+		tables:          make(tableState),
+	}
+
+	for _, stmt := range stmts {
+		checker.check(stmt.AST)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type columnRequirementChecker struct {
+	adviceList      []advisor.Advice
+	level           advisor.Status
+	title           string
+	requiredColumns columnSet
+	tables          tableState
+}
+
+func (v *columnRequirementChecker) check(stmt *pgquery.Node) {
+	switch node := stmt.Node.(type) {
+	// CREATE TABLE
+	case *pgquery.Node_CreateStmt:
+		v.createTable(node.CreateStmt)
+	// DROP TABLE
+	case *pgquery.Node_DropStmt:
+		if node.DropStmt.RemoveType == pgquery.ObjectType_OBJECT_TABLE {
+			for _, table := range dropTableNameList(node.DropStmt) {
+				delete(v.tables, table)
+			}
+		}
+	// ALTER TABLE
+	case *pgquery.Node_AlterTableStmt:
+		table := rangeVarTableName(node.AlterTableStmt.Relation)
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			cmd := cmd.GetAlterTableCmd()
+			if cmd == nil {
+				continue
+			}
+			switch cmd.Subtype {
+			// ADD COLUMN
+			case pgquery.AlterTableType_AT_AddColumn:
+				if column := cmd.Def.GetColumnDef(); column != nil {
+					v.addColumn(table, column.Colname)
+				}
+			// DROP COLUMN
+			case pgquery.AlterTableType_AT_DropColumn:
+				v.dropColumn(table, cmd.Name)
+			}
+		}
+	// ALTER TABLE RENAME COLUMN
+	case *pgquery.Node_RenameStmt:
+		if node.RenameStmt.RenameType == pgquery.ObjectType_OBJECT_COLUMN && node.RenameStmt.Relation != nil {
+			v.renameColumn(rangeVarTableName(node.RenameStmt.Relation), node.RenameStmt.Subname, node.RenameStmt.Newname)
+		}
+	}
+}
+
+func (v *columnRequirementChecker) generateAdviceList() []advisor.Advice {
+	// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+	tableList := v.tables.tableList()
+	for _, tableName := range tableList {
+		table := v.tables[tableName]
+		var missingColumns []string
+		for column := range v.requiredColumns {
+			if exist, ok := table[column]; !ok || !exist {
+				missingColumns = append(missingColumns, column)
+			}
+		}
+		if len(missingColumns) > 0 {
+			// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+			sort.Strings(missingColumns)
+			v.adviceList = append(v.adviceList, advisor.Advice{
+				Status:  v.level,
+				Code:    common.NoRequiredColumn,
+				Title:   v.title,
+				Content: fmt.Sprintf("Table `%s` requires columns: %s", tableName, strings.Join(missingColumns, ", ")),
+			})
+		}
+	}
+
+	if len(v.adviceList) == 0 {
+		v.adviceList = append(v.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return v.adviceList
+}
+
+// initEmptyTable will initialize a table without any required columns.
+func (v *columnRequirementChecker) initEmptyTable(name string) columnSet {
+	v.tables[name] = make(columnSet)
+	return v.tables[name]
+}
+
+// initFullTable will initialize a table with all required columns.
+func (v *columnRequirementChecker) initFullTable(name string) columnSet {
+	table := v.initEmptyTable(name)
+	for column := range v.requiredColumns {
+		table[column] = true
+	}
+	return table
+}
+
+func (v *columnRequirementChecker) renameColumn(table string, oldColumn string, newColumn string) {
+	_, oldNeed := v.requiredColumns[oldColumn]
+	_, newNeed := v.requiredColumns[newColumn]
+	if !oldNeed && !newNeed {
+		return
+	}
+	t, ok := v.tables[table]
+	if !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		t = v.initFullTable(table)
+	}
+	if oldNeed {
+		t[oldColumn] = false
+	}
+	if newNeed {
+		t[newColumn] = true
+	}
+}
+
+func (v *columnRequirementChecker) dropColumn(table string, column string) {
+	if _, ok := v.requiredColumns[column]; !ok {
+		return
+	}
+	t, ok := v.tables[table]
+	if !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		t = v.initFullTable(table)
+	}
+	t[column] = false
+}
+
+func (v *columnRequirementChecker) addColumn(table string, column string) {
+	if _, ok := v.requiredColumns[column]; !ok {
+		return
+	}
+	if t, ok := v.tables[table]; !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		v.initFullTable(table)
+	} else {
+		t[column] = true
+	}
+}
+
+func (v *columnRequirementChecker) createTable(node *pgquery.CreateStmt) {
+	table := rangeVarTableName(node.Relation)
+	v.initEmptyTable(table)
+	for _, elt := range node.TableElts {
+		if column := elt.GetColumnDef(); column != nil {
+			v.addColumn(table, column.Colname)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnRequirement(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE TABLE book(id int, creator_id int, created_ts timestamp, updater_id int, updated_ts timestamp)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE book(id int, name text)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NoRequiredColumn,
+					Title:   "column.required",
+					Content: "Table `public.book` requires columns: created_ts, creator_id, updated_ts, updater_id",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE book(id int, name text); ALTER TABLE book ADD COLUMN created_ts timestamp, ADD COLUMN creator_id int, ADD COLUMN updated_ts timestamp, ADD COLUMN updater_id int",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE book RENAME COLUMN creator_id TO author_id",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NoRequiredColumn,
+					Title:   "column.required",
+					Content: "Table `public.book` requires columns: creator_id",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE book DROP COLUMN created_ts",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NoRequiredColumn,
+					Title:   "column.required",
+					Content: "Table `public.book` requires columns: created_ts",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE book(id int); DROP TABLE book",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+	payload, err := json.Marshal(advisor.RequiredColumnRulePayload{
+		ColumnList: []string{
+			"id",
+			"created_ts",
+			"updated_ts",
+			"creator_id",
+			"updater_id",
+		},
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &ColumnRequirementAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleRequiredColumn,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
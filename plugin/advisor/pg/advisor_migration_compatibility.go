@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*CompatibilityAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLMigrationCompatibility, &CompatibilityAdvisor{})
+}
+
+// CompatibilityAdvisor is the advisor checking for schema backward compatibility.
+type CompatibilityAdvisor struct {
+}
+
+// Check checks schema backward compatibility.
+func (adv *CompatibilityAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compatibilityChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+	for _, stmt := range stmts {
+		c.check(stmt)
+	}
+
+	if len(c.adviceList) == 0 {
+		c.adviceList = append(c.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return c.adviceList, nil
+}
+
+type compatibilityChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+}
+
+func (v *compatibilityChecker) check(stmt statement) {
+	code := common.Ok
+	switch node := stmt.AST.Node.(type) {
+	// DROP DATABASE
+	case *pgquery.Node_DropdbStmt:
+		code = common.CompatibilityDropDatabase
+	// ALTER TABLE/VIEW RENAME
+	case *pgquery.Node_RenameStmt:
+		switch node.RenameStmt.RenameType {
+		// RENAME TO
+		case pgquery.ObjectType_OBJECT_TABLE, pgquery.ObjectType_OBJECT_VIEW:
+			code = common.CompatibilityRenameTable
+		// RENAME COLUMN
+		case pgquery.ObjectType_OBJECT_COLUMN:
+			code = common.CompatibilityRenameColumn
+		}
+	// DROP TABLE/VIEW
+	case *pgquery.Node_DropStmt:
+		switch node.DropStmt.RemoveType {
+		case pgquery.ObjectType_OBJECT_TABLE, pgquery.ObjectType_OBJECT_VIEW:
+			code = common.CompatibilityDropTable
+		}
+	// ALTER TABLE
+	case *pgquery.Node_AlterTableStmt:
+		code = alterTableCompatibilityCode(node.AlterTableStmt)
+	// CREATE UNIQUE INDEX
+	case *pgquery.Node_IndexStmt:
+		if node.IndexStmt.Unique {
+			code = common.CompatibilityAddUniqueKey
+		}
+	}
+
+	if code != common.Ok {
+		v.adviceList = append(v.adviceList, advisor.Advice{
+			Status:  v.level,
+			Code:    code,
+			Title:   v.title,
+			Content: fmt.Sprintf("\"%s\" may cause incompatibility with the existing data and code", stmt.SQL),
+		})
+	}
+}
+
+// alterTableCompatibilityCode returns the code of the first incompatible command in the ALTER TABLE statement.
+func alterTableCompatibilityCode(node *pgquery.AlterTableStmt) common.Code {
+	for _, cmd := range node.Cmds {
+		cmd := cmd.GetAlterTableCmd()
+		if cmd == nil {
+			continue
+		}
+		switch cmd.Subtype {
+		// DROP COLUMN
+		case pgquery.AlterTableType_AT_DropColumn:
+			return common.CompatibilityDropColumn
+		// ADD CONSTRAINT
+		case pgquery.AlterTableType_AT_AddConstraint:
+			switch cmd.Def.GetConstraint().GetContype() {
+			// ADD PRIMARY KEY
+			case pgquery.ConstrType_CONSTR_PRIMARY:
+				return common.CompatibilityAddPrimaryKey
+			// ADD UNIQUE
+			case pgquery.ConstrType_CONSTR_UNIQUE:
+				return common.CompatibilityAddUniqueKey
+			// ADD FOREIGN KEY
+			case pgquery.ConstrType_CONSTR_FOREIGN:
+				return common.CompatibilityAddForeignKey
+			// ADD CHECK
+			case pgquery.ConstrType_CONSTR_CHECK:
+				return common.CompatibilityAddCheck
+			}
+		// ALTER COLUMN TYPE / SET NOT NULL
+		// Due to the limitation that we don't know the current data type of the column before the change,
+		// so we treat all type changes as incompatible, including the compatible ones such as INT to BIGINT.
+		case pgquery.AlterTableType_AT_AlterColumnType, pgquery.AlterTableType_AT_SetNotNull:
+			return common.CompatibilityAlterColumn
+		}
+	}
+	return common.Ok
+}
@@ -0,0 +1,162 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestMigrationCompatibility(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE TABLE t(a int)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "DROP DATABASE test",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityDropDatabase,
+					Title:   "schema.backward-compatibility",
+					Content: "\"DROP DATABASE test\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t RENAME TO s",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityRenameTable,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t RENAME TO s\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "DROP TABLE t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityDropTable,
+					Title:   "schema.backward-compatibility",
+					Content: "\"DROP TABLE t\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t RENAME COLUMN a TO b",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityRenameColumn,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t RENAME COLUMN a TO b\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t DROP COLUMN a",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityDropColumn,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t DROP COLUMN a\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN b int",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ADD PRIMARY KEY (a)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAddPrimaryKey,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t ADD PRIMARY KEY (a)\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT uk_t_a UNIQUE (a)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAddUniqueKey,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t ADD CONSTRAINT uk_t_a UNIQUE (a)\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT fk_t_a_s_a FOREIGN KEY (a) REFERENCES s (a)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAddForeignKey,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t ADD CONSTRAINT fk_t_a_s_a FOREIGN KEY (a) REFERENCES s (a)\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT check_a CHECK (a > 0)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAddCheck,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t ADD CONSTRAINT check_a CHECK (a > 0)\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN a TYPE bigint",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAlterColumn,
+					Title:   "schema.backward-compatibility",
+					Content: "\"ALTER TABLE t ALTER COLUMN a TYPE bigint\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+		{
+			statement: "CREATE UNIQUE INDEX uk_t_a ON t (a)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.CompatibilityAddUniqueKey,
+					Title:   "schema.backward-compatibility",
+					Content: "\"CREATE UNIQUE INDEX uk_t_a ON t (a)\" may cause incompatibility with the existing data and code",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &CompatibilityAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleSchemaBackwardCompatibility,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &MockCatalogService{})
+}
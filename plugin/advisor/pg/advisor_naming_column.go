@@ -0,0 +1,107 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NamingColumnConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNamingColumnConvention, &NamingColumnConventionAdvisor{})
+}
+
+// NamingColumnConventionAdvisor is the advisor checking for column naming convention.
+type NamingColumnConventionAdvisor struct {
+}
+
+// Check checks for column naming convention.
+func (adv *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, err := advisor.UnamrshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingColumnConventionChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		format: format,
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt.AST)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingColumnConventionChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	format     *regexp.Regexp
+}
+
+func (checker *namingColumnConventionChecker) check(stmt *pgquery.Node) {
+	var columnList []string
+	var tableName string
+	switch node := stmt.Node.(type) {
+	// CREATE TABLE
+	case *pgquery.Node_CreateStmt:
+		tableName = node.CreateStmt.Relation.Relname
+		for _, elt := range node.CreateStmt.TableElts {
+			if column := elt.GetColumnDef(); column != nil {
+				columnList = append(columnList, column.Colname)
+			}
+		}
+	// ALTER TABLE ADD COLUMN
+	case *pgquery.Node_AlterTableStmt:
+		tableName = node.AlterTableStmt.Relation.Relname
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			if cmd := cmd.GetAlterTableCmd(); cmd != nil && cmd.Subtype == pgquery.AlterTableType_AT_AddColumn {
+				if column := cmd.Def.GetColumnDef(); column != nil {
+					columnList = append(columnList, column.Colname)
+				}
+			}
+		}
+	// ALTER TABLE RENAME COLUMN
+	case *pgquery.Node_RenameStmt:
+		if node.RenameStmt.RenameType == pgquery.ObjectType_OBJECT_COLUMN && node.RenameStmt.Relation != nil {
+			tableName = node.RenameStmt.Relation.Relname
+			columnList = append(columnList, node.RenameStmt.Newname)
+		}
+	}
+
+	for _, column := range columnList {
+		if !checker.format.MatchString(column) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.NamingColumnConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, naming format should be %q", tableName, column, checker.format),
+			})
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingColumnConvention(t *testing.T) {
+	tests := []test{
+		{
+			statement: `CREATE TABLE tech_book(id int, "bookName" text, "Author" text)`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NamingColumnConventionMismatch,
+					Title:   "naming.column",
+					Content: "`tech_book`.`bookName` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+				{
+					Status:  advisor.Warn,
+					Code:    common.NamingColumnConventionMismatch,
+					Title:   "naming.column",
+					Content: "`tech_book`.`Author` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE tech_book(id int, book_name text)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: `ALTER TABLE tech_book ADD COLUMN "createdTs" timestamp`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NamingColumnConventionMismatch,
+					Title:   "naming.column",
+					Content: "`tech_book`.`createdTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+			},
+		},
+		{
+			statement: `ALTER TABLE tech_book RENAME COLUMN book_name TO "bookName"`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NamingColumnConventionMismatch,
+					Title:   "naming.column",
+					Content: "`tech_book`.`bookName` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book RENAME COLUMN name TO book_name",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^[a-z]+(_[a-z]+)*$",
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &NamingColumnConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnNaming,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
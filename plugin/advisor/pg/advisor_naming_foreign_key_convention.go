@@ -0,0 +1,141 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NamingFKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNamingFKConvention, &NamingFKConventionAdvisor{})
+}
+
+// NamingFKConventionAdvisor is the advisor checking for foreign key naming convention.
+type NamingFKConventionAdvisor struct {
+}
+
+// Check checks for foreign key naming convention.
+func (check *NamingFKConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingFKConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+
+	return checker.adviceList, nil
+}
+
+type namingFKConventionChecker struct {
+	adviceList   []advisor.Advice
+	level        advisor.Status
+	title        string
+	format       string
+	templateList []string
+}
+
+func (checker *namingFKConventionChecker) check(stmt statement) {
+	indexDataList := checker.getMetaDataList(stmt.AST)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.Internal,
+				Title:   "Internal error for foreign key naming convention rule",
+				Content: fmt.Sprintf("%q meet internal error %q", stmt.SQL, err.Error()),
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.NamingFKConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("Foreign key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+			})
+		}
+	}
+}
+
+// getMetaDataList returns the list of foreign key with meta data.
+func (checker *namingFKConventionChecker) getMetaDataList(stmt *pgquery.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	switch node := stmt.Node.(type) {
+	case *pgquery.Node_CreateStmt:
+		tableName := node.CreateStmt.Relation.Relname
+		for _, elt := range node.CreateStmt.TableElts {
+			switch def := elt.Node.(type) {
+			case *pgquery.Node_ColumnDef:
+				for _, constraint := range columnConstraintList(def.ColumnDef, pgquery.ConstrType_CONSTR_FOREIGN) {
+					res = append(res, newFKMetaData(tableName, []string{def.ColumnDef.Colname}, constraint))
+				}
+			case *pgquery.Node_Constraint:
+				if def.Constraint.Contype == pgquery.ConstrType_CONSTR_FOREIGN {
+					res = append(res, newFKMetaData(tableName, stringList(def.Constraint.FkAttrs), def.Constraint))
+				}
+			}
+		}
+	case *pgquery.Node_AlterTableStmt:
+		tableName := node.AlterTableStmt.Relation.Relname
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			if cmd := cmd.GetAlterTableCmd(); cmd != nil && cmd.Subtype == pgquery.AlterTableType_AT_AddConstraint {
+				if constraint := cmd.Def.GetConstraint(); constraint != nil && constraint.Contype == pgquery.ConstrType_CONSTR_FOREIGN {
+					res = append(res, newFKMetaData(tableName, stringList(constraint.FkAttrs), constraint))
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func newFKMetaData(tableName string, columnList []string, constraint *pgquery.Constraint) *indexMetaData {
+	metaData := map[string]string{
+		advisor.ReferencingTableNameTemplateToken:  tableName,
+		advisor.ReferencingColumnNameTemplateToken: strings.Join(columnList, "_"),
+		advisor.ReferencedTableNameTemplateToken:   constraint.Pktable.Relname,
+		advisor.ReferencedColumnNameTemplateToken:  strings.Join(stringList(constraint.PkAttrs), "_"),
+	}
+	return &indexMetaData{
+		indexName: constraint.Conname,
+		tableName: tableName,
+		metaData:  metaData,
+	}
+}
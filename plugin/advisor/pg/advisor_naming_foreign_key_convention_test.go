@@ -0,0 +1,47 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingFKConvention(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE TABLE book(id int, author_id int, CONSTRAINT fk_book_author_id_author_id FOREIGN KEY (author_id) REFERENCES author (id))",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE book ADD CONSTRAINT book_author_id_fkey FOREIGN KEY (author_id) REFERENCES author (id)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.NamingFKConventionMismatch,
+					Title:   "naming.index.fk",
+					Content: "Foreign key in table `book` mismatches the naming convention, expect \"^fk_book_author_id_author_id$\" but found `book_author_id_fkey`",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^fk_{{referencing_table}}_{{referencing_column}}_{{referenced_table}}_{{referenced_column}}$",
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &NamingFKConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleFKNaming,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,116 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NamingIndexConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
+}
+
+// NamingIndexConventionAdvisor is the advisor checking for index naming convention.
+type NamingIndexConventionAdvisor struct {
+}
+
+// Check checks for index naming convention.
+func (check *NamingIndexConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingIndexConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+
+	return checker.adviceList, nil
+}
+
+type namingIndexConventionChecker struct {
+	adviceList   []advisor.Advice
+	level        advisor.Status
+	title        string
+	format       string
+	templateList []string
+}
+
+func (checker *namingIndexConventionChecker) check(stmt statement) {
+	indexDataList := checker.getMetaDataList(stmt.AST)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.Internal,
+				Title:   "Internal error for index naming convention rule",
+				Content: fmt.Sprintf("%q meet internal error %q", stmt.SQL, err.Error()),
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.NamingIndexConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("Index in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+			})
+		}
+	}
+}
+
+// getMetaDataList returns the list of index with meta data.
+// Postgres only creates indexes in CREATE INDEX, the ones created for constraints are checked by the constraint advisors.
+// ALTER INDEX ... RENAME TO is not checked because Postgres doesn't bind the index to a table in the statement.
+func (checker *namingIndexConventionChecker) getMetaDataList(stmt *pgquery.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	if node, ok := stmt.Node.(*pgquery.Node_IndexStmt); ok && !node.IndexStmt.Unique {
+		tableName := node.IndexStmt.Relation.Relname
+		metaData := map[string]string{
+			advisor.ColumnListTemplateToken: strings.Join(indexElemColumnList(node.IndexStmt.IndexParams), "_"),
+			advisor.TableNameTemplateToken:  tableName,
+		}
+		res = append(res, &indexMetaData{
+			indexName: node.IndexStmt.Idxname,
+			tableName: tableName,
+			metaData:  metaData,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,70 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingIndexConvention(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE INDEX idx_tech_book_id_name ON tech_book (id, name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE INDEX tech_book_id_name ON tech_book (id, name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.NamingIndexConventionMismatch,
+					Title:   "naming.index.idx",
+					Content: "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `tech_book_id_name`",
+				},
+			},
+		},
+		{
+			statement: "CREATE INDEX idx_tech_book_name ON public.tech_book USING btree (name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			// Unique index naming convention is checked by the unique key advisor.
+			statement: "CREATE UNIQUE INDEX tech_book_name ON tech_book (name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^idx_{{table}}_{{column_list}}$",
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &NamingIndexConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleIDXNaming,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NamingTableConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNamingTableConvention, &NamingTableConventionAdvisor{})
+}
+
+// NamingTableConventionAdvisor is the advisor checking for table naming convention.
+type NamingTableConventionAdvisor struct {
+}
+
+// Check checks for table naming convention.
+func (adv *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, err := advisor.UnamrshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingTableConventionChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		format: format,
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt.AST)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingTableConventionChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	format     *regexp.Regexp
+}
+
+func (checker *namingTableConventionChecker) check(stmt *pgquery.Node) {
+	var tableNames []string
+	switch node := stmt.Node.(type) {
+	// CREATE TABLE
+	case *pgquery.Node_CreateStmt:
+		tableNames = append(tableNames, node.CreateStmt.Relation.Relname)
+	// ALTER TABLE RENAME TO
+	case *pgquery.Node_RenameStmt:
+		if node.RenameStmt.RenameType == pgquery.ObjectType_OBJECT_TABLE {
+			tableNames = append(tableNames, node.RenameStmt.Newname)
+		}
+	}
+
+	for _, tableName := range tableNames {
+		if !checker.format.MatchString(tableName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.NamingTableConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("`%s` mismatches table naming convention, naming format should be %q", tableName, checker.format),
+			})
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingTableConvention(t *testing.T) {
+	tests := []test{
+		{
+			statement: `CREATE TABLE "techBook"(id int, name varchar(255))`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.NamingTableConventionMismatch,
+					Title:   "naming.table",
+					Content: "`techBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+			},
+		},
+		{
+			// Postgres folds unquoted identifiers to lower case.
+			statement: "CREATE TABLE techBook(id int, name varchar(255))",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE public.tech_book(id int, name varchar(255))",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: `ALTER TABLE tech_book RENAME TO "TechBook"`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.NamingTableConventionMismatch,
+					Title:   "naming.table",
+					Content: "`TechBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book RENAME TO literary_book",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: `ALTER VIEW tech_book_view RENAME TO "TechBookView"`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^[a-z]+(_[a-z]+)*$",
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &NamingTableConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleTableNaming,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,144 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NamingUKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNamingUKConvention, &NamingUKConventionAdvisor{})
+}
+
+// NamingUKConventionAdvisor is the advisor checking for unique key naming convention.
+type NamingUKConventionAdvisor struct {
+}
+
+// Check checks for unique key naming convention.
+func (check *NamingUKConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingUKConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+
+	return checker.adviceList, nil
+}
+
+type namingUKConventionChecker struct {
+	adviceList   []advisor.Advice
+	level        advisor.Status
+	title        string
+	format       string
+	templateList []string
+}
+
+func (checker *namingUKConventionChecker) check(stmt statement) {
+	indexDataList := checker.getMetaDataList(stmt.AST)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.Internal,
+				Title:   "Internal error for unique key naming convention rule",
+				Content: fmt.Sprintf("%q meet internal error %q", stmt.SQL, err.Error()),
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.NamingUKConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("Unique key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+			})
+		}
+	}
+}
+
+// getMetaDataList returns the list of unique key with meta data.
+func (checker *namingUKConventionChecker) getMetaDataList(stmt *pgquery.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	switch node := stmt.Node.(type) {
+	case *pgquery.Node_CreateStmt:
+		tableName := node.CreateStmt.Relation.Relname
+		for _, elt := range node.CreateStmt.TableElts {
+			switch def := elt.Node.(type) {
+			case *pgquery.Node_ColumnDef:
+				for _, constraint := range columnConstraintList(def.ColumnDef, pgquery.ConstrType_CONSTR_UNIQUE) {
+					res = append(res, newUKMetaData(tableName, constraint.Conname, []string{def.ColumnDef.Colname}))
+				}
+			case *pgquery.Node_Constraint:
+				if def.Constraint.Contype == pgquery.ConstrType_CONSTR_UNIQUE {
+					res = append(res, newUKMetaData(tableName, def.Constraint.Conname, stringList(def.Constraint.Keys)))
+				}
+			}
+		}
+	case *pgquery.Node_AlterTableStmt:
+		tableName := node.AlterTableStmt.Relation.Relname
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			if cmd := cmd.GetAlterTableCmd(); cmd != nil && cmd.Subtype == pgquery.AlterTableType_AT_AddConstraint {
+				// ADD CONSTRAINT ... UNIQUE USING INDEX is skipped because the columns are not in the statement.
+				if constraint := cmd.Def.GetConstraint(); constraint != nil && constraint.Contype == pgquery.ConstrType_CONSTR_UNIQUE && constraint.Indexname == "" {
+					res = append(res, newUKMetaData(tableName, constraint.Conname, stringList(constraint.Keys)))
+				}
+			}
+		}
+	case *pgquery.Node_IndexStmt:
+		if node.IndexStmt.Unique {
+			res = append(res, newUKMetaData(node.IndexStmt.Relation.Relname, node.IndexStmt.Idxname, indexElemColumnList(node.IndexStmt.IndexParams)))
+		}
+	}
+
+	return res
+}
+
+func newUKMetaData(tableName string, indexName string, columnList []string) *indexMetaData {
+	metaData := map[string]string{
+		advisor.ColumnListTemplateToken: strings.Join(columnList, "_"),
+		advisor.TableNameTemplateToken:  tableName,
+	}
+	return &indexMetaData{
+		indexName: indexName,
+		tableName: tableName,
+		metaData:  metaData,
+	}
+}
@@ -0,0 +1,81 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingUKConvention(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE UNIQUE INDEX uk_tech_book_id_name ON tech_book (id, name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE UNIQUE INDEX tech_book_id_name ON tech_book (id, name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.NamingUKConventionMismatch,
+					Title:   "naming.index.uk",
+					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `tech_book_id_name`",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE tech_book(id int PRIMARY KEY, name text, CONSTRAINT uk_tech_book_name UNIQUE (name))",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book ADD CONSTRAINT tech_book_name_key UNIQUE (name)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.NamingUKConventionMismatch,
+					Title:   "naming.index.uk",
+					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found `tech_book_name_key`",
+				},
+			},
+		},
+		{
+			// Primary key is not a unique key.
+			statement: "ALTER TABLE tech_book ADD CONSTRAINT tech_book_pkey PRIMARY KEY (id)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^uk_{{table}}_{{column_list}}$",
+	})
+	require.NoError(t, err)
+	runSchemaReviewRuleTests(t, tests, &NamingUKConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleUKNaming,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &MockCatalogService{})
+}
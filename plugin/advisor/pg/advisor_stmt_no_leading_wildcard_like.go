@@ -0,0 +1,85 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	wildcard string = "%"
+)
+
+var (
+	_ advisor.Advisor = (*NoLeadingWildcardLikeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
+}
+
+// NoLeadingWildcardLikeAdvisor is the advisor checking for no leading wildcard LIKE.
+type NoLeadingWildcardLikeAdvisor struct {
+}
+
+// Check checks for no leading wildcard LIKE.
+func (adv *NoLeadingWildcardLikeAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := &noLeadingWildcardLikeChecker{level: level}
+	for _, stmt := range stmts {
+		checker.leadingWildcardLike = false
+		walk(stmt.AST, checker.visit)
+
+		if checker.leadingWildcardLike {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    common.StatementLeadingWildcardLike,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("\"%s\" uses leading wildcard LIKE", stmt.SQL),
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type noLeadingWildcardLikeChecker struct {
+	adviceList          []advisor.Advice
+	level               advisor.Status
+	leadingWildcardLike bool
+}
+
+// visit looks for the LIKE expressions with a leading wildcard pattern, including the NOT and case-insensitive ones.
+func (v *noLeadingWildcardLikeChecker) visit(msg proto.Message) bool {
+	if expr, ok := msg.(*pgquery.A_Expr); ok {
+		switch expr.Kind {
+		case pgquery.A_Expr_Kind_AEXPR_LIKE, pgquery.A_Expr_Kind_AEXPR_ILIKE:
+			if pattern := expr.Rexpr.GetAConst().GetVal().GetString_(); pattern != nil && strings.HasPrefix(pattern.Str, wildcard) {
+				v.leadingWildcardLike = true
+			}
+		}
+	}
+	return !v.leadingWildcardLike
+}
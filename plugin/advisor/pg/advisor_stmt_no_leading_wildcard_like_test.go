@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestNoLeadingWildcardLike(t *testing.T) {
+	tests := []test{
+		{
+			statement: "SELECT a FROM t WHERE a LIKE 'abc%'",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "SELECT a FROM t WHERE a LIKE '%abc'",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementLeadingWildcardLike,
+					Title:   "statement.where.no-leading-wildcard-like",
+					Content: "\"SELECT a FROM t WHERE a LIKE '%abc'\" uses leading wildcard LIKE",
+				},
+			},
+		},
+		{
+			statement: "SELECT a FROM t WHERE a ILIKE 'abc' OR b NOT LIKE '%abc'",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementLeadingWildcardLike,
+					Title:   "statement.where.no-leading-wildcard-like",
+					Content: "\"SELECT a FROM t WHERE a ILIKE 'abc' OR b NOT LIKE '%abc'\" uses leading wildcard LIKE",
+				},
+			},
+		},
+		{
+			statement: "DELETE FROM t WHERE a IN (SELECT a FROM s WHERE b LIKE '%abc')",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementLeadingWildcardLike,
+					Title:   "statement.where.no-leading-wildcard-like",
+					Content: "\"DELETE FROM t WHERE a IN (SELECT a FROM s WHERE b LIKE '%abc')\" uses leading wildcard LIKE",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &NoLeadingWildcardLikeAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleStatementNoLeadingWildcardLike,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: "",
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*NoSelectAllAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLNoSelectAll, &NoSelectAllAdvisor{})
+}
+
+// NoSelectAllAdvisor is the advisor checking for no "select *".
+type NoSelectAllAdvisor struct {
+}
+
+// Check checks for no "select *".
+func (adv *NoSelectAllAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &noSelectAllChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type noSelectAllChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+}
+
+func (v *noSelectAllChecker) check(stmt statement) {
+	for _, clause := range selectStmtList(stmt.AST) {
+		for _, target := range clause.TargetList {
+			if isSelectAll(target.GetResTarget().GetVal()) {
+				v.adviceList = append(v.adviceList, advisor.Advice{
+					Status:  v.level,
+					Code:    common.StatementSelectAll,
+					Title:   v.title,
+					Content: fmt.Sprintf("\"%s\" uses SELECT all", stmt.SQL),
+				})
+				break
+			}
+		}
+	}
+}
+
+// isSelectAll returns whether the expression is "*" or "table.*".
+func isSelectAll(expr *pgquery.Node) bool {
+	fields := expr.GetColumnRef().GetFields()
+	return len(fields) > 0 && fields[len(fields)-1].GetAStar() != nil
+}
@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestNoSelectAll(t *testing.T) {
+	tests := []test{
+		{
+			statement: "SELECT * FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementSelectAll,
+					Title:   "statement.select.no-select-all",
+					Content: "\"SELECT * FROM t\" uses SELECT all",
+				},
+			},
+		},
+		{
+			statement: "SELECT t.* FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementSelectAll,
+					Title:   "statement.select.no-select-all",
+					Content: "\"SELECT t.* FROM t\" uses SELECT all",
+				},
+			},
+		},
+		{
+			statement: "SELECT a, b FROM (SELECT * FROM t) AS s",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementSelectAll,
+					Title:   "statement.select.no-select-all",
+					Content: "\"SELECT a, b FROM (SELECT * FROM t) AS s\" uses SELECT all",
+				},
+			},
+		},
+		{
+			statement: "SELECT a, b FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "SELECT count(*) FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &NoSelectAllAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleStatementNoSelectAll,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: "",
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,89 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+var (
+	_ advisor.Advisor = (*WhereRequirementAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLWhereRequirement, &WhereRequirementAdvisor{})
+}
+
+// WhereRequirementAdvisor is the advisor checking for the WHERE clause requirement.
+type WhereRequirementAdvisor struct {
+}
+
+// Check checks for the WHERE clause requirement.
+func (adv *WhereRequirementAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &whereRequirementChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+	for _, stmt := range stmts {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type whereRequirementChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+}
+
+func (v *whereRequirementChecker) check(stmt statement) {
+	noWhereCount := 0
+	switch node := stmt.AST.Node.(type) {
+	// DELETE
+	case *pgquery.Node_DeleteStmt:
+		if node.DeleteStmt.WhereClause == nil {
+			noWhereCount++
+		}
+	// UPDATE
+	case *pgquery.Node_UpdateStmt:
+		if node.UpdateStmt.WhereClause == nil {
+			noWhereCount++
+		}
+	}
+	// SELECT, including the ones in subqueries
+	for _, clause := range selectStmtList(stmt.AST) {
+		if clause.WhereClause == nil {
+			noWhereCount++
+		}
+	}
+
+	for i := 0; i < noWhereCount; i++ {
+		v.adviceList = append(v.adviceList, advisor.Advice{
+			Status:  v.level,
+			Code:    common.StatementNoWhere,
+			Title:   v.title,
+			Content: fmt.Sprintf("\"%s\" requires WHERE clause", stmt.SQL),
+		})
+	}
+}
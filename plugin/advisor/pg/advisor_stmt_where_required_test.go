@@ -0,0 +1,85 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestWhereRequirement(t *testing.T) {
+	tests := []test{
+		{
+			statement: "DELETE FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementNoWhere,
+					Title:   "statement.where.require",
+					Content: "\"DELETE FROM t\" requires WHERE clause",
+				},
+			},
+		},
+		{
+			statement: "UPDATE t SET a = 1",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementNoWhere,
+					Title:   "statement.where.require",
+					Content: "\"UPDATE t SET a = 1\" requires WHERE clause",
+				},
+			},
+		},
+		{
+			statement: "DELETE FROM t WHERE a = (SELECT max(id) FROM t)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementNoWhere,
+					Title:   "statement.where.require",
+					Content: "\"DELETE FROM t WHERE a = (SELECT max(id) FROM t)\" requires WHERE clause",
+				},
+			},
+		},
+		{
+			statement: "UPDATE t SET a = 1 WHERE b = 1; DELETE FROM t WHERE a = 1",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "SELECT a FROM t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    common.StatementNoWhere,
+					Title:   "statement.where.require",
+					Content: "\"SELECT a FROM t\" requires WHERE clause",
+				},
+			},
+		},
+		{
+			statement: "SELECT a FROM t WHERE a > 0",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &WhereRequirementAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleStatementRequireWhere,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: "",
+	}, &MockCatalogService{})
+}
@@ -0,0 +1,254 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/catalog"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/pg"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequirePKAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLTableRequirePK, &TableRequirePKAdvisor{})
+}
+
+// TableRequirePKAdvisor is the advisor checking table requires PK.
+type TableRequirePKAdvisor struct {
+}
+
+// Check checks table requires PK.
+func (adv *TableRequirePKAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &tableRequirePKChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		tables:  make(tablePK),
+		catalog: ctx.Catalog,
+	}
+
+	for _, stmt := range stmts {
+		checker.check(stmt.AST)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type tableRequirePKChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	tables     tablePK
+	catalog    catalog.Catalog
+}
+
+func (v *tableRequirePKChecker) check(stmt *pgquery.Node) {
+	switch node := stmt.Node.(type) {
+	// CREATE TABLE
+	case *pgquery.Node_CreateStmt:
+		v.createTable(node.CreateStmt)
+	// DROP TABLE
+	case *pgquery.Node_DropStmt:
+		if node.DropStmt.RemoveType == pgquery.ObjectType_OBJECT_TABLE {
+			for _, table := range dropTableNameList(node.DropStmt) {
+				delete(v.tables, table)
+			}
+		}
+	// ALTER TABLE
+	case *pgquery.Node_AlterTableStmt:
+		table := node.AlterTableStmt.Relation
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			cmd := cmd.GetAlterTableCmd()
+			if cmd == nil {
+				continue
+			}
+			switch cmd.Subtype {
+			// ADD CONSTRAINT
+			case pgquery.AlterTableType_AT_AddConstraint:
+				if constraint := cmd.Def.GetConstraint(); constraint != nil && constraint.Contype == pgquery.ConstrType_CONSTR_PRIMARY {
+					v.tables[rangeVarTableName(table)] = v.newPrimaryKey(table, constraint, stringList(constraint.Keys))
+				}
+			// DROP CONSTRAINT
+			case pgquery.AlterTableType_AT_DropConstraint:
+				v.dropConstraint(table, cmd.Name)
+			// ADD COLUMN
+			case pgquery.AlterTableType_AT_AddColumn:
+				if column := cmd.Def.GetColumnDef(); column != nil {
+					for _, constraint := range columnConstraintList(column, pgquery.ConstrType_CONSTR_PRIMARY) {
+						v.tables[rangeVarTableName(table)] = v.newPrimaryKey(table, constraint, []string{column.Colname})
+					}
+				}
+			// DROP COLUMN
+			case pgquery.AlterTableType_AT_DropColumn:
+				v.dropColumn(table, cmd.Name)
+			}
+		}
+	// ALTER TABLE RENAME COLUMN
+	case *pgquery.Node_RenameStmt:
+		if node.RenameStmt.RenameType == pgquery.ObjectType_OBJECT_COLUMN && node.RenameStmt.Relation != nil {
+			v.renameColumn(node.RenameStmt.Relation, node.RenameStmt.Subname, node.RenameStmt.Newname)
+		}
+	}
+}
+
+func (v *tableRequirePKChecker) generateAdviceList() []advisor.Advice {
+	tableList := v.tables.tableList()
+	for _, tableName := range tableList {
+		if v.tables[tableName] == nil {
+			v.adviceList = append(v.adviceList, advisor.Advice{
+				Status:  v.level,
+				Code:    common.TableNoPK,
+				Title:   v.title,
+				Content: fmt.Sprintf("Table `%s` requires PRIMARY KEY", tableName),
+			})
+		}
+	}
+
+	if len(v.adviceList) == 0 {
+		v.adviceList = append(v.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return v.adviceList
+}
+
+func (v *tableRequirePKChecker) createTable(node *pgquery.CreateStmt) {
+	table := rangeVarTableName(node.Relation)
+	v.tables[table] = nil
+
+	for _, elt := range node.TableElts {
+		switch def := elt.Node.(type) {
+		case *pgquery.Node_ColumnDef:
+			for _, constraint := range columnConstraintList(def.ColumnDef, pgquery.ConstrType_CONSTR_PRIMARY) {
+				v.tables[table] = v.newPrimaryKey(node.Relation, constraint, []string{def.ColumnDef.Colname})
+			}
+		case *pgquery.Node_Constraint:
+			if def.Constraint.Contype == pgquery.ConstrType_CONSTR_PRIMARY {
+				v.tables[table] = v.newPrimaryKey(node.Relation, def.Constraint, stringList(def.Constraint.Keys))
+			}
+		}
+	}
+}
+
+// newPrimaryKey returns the primary key defined by the constraint.
+// The columns are loaded from the catalog for PRIMARY KEY USING INDEX.
+func (v *tableRequirePKChecker) newPrimaryKey(table *pgquery.RangeVar, constraint *pgquery.Constraint, columns []string) *primaryKey {
+	name := constraint.Conname
+	if name == "" {
+		name = constraint.Indexname
+	}
+	if name == "" {
+		name = primaryKeyName(table.Relname)
+	}
+	if constraint.Indexname != "" {
+		index, err := v.catalog.FindIndex(context.Background(), &catalog.IndexFind{
+			TableName: rangeVarTableName(table),
+			IndexName: pg.QuoteIdentifier(constraint.Indexname),
+		})
+		if err != nil {
+			log.Error(
+				"Cannot find index in table",
+				zap.String("table_name", rangeVarTableName(table)),
+				zap.String("index_name", constraint.Indexname),
+				zap.Error(err),
+			)
+		} else if index != nil {
+			columns = index.ColumnExpressions
+		}
+	}
+	return &primaryKey{
+		name:    pg.QuoteIdentifier(name),
+		columns: newColumnSet(columns),
+	}
+}
+
+// loadTable loads the primary key of the table from the catalog if the table is not created in the statement.
+// It returns false if the state of the table is unknown.
+func (v *tableRequirePKChecker) loadTable(table *pgquery.RangeVar) bool {
+	tableName := rangeVarTableName(table)
+	if _, ok := v.tables[tableName]; ok {
+		return true
+	}
+	ctx := context.Background()
+	// The primary key can have any name, so we look for it by the primary flag first,
+	// then fall back to the name Postgres gives by default.
+	for _, find := range []*catalog.IndexFind{
+		{TableName: tableName, Primary: true},
+		{TableName: tableName, IndexName: pg.QuoteIdentifier(primaryKeyName(table.Relname))},
+	} {
+		pk, err := v.catalog.FindIndex(ctx, find)
+		if err != nil {
+			log.Error(
+				"Cannot find primary key in table",
+				zap.String("table_name", tableName),
+				zap.Error(err),
+			)
+			return false
+		}
+		if pk != nil {
+			v.tables[tableName] = &primaryKey{
+				name:    pk.Name,
+				columns: newColumnSet(pk.ColumnExpressions),
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (v *tableRequirePKChecker) dropConstraint(table *pgquery.RangeVar, constraint string) {
+	if !v.loadTable(table) {
+		return
+	}
+	tableName := rangeVarTableName(table)
+	if pk := v.tables[tableName]; pk != nil && pk.name == pg.QuoteIdentifier(constraint) {
+		v.tables[tableName] = nil
+	}
+}
+
+func (v *tableRequirePKChecker) dropColumn(table *pgquery.RangeVar, column string) {
+	if !v.loadTable(table) {
+		return
+	}
+	tableName := rangeVarTableName(table)
+	// Postgres drops the primary key along with any of its columns.
+	if pk := v.tables[tableName]; pk != nil && pk.columns[column] {
+		v.tables[tableName] = nil
+	}
+}
+
+func (v *tableRequirePKChecker) renameColumn(table *pgquery.RangeVar, oldColumn string, newColumn string) {
+	if !v.loadTable(table) {
+		return
+	}
+	pk := v.tables[rangeVarTableName(table)]
+	if pk != nil && pk.columns[oldColumn] {
+		delete(pk.columns, oldColumn)
+		pk.columns[newColumn] = true
+	}
+}
+
+// primaryKeyName returns the name Postgres gives to the primary key constraint of the table by default.
+func primaryKeyName(table string) string {
+	return fmt.Sprintf("%s_pkey", table)
+}
@@ -0,0 +1,173 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestTableRequirePK(t *testing.T) {
+	tests := []test{
+		{
+			statement: "CREATE TABLE t(id int)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.t` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int PRIMARY KEY)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int, name text, CONSTRAINT t_pkey PRIMARY KEY (id, name))",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int); ALTER TABLE t ADD PRIMARY KEY (id)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int PRIMARY KEY); ALTER TABLE t DROP CONSTRAINT t_pkey",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.t` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int); DROP TABLE t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book DROP COLUMN id",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.tech_book` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book RENAME COLUMN id TO book_id",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int, CONSTRAINT pk_t PRIMARY KEY (id)); ALTER TABLE t DROP CONSTRAINT pk_t",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.t` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE a.t(id int PRIMARY KEY); CREATE TABLE b.t(id int)",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `b.t` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: "ALTER TABLE tech_book DROP CONSTRAINT tech_book_pkey",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.tech_book` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: `ALTER TABLE "tech-book" DROP CONSTRAINT pk_tech_book`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    common.TableNoPK,
+					Title:   "table.require-pk",
+					Content: "Table `public.\"tech-book\"` requires PRIMARY KEY",
+				},
+			},
+		},
+		{
+			statement: `ALTER TABLE "tech-book" DROP CONSTRAINT "tech-book_pkey"`,
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			statement: "CREATE TABLE t(id int); CREATE UNIQUE INDEX t_id_idx ON t(id); ALTER TABLE t ADD CONSTRAINT pk_t PRIMARY KEY USING INDEX t_id_idx",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    common.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	runSchemaReviewRuleTests(t, tests, &TableRequirePKAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleTableRequirePK,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &MockCatalogService{})
+}
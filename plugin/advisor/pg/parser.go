@@ -0,0 +1,45 @@
+package pg
+
+import (
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+// statement is a single parsed statement along with its text.
+type statement struct {
+	AST *pgquery.Node
+	SQL string
+}
+
+// parseStatement parses the statement with the Postgres parser and splits it into single statements.
+func parseStatement(sql string) ([]statement, []advisor.Advice) {
+	res, err := pgquery.Parse(sql)
+	if err != nil {
+		return nil, []advisor.Advice{
+			{
+				Status:  advisor.Error,
+				Code:    common.DbStatementSyntaxError,
+				Title:   advisor.SyntaxErrorTitle,
+				Content: err.Error(),
+			},
+		}
+	}
+
+	var stmts []statement
+	for _, rawStmt := range res.Stmts {
+		start := int(rawStmt.StmtLocation)
+		end := len(sql)
+		// StmtLen is 0 if the statement runs to the end of the string.
+		if rawStmt.StmtLen > 0 {
+			end = start + int(rawStmt.StmtLen)
+		}
+		stmts = append(stmts, statement{
+			AST: rawStmt.Stmt,
+			SQL: strings.TrimSpace(sql[start:end]),
+		})
+	}
+	return stmts, nil
+}
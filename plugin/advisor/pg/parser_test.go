@@ -0,0 +1,45 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatement(t *testing.T) {
+	stmts, errAdvice := parseStatement("CREATE TABLE tech_book(id int, name text);\nDELETE FROM tech_book")
+	require.Nil(t, errAdvice)
+	require.Len(t, stmts, 2)
+	assert.Equal(t, "CREATE TABLE tech_book(id int, name text)", stmts[0].SQL)
+	assert.Equal(t, "DELETE FROM tech_book", stmts[1].SQL)
+
+	_, errAdvice = parseStatement("CREATE TABLE")
+	require.Len(t, errAdvice, 1)
+	assert.Equal(t, advisor.Error, errAdvice[0].Status)
+	assert.Equal(t, common.DbStatementSyntaxError, errAdvice[0].Code)
+	assert.Equal(t, advisor.SyntaxErrorTitle, errAdvice[0].Title)
+}
+
+func TestParsePostgresStatement(t *testing.T) {
+	tests := []string{
+		"CREATE EXTENSION IF NOT EXISTS pgcrypto",
+		`CREATE FUNCTION update_updated_ts() RETURNS TRIGGER AS $$
+BEGIN
+	NEW.updated_ts = extract(epoch from now());
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`,
+		"CREATE TRIGGER update_book_updated_ts BEFORE UPDATE ON book FOR EACH ROW EXECUTE FUNCTION update_updated_ts()",
+		"COMMENT ON TABLE book IS 'The books'",
+		"ALTER TABLE book ADD CONSTRAINT pk_book PRIMARY KEY USING INDEX idx_book_id",
+	}
+	for _, test := range tests {
+		stmts, errAdvice := parseStatement(test)
+		require.Nil(t, errAdvice, test)
+		require.Len(t, stmts, 1, test)
+		assert.Equal(t, test, stmts[0].SQL)
+	}
+}
@@ -0,0 +1,178 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db/pg"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// defaultSchemaName is the schema Postgres resolves unqualified names to by default.
+	defaultSchemaName = "public"
+)
+
+type columnSet map[string]bool
+
+func newColumnSet(columns []string) columnSet {
+	res := make(columnSet)
+	for _, col := range columns {
+		res[col] = true
+	}
+	return res
+}
+
+type tableState map[string]columnSet
+
+// tableList returns table list in lexicographical order.
+func (t tableState) tableList() []string {
+	var tableList []string
+	for tableName := range t {
+		tableList = append(tableList, tableName)
+	}
+	sort.Strings(tableList)
+	return tableList
+}
+
+// primaryKey is the primary key constraint of a table.
+type primaryKey struct {
+	name    string
+	columns columnSet
+}
+
+// tablePK is the primary key of each table, nil if the table doesn't have one.
+type tablePK map[string]*primaryKey
+
+// tableList returns table list in lexicographical order.
+func (t tablePK) tableList() []string {
+	var tableList []string
+	for tableName := range t {
+		tableList = append(tableList, tableName)
+	}
+	sort.Strings(tableList)
+	return tableList
+}
+
+type indexMetaData struct {
+	indexName string
+	tableName string
+	metaData  map[string]string
+}
+
+// getTemplateRegexp formats the template as regex.
+func getTemplateRegexp(template string, templateList []string, tokens map[string]string) (*regexp.Regexp, error) {
+	for _, key := range templateList {
+		if token, ok := tokens[key]; ok {
+			template = strings.ReplaceAll(template, key, token)
+		}
+	}
+
+	return regexp.Compile(template)
+}
+
+// catalogTableName returns the table name in the quoted "schema.table" form synced from Postgres instances.
+func catalogTableName(schema string, table string) string {
+	if schema == "" {
+		schema = defaultSchemaName
+	}
+	return fmt.Sprintf("%s.%s", pg.QuoteIdentifier(schema), pg.QuoteIdentifier(table))
+}
+
+// rangeVarTableName returns the catalog table name of the relation.
+func rangeVarTableName(rangeVar *pgquery.RangeVar) string {
+	return catalogTableName(rangeVar.Schemaname, rangeVar.Relname)
+}
+
+// dropTableNameList returns the catalog table names in the DROP TABLE statement.
+func dropTableNameList(node *pgquery.DropStmt) []string {
+	var res []string
+	for _, object := range node.Objects {
+		nameList := stringList(object.GetList().GetItems())
+		switch len(nameList) {
+		case 1:
+			res = append(res, catalogTableName("", nameList[0]))
+		case 2:
+			res = append(res, catalogTableName(nameList[0], nameList[1]))
+		case 3:
+			// The name is qualified by the database.
+			res = append(res, catalogTableName(nameList[1], nameList[2]))
+		}
+	}
+	return res
+}
+
+// stringList returns the values of the String nodes.
+func stringList(nodes []*pgquery.Node) []string {
+	var res []string
+	for _, node := range nodes {
+		if str := node.GetString_(); str != nil {
+			res = append(res, str.Str)
+		}
+	}
+	return res
+}
+
+// indexElemColumnList returns the column names of the index elements, skipping the expressions.
+func indexElemColumnList(nodes []*pgquery.Node) []string {
+	var res []string
+	for _, node := range nodes {
+		if elem := node.GetIndexElem(); elem != nil && elem.Name != "" {
+			res = append(res, elem.Name)
+		}
+	}
+	return res
+}
+
+// columnConstraintList returns the constraints of the column with the given type.
+func columnConstraintList(column *pgquery.ColumnDef, contype pgquery.ConstrType) []*pgquery.Constraint {
+	var res []*pgquery.Constraint
+	for _, node := range column.Constraints {
+		if constraint := node.GetConstraint(); constraint != nil && constraint.Contype == contype {
+			res = append(res, constraint)
+		}
+	}
+	return res
+}
+
+// walk traverses the message and all the messages nested in it in depth-first order.
+// The children of a message are skipped if fn returns false.
+func walk(msg proto.Message, fn func(proto.Message) bool) {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return
+	}
+	if !fn(msg) {
+		return
+	}
+	msg.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.Kind() != protoreflect.MessageKind {
+			return true
+		}
+		if field.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				walk(list.Get(i).Message().Interface(), fn)
+			}
+			return true
+		}
+		walk(value.Message().Interface(), fn)
+		return true
+	})
+}
+
+// selectStmtList returns the SELECT clauses in the statement, including the ones in CTEs, derived tables and subqueries.
+// The set operations such as UNION are not SELECT clauses themselves, but their operands are.
+func selectStmtList(node *pgquery.Node) []*pgquery.SelectStmt {
+	var res []*pgquery.SelectStmt
+	walk(node, func(msg proto.Message) bool {
+		if stmt, ok := msg.(*pgquery.SelectStmt); ok && stmt.Op == pgquery.SetOperation_SETOP_NONE && len(stmt.ValuesLists) == 0 {
+			res = append(res, stmt)
+		}
+		return true
+	})
+	return res
+}
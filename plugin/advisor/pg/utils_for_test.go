@@ -0,0 +1,69 @@
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCatalogService struct{}
+
+const (
+	MockTableName      = "public.tech_book"
+	MockOldPKName      = "tech_book_pkey"
+	MockPKColumnID     = "id"
+	MockNamedTableName = `public."tech-book"`
+	MockNamedPKName    = "pk_tech_book"
+)
+
+func (c *MockCatalogService) FindIndex(ctx context.Context, find *catalog.IndexFind) (*catalog.Index, error) {
+	indexList := []*catalog.Index{
+		{
+			Unique:            true,
+			Name:              MockOldPKName,
+			TableName:         MockTableName,
+			ColumnExpressions: []string{MockPKColumnID},
+		},
+		{
+			Unique:            true,
+			Name:              MockNamedPKName,
+			TableName:         MockNamedTableName,
+			ColumnExpressions: []string{MockPKColumnID},
+		},
+	}
+	for _, index := range indexList {
+		if index.TableName == find.TableName && (find.Primary || index.Name == find.IndexName) {
+			return index, nil
+		}
+	}
+	return nil, nil
+}
+
+type test struct {
+	statement string
+	want      []advisor.Advice
+}
+
+func runSchemaReviewRuleTests(
+	t *testing.T,
+	tests []test,
+	adv advisor.Advisor,
+	rule *advisor.SchemaReviewRule,
+	catalog catalog.Catalog,
+) {
+	ctx := advisor.Context{
+		Charset:   "",
+		Collation: "",
+		Rule:      rule,
+		Catalog:   catalog,
+	}
+	for _, tc := range tests {
+		adviceList, err := adv.Check(ctx, tc.statement)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, adviceList, tc.statement)
+	}
+}
@@ -22,4 +22,6 @@ type Index struct {
 type IndexFind struct {
 	TableName string
 	IndexName string
+	// Primary finds the primary key of the table regardless of its name if IndexName is empty.
+	Primary bool
 }
@@ -0,0 +1,134 @@
+// Package crypto provides the envelope encryption of the credentials stored by Bytebase.
+//
+// The credentials are encrypted by a random data key, and the data key is encrypted by the master key supplied by the user.
+// So rotating the master key only needs to re-encrypt the data key instead of all the credentials.
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// KeySize is the size of both master key and data key in bytes, for AES-256.
+	KeySize = 32
+	// encryptedPrefix marks an encrypted value, so we can tell it from the plaintext values written before encryption was enabled.
+	encryptedPrefix = "bbenc:v1:"
+)
+
+// GenerateKey generates a random key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key, error: %w", err)
+	}
+	return key, nil
+}
+
+// ParseKey parses the base64 encoded key.
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded, error: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d bytes", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// EncodeKey encodes the key in base64, which can be parsed by ParseKey.
+func EncodeKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// IsEncrypted returns whether the value is encrypted by Cipher.
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, encryptedPrefix)
+}
+
+// Cipher encrypts and decrypts values with AES-256-GCM.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a cipher with the key.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d bytes", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt encrypts the plaintext with a random nonce.
+// The empty plaintext is kept as is, so that an empty password is still empty.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce, error: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value encrypted by Encrypt.
+// The value not encrypted is returned as is.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value, error: %w", err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("malformed encrypted value, too short")
+	}
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt, the key may be wrong, error: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// WrapDataKey encrypts the data key with the master key.
+func WrapDataKey(masterKey, dataKey []byte) (string, error) {
+	c, err := NewCipher(masterKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid master key, error: %w", err)
+	}
+	return c.Encrypt(EncodeKey(dataKey))
+}
+
+// UnwrapDataKey decrypts the data key wrapped by WrapDataKey with the master key.
+func UnwrapDataKey(masterKey []byte, wrapped string) ([]byte, error) {
+	if !IsEncrypted(wrapped) {
+		return nil, fmt.Errorf("the data key isn't wrapped")
+	}
+	c, err := NewCipher(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key, error: %w", err)
+	}
+	encoded, err := c.Decrypt(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the data key with the master key, error: %w", err)
+	}
+	return ParseKey(encoded)
+}
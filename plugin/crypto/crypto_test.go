@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	c, err := NewCipher(key)
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("secret")
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, encrypted, "secret")
+	decrypted, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "secret", decrypted)
+
+	// The nonce is random.
+	encryptedAgain, err := c.Encrypt("secret")
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, encryptedAgain)
+
+	// Empty and plaintext values are kept as is.
+	encrypted, err = c.Encrypt("")
+	require.NoError(t, err)
+	require.Equal(t, "", encrypted)
+	decrypted, err = c.Decrypt("plaintext")
+	require.NoError(t, err)
+	require.Equal(t, "plaintext", decrypted)
+
+	// Wrong key.
+	otherKey, err := GenerateKey()
+	require.NoError(t, err)
+	other, err := NewCipher(otherKey)
+	require.NoError(t, err)
+	encrypted, err = c.Encrypt("secret")
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	require.Error(t, err)
+}
+
+func TestWrapDataKey(t *testing.T) {
+	masterKey, err := GenerateKey()
+	require.NoError(t, err)
+	dataKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	wrapped, err := WrapDataKey(masterKey, dataKey)
+	require.NoError(t, err)
+	unwrapped, err := UnwrapDataKey(masterKey, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	otherKey, err := GenerateKey()
+	require.NoError(t, err)
+	_, err = UnwrapDataKey(otherKey, wrapped)
+	require.Error(t, err)
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	parsed, err := ParseKey(EncodeKey(key) + "\n")
+	require.NoError(t, err)
+	require.Equal(t, key, parsed)
+
+	_, err = ParseKey("not base64!")
+	require.Error(t, err)
+	_, err = ParseKey(EncodeKey([]byte("short")))
+	require.Error(t, err)
+}
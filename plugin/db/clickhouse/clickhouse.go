@@ -42,6 +42,8 @@ func init() {
 type Driver struct {
 	connectionCtx db.ConnectionContext
 	dbType        db.Type
+	// sshTunnel is nil if the database is connected directly.
+	sshTunnel *db.SSHTunnel
 
 	db *sql.DB
 }
@@ -56,12 +58,19 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 	if port == "" {
 		port = "9000"
 	}
-	addr := fmt.Sprintf("%s:%s", config.Host, port)
 	// Set SSL configuration.
 	tlsConfig, err := config.TLSConfig.GetSslConfig()
 	if err != nil {
 		return nil, fmt.Errorf("sql: tls config error: %v", err)
 	}
+	sshTunnel, err := db.OpenSSHTunnel(&config, port)
+	if err != nil {
+		return nil, err
+	}
+	if sshTunnel != nil {
+		port = config.Port
+	}
+	addr := fmt.Sprintf("%s:%s", config.Host, port)
 	// Default user name is "default".
 	conn := clickhouse.OpenDB(&clickhouse.Options{
 		Addr: []string{addr},
@@ -86,13 +95,20 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 	driver.dbType = dbType
 	driver.db = conn
 	driver.connectionCtx = connCtx
+	driver.sshTunnel = sshTunnel
 
 	return driver, nil
 }
 
 // Close closes the driver.
 func (driver *Driver) Close(ctx context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		if tunnelErr := driver.sshTunnel.Close(); err == nil {
+			err = tunnelErr
+		}
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -122,8 +138,17 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// Capabilities returns the features supported by ClickHouse.
+func (*Driver) Capabilities() db.Capabilities {
+	return db.Capabilities{
+		SchemaObjectKindList: []db.SchemaObjectKind{db.SchemaObjectTable, db.SchemaObjectView},
+		DumpFormat:           db.DumpFormatSQL,
+		Explain:              true,
+	}
+}
+
 // SyncSchema syncs the schema.
-func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema, error) {
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
 	excludedDatabaseList := []string{
 		// Skip our internal "bytebase" database
 		"'bytebase'",
@@ -140,8 +165,11 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		return nil, nil, err
 	}
 
+	// Restrict the sync to the given databases if any.
+	databaseWhere, databaseArgs := getDatabaseFilter("database", databaseList)
+
 	// Query column info
-	columnWhere := fmt.Sprintf("LOWER(database) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	columnWhere := fmt.Sprintf("LOWER(database) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query := `
 			SELECT
 				database,
@@ -153,7 +181,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				comment
 			FROM system.columns
 			WHERE ` + columnWhere
-	columnRows, err := driver.db.QueryContext(ctx, query)
+	columnRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -186,7 +214,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	}
 
 	// Query table info
-	tableWhere := fmt.Sprintf("LOWER(database) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	tableWhere := fmt.Sprintf("LOWER(database) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query = `
 			SELECT
 				database,
@@ -199,7 +227,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				comment
 			FROM system.tables
 			WHERE ` + tableWhere
-	tableRows, err := driver.db.QueryContext(ctx, query)
+	tableRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -251,13 +279,14 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 
 	var schemaList []*db.Schema
 	// Query db info
-	where := fmt.Sprintf("name NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	nameWhere, _ := getDatabaseFilter("name", databaseList)
+	where := fmt.Sprintf("name NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + nameWhere
 	query = `
 		SELECT
 			name
 		FROM system.databases
 		WHERE ` + where
-	rows, err := driver.db.QueryContext(ctx, query)
+	rows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -281,6 +310,21 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	return userList, schemaList, nil
 }
 
+// getDatabaseFilter returns the condition restricting the column to the databases in databaseList and its query arguments.
+// It returns an empty condition if databaseList is empty.
+func getDatabaseFilter(column string, databaseList []string) (string, []interface{}) {
+	if len(databaseList) == 0 {
+		return "", nil
+	}
+	var placeholderList []string
+	var args []interface{}
+	for _, database := range databaseList {
+		placeholderList = append(placeholderList, "?")
+		args = append(args, database)
+	}
+	return fmt.Sprintf(" AND %s IN (%s)", column, strings.Join(placeholderList, ", ")), args
+}
+
 func (driver *Driver) getUserList(ctx context.Context) ([]*db.User, error) {
 	// Query user info
 	// host_ip isn't used for user identifier.
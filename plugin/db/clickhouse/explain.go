@@ -0,0 +1,79 @@
+package clickhouse
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// readFromReg matches the reading steps with the table name since ClickHouse 22.5, e.g. "ReadFromMergeTree (default.hits)".
+var readFromReg = regexp.MustCompile(`^ReadFrom\w+ \(([^ ]+)\)$`)
+
+// Explain returns the query plan of the statement by EXPLAIN.
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.PlanNode, error) {
+	rows, err := util.ExplainRows(ctx, driver.db, "EXPLAIN", statement)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, row := range rows {
+		if len(row) > 0 {
+			lines = append(lines, row[0])
+		}
+	}
+	return parseClickHousePlan(lines), nil
+}
+
+// parseClickHousePlan parses the plan steps, which are indented by two spaces per level.
+func parseClickHousePlan(lines []string) *db.PlanNode {
+	root := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    "EXPLAIN",
+	}
+	// stack[i] is the last node at depth i-1.
+	stack := []*db.PlanNode{root}
+	for _, line := range lines {
+		detail := strings.TrimLeft(line, " ")
+		if detail == "" {
+			continue
+		}
+		depth := (len(line) - len(detail)) / 2
+		if depth > len(stack)-1 {
+			depth = len(stack) - 1
+		}
+		node := clickhousePlanNode(detail)
+		parent := stack[depth]
+		parent.Children = append(parent.Children, node)
+		stack = append(stack[:depth+1], node)
+	}
+	if len(root.Children) == 1 {
+		return root.Children[0]
+	}
+	return root
+}
+
+func clickhousePlanNode(detail string) *db.PlanNode {
+	node := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    detail,
+	}
+	switch {
+	case strings.HasPrefix(detail, "ReadFrom"):
+		node.Operation = db.PlanOperationTableScan
+		if matches := readFromReg.FindStringSubmatch(detail); matches != nil {
+			// The table name is qualified by the database name.
+			parts := strings.SplitN(matches[1], ".", 2)
+			node.Table = parts[len(parts)-1]
+		}
+	case strings.HasPrefix(detail, "Join"):
+		node.Operation = db.PlanOperationJoin
+	case strings.Contains(detail, "Sorting") || strings.HasPrefix(detail, "MergingSorted"):
+		node.Operation = db.PlanOperationSort
+	case strings.HasPrefix(detail, "Aggregating") || strings.HasPrefix(detail, "MergingAggregated"):
+		node.Operation = db.PlanOperationAggregate
+	}
+	return node
+}
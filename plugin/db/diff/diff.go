@@ -0,0 +1,346 @@
+// Package diff computes the DDL statements that migrate one database schema to another.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// dialect generates the engine-specific DDL statements.
+// A method returns an empty string or nil if the change isn't supported by the engine.
+type dialect interface {
+	// checkColumn returns an error if the column definition can't be generated losslessly.
+	checkColumn(column *db.Column) error
+
+	createExtension(extension *db.Extension) string
+	updateExtension(extension *db.Extension) string
+	dropExtension(extension *db.Extension) string
+
+	createTable(table *db.Table, indexList []*index) []string
+	alterTable(oldTable, newTable *db.Table) []string
+	dropTable(table *db.Table) string
+
+	addColumn(table *db.Table, column *db.Column, after *db.Column) []string
+	modifyColumn(table *db.Table, oldColumn, newColumn *db.Column) []string
+	dropColumn(table *db.Table, column *db.Column) string
+
+	createIndex(table *db.Table, index *index) []string
+	commentIndex(table *db.Table, index *index) string
+	dropIndex(table *db.Table, index *index) string
+
+	createView(view *db.View) string
+	dropView(view *db.View) string
+}
+
+// index is the index aggregated from the per-expression db.Index rows.
+type index struct {
+	name        string
+	expressions []string
+	indexType   string
+	unique      bool
+	primary     bool
+	visible     bool
+	comment     string
+}
+
+func (idx *index) equal(other *index) bool {
+	return idx.equalDefinition(other) && idx.comment == other.comment
+}
+
+// equalDefinition reports whether the indexes are the same regardless of the comment.
+func (idx *index) equalDefinition(other *index) bool {
+	if idx.indexType != other.indexType || idx.unique != other.unique || idx.primary != other.primary || idx.visible != other.visible {
+		return false
+	}
+	if len(idx.expressions) != len(other.expressions) {
+		return false
+	}
+	for i := range idx.expressions {
+		if idx.expressions[i] != other.expressions[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SchemaDiff returns the ordered DDL statements that migrate oldSchema to newSchema on the engine.
+// Either schema can be nil, which is treated as an empty schema.
+func SchemaDiff(engine db.Type, oldSchema, newSchema *db.Schema) ([]string, error) {
+	var d dialect
+	switch engine {
+	case db.MySQL, db.TiDB, db.MariaDB:
+		d = &mysqlDialect{}
+	case db.Postgres:
+		d = &pgDialect{}
+	default:
+		return nil, fmt.Errorf("schema diff is not supported for engine %s", engine)
+	}
+	if oldSchema == nil {
+		oldSchema = &db.Schema{}
+	}
+	if newSchema == nil {
+		newSchema = &db.Schema{}
+	}
+
+	var stmts []string
+	add := func(list ...string) {
+		for _, stmt := range list {
+			if stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+		}
+	}
+
+	oldTableMap := make(map[string]*db.Table)
+	for i := range oldSchema.TableList {
+		oldTableMap[oldSchema.TableList[i].Name] = &oldSchema.TableList[i]
+	}
+	newTableMap := make(map[string]*db.Table)
+	for i := range newSchema.TableList {
+		newTableMap[newSchema.TableList[i].Name] = &newSchema.TableList[i]
+	}
+	oldViewMap := make(map[string]*db.View)
+	for i := range oldSchema.ViewList {
+		oldViewMap[oldSchema.ViewList[i].Name] = &oldSchema.ViewList[i]
+	}
+	newViewMap := make(map[string]*db.View)
+	for i := range newSchema.ViewList {
+		newViewMap[newSchema.ViewList[i].Name] = &newSchema.ViewList[i]
+	}
+	oldExtensionMap := make(map[string]*db.Extension)
+	for i := range oldSchema.ExtensionList {
+		oldExtensionMap[oldSchema.ExtensionList[i].Name] = &oldSchema.ExtensionList[i]
+	}
+	newExtensionMap := make(map[string]*db.Extension)
+	for i := range newSchema.ExtensionList {
+		newExtensionMap[newSchema.ExtensionList[i].Name] = &newSchema.ExtensionList[i]
+	}
+
+	// 1. Create or update extensions first because tables may use the types they define.
+	for i := range newSchema.ExtensionList {
+		newExtension := &newSchema.ExtensionList[i]
+		oldExtension, ok := oldExtensionMap[newExtension.Name]
+		if !ok {
+			add(d.createExtension(newExtension))
+		} else if oldExtension.Version != newExtension.Version {
+			add(d.updateExtension(newExtension))
+		}
+	}
+
+	// 2. Drop the removed and changed views because they may depend on the tables to be altered.
+	for i := range oldSchema.ViewList {
+		view := &oldSchema.ViewList[i]
+		if newView, ok := newViewMap[view.Name]; !ok || newView.Definition != view.Definition {
+			add(d.dropView(view))
+		}
+	}
+
+	// 3. Drop the removed tables.
+	for i := range oldSchema.TableList {
+		if _, ok := newTableMap[oldSchema.TableList[i].Name]; !ok {
+			add(d.dropTable(&oldSchema.TableList[i]))
+		}
+	}
+
+	// 4. Create the added tables and alter the existing ones.
+	for i := range newSchema.TableList {
+		newTable := &newSchema.TableList[i]
+		newIndexList := buildIndexList(newTable)
+		oldTable, ok := oldTableMap[newTable.Name]
+		if !ok {
+			for i := range newTable.ColumnList {
+				if err := checkColumn(d, newTable, &newTable.ColumnList[i]); err != nil {
+					return nil, err
+				}
+			}
+			add(d.createTable(newTable, newIndexList)...)
+			continue
+		}
+		oldIndexList := buildIndexList(oldTable)
+		tableStmts, err := diffTable(d, oldTable, newTable, oldIndexList, newIndexList)
+		if err != nil {
+			return nil, err
+		}
+		add(tableStmts...)
+	}
+
+	// 5. Create the added and changed views.
+	for i := range newSchema.ViewList {
+		view := &newSchema.ViewList[i]
+		if oldView, ok := oldViewMap[view.Name]; !ok || oldView.Definition != view.Definition {
+			add(d.createView(view))
+		}
+	}
+
+	// 6. Drop the removed extensions last because the dropped objects may have used them.
+	for i := range oldSchema.ExtensionList {
+		if _, ok := newExtensionMap[oldSchema.ExtensionList[i].Name]; !ok {
+			add(d.dropExtension(&oldSchema.ExtensionList[i]))
+		}
+	}
+
+	return stmts, nil
+}
+
+// diffTable returns the statements to migrate oldTable to newTable.
+// Changed indexes are dropped before altering columns and recreated afterwards,
+// unless only the comment changed and the engine can update it in place.
+func diffTable(d dialect, oldTable, newTable *db.Table, oldIndexList, newIndexList []*index) ([]string, error) {
+	var stmts []string
+
+	newIndexMap := make(map[string]*index)
+	for _, idx := range newIndexList {
+		newIndexMap[idx.name] = idx
+	}
+	oldIndexMap := make(map[string]*index)
+	for _, idx := range oldIndexList {
+		oldIndexMap[idx.name] = idx
+	}
+
+	// index name -> statement updating the comment in place
+	commentMap := make(map[string]string)
+	for _, idx := range newIndexList {
+		if oldIdx, ok := oldIndexMap[idx.name]; ok && oldIdx.comment != idx.comment && oldIdx.equalDefinition(idx) {
+			if stmt := d.commentIndex(newTable, idx); stmt != "" {
+				commentMap[idx.name] = stmt
+			}
+		}
+	}
+
+	for _, idx := range oldIndexList {
+		if _, ok := commentMap[idx.name]; ok {
+			continue
+		}
+		if newIdx, ok := newIndexMap[idx.name]; !ok || !newIdx.equal(idx) {
+			stmts = append(stmts, d.dropIndex(oldTable, idx))
+		}
+	}
+
+	oldColumnMap := make(map[string]*db.Column)
+	for i := range oldTable.ColumnList {
+		oldColumnMap[oldTable.ColumnList[i].Name] = &oldTable.ColumnList[i]
+	}
+	newColumnMap := make(map[string]*db.Column)
+	for i := range newTable.ColumnList {
+		newColumnMap[newTable.ColumnList[i].Name] = &newTable.ColumnList[i]
+	}
+	for _, column := range sortedColumnList(oldTable.ColumnList) {
+		if _, ok := newColumnMap[column.Name]; !ok {
+			stmts = append(stmts, d.dropColumn(oldTable, column))
+		}
+	}
+	var prev *db.Column
+	for _, column := range sortedColumnList(newTable.ColumnList) {
+		oldColumn, ok := oldColumnMap[column.Name]
+		if !ok {
+			if err := checkColumn(d, newTable, column); err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, d.addColumn(newTable, column, prev)...)
+		} else if !columnEqual(oldColumn, column) {
+			if err := checkColumn(d, newTable, column); err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, d.modifyColumn(newTable, oldColumn, column)...)
+		}
+		prev = column
+	}
+
+	for _, idx := range newIndexList {
+		if stmt, ok := commentMap[idx.name]; ok {
+			stmts = append(stmts, stmt)
+			continue
+		}
+		if oldIdx, ok := oldIndexMap[idx.name]; !ok || !oldIdx.equal(idx) {
+			stmts = append(stmts, d.createIndex(newTable, idx)...)
+		}
+	}
+
+	stmts = append(stmts, d.alterTable(oldTable, newTable)...)
+	return stmts, nil
+}
+
+// checkColumn returns an error if the column can't be generated without losing its definition.
+func checkColumn(d dialect, table *db.Table, column *db.Column) error {
+	if err := d.checkColumn(column); err != nil {
+		return fmt.Errorf("cannot generate column %q of table %q, error: %w", column.Name, table.Name, err)
+	}
+	return nil
+}
+
+func columnEqual(a, b *db.Column) bool {
+	if a.Type != b.Type || a.Nullable != b.Nullable || a.CharacterSet != b.CharacterSet || a.Collation != b.Collation || a.Comment != b.Comment || a.Extra != b.Extra {
+		return false
+	}
+	if (a.Default == nil) != (b.Default == nil) {
+		return false
+	}
+	return a.Default == nil || *a.Default == *b.Default
+}
+
+// buildIndexList aggregates the per-expression index rows of the table by index name.
+func buildIndexList(table *db.Table) []*index {
+	var indexList []*index
+	indexMap := make(map[string]*index)
+	positionMap := make(map[string][]int)
+	for _, row := range table.IndexList {
+		idx, ok := indexMap[row.Name]
+		if !ok {
+			idx = &index{
+				name:      row.Name,
+				indexType: row.Type,
+				unique:    row.Unique,
+				primary:   row.Primary,
+				visible:   row.Visible,
+				comment:   row.Comment,
+			}
+			indexMap[row.Name] = idx
+			indexList = append(indexList, idx)
+		}
+		idx.expressions = append(idx.expressions, row.Expression)
+		positionMap[row.Name] = append(positionMap[row.Name], row.Position)
+	}
+	for _, idx := range indexList {
+		positionList := positionMap[idx.name]
+		sort.Sort(&expressionSorter{expressions: idx.expressions, positions: positionList})
+	}
+	sort.SliceStable(indexList, func(i, j int) bool {
+		// Primary key goes first to keep the generated statements deterministic and readable.
+		if indexList[i].primary != indexList[j].primary {
+			return indexList[i].primary
+		}
+		return indexList[i].name < indexList[j].name
+	})
+	return indexList
+}
+
+type expressionSorter struct {
+	expressions []string
+	positions   []int
+}
+
+func (s *expressionSorter) Len() int           { return len(s.expressions) }
+func (s *expressionSorter) Less(i, j int) bool { return s.positions[i] < s.positions[j] }
+func (s *expressionSorter) Swap(i, j int) {
+	s.expressions[i], s.expressions[j] = s.expressions[j], s.expressions[i]
+	s.positions[i], s.positions[j] = s.positions[j], s.positions[i]
+}
+
+func sortedColumnList(columnList []db.Column) []*db.Column {
+	var list []*db.Column
+	for i := range columnList {
+		list = append(list, &columnList[i])
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Position < list[j].Position
+	})
+	return list
+}
+
+// quoteString quotes the string literal with single quotes.
+func quoteString(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
@@ -0,0 +1,364 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestSchemaDiffMySQL(t *testing.T) {
+	oldSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name:   "book",
+				Engine: "InnoDB",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "int"},
+					{Name: "name", Position: 2, Type: "varchar(64)", Nullable: true},
+					{Name: "legacy", Position: 3, Type: "int", Nullable: true},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+					{Name: "idx_name", Expression: "name", Position: 1, Type: "BTREE", Visible: true},
+				},
+			},
+			{
+				Name:       "obsolete",
+				ColumnList: []db.Column{{Name: "id", Position: 1, Type: "int"}},
+			},
+		},
+		ViewList: []db.View{
+			{Name: "v_book", Definition: "select `id` from `book`"},
+		},
+	}
+	newSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name:    "book",
+				Engine:  "InnoDB",
+				Comment: "books",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "int"},
+					{Name: "name", Position: 2, Type: "varchar(128)"},
+					{Name: "author", Position: 3, Type: "varchar(64)", Default: strPtr("unknown")},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+					{Name: "idx_name", Expression: "author", Position: 2, Type: "BTREE", Unique: true, Visible: true},
+					{Name: "idx_name", Expression: "name", Position: 1, Type: "BTREE", Unique: true, Visible: true},
+				},
+			},
+			{
+				Name:   "author",
+				Engine: "InnoDB",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "int"},
+					{Name: "created_ts", Position: 2, Type: "timestamp", Default: strPtr("CURRENT_TIMESTAMP")},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				},
+			},
+		},
+		ViewList: []db.View{
+			{Name: "v_book", Definition: "select `id`,`name` from `book`"},
+		},
+	}
+
+	stmts, err := SchemaDiff(db.MySQL, oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"DROP VIEW `v_book`;",
+		"DROP TABLE `obsolete`;",
+		"ALTER TABLE `book` DROP INDEX `idx_name`;",
+		"ALTER TABLE `book` DROP COLUMN `legacy`;",
+		"ALTER TABLE `book` MODIFY COLUMN `name` varchar(128) NOT NULL;",
+		"ALTER TABLE `book` ADD COLUMN `author` varchar(64) NOT NULL DEFAULT 'unknown' AFTER `name`;",
+		"ALTER TABLE `book` ADD UNIQUE KEY `idx_name` (`name`, `author`);",
+		"ALTER TABLE `book` COMMENT='books';",
+		"CREATE TABLE `author` (\n" +
+			"  `id` int NOT NULL,\n" +
+			"  `created_ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			") ENGINE=InnoDB;",
+		"CREATE VIEW `v_book` AS select `id`,`name` from `book`;",
+	}, stmts)
+
+	// Identical schemas produce no statements.
+	stmts, err = SchemaDiff(db.TiDB, newSchema, newSchema)
+	require.NoError(t, err)
+	require.Empty(t, stmts)
+}
+
+func TestSchemaDiffPostgres(t *testing.T) {
+	oldSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+					{Name: "name", Position: 2, Type: "text", Nullable: true, Default: strPtr("")},
+				},
+				IndexList: []db.Index{
+					{Name: "book_pkey", Expression: "id", Position: 1, Type: "btree", Unique: true, Primary: true},
+				},
+			},
+		},
+		ExtensionList: []db.Extension{
+			{Name: "hstore", Version: "1.7", Schema: "public"},
+		},
+	}
+	newSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+					{Name: "name", Position: 2, Type: "text", Default: strPtr("'untitled'::text"), Comment: "book name"},
+				},
+				IndexList: []db.Index{
+					{Name: "book_pkey", Expression: "id", Position: 1, Type: "btree", Unique: true, Primary: true},
+					{Name: "idx_book_name", Expression: "name", Position: 1, Type: "btree"},
+				},
+			},
+			{
+				Name: "public.\"Author\"",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+				},
+				IndexList: []db.Index{
+					{Name: "\"Author_pkey\"", Expression: "id", Position: 1, Type: "btree", Unique: true, Primary: true},
+				},
+			},
+		},
+		ExtensionList: []db.Extension{
+			{Name: "pg_trgm", Version: "1.6", Schema: "public"},
+		},
+	}
+
+	stmts, err := SchemaDiff(db.Postgres, oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`CREATE EXTENSION IF NOT EXISTS "pg_trgm" WITH SCHEMA "public" VERSION '1.6';`,
+		`ALTER TABLE public.book ALTER COLUMN "name" SET NOT NULL;`,
+		`ALTER TABLE public.book ALTER COLUMN "name" SET DEFAULT 'untitled'::text;`,
+		`COMMENT ON COLUMN public.book."name" IS 'book name';`,
+		`CREATE INDEX idx_book_name ON public.book USING btree (name);`,
+		"CREATE TABLE public.\"Author\" (\n  \"id\" integer NOT NULL\n);",
+		`ALTER TABLE public."Author" ADD CONSTRAINT "Author_pkey" PRIMARY KEY (id);`,
+		`DROP EXTENSION "hstore";`,
+	}, stmts)
+}
+
+func TestSchemaDiffMySQLColumnExtra(t *testing.T) {
+	oldSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "int", Extra: "auto_increment"},
+					{Name: "updated_ts", Position: 2, Type: "timestamp", Default: strPtr("CURRENT_TIMESTAMP"), Extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				},
+			},
+		},
+	}
+	newSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "bigint", Extra: "auto_increment"},
+					{Name: "updated_ts", Position: 2, Type: "timestamp(3)", Default: strPtr("CURRENT_TIMESTAMP(3)"), Extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP(3)"},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				},
+			},
+			{
+				Name: "author",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "int", Extra: "auto_increment"},
+					{Name: "uid", Position: 2, Type: "varchar(36)", Default: strPtr("uuid()"), Extra: "DEFAULT_GENERATED"},
+				},
+				IndexList: []db.Index{
+					{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				},
+			},
+		},
+	}
+
+	stmts, err := SchemaDiff(db.MySQL, oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ALTER TABLE `book` MODIFY COLUMN `id` bigint NOT NULL AUTO_INCREMENT;",
+		"ALTER TABLE `book` MODIFY COLUMN `updated_ts` timestamp(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3);",
+		"CREATE TABLE `author` (\n" +
+			"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+			"  `uid` varchar(36) NOT NULL DEFAULT (uuid()),\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			");",
+	}, stmts)
+
+	// The generation expression isn't synced, so generated columns are refused instead of dropping the expression.
+	newSchema.TableList[1].ColumnList = append(newSchema.TableList[1].ColumnList, db.Column{Name: "full_uid", Position: 3, Type: "varchar(64)", Nullable: true, Extra: "VIRTUAL GENERATED"})
+	_, err = SchemaDiff(db.MySQL, oldSchema, newSchema)
+	require.Error(t, err)
+}
+
+func TestSchemaDiffIndex(t *testing.T) {
+	oldSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+					{Name: "name", Position: 2, Type: "text", Default: strPtr("")},
+				},
+				IndexList: []db.Index{
+					{Name: "idx_book_name", Expression: "name", Position: 1, Type: "btree"},
+				},
+			},
+		},
+	}
+	newSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+					{Name: "name", Position: 2, Type: "text", Default: strPtr("")},
+				},
+				IndexList: []db.Index{
+					// The primary key is identified by the synced flag rather than the "<table>_pkey" name.
+					{Name: "pk_book", Expression: "id", Position: 1, Type: "btree", Unique: true, Primary: true},
+					{Name: "name_pkey", Expression: "name", Position: 1, Type: "btree", Unique: true},
+					{Name: "idx_book_name", Expression: "name", Position: 1, Type: "btree", Comment: "lookup by name"},
+				},
+			},
+		},
+	}
+
+	stmts, err := SchemaDiff(db.Postgres, oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`ALTER TABLE public.book ADD CONSTRAINT pk_book PRIMARY KEY (id);`,
+		`COMMENT ON INDEX public.idx_book_name IS 'lookup by name';`,
+		`CREATE UNIQUE INDEX name_pkey ON public.book USING btree (name);`,
+	}, stmts)
+
+	// MySQL can only change the index comment by recreating the index.
+	oldMySQLSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name:       "book",
+				ColumnList: []db.Column{{Name: "name", Position: 1, Type: "varchar(64)"}},
+				IndexList: []db.Index{
+					{Name: "idx_name", Expression: "name", Position: 1, Type: "BTREE", Visible: true},
+				},
+			},
+		},
+	}
+	newMySQLSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name:       "book",
+				ColumnList: []db.Column{{Name: "name", Position: 1, Type: "varchar(64)"}},
+				IndexList: []db.Index{
+					{Name: "idx_name", Expression: "name", Position: 1, Type: "BTREE", Visible: true, Comment: "lookup by name"},
+				},
+			},
+		},
+	}
+	stmts, err = SchemaDiff(db.MySQL, oldMySQLSchema, newMySQLSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ALTER TABLE `book` DROP INDEX `idx_name`;",
+		"ALTER TABLE `book` ADD KEY `idx_name` (`name`) COMMENT 'lookup by name';",
+	}, stmts)
+}
+
+func TestSchemaDiffPostgresSequence(t *testing.T) {
+	oldSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.author",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("")},
+				},
+			},
+		},
+	}
+	newSchema := &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.author",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr(""), Extra: "GENERATED BY DEFAULT AS IDENTITY"},
+				},
+			},
+			{
+				Name: "public.book",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr("nextval('book_id_seq'::regclass)")},
+					{Name: "author_id", Position: 2, Type: "bigint", Default: strPtr(""), Extra: "GENERATED ALWAYS AS IDENTITY"},
+				},
+			},
+		},
+	}
+
+	stmts, err := SchemaDiff(db.Postgres, oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`ALTER TABLE public.author ALTER COLUMN "id" ADD GENERATED BY DEFAULT AS IDENTITY;`,
+		`CREATE SEQUENCE IF NOT EXISTS book_id_seq;`,
+		"CREATE TABLE public.book (\n" +
+			"  \"id\" integer NOT NULL DEFAULT nextval('book_id_seq'::regclass),\n" +
+			"  \"author_id\" bigint NOT NULL GENERATED ALWAYS AS IDENTITY\n" +
+			");",
+		`ALTER SEQUENCE book_id_seq OWNED BY public.book."id";`,
+	}, stmts)
+
+	// Switching the identity generation keeps the identity.
+	stmts, err = SchemaDiff(db.Postgres, newSchema, &db.Schema{
+		Name: "test",
+		TableList: []db.Table{
+			{
+				Name: "public.author",
+				ColumnList: []db.Column{
+					{Name: "id", Position: 1, Type: "integer", Default: strPtr(""), Extra: "GENERATED ALWAYS AS IDENTITY"},
+				},
+			},
+			newSchema.TableList[1],
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`ALTER TABLE public.author ALTER COLUMN "id" SET GENERATED ALWAYS;`,
+	}, stmts)
+}
+
+func TestSchemaDiffUnsupportedEngine(t *testing.T) {
+	_, err := SchemaDiff(db.SQLite, nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+var (
+	_ dialect = (*mysqlDialect)(nil)
+
+	mysqlNumberRegexp     = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+	mysqlIdentifierRegexp = regexp.MustCompile("^[a-zA-Z0-9_$]+$")
+)
+
+// mysqlDialect generates DDL statements for MySQL, TiDB and MariaDB.
+type mysqlDialect struct{}
+
+func (*mysqlDialect) checkColumn(column *db.Column) error {
+	for _, token := range strings.Fields(strings.ToUpper(column.Extra)) {
+		// The generation expression of the VIRTUAL GENERATED and STORED GENERATED columns isn't synced.
+		if token == "GENERATED" {
+			return fmt.Errorf("generated column %q isn't supported", column.Extra)
+		}
+	}
+	return nil
+}
+
+func (*mysqlDialect) createExtension(*db.Extension) string { return "" }
+func (*mysqlDialect) updateExtension(*db.Extension) string { return "" }
+func (*mysqlDialect) dropExtension(*db.Extension) string   { return "" }
+
+func (*mysqlDialect) createTable(table *db.Table, indexList []*index) []string {
+	var defList []string
+	for _, column := range sortedColumnList(table.ColumnList) {
+		defList = append(defList, mysqlColumnDefinition(column))
+	}
+	for _, idx := range indexList {
+		defList = append(defList, mysqlIndexDefinition(idx))
+	}
+	stmt := fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", mysqlQuoteIdentifier(table.Name), strings.Join(defList, ",\n  "))
+	if table.Engine != "" {
+		stmt += fmt.Sprintf(" ENGINE=%s", table.Engine)
+	}
+	if table.Collation != "" {
+		stmt += fmt.Sprintf(" COLLATE=%s", table.Collation)
+	}
+	if table.Comment != "" {
+		stmt += fmt.Sprintf(" COMMENT=%s", quoteString(table.Comment))
+	}
+	return []string{stmt + ";"}
+}
+
+func (*mysqlDialect) alterTable(oldTable, newTable *db.Table) []string {
+	var optionList []string
+	if newTable.Engine != "" && oldTable.Engine != newTable.Engine {
+		optionList = append(optionList, fmt.Sprintf("ENGINE=%s", newTable.Engine))
+	}
+	if newTable.Collation != "" && oldTable.Collation != newTable.Collation {
+		optionList = append(optionList, fmt.Sprintf("COLLATE=%s", newTable.Collation))
+	}
+	if oldTable.Comment != newTable.Comment {
+		optionList = append(optionList, fmt.Sprintf("COMMENT=%s", quoteString(newTable.Comment)))
+	}
+	if len(optionList) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s %s;", mysqlQuoteIdentifier(newTable.Name), strings.Join(optionList, " "))}
+}
+
+func (*mysqlDialect) dropTable(table *db.Table) string {
+	return fmt.Sprintf("DROP TABLE %s;", mysqlQuoteIdentifier(table.Name))
+}
+
+func (*mysqlDialect) addColumn(table *db.Table, column *db.Column, after *db.Column) []string {
+	position := " FIRST"
+	if after != nil {
+		position = fmt.Sprintf(" AFTER %s", mysqlQuoteIdentifier(after.Name))
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s%s;", mysqlQuoteIdentifier(table.Name), mysqlColumnDefinition(column), position)}
+}
+
+func (*mysqlDialect) modifyColumn(table *db.Table, _, newColumn *db.Column) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", mysqlQuoteIdentifier(table.Name), mysqlColumnDefinition(newColumn))}
+}
+
+func (*mysqlDialect) dropColumn(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", mysqlQuoteIdentifier(table.Name), mysqlQuoteIdentifier(column.Name))
+}
+
+func (*mysqlDialect) createIndex(table *db.Table, index *index) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD %s;", mysqlQuoteIdentifier(table.Name), mysqlIndexDefinition(index))}
+}
+
+// commentIndex returns an empty string because MySQL can only change the index comment by recreating the index.
+func (*mysqlDialect) commentIndex(*db.Table, *index) string { return "" }
+
+func (*mysqlDialect) dropIndex(table *db.Table, index *index) string {
+	if index.primary {
+		return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", mysqlQuoteIdentifier(table.Name))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", mysqlQuoteIdentifier(table.Name), mysqlQuoteIdentifier(index.name))
+}
+
+func (*mysqlDialect) createView(view *db.View) string {
+	return fmt.Sprintf("CREATE VIEW %s AS %s;", mysqlQuoteIdentifier(view.Name), strings.TrimSuffix(strings.TrimSpace(view.Definition), ";"))
+}
+
+func (*mysqlDialect) dropView(view *db.View) string {
+	return fmt.Sprintf("DROP VIEW %s;", mysqlQuoteIdentifier(view.Name))
+}
+
+func mysqlColumnDefinition(column *db.Column) string {
+	def := fmt.Sprintf("%s %s", mysqlQuoteIdentifier(column.Name), column.Type)
+	if column.CharacterSet != "" {
+		def += fmt.Sprintf(" CHARACTER SET %s", column.CharacterSet)
+	}
+	if column.Collation != "" {
+		def += fmt.Sprintf(" COLLATE %s", column.Collation)
+	}
+	if column.Nullable {
+		def += " NULL"
+	} else {
+		def += " NOT NULL"
+	}
+	if column.Default != nil {
+		def += fmt.Sprintf(" DEFAULT %s", mysqlDefaultValue(column))
+	}
+	if extra := mysqlColumnExtra(column.Extra); extra != "" {
+		def += " " + extra
+	}
+	if column.Comment != "" {
+		def += fmt.Sprintf(" COMMENT %s", quoteString(column.Comment))
+	}
+	return def
+}
+
+// mysqlDefaultValue formats the COLUMN_DEFAULT value from information_schema.
+// Literals are returned unquoted by MySQL, so we quote everything except NULL, numbers, bit values and
+// the CURRENT_TIMESTAMP family. MySQL 8.0 marks expression defaults as DEFAULT_GENERATED in EXTRA,
+// and expressions must be enclosed within parentheses.
+func mysqlDefaultValue(column *db.Column) string {
+	value := *column.Default
+	upper := strings.ToUpper(value)
+	switch {
+	case upper == "NULL",
+		strings.HasPrefix(upper, "CURRENT_TIMESTAMP"),
+		mysqlNumberRegexp.MatchString(value),
+		strings.HasPrefix(value, "b'"),
+		strings.HasPrefix(value, "("):
+		return value
+	case strings.Contains(strings.ToUpper(column.Extra), "DEFAULT_GENERATED"):
+		return fmt.Sprintf("(%s)", value)
+	}
+	return quoteString(value)
+}
+
+// mysqlColumnExtra returns the column attributes kept in the EXTRA of information_schema.COLUMNS,
+// such as "auto_increment" and "on update CURRENT_TIMESTAMP".
+func mysqlColumnExtra(extra string) string {
+	var attributeList []string
+	tokenList := strings.Fields(extra)
+	for i := 0; i < len(tokenList); i++ {
+		switch strings.ToUpper(tokenList[i]) {
+		case "AUTO_INCREMENT":
+			attributeList = append(attributeList, "AUTO_INCREMENT")
+		case "INVISIBLE":
+			attributeList = append(attributeList, "INVISIBLE")
+		case "ON":
+			if i+2 < len(tokenList) && strings.ToUpper(tokenList[i+1]) == "UPDATE" {
+				attributeList = append(attributeList, fmt.Sprintf("ON UPDATE %s", tokenList[i+2]))
+				i += 2
+			}
+		}
+	}
+	return strings.Join(attributeList, " ")
+}
+
+func mysqlIndexDefinition(index *index) string {
+	var keyPartList []string
+	for _, expression := range index.expressions {
+		if mysqlIdentifierRegexp.MatchString(expression) {
+			keyPartList = append(keyPartList, mysqlQuoteIdentifier(expression))
+		} else {
+			// Functional key parts must be enclosed within parentheses.
+			keyPartList = append(keyPartList, fmt.Sprintf("(%s)", expression))
+		}
+	}
+	keyParts := strings.Join(keyPartList, ", ")
+
+	var def string
+	switch {
+	case index.primary:
+		return fmt.Sprintf("PRIMARY KEY (%s)", keyParts)
+	case index.indexType == "FULLTEXT":
+		def = fmt.Sprintf("FULLTEXT KEY %s (%s)", mysqlQuoteIdentifier(index.name), keyParts)
+	case index.indexType == "SPATIAL":
+		def = fmt.Sprintf("SPATIAL KEY %s (%s)", mysqlQuoteIdentifier(index.name), keyParts)
+	case index.unique:
+		def = fmt.Sprintf("UNIQUE KEY %s (%s)", mysqlQuoteIdentifier(index.name), keyParts)
+	default:
+		def = fmt.Sprintf("KEY %s (%s)", mysqlQuoteIdentifier(index.name), keyParts)
+	}
+	if index.indexType == "HASH" {
+		def += " USING HASH"
+	}
+	if index.comment != "" {
+		def += fmt.Sprintf(" COMMENT %s", quoteString(index.comment))
+	}
+	if !index.visible {
+		def += " INVISIBLE"
+	}
+	return def
+}
+
+func mysqlQuoteIdentifier(s string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(s, "`", "``"))
+}
@@ -0,0 +1,263 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+var (
+	_ dialect = (*pgDialect)(nil)
+
+	pgNextvalRegexp = regexp.MustCompile(`^nextval\('(.+)'::regclass\)$`)
+)
+
+// pgDialect generates DDL statements for Postgres.
+// The Postgres driver returns table, view and index names as "schema.name" with identifiers already quoted if needed,
+// so only column names are quoted here.
+type pgDialect struct{}
+
+func (*pgDialect) checkColumn(*db.Column) error { return nil }
+
+func (*pgDialect) createExtension(extension *db.Extension) string {
+	stmt := fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s", pgQuoteIdentifier(extension.Name))
+	if extension.Schema != "" {
+		stmt += fmt.Sprintf(" WITH SCHEMA %s", pgQuoteIdentifier(extension.Schema))
+	}
+	if extension.Version != "" {
+		stmt += fmt.Sprintf(" VERSION %s", quoteString(extension.Version))
+	}
+	return stmt + ";"
+}
+
+func (*pgDialect) updateExtension(extension *db.Extension) string {
+	return fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s;", pgQuoteIdentifier(extension.Name), quoteString(extension.Version))
+}
+
+func (*pgDialect) dropExtension(extension *db.Extension) string {
+	return fmt.Sprintf("DROP EXTENSION %s;", pgQuoteIdentifier(extension.Name))
+}
+
+func (d *pgDialect) createTable(table *db.Table, indexList []*index) []string {
+	columnList := sortedColumnList(table.ColumnList)
+	var stmts []string
+	var defList []string
+	var ownedList []string
+	for _, column := range columnList {
+		if create, owned, ok := pgSequenceStatements(table, column); ok {
+			stmts = append(stmts, create)
+			ownedList = append(ownedList, owned)
+		}
+		defList = append(defList, pgColumnDefinition(column))
+	}
+	stmts = append(stmts, fmt.Sprintf("CREATE TABLE %s (\n  %s\n);", table.Name, strings.Join(defList, ",\n  ")))
+	stmts = append(stmts, ownedList...)
+	if table.Comment != "" {
+		stmts = append(stmts, fmt.Sprintf("COMMENT ON TABLE %s IS %s;", table.Name, quoteString(table.Comment)))
+	}
+	for _, column := range columnList {
+		if column.Comment != "" {
+			stmts = append(stmts, pgColumnComment(table, column))
+		}
+	}
+	for _, idx := range indexList {
+		stmts = append(stmts, d.createIndex(table, idx)...)
+	}
+	return stmts
+}
+
+func (*pgDialect) alterTable(oldTable, newTable *db.Table) []string {
+	if oldTable.Comment == newTable.Comment {
+		return nil
+	}
+	return []string{fmt.Sprintf("COMMENT ON TABLE %s IS %s;", newTable.Name, pgCommentValue(newTable.Comment))}
+}
+
+func (*pgDialect) dropTable(table *db.Table) string {
+	return fmt.Sprintf("DROP TABLE %s;", table.Name)
+}
+
+func (*pgDialect) addColumn(table *db.Table, column *db.Column, _ *db.Column) []string {
+	// Postgres always appends the new column at the end.
+	create, owned, hasSequence := pgSequenceStatements(table, column)
+	var stmts []string
+	if hasSequence {
+		stmts = append(stmts, create)
+	}
+	stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table.Name, pgColumnDefinition(column)))
+	if hasSequence {
+		stmts = append(stmts, owned)
+	}
+	if column.Comment != "" {
+		stmts = append(stmts, pgColumnComment(table, column))
+	}
+	return stmts
+}
+
+func (*pgDialect) modifyColumn(table *db.Table, oldColumn, newColumn *db.Column) []string {
+	var stmts []string
+	alter := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table.Name, pgQuoteIdentifier(newColumn.Name))
+	if oldColumn.Type != newColumn.Type || oldColumn.Collation != newColumn.Collation {
+		stmt := fmt.Sprintf("%s TYPE %s", alter, newColumn.Type)
+		if newColumn.Collation != "" {
+			stmt += fmt.Sprintf(" COLLATE %s", pgQuoteIdentifier(newColumn.Collation))
+		}
+		stmts = append(stmts, stmt+";")
+	}
+	if oldColumn.Nullable != newColumn.Nullable {
+		if newColumn.Nullable {
+			stmts = append(stmts, fmt.Sprintf("%s DROP NOT NULL;", alter))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("%s SET NOT NULL;", alter))
+		}
+	}
+	oldDefault, oldHasDefault := pgDefaultValue(oldColumn)
+	newDefault, newHasDefault := pgDefaultValue(newColumn)
+	if oldHasDefault != newHasDefault || oldDefault != newDefault {
+		if newHasDefault {
+			create, owned, hasSequence := pgSequenceStatements(table, newColumn)
+			if hasSequence {
+				stmts = append(stmts, create)
+			}
+			stmts = append(stmts, fmt.Sprintf("%s SET DEFAULT %s;", alter, newDefault))
+			if hasSequence {
+				stmts = append(stmts, owned)
+			}
+		} else {
+			stmts = append(stmts, fmt.Sprintf("%s DROP DEFAULT;", alter))
+		}
+	}
+	if oldColumn.Extra != newColumn.Extra {
+		switch {
+		case newColumn.Extra == "":
+			stmts = append(stmts, fmt.Sprintf("%s DROP IDENTITY;", alter))
+		case oldColumn.Extra == "":
+			stmts = append(stmts, fmt.Sprintf("%s ADD %s;", alter, newColumn.Extra))
+		default:
+			// Turn "GENERATED BY DEFAULT AS IDENTITY" into "SET GENERATED BY DEFAULT".
+			stmts = append(stmts, fmt.Sprintf("%s SET %s;", alter, strings.TrimSuffix(newColumn.Extra, " AS IDENTITY")))
+		}
+	}
+	if oldColumn.Comment != newColumn.Comment {
+		stmts = append(stmts, pgColumnComment(table, newColumn))
+	}
+	return stmts
+}
+
+func (*pgDialect) dropColumn(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table.Name, pgQuoteIdentifier(column.Name))
+}
+
+func (*pgDialect) createIndex(table *db.Table, index *index) []string {
+	schemaName, _ := pgSplitName(table.Name)
+	expressions := strings.Join(index.expressions, ", ")
+	var stmts []string
+	if index.primary {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);", table.Name, index.name, expressions))
+	} else {
+		stmt := "CREATE"
+		if index.unique {
+			stmt += " UNIQUE"
+		}
+		stmt += fmt.Sprintf(" INDEX %s ON %s", index.name, table.Name)
+		if index.indexType != "" {
+			stmt += fmt.Sprintf(" USING %s", index.indexType)
+		}
+		stmts = append(stmts, fmt.Sprintf("%s (%s);", stmt, expressions))
+	}
+	if index.comment != "" {
+		stmts = append(stmts, fmt.Sprintf("COMMENT ON INDEX %s.%s IS %s;", schemaName, index.name, quoteString(index.comment)))
+	}
+	return stmts
+}
+
+func (*pgDialect) commentIndex(table *db.Table, index *index) string {
+	schemaName, _ := pgSplitName(table.Name)
+	return fmt.Sprintf("COMMENT ON INDEX %s.%s IS %s;", schemaName, index.name, pgCommentValue(index.comment))
+}
+
+func (*pgDialect) dropIndex(table *db.Table, index *index) string {
+	if index.primary {
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", table.Name, index.name)
+	}
+	schemaName, _ := pgSplitName(table.Name)
+	return fmt.Sprintf("DROP INDEX %s.%s;", schemaName, index.name)
+}
+
+func (*pgDialect) createView(view *db.View) string {
+	return fmt.Sprintf("CREATE VIEW %s AS\n%s;", view.Name, strings.TrimSuffix(strings.TrimSpace(view.Definition), ";"))
+}
+
+func (*pgDialect) dropView(view *db.View) string {
+	return fmt.Sprintf("DROP VIEW %s;", view.Name)
+}
+
+func pgColumnDefinition(column *db.Column) string {
+	def := fmt.Sprintf("%s %s", pgQuoteIdentifier(column.Name), column.Type)
+	if column.Collation != "" {
+		def += fmt.Sprintf(" COLLATE %s", pgQuoteIdentifier(column.Collation))
+	}
+	if !column.Nullable {
+		def += " NOT NULL"
+	}
+	if value, ok := pgDefaultValue(column); ok {
+		def += fmt.Sprintf(" DEFAULT %s", value)
+	}
+	if column.Extra != "" {
+		def += " " + column.Extra
+	}
+	return def
+}
+
+// pgSequenceStatements returns the statements creating the sequence used by the column default
+// and making the column own it, the same as a serial column does.
+// The Postgres driver syncs serial columns as "DEFAULT nextval('t_id_seq'::regclass)", and the sequence must exist
+// before the default can be set.
+func pgSequenceStatements(table *db.Table, column *db.Column) (string, string, bool) {
+	value, ok := pgDefaultValue(column)
+	if !ok {
+		return "", "", false
+	}
+	matches := pgNextvalRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return "", "", false
+	}
+	sequenceName := strings.ReplaceAll(matches[1], "''", "'")
+	create := fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s;", sequenceName)
+	owned := fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s;", sequenceName, table.Name, pgQuoteIdentifier(column.Name))
+	return create, owned, true
+}
+
+// pgDefaultValue returns the column default expression.
+// The Postgres driver sets an empty default for columns without a default value.
+func pgDefaultValue(column *db.Column) (string, bool) {
+	if column.Default == nil || *column.Default == "" {
+		return "", false
+	}
+	return *column.Default, true
+}
+
+func pgColumnComment(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table.Name, pgQuoteIdentifier(column.Name), pgCommentValue(column.Comment))
+}
+
+func pgCommentValue(comment string) string {
+	if comment == "" {
+		return "NULL"
+	}
+	return quoteString(comment)
+}
+
+// pgSplitName splits the "schema.name" returned by the Postgres driver.
+func pgSplitName(name string) (string, string) {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "public", name
+}
+
+func pgQuoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
@@ -19,6 +19,10 @@ type Type string
 const (
 	// ClickHouse is the database type for CLICKHOUSE.
 	ClickHouse Type = "CLICKHOUSE"
+	// DuckDB is the database type for DuckDB.
+	DuckDB Type = "DUCKDB"
+	// MariaDB is the database type for MariaDB.
+	MariaDB Type = "MARIADB"
 	// MySQL is the database type for MYSQL.
 	MySQL Type = "MYSQL"
 	// Postgres is the database type for POSTGRES.
@@ -67,12 +71,68 @@ type Index struct {
 	// Type isn't supported for SQLite.
 	Type   string
 	Unique bool
-	// Visible isn't supported for Postgres, SQLite.
+	// Primary isn't supported for ClickHouse, Snowflake, SQLite.
+	Primary bool
+	// Visible isn't supported for Postgres, SQLite, and MariaDB before 10.6.
 	Visible bool
 	// Comment isn't supported for SQLite.
 	Comment string
 }
 
+// ForeignKey is the database table foreign key.
+type ForeignKey struct {
+	// Name is generated from the table and the id for SQLite, which doesn't expose the names of foreign keys.
+	Name       string
+	ColumnList []string
+	// ReferencedSchema is the schema of the referenced table in Postgres, or the database of the referenced table in MySQL and TiDB.
+	// ReferencedSchema isn't supported for SQLite.
+	ReferencedSchema     string
+	ReferencedTable      string
+	ReferencedColumnList []string
+	// OnDelete is the referential action such as "CASCADE", "SET NULL", "RESTRICT" or "NO ACTION".
+	OnDelete string
+	OnUpdate string
+}
+
+// CheckConstraint is the database table check constraint.
+type CheckConstraint struct {
+	Name       string
+	Expression string
+}
+
+// Trigger is the database table trigger.
+type Trigger struct {
+	Name string
+	// Timing is "BEFORE", "AFTER" or "INSTEAD OF".
+	Timing string
+	// Event is the triggering event such as "INSERT", or events joined by " OR " such as "INSERT OR UPDATE" for Postgres.
+	Event string
+	Body  string
+}
+
+// Routine is the database stored procedure or function.
+type Routine struct {
+	Name string
+	// Type is "PROCEDURE" or "FUNCTION".
+	Type      string
+	Arguments string
+	// ReturnType is empty for procedures.
+	ReturnType string
+	Definition string
+	Comment    string
+}
+
+// Sequence is the database sequence.
+type Sequence struct {
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+}
+
 // Column the database table column.
 type Column struct {
 	Name     string
@@ -87,6 +147,9 @@ type Column struct {
 	Collation string
 	// Comment isn't supported for SQLite.
 	Comment string
+	// Extra is the MySQL EXTRA such as "auto_increment" or the Postgres identity such as "GENERATED ALWAYS AS IDENTITY".
+	// Extra is only supported for MySQL, TiDB and Postgres.
+	Extra string
 }
 
 // Table is the database table.
@@ -115,6 +178,12 @@ type Table struct {
 	ColumnList []Column
 	// IndexList isn't supported for ClickHouse, Snowflake.
 	IndexList []Index
+	// ForeignKeyList isn't supported for ClickHouse, Snowflake.
+	ForeignKeyList []ForeignKey
+	// CheckConstraintList isn't supported for ClickHouse, Snowflake, SQLite, TiDB, MySQL before 8.0.16, and MariaDB before 10.2.22.
+	CheckConstraintList []CheckConstraint
+	// TriggerList isn't supported for ClickHouse, Snowflake.
+	TriggerList []Trigger
 }
 
 // Schema is the database schema.
@@ -128,6 +197,123 @@ type Schema struct {
 	TableList     []Table
 	ViewList      []View
 	ExtensionList []Extension
+	// RoutineList isn't supported for ClickHouse, Snowflake, SQLite, TiDB.
+	RoutineList []Routine
+	// SequenceList is only supported for Postgres and MariaDB.
+	SequenceList []Sequence
+}
+
+// SchemaObjectKind is the kind of the schema objects synced by the driver.
+type SchemaObjectKind string
+
+const (
+	// SchemaObjectTable is the schema object kind for tables.
+	SchemaObjectTable SchemaObjectKind = "TABLE"
+	// SchemaObjectView is the schema object kind for views.
+	SchemaObjectView SchemaObjectKind = "VIEW"
+	// SchemaObjectIndex is the schema object kind for indexes.
+	SchemaObjectIndex SchemaObjectKind = "INDEX"
+	// SchemaObjectForeignKey is the schema object kind for foreign keys.
+	SchemaObjectForeignKey SchemaObjectKind = "FOREIGN_KEY"
+	// SchemaObjectCheckConstraint is the schema object kind for check constraints.
+	SchemaObjectCheckConstraint SchemaObjectKind = "CHECK_CONSTRAINT"
+	// SchemaObjectTrigger is the schema object kind for triggers.
+	SchemaObjectTrigger SchemaObjectKind = "TRIGGER"
+	// SchemaObjectRoutine is the schema object kind for stored procedures and functions.
+	SchemaObjectRoutine SchemaObjectKind = "ROUTINE"
+	// SchemaObjectSequence is the schema object kind for sequences.
+	SchemaObjectSequence SchemaObjectKind = "SEQUENCE"
+	// SchemaObjectExtension is the schema object kind for extensions.
+	SchemaObjectExtension SchemaObjectKind = "EXTENSION"
+)
+
+// DumpFormat is the format of the dump generated by the driver.
+type DumpFormat string
+
+const (
+	// DumpFormatSQL is the dump format for the plain SQL statements.
+	DumpFormatSQL DumpFormat = "SQL"
+)
+
+// Capabilities describes the features supported by a database engine.
+type Capabilities struct {
+	// TransactionalDDL is whether the DDL statements of a migration are applied in a transaction,
+	// so that a failed migration doesn't leave the schema partially changed.
+	TransactionalDDL bool `json:"transactionalDDL"`
+	// TransactionalDML is whether the DML statements can be rolled back, which isn't the case for the non-transactional
+	// storage engines such as MySQL MyISAM tables.
+	TransactionalDML bool `json:"transactionalDML"`
+	// PITR is whether the engine supports point-in-time recovery.
+	PITR bool `json:"pitr"`
+	// GhOst is whether the engine supports online schema migration with gh-ost.
+	GhOst bool `json:"ghOst"`
+	// ReadOnlyConnection is whether the driver can open read-only connections, see ConnectionConfig.ReadOnly.
+	ReadOnlyConnection bool `json:"readOnlyConnection"`
+	// SchemaObjectKindList is the kinds of the schema objects synced by SyncSchema.
+	// Some kinds may still be unavailable on old versions, e.g. check constraints before MySQL 8.0.16.
+	SchemaObjectKindList []SchemaObjectKind `json:"schemaObjectKindList"`
+	// DumpFormat is the format of the dump generated by Dump.
+	DumpFormat DumpFormat `json:"dumpFormat"`
+	// Explain is whether the driver supports Explain.
+	Explain bool `json:"explain"`
+}
+
+// PlanOperation is the normalized operation of a query plan node.
+type PlanOperation string
+
+const (
+	// PlanOperationTableScan is the plan operation for scanning the whole table.
+	PlanOperationTableScan PlanOperation = "TABLE_SCAN"
+	// PlanOperationIndexScan is the plan operation for scanning or looking up an index.
+	PlanOperationIndexScan PlanOperation = "INDEX_SCAN"
+	// PlanOperationJoin is the plan operation for joins.
+	PlanOperationJoin PlanOperation = "JOIN"
+	// PlanOperationSort is the plan operation for sorting.
+	PlanOperationSort PlanOperation = "SORT"
+	// PlanOperationAggregate is the plan operation for grouping and aggregation.
+	PlanOperationAggregate PlanOperation = "AGGREGATE"
+	// PlanOperationModify is the plan operation for inserting, updating and deleting rows.
+	PlanOperationModify PlanOperation = "MODIFY"
+	// PlanOperationOther is the plan operation for the rest operations.
+	PlanOperationOther PlanOperation = "OTHER"
+)
+
+// PlanNode is a node of the normalized query plan tree returned by Explain.
+type PlanNode struct {
+	Operation PlanOperation `json:"operation"`
+	// Detail is the engine specific description of the node, e.g. "Seq Scan" for Postgres.
+	Detail string `json:"detail"`
+	// Table is the table name in the same form as Table.Name of the synced schema.
+	// Table isn't supported for ClickHouse before 22.5.
+	Table string `json:"table,omitempty"`
+	Index string `json:"index,omitempty"`
+	// EstimatedRows isn't supported for SQLite, ClickHouse.
+	EstimatedRows int64 `json:"estimatedRows,omitempty"`
+	// Cost is in the engine specific unit. Cost isn't supported for SQLite, ClickHouse.
+	Cost     float64     `json:"cost,omitempty"`
+	Children []*PlanNode `json:"children,omitempty"`
+}
+
+// FullTableScanList returns the nodes scanning the whole table in the plan tree.
+func (n *PlanNode) FullTableScanList() []*PlanNode {
+	var list []*PlanNode
+	if n.Operation == PlanOperationTableScan {
+		list = append(list, n)
+	}
+	for _, child := range n.Children {
+		list = append(list, child.FullTableScanList()...)
+	}
+	return list
+}
+
+// SupportSchemaObject returns whether the schema objects of the kind are synced by SyncSchema.
+func (c Capabilities) SupportSchemaObject(kind SchemaObjectKind) bool {
+	for _, k := range c.SchemaObjectKindList {
+		if k == kind {
+			return true
+		}
+	}
+	return false
 }
 
 var (
@@ -137,6 +323,8 @@ var (
 
 // DriverConfig is the driver configuration.
 type DriverConfig struct {
+	// DbType is the engine type, which is useful for drivers supporting multiple engines.
+	DbType        Type
 	PgInstanceDir string
 }
 
@@ -383,7 +571,9 @@ type ConnectionConfig struct {
 	Password  string
 	Database  string
 	TLSConfig TLSConfig
-	// ReadOnly is only supported for Postgres at the moment.
+	// SSHConfig isn't supported for SQLite, DuckDB.
+	SSHConfig SSHConfig
+	// ReadOnly is only supported for the drivers with Capabilities.ReadOnlyConnection.
 	ReadOnly bool
 	// StrictUseDb will only set as true if the user gives only a database instead of a whole instance to access.
 	StrictUseDb bool
@@ -406,13 +596,19 @@ type Driver interface {
 	Ping(ctx context.Context) error
 	GetDbConnection(ctx context.Context, database string) (*sql.DB, error)
 	GetVersion(ctx context.Context) (string, error)
-	SyncSchema(ctx context.Context) ([]*User, []*Schema, error)
+	// Capabilities returns the features supported by the engine. It's available before the driver is opened.
+	Capabilities() Capabilities
+	// SyncSchema syncs the users and the schemas of the databases in databaseList, or all databases if databaseList is empty.
+	SyncSchema(ctx context.Context, databaseList ...string) ([]*User, []*Schema, error)
 	// Execute will execute the statement. For CREATE DATABASE statement, some types of databases such as Postgres
 	// will not use transactions to execute the statement but will still use transactions to execute the rest of statements.
 	Execute(ctx context.Context, statement string) error
 	// Used for execute readonly SELECT statement
 	// limit is the maximum row count returned. No limit enforced if limit <= 0
 	Query(ctx context.Context, statement string, limit int) ([]interface{}, error)
+	// Explain returns the query plan of the statement without executing it.
+	// It's only supported for the drivers with Capabilities.Explain.
+	Explain(ctx context.Context, statement string) (*PlanNode, error)
 
 	// Migration related
 	// Check whether we need to setup migration (e.g. creating/upgrading the migration related tables)
@@ -461,6 +657,7 @@ func Open(ctx context.Context, dbType Type, driverConfig DriverConfig, connectio
 		return nil, fmt.Errorf("db: unknown driver %v", dbType)
 	}
 
+	driverConfig.DbType = dbType
 	driver, err := f(driverConfig).Open(ctx, dbType, connectionConfig, connCtx)
 	if err != nil {
 		return nil, err
@@ -474,6 +671,17 @@ func Open(ctx context.Context, dbType Type, driverConfig DriverConfig, connectio
 	return driver, nil
 }
 
+// GetCapabilities returns the features supported by the engine without connecting to the database.
+func GetCapabilities(dbType Type) (Capabilities, error) {
+	driversMu.RLock()
+	f, ok := drivers[dbType]
+	driversMu.RUnlock()
+	if !ok {
+		return Capabilities{}, fmt.Errorf("db: unknown driver %v", dbType)
+	}
+	return f(DriverConfig{DbType: dbType}).Capabilities(), nil
+}
+
 // FormatParamNameInQuestionMark formats the param name in question mark.
 // For example, it will be WHERE hello = ? AND world = ?.
 func FormatParamNameInQuestionMark(paramNames []string) string {
@@ -206,3 +206,14 @@ func TestParseMigrationInfo(t *testing.T) {
 		require.Equal(t, tc.want, *mi)
 	}
 }
+
+func TestCapabilities(t *testing.T) {
+	capabilities := Capabilities{
+		SchemaObjectKindList: []SchemaObjectKind{SchemaObjectTable, SchemaObjectView},
+	}
+	require.True(t, capabilities.SupportSchemaObject(SchemaObjectView))
+	require.False(t, capabilities.SupportSchemaObject(SchemaObjectSequence))
+
+	_, err := GetCapabilities(Type("UNKNOWN"))
+	require.Error(t, err)
+}
@@ -0,0 +1,976 @@
+//go:build duckdb
+// +build duckdb
+
+// Package duckdb is the DuckDB driver.
+//
+// The driver requires cgo and github.com/marcboeker/go-duckdb, so it's only compiled with the duckdb build tag, e.g.
+// "go build -tags duckdb ./bin/server/...".
+package duckdb
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+
+	// embed will embeds the migration schema.
+	_ "embed"
+
+	// Import duckdb driver.
+	_ "github.com/marcboeker/go-duckdb"
+
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+	"go.uber.org/zap"
+)
+
+//go:embed duckdb_migration_schema.sql
+var migrationSchema string
+
+var (
+	bytebaseDatabase     = "bytebase"
+	excludedDatabaseList = map[string]bool{
+		// Skip our internal "bytebase" database
+		bytebaseDatabase: true,
+	}
+	// systemSchemaList are the schemas created by DuckDB itself.
+	systemSchemaList = map[string]bool{
+		"information_schema": true,
+		"pg_catalog":         true,
+	}
+
+	_ db.Driver              = (*Driver)(nil)
+	_ util.MigrationExecutor = (*Driver)(nil)
+)
+
+func init() {
+	db.Register(db.DuckDB, newDriver)
+}
+
+// Driver is the DuckDB driver.
+type Driver struct {
+	dir           string
+	db            *sql.DB
+	connectionCtx db.ConnectionContext
+}
+
+func newDriver(config db.DriverConfig) db.Driver {
+	return &Driver{}
+}
+
+// Open opens a DuckDB driver.
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	// Host is the directory (instance) containing all DuckDB databases.
+	driver.dir = config.Host
+
+	// If config.Database is empty, we will get a connection to in-memory database.
+	if _, err := driver.GetDbConnection(ctx, config.Database); err != nil {
+		return nil, err
+	}
+	driver.connectionCtx = connCtx
+	return driver, nil
+}
+
+// Close closes the driver.
+func (driver *Driver) Close(ctx context.Context) error {
+	if driver.db != nil {
+		return driver.db.Close()
+	}
+	return nil
+}
+
+// Ping pings the database.
+func (driver *Driver) Ping(ctx context.Context) error {
+	return driver.db.PingContext(ctx)
+}
+
+// GetDbConnection gets a database connection.
+// If database is empty, we will get a connect to in-memory database.
+func (driver *Driver) GetDbConnection(ctx context.Context, database string) (*sql.DB, error) {
+	if driver.db != nil {
+		if err := driver.db.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	// An empty DSN opens an in-memory database.
+	dns := ""
+	if database != "" {
+		dns = path.Join(driver.dir, fmt.Sprintf("%s.duckdb", database))
+	}
+	db, err := sql.Open("duckdb", dns)
+	if err != nil {
+		return nil, err
+	}
+	driver.db = db
+	return db, nil
+}
+
+// GetVersion gets the version.
+func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
+	var version string
+	row := driver.db.QueryRowContext(ctx, "SELECT version();")
+	if err := row.Scan(&version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// Capabilities returns the features supported by DuckDB.
+func (*Driver) Capabilities() db.Capabilities {
+	return db.Capabilities{
+		TransactionalDDL:     true,
+		TransactionalDML:     true,
+		SchemaObjectKindList: []db.SchemaObjectKind{db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey, db.SchemaObjectCheckConstraint},
+		DumpFormat:           db.DumpFormatSQL,
+	}
+}
+
+// SyncSchema syncs the schema.
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
+	databases, err := driver.getDatabases()
+	if err != nil {
+		return nil, nil, err
+	}
+	includedDatabaseList := make(map[string]bool)
+	for _, dbName := range databaseList {
+		includedDatabaseList[dbName] = true
+	}
+
+	var schemaList []*db.Schema
+	for _, dbName := range databases {
+		if _, ok := excludedDatabaseList[dbName]; ok {
+			continue
+		}
+		if len(includedDatabaseList) > 0 && !includedDatabaseList[dbName] {
+			continue
+		}
+
+		var schema db.Schema
+		schema.Name = dbName
+
+		sqldb, err := driver.GetDbConnection(ctx, dbName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get database connection for %q: %s", dbName, err)
+		}
+		txn, err := sqldb.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer txn.Rollback()
+
+		indicesMap, err := getIndices(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get indices from database %q: %s", dbName, err)
+		}
+		constraintsMap, err := getConstraints(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get constraints from database %q: %s", dbName, err)
+		}
+		tbls, err := getTables(txn)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range tbls {
+			tbls[i].IndexList = indicesMap[tbls[i].Name]
+			if constraints, ok := constraintsMap[tbls[i].Name]; ok {
+				tbls[i].ForeignKeyList = constraints.foreignKeyList
+				tbls[i].CheckConstraintList = constraints.checkConstraintList
+			}
+		}
+		schema.TableList = tbls
+
+		views, err := getViews(txn)
+		if err != nil {
+			return nil, nil, err
+		}
+		schema.ViewList = views
+
+		if err := txn.Commit(); err != nil {
+			return nil, nil, err
+		}
+
+		schemaList = append(schemaList, &schema)
+	}
+	return nil, schemaList, nil
+}
+
+// getTables gets all tables of a database.
+// The tables are named by their schemas and names, e.g. "main.book".
+func getTables(txn *sql.Tx) ([]db.Table, error) {
+	var tables []db.Table
+	query := `
+		SELECT schema_name, table_name, estimated_size
+		FROM duckdb_tables()
+		WHERE database_name = current_database() AND NOT internal AND NOT temporary
+		ORDER BY schema_name, table_name;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var schemaName, name string
+		var tbl db.Table
+		if err := rows.Scan(&schemaName, &name, &tbl.RowCount); err != nil {
+			return nil, err
+		}
+		if systemSchemaList[schemaName] {
+			continue
+		}
+		tbl.Name = fmt.Sprintf("%s.%s", schemaName, name)
+		tbl.Type = "BASE TABLE"
+		tables = append(tables, tbl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	columnsMap, err := getColumns(txn)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tables {
+		tables[i].ColumnList = columnsMap[tables[i].Name]
+	}
+	return tables, nil
+}
+
+// getColumns returns the schemaName.tableName -> columnList map of a database.
+func getColumns(txn *sql.Tx) (map[string][]db.Column, error) {
+	query := `
+		SELECT schema_name, table_name, column_name, column_index, is_nullable, column_default, data_type
+		FROM duckdb_columns()
+		WHERE database_name = current_database() AND NOT internal
+		ORDER BY schema_name, table_name, column_index;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]db.Column)
+	for rows.Next() {
+		var schemaName, tableName string
+		var col db.Column
+		var dfltValue sql.NullString
+		if err := rows.Scan(&schemaName, &tableName, &col.Name, &col.Position, &col.Nullable, &dfltValue, &col.Type); err != nil {
+			return nil, err
+		}
+		if dfltValue.Valid {
+			col.Default = &dfltValue.String
+		}
+		key := fmt.Sprintf("%s.%s", schemaName, tableName)
+		columns[key] = append(columns[key], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// getIndices returns the schemaName.tableName -> indexList map of a database.
+// The primary keys and the unique constraints are backed by indices, but DuckDB only lists the indices created by CREATE INDEX.
+func getIndices(txn *sql.Tx) (map[string][]db.Index, error) {
+	query := `
+		SELECT schema_name, table_name, index_name, is_unique, expressions
+		FROM duckdb_indexes()
+		WHERE database_name = current_database()
+		ORDER BY schema_name, table_name, index_name;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	indices := make(map[string][]db.Index)
+	for rows.Next() {
+		var schemaName, tableName, name, expressions string
+		var unique bool
+		if err := rows.Scan(&schemaName, &tableName, &name, &unique, &expressions); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s.%s", schemaName, tableName)
+		// The expressions are formatted as a list, e.g. "[a, lower(b)]".
+		for i, expression := range splitList(expressions) {
+			indices[key] = append(indices[key], db.Index{
+				Name:       name,
+				Expression: expression,
+				Position:   i + 1,
+				Unique:     unique,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+// foreignKeyRegexp matches the referenced table and columns of a foreign key constraint,
+// e.g. "FOREIGN KEY (author_id) REFERENCES author(id)".
+var foreignKeyRegexp = regexp.MustCompile(`(?is)REFERENCES\s+(\S+?)\s*\((.*)\)`)
+
+// tableConstraints is the constraints of a table.
+type tableConstraints struct {
+	foreignKeyList      []db.ForeignKey
+	checkConstraintList []db.CheckConstraint
+}
+
+// getConstraints returns the schemaName.tableName -> constraints map of a database.
+// DuckDB doesn't expose the names of the constraints, so they are named by the table and their indexes, e.g. "book_fk_0" and "book_check_1".
+func getConstraints(txn *sql.Tx) (map[string]*tableConstraints, error) {
+	query := `
+		SELECT schema_name, table_name, constraint_index, constraint_type, constraint_text, array_to_string(constraint_column_names, ',')
+		FROM duckdb_constraints()
+		WHERE database_name = current_database() AND constraint_type IN ('FOREIGN KEY', 'CHECK')
+		ORDER BY schema_name, table_name, constraint_index;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	constraints := make(map[string]*tableConstraints)
+	for rows.Next() {
+		var schemaName, tableName, constraintType, text, columns string
+		var index int
+		if err := rows.Scan(&schemaName, &tableName, &index, &constraintType, &text, &columns); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s.%s", schemaName, tableName)
+		if constraints[key] == nil {
+			constraints[key] = &tableConstraints{}
+		}
+		switch constraintType {
+		case "FOREIGN KEY":
+			fk := db.ForeignKey{
+				Name:       fmt.Sprintf("%s_fk_%d", tableName, index),
+				ColumnList: strings.Split(columns, ","),
+			}
+			if matches := foreignKeyRegexp.FindStringSubmatch(text); matches != nil {
+				fk.ReferencedTable = matches[1]
+				for _, column := range strings.Split(matches[2], ",") {
+					fk.ReferencedColumnList = append(fk.ReferencedColumnList, strings.TrimSpace(column))
+				}
+			}
+			constraints[key].foreignKeyList = append(constraints[key].foreignKeyList, fk)
+		case "CHECK":
+			constraints[key].checkConstraintList = append(constraints[key].checkConstraintList, db.CheckConstraint{
+				Name:       fmt.Sprintf("%s_check_%d", tableName, index),
+				Expression: text,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
+
+func getViews(txn *sql.Tx) ([]db.View, error) {
+	var views []db.View
+	query := `
+		SELECT schema_name, view_name, sql
+		FROM duckdb_views()
+		WHERE database_name = current_database() AND NOT internal AND NOT temporary
+		ORDER BY schema_name, view_name;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schemaName, name string
+		var view db.View
+		if err := rows.Scan(&schemaName, &name, &view.Definition); err != nil {
+			return nil, err
+		}
+		if systemSchemaList[schemaName] {
+			continue
+		}
+		view.Name = fmt.Sprintf("%s.%s", schemaName, name)
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// splitList splits a list formatted by DuckDB, e.g. "[a, lower(b)]", into its elements.
+func splitList(list string) []string {
+	list = strings.TrimSuffix(strings.TrimPrefix(list, "["), "]")
+	var elements []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				elements = append(elements, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(list[start:]); last != "" {
+		elements = append(elements, last)
+	}
+	return elements
+}
+
+func (driver *Driver) getDatabases() ([]string, error) {
+	files, err := ioutil.ReadDir(driver.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %q, error %w", driver.dir, err)
+	}
+	var databases []string
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".duckdb") {
+			continue
+		}
+		databases = append(databases, strings.TrimSuffix(file.Name(), ".duckdb"))
+	}
+	return databases, nil
+}
+
+func (driver *Driver) hasBytebaseDatabase() (bool, error) {
+	databases, err := driver.getDatabases()
+	if err != nil {
+		return false, err
+	}
+	for _, database := range databases {
+		if database == bytebaseDatabase {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Execute executes a SQL statement.
+func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	var remainingStmts []string
+	f := func(stmt string) error {
+		// This is a fake CREATE DATABASE statement. Engine driver will recognize it and establish a connection to create the database.
+		stmt = strings.TrimLeft(stmt, " \t")
+		if strings.HasPrefix(stmt, "CREATE DATABASE ") {
+			parts := strings.Split(stmt, `'`)
+			if len(parts) != 3 {
+				return fmt.Errorf("invalid statement %q", stmt)
+			}
+			db, err := driver.GetDbConnection(ctx, parts[1])
+			if err != nil {
+				return err
+			}
+			// We need to query to persist the database file.
+			if _, err := db.ExecContext(ctx, "SELECT 1;"); err != nil {
+				return err
+			}
+		} else if strings.HasPrefix(stmt, "USE ") {
+			// ignore this fake use database statement.
+		} else {
+			remainingStmts = append(remainingStmts, stmt)
+		}
+		return nil
+	}
+	sc := bufio.NewScanner(strings.NewReader(statement))
+	if err := util.ApplyMultiStatements(sc, f); err != nil {
+		return err
+	}
+
+	if len(remainingStmts) == 0 {
+		return nil
+	}
+
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, strings.Join(remainingStmts, "\n")); err == nil {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// Query queries a SQL statement.
+func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
+	return util.Query(ctx, driver.db, statement, limit)
+}
+
+// Explain isn't supported for DuckDB.
+func (*Driver) Explain(_ context.Context, _ string) (*db.PlanNode, error) {
+	return nil, fmt.Errorf("explain isn't supported for DuckDB")
+}
+
+// NeedsSetupMigration returns whether it needs to setup migration.
+func (driver *Driver) NeedsSetupMigration(ctx context.Context) (bool, error) {
+	exist, err := driver.hasBytebaseDatabase()
+	if err != nil {
+		return false, err
+	}
+	if !exist {
+		return true, nil
+	}
+	if _, err := driver.GetDbConnection(ctx, bytebaseDatabase); err != nil {
+		return false, err
+	}
+
+	const query = `
+		SELECT
+		    1
+		FROM duckdb_tables()
+		WHERE schema_name = 'bytebase' AND table_name = 'migration_history'
+	`
+	return util.NeedsSetupMigrationSchema(ctx, driver.db, query)
+}
+
+// SetupMigrationIfNeeded sets up migration if needed.
+func (driver *Driver) SetupMigrationIfNeeded(ctx context.Context) error {
+	setup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if setup {
+		log.Info("Bytebase migration schema not found, creating schema...",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+
+		if _, err := driver.GetDbConnection(ctx, bytebaseDatabase); err != nil {
+			log.Error("Failed to switch to bytebase database.",
+				zap.Error(err),
+				zap.String("environment", driver.connectionCtx.EnvironmentName),
+				zap.String("database", driver.connectionCtx.InstanceName),
+			)
+			return fmt.Errorf("failed to switch to bytebase database error: %v", err)
+		}
+
+		if _, err := driver.db.ExecContext(ctx, migrationSchema); err != nil {
+			log.Error("Failed to initialize migration schema.",
+				zap.Error(err),
+				zap.String("environment", driver.connectionCtx.EnvironmentName),
+				zap.String("database", driver.connectionCtx.InstanceName),
+			)
+			return util.FormatErrorWithQuery(err, migrationSchema)
+		}
+		log.Info("Successfully created migration schema.",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+	}
+
+	return nil
+}
+
+// FindLargestVersionSinceBaseline will find the largest version since last baseline or branch.
+func (driver Driver) FindLargestVersionSinceBaseline(ctx context.Context, tx *sql.Tx, namespace string) (*string, error) {
+	largestBaselineSequence, err := driver.FindLargestSequence(ctx, tx, namespace, true /* baseline */)
+	if err != nil {
+		return nil, err
+	}
+	const getLargestVersionSinceLastBaselineQuery = `
+		SELECT MAX(version) FROM bytebase.migration_history
+		WHERE namespace = ? AND sequence >= ?
+	`
+	row, err := tx.QueryContext(ctx, getLargestVersionSinceLastBaselineQuery,
+		namespace, largestBaselineSequence,
+	)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, getLargestVersionSinceLastBaselineQuery)
+	}
+	defer row.Close()
+
+	var version sql.NullString
+	row.Next()
+	if err := row.Scan(&version); err != nil {
+		return nil, err
+	}
+
+	if version.Valid {
+		return &version.String, nil
+	}
+
+	return nil, nil
+}
+
+// FindLargestSequence will return the largest sequence number.
+func (Driver) FindLargestSequence(ctx context.Context, tx *sql.Tx, namespace string, baseline bool) (int, error) {
+	findLargestSequenceQuery := `
+		SELECT MAX(sequence) FROM bytebase.migration_history
+		WHERE namespace = ?`
+	if baseline {
+		findLargestSequenceQuery = fmt.Sprintf("%s AND (type = '%s' OR type = '%s')", findLargestSequenceQuery, db.Baseline, db.Branch)
+	}
+	row, err := tx.QueryContext(ctx, findLargestSequenceQuery,
+		namespace,
+	)
+	if err != nil {
+		return -1, util.FormatErrorWithQuery(err, findLargestSequenceQuery)
+	}
+	defer row.Close()
+
+	var sequence sql.NullInt64
+	row.Next()
+	if err := row.Scan(&sequence); err != nil {
+		return -1, err
+	}
+
+	if !sequence.Valid {
+		// Returns 0 if we haven't applied any migration for this namespace.
+		return 0, nil
+	}
+
+	return int(sequence.Int64), nil
+}
+
+// InsertPendingHistory will insert the migration record with pending status and return the inserted ID.
+func (Driver) InsertPendingHistory(ctx context.Context, tx *sql.Tx, sequence int, prevSchema string, m *db.MigrationInfo, storedVersion, statement string) (int64, error) {
+	// DuckDB doesn't support LastInsertId, so the inserted ID is returned by the RETURNING clause.
+	const insertHistoryQuery = `
+	INSERT INTO bytebase.migration_history (
+		created_by,
+		created_ts,
+		updated_by,
+		updated_ts,
+		release_version,
+		namespace,
+		sequence,
+		source,
+		type,
+		status,
+		version,
+		description,
+		statement,
+		schema,
+		schema_prev,
+		execution_duration_ns,
+		issue_id,
+		payload
+	)
+	VALUES (?, epoch(now())::BIGINT, ?, epoch(now())::BIGINT, ?, ?, ?, ?,  ?, 'PENDING', ?, ?, ?, ?, ?, 0, ?, ?)
+	RETURNING id
+	`
+	var insertedID int64
+	if err := tx.QueryRowContext(ctx, insertHistoryQuery,
+		m.Creator,
+		m.Creator,
+		m.ReleaseVersion,
+		m.Namespace,
+		sequence,
+		m.Source,
+		m.Type,
+		storedVersion,
+		m.Description,
+		statement,
+		prevSchema,
+		prevSchema,
+		m.IssueID,
+		m.Payload,
+	).Scan(&insertedID); err != nil {
+		return int64(0), util.FormatErrorWithQuery(err, insertHistoryQuery)
+	}
+	return insertedID, nil
+}
+
+// UpdateHistoryAsDone will update the migration record as done.
+func (Driver) UpdateHistoryAsDone(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, updatedSchema string, insertedID int64) error {
+	const updateHistoryAsDoneQuery = `
+	UPDATE
+		bytebase.migration_history
+	SET
+		status = 'DONE',
+		execution_duration_ns = ?,
+		schema = ?
+	WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryAsDoneQuery, migrationDurationNs, updatedSchema, insertedID)
+	return err
+}
+
+// UpdateHistoryAsFailed will update the migration record as failed.
+func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, insertedID int64) error {
+	const updateHistoryAsFailedQuery = `
+	UPDATE
+		bytebase.migration_history
+	SET
+		status = 'FAILED',
+		execution_duration_ns = ?
+	WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryAsFailedQuery, migrationDurationNs, insertedID)
+	return err
+}
+
+// ExecuteMigration will execute the migration.
+func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
+	return util.ExecuteMigration(ctx, driver, m, statement, bytebaseDatabase)
+}
+
+// FindMigrationHistoryList finds the migration history.
+func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
+	baseQuery := `
+	SELECT
+		id,
+		created_by,
+		created_ts,
+		updated_by,
+		updated_ts,
+		release_version,
+		namespace,
+		sequence,
+		source,
+		type,
+		status,
+		version,
+		description,
+		statement,
+		schema,
+		schema_prev,
+		execution_duration_ns,
+		issue_id,
+		payload
+		FROM bytebase.migration_history `
+	paramNames, params := []string{}, []interface{}{}
+	if v := find.ID; v != nil {
+		paramNames, params = append(paramNames, "id"), append(params, *v)
+	}
+	if v := find.Database; v != nil {
+		paramNames, params = append(paramNames, "namespace"), append(params, *v)
+	}
+	if v := find.Version; v != nil {
+		storedVersion, err := util.ToStoredVersion(false, *v, "")
+		if err != nil {
+			return nil, err
+		}
+		paramNames, params = append(paramNames, "version"), append(params, storedVersion)
+	}
+	if v := find.Source; v != nil {
+		paramNames, params = append(paramNames, "source"), append(params, *v)
+	}
+	var query = baseQuery +
+		db.FormatParamNameInQuestionMark(paramNames) +
+		`ORDER BY created_ts DESC`
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	return util.FindMigrationHistoryList(ctx, query, params, driver, bytebaseDatabase, find, baseQuery)
+}
+
+// Dump dumps the database.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
+	if database == "" {
+		return "", fmt.Errorf("DuckDB can dump one database only at a time")
+	}
+
+	// Find all dumpable databases and make sure the existence of the database to be dumped.
+	databases, err := driver.getDatabases()
+	if err != nil {
+		return "", fmt.Errorf("failed to get databases: %s", err)
+	}
+	exist := false
+	for _, n := range databases {
+		if n == database {
+			exist = true
+			break
+		}
+	}
+	if !exist {
+		return "", fmt.Errorf("database %s not found", database)
+	}
+
+	if err := driver.dumpOneDatabase(ctx, database, out, schemaOnly); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// duckdbSchema is a schema object with its CREATE statement.
+type duckdbSchema struct {
+	schemaName string
+	name       string
+	statement  string
+}
+
+// dumpQuery is a query getting the schema objects of a kind with their CREATE statements.
+type dumpQuery struct {
+	query string
+	// withData is true if the objects are tables whose data should be dumped along with the schema.
+	withData bool
+}
+
+// dumpQueryList are the queries getting the schema objects in the order of creation, so that the objects depended on are created first.
+// The indices are created after the table data for faster restoring.
+var dumpQueryList = []dumpQuery{
+	{query: `SELECT schema_name, schema_name, 'CREATE SCHEMA ' || schema_name FROM duckdb_schemas()
+		WHERE database_name = current_database() AND NOT internal AND schema_name NOT IN ('main', 'information_schema', 'pg_catalog') ORDER BY oid;`},
+	{query: `SELECT schema_name, sequence_name, sql FROM duckdb_sequences()
+		WHERE database_name = current_database() AND NOT temporary ORDER BY oid;`},
+	{query: `SELECT schema_name, table_name, sql FROM duckdb_tables()
+		WHERE database_name = current_database() AND NOT internal AND NOT temporary ORDER BY oid;`, withData: true},
+	{query: `SELECT schema_name, view_name, sql FROM duckdb_views()
+		WHERE database_name = current_database() AND NOT internal AND NOT temporary ORDER BY oid;`},
+	{query: `SELECT schema_name, index_name, sql FROM duckdb_indexes()
+		WHERE database_name = current_database() AND sql IS NOT NULL ORDER BY oid;`},
+}
+
+func (driver *Driver) dumpOneDatabase(ctx context.Context, database string, out io.Writer, schemaOnly bool) error {
+	if _, err := driver.GetDbConnection(ctx, database); err != nil {
+		return err
+	}
+
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	for _, q := range dumpQueryList {
+		duckdbSchemas, err := getDuckDBSchemas(ctx, txn, q.query)
+		if err != nil {
+			return err
+		}
+		for _, s := range duckdbSchemas {
+			if systemSchemaList[s.schemaName] {
+				continue
+			}
+			if _, err := io.WriteString(out, fmt.Sprintf("%s;\n", strings.TrimSuffix(s.statement, ";"))); err != nil {
+				return err
+			}
+
+			// Dump table data.
+			if !schemaOnly && q.withData {
+				if err := exportTableData(txn, s.schemaName, s.name, out); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getDuckDBSchemas(ctx context.Context, txn *sql.Tx, query string) ([]duckdbSchema, error) {
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var duckdbSchemas []duckdbSchema
+	for rows.Next() {
+		var s duckdbSchema
+		if err := rows.Scan(
+			&s.schemaName,
+			&s.name,
+			&s.statement,
+		); err != nil {
+			return nil, err
+		}
+		duckdbSchemas = append(duckdbSchemas, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return duckdbSchemas, nil
+}
+
+// exportTableData gets the data of a table.
+func exportTableData(txn *sql.Tx, schemaName, tblName string, out io.Writer) error {
+	table := fmt.Sprintf(`"%s"."%s"`, schemaName, tblName)
+	query := fmt.Sprintf("SELECT * FROM %s;", table)
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	values := make([]*sql.NullString, len(cols))
+	refs := make([]interface{}, len(cols))
+	for i := 0; i < len(cols); i++ {
+		refs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(refs...); err != nil {
+			return err
+		}
+		tokens := make([]string, len(cols))
+		for i, v := range values {
+			switch {
+			case v == nil || !v.Valid:
+				tokens[i] = "NULL"
+			default:
+				tokens[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(v.String, "'", "''"))
+			}
+		}
+		stmt := fmt.Sprintf("INSERT INTO %s VALUES (%s);\n", table, strings.Join(tokens, ", "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, sc *bufio.Scanner) (err error) {
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	f := func(stmt string) error {
+		if _, err := txn.Exec(stmt); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := util.ApplyMultiStatements(sc, f); err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RestoreTx restores the database in the given transaction.
+func (driver *Driver) RestoreTx(ctx context.Context, tx *sql.Tx, sc *bufio.Scanner) error {
+	return fmt.Errorf("Unimplemented")
+}
@@ -0,0 +1,34 @@
+//go:build duckdb
+// +build duckdb
+
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		list string
+		want []string
+	}{
+		{
+			list: "[]",
+			want: nil,
+		},
+		{
+			list: "[id]",
+			want: []string{"id"},
+		},
+		{
+			list: "[author_id, lower(title), substr(isbn, 1, 3)]",
+			want: []string{"author_id", "lower(title)", "substr(isbn, 1, 3)"},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, splitList(test.list), test.list)
+	}
+}
@@ -0,0 +1,165 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+var (
+	// mysqlPlanOperations is the operations of the JSON plan keys wrapping the nested plans.
+	// The keys not listed are transparent, e.g. "attached_subqueries".
+	mysqlPlanOperations = map[string]db.PlanOperation{
+		"query_block":                db.PlanOperationOther,
+		"nested_loop":                db.PlanOperationJoin,
+		"ordering_operation":         db.PlanOperationSort,
+		"grouping_operation":         db.PlanOperationAggregate,
+		"duplicates_removal":         db.PlanOperationOther,
+		"union_result":               db.PlanOperationOther,
+		"windowing":                  db.PlanOperationOther,
+		"materialized_from_subquery": db.PlanOperationOther,
+	}
+	// mysqlIndexAccessTypes is the access types using indexes, see https://dev.mysql.com/doc/refman/8.0/en/explain-output.html#explain-join-types.
+	mysqlIndexAccessTypes = map[string]bool{
+		"system":          true,
+		"const":           true,
+		"eq_ref":          true,
+		"ref":             true,
+		"fulltext":        true,
+		"ref_or_null":     true,
+		"index_merge":     true,
+		"unique_subquery": true,
+		"index_subquery":  true,
+		"range":           true,
+		"index":           true,
+	}
+)
+
+// Explain returns the query plan of the statement by EXPLAIN FORMAT=JSON.
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.PlanNode, error) {
+	if driver.dbType == db.TiDB {
+		return nil, fmt.Errorf("explain isn't supported for TiDB")
+	}
+	rows, err := util.ExplainRows(ctx, driver.db, "EXPLAIN FORMAT=JSON", statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("expect one row of the JSON plan, got %d rows", len(rows))
+	}
+	return parseMySQLPlan([]byte(rows[0][0]))
+}
+
+// parseMySQLPlan parses the MySQL and MariaDB JSON plan.
+func parseMySQLPlan(data []byte) (*db.PlanNode, error) {
+	var plan map[string]interface{}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON plan, error: %w", err)
+	}
+	queryBlock, ok := plan["query_block"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("query_block not found in JSON plan")
+	}
+	root := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    "query_block",
+		Children:  mysqlPlanChildren(queryBlock),
+	}
+	if costInfo, ok := queryBlock["cost_info"].(map[string]interface{}); ok {
+		root.Cost = jsonFloat(costInfo["query_cost"])
+	}
+	return root, nil
+}
+
+func mysqlPlanChildren(m map[string]interface{}) []*db.PlanNode {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var nodes []*db.PlanNode
+	for _, key := range keys {
+		nodes = append(nodes, mysqlPlanNodes(key, m[key])...)
+	}
+	return nodes
+}
+
+func mysqlPlanNodes(key string, value interface{}) []*db.PlanNode {
+	var children []*db.PlanNode
+	switch v := value.(type) {
+	case []interface{}:
+		// The items of arrays such as "nested_loop" are the children of the same node.
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				children = append(children, mysqlPlanChildren(m)...)
+			}
+		}
+	case map[string]interface{}:
+		switch key {
+		case "cost_info":
+			return nil
+		case "table":
+			return []*db.PlanNode{mysqlTableNode(v)}
+		}
+		children = mysqlPlanChildren(v)
+	default:
+		return nil
+	}
+	operation, ok := mysqlPlanOperations[key]
+	if !ok {
+		return children
+	}
+	return []*db.PlanNode{{
+		Operation: operation,
+		Detail:    key,
+		Children:  children,
+	}}
+}
+
+func mysqlTableNode(m map[string]interface{}) *db.PlanNode {
+	accessType, _ := m["access_type"].(string)
+	node := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    accessType,
+		Children:  mysqlPlanChildren(m),
+	}
+	node.Table, _ = m["table_name"].(string)
+	node.Index, _ = m["key"].(string)
+	switch {
+	case accessType == "ALL":
+		node.Operation = db.PlanOperationTableScan
+	case mysqlIndexAccessTypes[accessType]:
+		node.Operation = db.PlanOperationIndexScan
+	}
+	// MariaDB uses "rows" instead.
+	if rows, ok := m["rows_examined_per_scan"]; ok {
+		node.EstimatedRows = int64(jsonFloat(rows))
+	} else {
+		node.EstimatedRows = int64(jsonFloat(m["rows"]))
+	}
+	if costInfo, ok := m["cost_info"].(map[string]interface{}); ok {
+		node.Cost = jsonFloat(costInfo["prefix_cost"])
+	}
+	return node
+}
+
+// jsonFloat returns the float of the JSON number, or the number string used by MySQL for the costs.
+func jsonFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	}
+	return 0
+}
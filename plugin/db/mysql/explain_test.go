@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestParseMySQLPlan(t *testing.T) {
+	// MySQL plan of "SELECT * FROM t1 JOIN t2 ON t1.id = t2.t1_id ORDER BY t1.name".
+	plan := `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {"query_cost": "1210.50"},
+    "ordering_operation": {
+      "using_filesort": true,
+      "nested_loop": [
+        {"table": {"table_name": "t2", "access_type": "ALL", "rows_examined_per_scan": 1000, "cost_info": {"prefix_cost": "101.00"}}},
+        {"table": {"table_name": "t1", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1, "cost_info": {"prefix_cost": "1210.50"}}}
+      ]
+    }
+  }
+}`
+	got, err := parseMySQLPlan([]byte(plan))
+	require.NoError(t, err)
+	require.Equal(t, 1210.5, got.Cost)
+	require.Len(t, got.Children, 1)
+	sortNode := got.Children[0]
+	require.Equal(t, db.PlanOperationSort, sortNode.Operation)
+	require.Len(t, sortNode.Children, 1)
+	join := sortNode.Children[0]
+	require.Equal(t, db.PlanOperationJoin, join.Operation)
+	require.Len(t, join.Children, 2)
+	require.Equal(t, &db.PlanNode{Operation: db.PlanOperationTableScan, Detail: "ALL", Table: "t2", EstimatedRows: 1000, Cost: 101}, join.Children[0])
+	require.Equal(t, &db.PlanNode{Operation: db.PlanOperationIndexScan, Detail: "eq_ref", Table: "t1", Index: "PRIMARY", EstimatedRows: 1, Cost: 1210.5}, join.Children[1])
+
+	// MariaDB plan of "UPDATE t SET a = 1".
+	plan = `{"query_block": {"select_id": 1, "table": {"update": 1, "table_name": "t", "access_type": "ALL", "rows": 42}}}`
+	got, err = parseMySQLPlan([]byte(plan))
+	require.NoError(t, err)
+	scanList := got.FullTableScanList()
+	require.Len(t, scanList, 1)
+	require.Equal(t, "t", scanList[0].Table)
+	require.Equal(t, int64(42), scanList[0].EstimatedRows)
+
+	_, err = parseMySQLPlan([]byte(`{}`))
+	require.Error(t, err)
+}
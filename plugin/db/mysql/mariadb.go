@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// mariadbReplicationVersionPrefix is the fake version prefix of MariaDB servers, which is reported by MariaDB
+// before 11.0 for compatibility with the MySQL replication protocol, e.g. "5.5.5-10.6.8-MariaDB".
+const mariadbReplicationVersionPrefix = "5.5.5-"
+
+// parseMariaDBVersion returns the MariaDB version without the replication prefix, e.g. "10.6.8-MariaDB-log".
+// It returns an error if the version isn't a MariaDB version, i.e. the server isn't MariaDB.
+func parseMariaDBVersion(version string) (string, error) {
+	if !strings.Contains(version, "MariaDB") {
+		return "", fmt.Errorf("%q isn't a MariaDB version, please check the engine of the instance", version)
+	}
+	return strings.TrimPrefix(version, mariadbReplicationVersionPrefix), nil
+}
+
+// mariadbVersionAtLeast returns whether the MariaDB version such as "10.6.8-MariaDB-log" is at least minVersion.
+func mariadbVersionAtLeast(version string, minVersion string) bool {
+	v, err := semver.ParseTolerant(strings.SplitN(strings.TrimPrefix(version, mariadbReplicationVersionPrefix), "-", 2)[0])
+	if err != nil {
+		return false
+	}
+	return v.GE(semver.MustParse(minVersion))
+}
+
+// supportMariaDBCheckConstraint returns whether the MariaDB version has information_schema.CHECK_CONSTRAINTS, which is added in 10.2.22.
+// Unlike MySQL, MariaDB enforces the check constraints since 10.2.1.
+func supportMariaDBCheckConstraint(version string) bool {
+	return mariadbVersionAtLeast(version, "10.2.22")
+}
+
+// supportMariaDBIgnoredIndex returns whether the MariaDB version supports ignored indexes, the counterpart of the MySQL invisible indexes,
+// which are added in 10.6.
+func supportMariaDBIgnoredIndex(version string) bool {
+	return mariadbVersionAtLeast(version, "10.6.0")
+}
+
+// syncMariaDBCheckConstraints returns the dbName/tableName -> checkConstraintList map.
+// MariaDB has the table names in information_schema.CHECK_CONSTRAINTS, and lists the column level constraints there only.
+func (driver *Driver) syncMariaDBCheckConstraints(ctx context.Context, excludedDatabaseList []string, databaseList []string) (map[string][]db.CheckConstraint, error) {
+	where, args := getSchemaWhere("CONSTRAINT_SCHEMA", excludedDatabaseList, databaseList)
+	query := `
+			SELECT
+				CONSTRAINT_SCHEMA,
+				TABLE_NAME,
+				CONSTRAINT_NAME,
+				CHECK_CLAUSE
+			FROM information_schema.CHECK_CONSTRAINTS
+			WHERE ` + where + `
+			ORDER BY CONSTRAINT_SCHEMA, TABLE_NAME, CONSTRAINT_NAME`
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	checkConstraintMap := make(map[string][]db.CheckConstraint)
+	for rows.Next() {
+		var dbName, tableName string
+		var checkConstraint db.CheckConstraint
+		if err := rows.Scan(
+			&dbName,
+			&tableName,
+			&checkConstraint.Name,
+			&checkConstraint.Expression,
+		); err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s/%s", dbName, tableName)
+		checkConstraintMap[key] = append(checkConstraintMap[key], checkConstraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return checkConstraintMap, nil
+}
+
+// syncMariaDBSequences returns the dbName -> sequenceList map of the sequences in sequenceNames, which are "dbName/sequenceName".
+// MariaDB sequences are special tables, whose options are read from the single row of the tables.
+func (driver *Driver) syncMariaDBSequences(ctx context.Context, sequenceNames []string) (map[string][]db.Sequence, error) {
+	sequenceMap := make(map[string][]db.Sequence)
+	for _, name := range sequenceNames {
+		parts := strings.SplitN(name, "/", 2)
+		dbName, sequenceName := parts[0], parts[1]
+		query := fmt.Sprintf("SELECT start_value, minimum_value, maximum_value, increment, cycle_option FROM `%s`.`%s`", dbName, sequenceName)
+		sequence := db.Sequence{
+			Name: sequenceName,
+			// MariaDB sequences are always BIGINT.
+			DataType: "bigint",
+		}
+		if err := driver.db.QueryRowContext(ctx, query).Scan(
+			&sequence.StartValue,
+			&sequence.MinValue,
+			&sequence.MaxValue,
+			&sequence.Increment,
+			&sequence.Cycle,
+		); err != nil {
+			return nil, util.FormatErrorWithQuery(err, query)
+		}
+		sequenceMap[dbName] = append(sequenceMap[dbName], sequence)
+	}
+	return sequenceMap, nil
+}
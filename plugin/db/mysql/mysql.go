@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,6 +27,11 @@ import (
 //go:embed mysql_migration_schema.sql
 var migrationSchema string
 
+const (
+	// killQueryTimeout is the timeout of killing the canceled query.
+	killQueryTimeout = 10 * time.Second
+)
+
 var (
 	systemDatabases = map[string]bool{
 		"information_schema": true,
@@ -38,6 +44,9 @@ var (
 	baseTableType        = "BASE TABLE"
 	viewTableType        = "VIEW"
 	excludeAutoIncrement = regexp.MustCompile(`AUTO_INCREMENT=\d+ `)
+	// MariaDB only
+	systemVersionedTableType = "SYSTEM VERSIONED"
+	sequenceTableType        = "SEQUENCE"
 
 	_ db.Driver              = (*Driver)(nil)
 	_ util.MigrationExecutor = (*Driver)(nil)
@@ -46,18 +55,25 @@ var (
 func init() {
 	db.Register(db.MySQL, newDriver)
 	db.Register(db.TiDB, newDriver)
+	db.Register(db.MariaDB, newDriver)
 }
 
 // Driver is the MySQL driver.
 type Driver struct {
 	connectionCtx db.ConnectionContext
 	dbType        db.Type
+	// sshTunnel is nil if the database is connected directly.
+	sshTunnel *db.SSHTunnel
 
 	db *sql.DB
+	// executeConnectionID is the CONNECTION_ID() of the connection executing the last statement by Execute.
+	executeConnectionID int64
 }
 
 func newDriver(config db.DriverConfig) db.Driver {
-	return &Driver{}
+	return &Driver{
+		dbType: config.DbType,
+	}
 }
 
 // Open opens a MySQL driver.
@@ -83,6 +99,14 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 		return nil, fmt.Errorf("sql: tls config error: %v", err)
 	}
 
+	sshTunnel, err := db.OpenSSHTunnel(&config, port)
+	if err != nil {
+		return nil, err
+	}
+	if sshTunnel != nil {
+		port = config.Port
+	}
+
 	loggedDSN := fmt.Sprintf("%s:<<redacted password>>@%s(%s:%s)/%s?%s", config.Username, protocol, config.Host, port, config.Database, strings.Join(params, "&"))
 	dsn := fmt.Sprintf("%s@%s(%s:%s)/%s?%s", config.Username, protocol, config.Host, port, config.Database, strings.Join(params, "&"))
 	if config.Password != "" {
@@ -91,6 +115,9 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 	tlsKey := "db.mysql.tls"
 	if tlsConfig != nil {
 		if err := mysql.RegisterTLSConfig(tlsKey, tlsConfig); err != nil {
+			if sshTunnel != nil {
+				sshTunnel.Close()
+			}
 			return nil, fmt.Errorf("sql: failed to register tls config: %v", err)
 		}
 		// TLS config is only used during sql.Open, so should be safe to deregister afterwards.
@@ -109,13 +136,31 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 	driver.dbType = dbType
 	driver.db = db
 	driver.connectionCtx = connCtx
+	driver.sshTunnel = sshTunnel
 
 	return driver, nil
 }
 
 // Close closes the driver.
 func (driver *Driver) Close(ctx context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		if tunnelErr := driver.sshTunnel.Close(); err == nil {
+			err = tunnelErr
+		}
+	}
+	return err
+}
+
+// GetDbType returns the engine type of the driver, i.e. MySQL, TiDB or MariaDB.
+func (driver *Driver) GetDbType() db.Type {
+	return driver.dbType
+}
+
+// GetSSHTunnel returns the SSH tunnel of the driver, or nil if the database is connected directly.
+// The MySQL client subprocesses should connect through the local end of the tunnel.
+func (driver *Driver) GetSSHTunnel() *db.SSHTunnel {
+	return driver.sshTunnel
 }
 
 // Ping pings the database.
@@ -142,11 +187,49 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	if err := versionRow.Scan(&version); err != nil {
 		return "", err
 	}
+	if driver.dbType == db.MariaDB {
+		return parseMariaDBVersion(version)
+	}
 	return version, nil
 }
 
+// Capabilities returns the features supported by MySQL, TiDB or MariaDB.
+func (driver *Driver) Capabilities() db.Capabilities {
+	switch driver.dbType {
+	case db.TiDB:
+		return db.Capabilities{
+			TransactionalDML:     true,
+			SchemaObjectKindList: []db.SchemaObjectKind{db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey},
+			DumpFormat:           db.DumpFormatSQL,
+		}
+	case db.MariaDB:
+		return db.Capabilities{
+			TransactionalDML: true,
+			PITR:             true,
+			SchemaObjectKindList: []db.SchemaObjectKind{
+				db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey,
+				db.SchemaObjectCheckConstraint, db.SchemaObjectTrigger, db.SchemaObjectRoutine, db.SchemaObjectSequence,
+			},
+			DumpFormat: db.DumpFormatSQL,
+			Explain:    true,
+		}
+	default:
+		return db.Capabilities{
+			TransactionalDML: true,
+			PITR:             true,
+			GhOst:            true,
+			SchemaObjectKindList: []db.SchemaObjectKind{
+				db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey,
+				db.SchemaObjectCheckConstraint, db.SchemaObjectTrigger, db.SchemaObjectRoutine,
+			},
+			DumpFormat: db.DumpFormatSQL,
+			Explain:    true,
+		}
+	}
+}
+
 // SyncSchema syncs the schema.
-func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema, error) {
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
 	// Query MySQL version
 	version, err := driver.GetVersion(ctx)
 	if err != nil {
@@ -170,8 +253,11 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		return nil, nil, err
 	}
 
+	// Restrict the sync to the given databases if any.
+	databaseWhere, databaseArgs := getDatabaseFilter("TABLE_SCHEMA", databaseList)
+
 	// Query index info
-	indexWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	indexWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query := `
 			SELECT
 				TABLE_SCHEMA,
@@ -186,7 +272,22 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				INDEX_COMMENT
 			FROM information_schema.STATISTICS
 			WHERE ` + indexWhere
-	if isMySQL8 {
+	if driver.dbType == db.MariaDB && supportMariaDBIgnoredIndex(version) {
+		query = `
+			SELECT
+				TABLE_SCHEMA,
+				TABLE_NAME,
+				INDEX_NAME,
+				COLUMN_NAME,
+				'',
+				SEQ_IN_INDEX,
+				INDEX_TYPE,
+				CASE NON_UNIQUE WHEN 0 THEN 1 ELSE 0 END AS IS_UNIQUE,
+				CASE IGNORED WHEN 'NO' THEN 1 ELSE 0 END,
+				INDEX_COMMENT
+			FROM information_schema.STATISTICS
+			WHERE ` + indexWhere
+	} else if isMySQL8 {
 		query = `
 			SELECT
 				TABLE_SCHEMA,
@@ -202,7 +303,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			FROM information_schema.STATISTICS
 			WHERE ` + indexWhere
 	}
-	indexRows, err := driver.db.QueryContext(ctx, query)
+	indexRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -236,6 +337,8 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		} else if expression.Valid {
 			index.Expression = expression.String
 		}
+		// MySQL always names the primary key "PRIMARY".
+		index.Primary = index.Name == "PRIMARY"
 
 		key := fmt.Sprintf("%s/%s", dbName, tableName)
 		if indexList, ok := indexMap[key]; ok {
@@ -246,7 +349,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	}
 
 	// Query column info
-	columnWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	columnWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query = `
 			SELECT
 				TABLE_SCHEMA,
@@ -258,10 +361,11 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				COLUMN_TYPE,
 				IFNULL(CHARACTER_SET_NAME, ''),
 				IFNULL(COLLATION_NAME, ''),
-				COLUMN_COMMENT
+				COLUMN_COMMENT,
+				EXTRA
 			FROM information_schema.COLUMNS
 			WHERE ` + columnWhere
-	columnRows, err := driver.db.QueryContext(ctx, query)
+	columnRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -286,6 +390,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			&column.CharacterSet,
 			&column.Collation,
 			&column.Comment,
+			&column.Extra,
 		); err != nil {
 			return nil, nil, err
 		}
@@ -302,8 +407,29 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		}
 	}
 
+	// Query foreign key, check constraint and trigger info
+	foreignKeyMap, err := driver.syncForeignKeys(ctx, excludedDatabaseList, databaseList)
+	if err != nil {
+		return nil, nil, err
+	}
+	checkConstraintMap := make(map[string][]db.CheckConstraint)
+	switch {
+	case driver.dbType == db.MySQL && supportCheckConstraint(version):
+		if checkConstraintMap, err = driver.syncCheckConstraints(ctx, excludedDatabaseList, databaseList); err != nil {
+			return nil, nil, err
+		}
+	case driver.dbType == db.MariaDB && supportMariaDBCheckConstraint(version):
+		if checkConstraintMap, err = driver.syncMariaDBCheckConstraints(ctx, excludedDatabaseList, databaseList); err != nil {
+			return nil, nil, err
+		}
+	}
+	triggerMap, err := driver.syncTriggers(ctx, excludedDatabaseList, databaseList)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Query table info
-	tableWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	tableWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query = `
 			SELECT
 				TABLE_SCHEMA,
@@ -321,7 +447,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				IFNULL(TABLE_COMMENT, '')
 			FROM information_schema.TABLES
 			WHERE ` + tableWhere
-	tableRows, err := driver.db.QueryContext(ctx, query)
+	tableRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -336,6 +462,8 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	}
 	// dbName/viewName -> ViewInfo
 	viewInfoMap := make(map[string]ViewInfo)
+	// dbName/sequenceName list of the MariaDB sequences.
+	var sequenceNames []string
 	for tableRows.Next() {
 		var dbName string
 		// Workaround TiDB bug https://github.com/pingcap/tidb/issues/27970
@@ -360,7 +488,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		}
 
 		switch table.Type {
-		case baseTableType:
+		case baseTableType, systemVersionedTableType:
 			if tableCollation.Valid {
 				table.Collation = tableCollation.String
 			}
@@ -368,6 +496,9 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			key := fmt.Sprintf("%s/%s", dbName, table.Name)
 			table.ColumnList = columnMap[key]
 			table.IndexList = indexMap[key]
+			table.ForeignKeyList = foreignKeyMap[key]
+			table.CheckConstraintList = checkConstraintMap[key]
+			table.TriggerList = triggerMap[key]
 
 			if tableList, ok := tableMap[dbName]; ok {
 				tableMap[dbName] = append(tableList, table)
@@ -380,11 +511,13 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				updatedTs: table.UpdatedTs,
 				comment:   table.Comment,
 			}
+		case sequenceTableType:
+			sequenceNames = append(sequenceNames, fmt.Sprintf("%s/%s", dbName, table.Name))
 		}
 	}
 
 	// Query view info
-	viewWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	viewWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + databaseWhere
 	query = `
 			SELECT
 				TABLE_SCHEMA,
@@ -392,7 +525,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				VIEW_DEFINITION
 			FROM information_schema.VIEWS
 			WHERE ` + viewWhere
-	viewRows, err := driver.db.QueryContext(ctx, query)
+	viewRows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -423,8 +556,24 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		}
 	}
 
+	// Query routine info
+	// TiDB doesn't support stored procedures and functions.
+	routineMap := make(map[string][]db.Routine)
+	if driver.dbType == db.MySQL || driver.dbType == db.MariaDB {
+		if routineMap, err = driver.syncRoutines(ctx, excludedDatabaseList, databaseList); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Query sequence info
+	sequenceMap, err := driver.syncMariaDBSequences(ctx, sequenceNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Query db info
-	where := fmt.Sprintf("LOWER(SCHEMA_NAME) NOT IN (%s)", strings.Join(excludedDatabaseList, ", "))
+	schemaWhere, _ := getDatabaseFilter("SCHEMA_NAME", databaseList)
+	where := fmt.Sprintf("LOWER(SCHEMA_NAME) NOT IN (%s)", strings.Join(excludedDatabaseList, ", ")) + schemaWhere
 	query = `
 			SELECT
 		    SCHEMA_NAME,
@@ -432,7 +581,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			DEFAULT_COLLATION_NAME
 		FROM information_schema.SCHEMATA
 		WHERE ` + where
-	rows, err := driver.db.QueryContext(ctx, query)
+	rows, err := driver.db.QueryContext(ctx, query, databaseArgs...)
 	if err != nil {
 		return nil, nil, util.FormatErrorWithQuery(err, query)
 	}
@@ -451,6 +600,8 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 
 		schema.TableList = tableMap[schema.Name]
 		schema.ViewList = viewMap[schema.Name]
+		schema.RoutineList = routineMap[schema.Name]
+		schema.SequenceList = sequenceMap[schema.Name]
 
 		schemaList = append(schemaList, &schema)
 	}
@@ -461,6 +612,21 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	return userList, schemaList, err
 }
 
+// getDatabaseFilter returns the condition restricting the column to the databases in databaseList and its query arguments.
+// It returns an empty condition if databaseList is empty.
+func getDatabaseFilter(column string, databaseList []string) (string, []interface{}) {
+	if len(databaseList) == 0 {
+		return "", nil
+	}
+	var placeholderList []string
+	var args []interface{}
+	for _, database := range databaseList {
+		placeholderList = append(placeholderList, "?")
+		args = append(args, database)
+	}
+	return fmt.Sprintf(" AND %s IN (%s)", column, strings.Join(placeholderList, ", ")), args
+}
+
 func (driver *Driver) getUserList(ctx context.Context) ([]*db.User, error) {
 	// Query user info
 	query := `
@@ -520,7 +686,20 @@ func (driver *Driver) getUserList(ctx context.Context) ([]*db.User, error) {
 
 // Execute executes a SQL statement.
 func (driver *Driver) Execute(ctx context.Context, statement string) error {
-	tx, err := driver.db.BeginTx(ctx, nil)
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var connectionID int64
+	if err := conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connectionID); err != nil {
+		return err
+	}
+	driver.executeConnectionID = connectionID
+	stop := driver.killQueryOnCancel(ctx, connectionID)
+	defer stop()
+
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -537,6 +716,37 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 	return err
 }
 
+// GetExecuteConnectionID returns the CONNECTION_ID() of the connection executing the last statement by Execute, or 0 if Execute isn't called.
+// The binlog events of the statement carry it as the thread ID.
+func (driver *Driver) GetExecuteConnectionID() int64 {
+	return driver.executeConnectionID
+}
+
+// killQueryOnCancel kills the query running on the connection once the context is canceled.
+// Closing the client connection doesn't stop the query on the server, so we need to kill it explicitly.
+// The returned function must be called after the query finishes.
+func (driver *Driver) killQueryOnCancel(ctx context.Context, connectionID int64) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Use a new context because ctx is already canceled.
+			killCtx, cancel := context.WithTimeout(context.Background(), killQueryTimeout)
+			defer cancel()
+			if _, err := driver.db.ExecContext(killCtx, fmt.Sprintf("KILL QUERY %d", connectionID)); err != nil {
+				log.Warn("Failed to kill the canceled query",
+					zap.Int64("connection_id", connectionID),
+					zap.Error(err),
+				)
+			}
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
 // Query queries a SQL statement.
 func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
 	return util.Query(ctx, driver.db, statement, limit)
@@ -862,6 +1072,11 @@ const (
 		"-- View structure for `%s`\n" +
 		"--\n" +
 		"%s;\n"
+	sequenceStmtFmt = "" +
+		"--\n" +
+		"-- Sequence structure for `%s`\n" +
+		"--\n" +
+		"%s;\n"
 	routineStmtFmt = "" +
 		"--\n" +
 		"-- %s structure for `%s`\n" +
@@ -931,8 +1146,8 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	}
 
 	options := sql.TxOptions{}
-	// TiDB does not support readonly, so we only set for MySQL.
-	if driver.dbType == "MYSQL" {
+	// TiDB does not support readonly, so we only set for MySQL and MariaDB.
+	if driver.dbType == db.MySQL || driver.dbType == db.MariaDB {
 		options.ReadOnly = true
 	}
 	// If `schemaOnly` is false, now we are still holding the tables' exclusive locks.
@@ -1087,14 +1302,19 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, s
 		if err != nil {
 			return fmt.Errorf("failed to get tables of database %q, error[%w]", dbName, err)
 		}
+		// The MariaDB sequences go first since the tables may use them in the column defaults.
+		sort.SliceStable(tables, func(i, j int) bool {
+			return tables[i].TableType == sequenceTableType && tables[j].TableType != sequenceTableType
+		})
 		for _, tbl := range tables {
-			if schemaOnly && tbl.TableType == baseTableType {
+			if schemaOnly && (tbl.TableType == baseTableType || tbl.TableType == systemVersionedTableType) {
 				tbl.Statement = excludeSchemaAutoIncrementValue(tbl.Statement)
 			}
 			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tbl.Statement)); err != nil {
 				return err
 			}
-			if !schemaOnly && tbl.TableType == baseTableType {
+			// The history rows of the system-versioned tables are not dumped.
+			if !schemaOnly && (tbl.TableType == baseTableType || tbl.TableType == systemVersionedTableType) {
 				// Include db prefix if dumping multiple databases.
 				includeDbPrefix := len(dumpableDbNames) > 1
 				if err := exportTableData(txn, dbName, tbl.Name, includeDbPrefix, out); err != nil {
@@ -1165,6 +1385,16 @@ func getDatabases(ctx context.Context, txn *sql.Tx) ([]string, error) {
 	return dbNames, nil
 }
 
+// GetBinlogInfo returns the current binlog coordination of the instance.
+func (driver *Driver) GetBinlogInfo(ctx context.Context) (api.BinlogInfo, error) {
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return api.BinlogInfo{}, err
+	}
+	defer conn.Close()
+	return getBinlogInfo(ctx, conn)
+}
+
 func getBinlogInfo(ctx context.Context, conn *sql.Conn) (api.BinlogInfo, error) {
 	rows, err := conn.QueryContext(ctx, "SHOW MASTER STATUS;")
 	if err != nil {
@@ -1172,10 +1402,22 @@ func getBinlogInfo(ctx context.Context, conn *sql.Conn) (api.BinlogInfo, error)
 	}
 	defer rows.Close()
 
+	// MariaDB doesn't have the Executed_Gtid_Set column, so we only scan the first two columns.
+	columns, err := rows.Columns()
+	if err != nil {
+		return api.BinlogInfo{}, err
+	}
+	if len(columns) < 2 {
+		return api.BinlogInfo{}, fmt.Errorf("SHOW MASTER STATUS returned %d columns, expecting at least 2", len(columns))
+	}
 	rows.Next()
 	binlogInfo := api.BinlogInfo{}
-	var unused interface{}
-	if err := rows.Scan(&binlogInfo.FileName, &binlogInfo.Position, &unused, &unused, &unused); err != nil {
+	dest := []interface{}{&binlogInfo.FileName, &binlogInfo.Position}
+	for i := 2; i < len(columns); i++ {
+		var unused interface{}
+		dest = append(dest, &unused)
+	}
+	if err := rows.Scan(dest...); err != nil {
 		return api.BinlogInfo{}, err
 	}
 
@@ -1271,7 +1513,7 @@ func getTablesImpl(txn *sql.Tx, dbName string) ([]*TableSchema, error) {
 // getTableStmt gets the create statement of a table.
 func getTableStmt(txn *sql.Tx, dbName, tblName, tblType string) (string, error) {
 	switch tblType {
-	case baseTableType:
+	case baseTableType, systemVersionedTableType:
 		query := fmt.Sprintf("SHOW CREATE TABLE `%s`.`%s`;", dbName, tblName)
 		rows, err := txn.Query(query)
 		if err != nil {
@@ -1304,6 +1546,22 @@ func getTableStmt(txn *sql.Tx, dbName, tblName, tblType string) (string, error)
 			return fmt.Sprintf(viewStmtFmt, tblName, createStmt), nil
 		}
 		return "", fmt.Errorf("query %q returned invalid rows", query)
+	case sequenceTableType:
+		query := fmt.Sprintf("SHOW CREATE SEQUENCE `%s`.`%s`;", dbName, tblName)
+		rows, err := txn.Query(query)
+		if err != nil {
+			return "", err
+		}
+		defer rows.Close()
+
+		if rows.Next() {
+			var stmt, unused string
+			if err := rows.Scan(&unused, &stmt); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(sequenceStmtFmt, tblName, stmt), nil
+		}
+		return "", fmt.Errorf("query %q returned invalid rows", query)
 	default:
 		return "", fmt.Errorf("unrecognized table type %q for database %q table %q", tblType, dbName, tblName)
 	}
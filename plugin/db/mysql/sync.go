@@ -0,0 +1,229 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// supportCheckConstraint returns whether the MySQL version supports check constraints, which are enforced since 8.0.16.
+// The version could have a suffix such as "8.0.28-0ubuntu0.20.04.1" or "5.7.25-TiDB-v5.4.0".
+func supportCheckConstraint(version string) bool {
+	v, err := semver.ParseTolerant(strings.SplitN(version, "-", 2)[0])
+	if err != nil {
+		return false
+	}
+	return v.GE(semver.MustParse("8.0.16"))
+}
+
+// getSchemaWhere returns the condition excluding the system databases and restricting the column to the databases in databaseList,
+// and its query arguments.
+func getSchemaWhere(column string, excludedDatabaseList []string, databaseList []string) (string, []interface{}) {
+	databaseWhere, databaseArgs := getDatabaseFilter(column, databaseList)
+	return fmt.Sprintf("LOWER(%s) NOT IN (%s)", column, strings.Join(excludedDatabaseList, ", ")) + databaseWhere, databaseArgs
+}
+
+// syncForeignKeys returns the dbName/tableName -> foreignKeyList map.
+func (driver *Driver) syncForeignKeys(ctx context.Context, excludedDatabaseList []string, databaseList []string) (map[string][]db.ForeignKey, error) {
+	where, args := getSchemaWhere("k.TABLE_SCHEMA", excludedDatabaseList, databaseList)
+	query := `
+			SELECT
+				k.TABLE_SCHEMA,
+				k.TABLE_NAME,
+				k.CONSTRAINT_NAME,
+				k.COLUMN_NAME,
+				IFNULL(k.REFERENCED_TABLE_SCHEMA, ''),
+				IFNULL(k.REFERENCED_TABLE_NAME, ''),
+				IFNULL(k.REFERENCED_COLUMN_NAME, ''),
+				r.DELETE_RULE,
+				r.UPDATE_RULE
+			FROM information_schema.KEY_COLUMN_USAGE k
+			JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+				ON k.CONSTRAINT_SCHEMA = r.CONSTRAINT_SCHEMA AND k.TABLE_NAME = r.TABLE_NAME AND k.CONSTRAINT_NAME = r.CONSTRAINT_NAME
+			WHERE ` + where + `
+			ORDER BY k.TABLE_SCHEMA, k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	foreignKeyMap := make(map[string][]db.ForeignKey)
+	for rows.Next() {
+		var dbName, tableName, name, column, referencedSchema, referencedTable, referencedColumn, onDelete, onUpdate string
+		if err := rows.Scan(
+			&dbName,
+			&tableName,
+			&name,
+			&column,
+			&referencedSchema,
+			&referencedTable,
+			&referencedColumn,
+			&onDelete,
+			&onUpdate,
+		); err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s/%s", dbName, tableName)
+		foreignKeyList := foreignKeyMap[key]
+		// The columns of a multi-column foreign key are in consecutive rows ordered by the position.
+		if n := len(foreignKeyList); n > 0 && foreignKeyList[n-1].Name == name {
+			foreignKeyList[n-1].ColumnList = append(foreignKeyList[n-1].ColumnList, column)
+			foreignKeyList[n-1].ReferencedColumnList = append(foreignKeyList[n-1].ReferencedColumnList, referencedColumn)
+			continue
+		}
+		foreignKeyMap[key] = append(foreignKeyList, db.ForeignKey{
+			Name:                 name,
+			ColumnList:           []string{column},
+			ReferencedSchema:     referencedSchema,
+			ReferencedTable:      referencedTable,
+			ReferencedColumnList: []string{referencedColumn},
+			OnDelete:             onDelete,
+			OnUpdate:             onUpdate,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return foreignKeyMap, nil
+}
+
+// syncCheckConstraints returns the dbName/tableName -> checkConstraintList map.
+// It should only be called for MySQL 8.0.16 and later, which have information_schema.CHECK_CONSTRAINTS.
+func (driver *Driver) syncCheckConstraints(ctx context.Context, excludedDatabaseList []string, databaseList []string) (map[string][]db.CheckConstraint, error) {
+	where, args := getSchemaWhere("t.TABLE_SCHEMA", excludedDatabaseList, databaseList)
+	query := `
+			SELECT
+				t.TABLE_SCHEMA,
+				t.TABLE_NAME,
+				t.CONSTRAINT_NAME,
+				c.CHECK_CLAUSE
+			FROM information_schema.TABLE_CONSTRAINTS t
+			JOIN information_schema.CHECK_CONSTRAINTS c
+				ON t.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA AND t.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+			WHERE t.CONSTRAINT_TYPE = 'CHECK' AND ` + where
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	checkConstraintMap := make(map[string][]db.CheckConstraint)
+	for rows.Next() {
+		var dbName, tableName string
+		var checkConstraint db.CheckConstraint
+		if err := rows.Scan(
+			&dbName,
+			&tableName,
+			&checkConstraint.Name,
+			&checkConstraint.Expression,
+		); err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s/%s", dbName, tableName)
+		checkConstraintMap[key] = append(checkConstraintMap[key], checkConstraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return checkConstraintMap, nil
+}
+
+// syncTriggers returns the dbName/tableName -> triggerList map.
+func (driver *Driver) syncTriggers(ctx context.Context, excludedDatabaseList []string, databaseList []string) (map[string][]db.Trigger, error) {
+	where, args := getSchemaWhere("EVENT_OBJECT_SCHEMA", excludedDatabaseList, databaseList)
+	query := `
+			SELECT
+				EVENT_OBJECT_SCHEMA,
+				EVENT_OBJECT_TABLE,
+				TRIGGER_NAME,
+				ACTION_TIMING,
+				EVENT_MANIPULATION,
+				ACTION_STATEMENT
+			FROM information_schema.TRIGGERS
+			WHERE ` + where + `
+			ORDER BY EVENT_OBJECT_SCHEMA, EVENT_OBJECT_TABLE, ACTION_ORDER`
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	triggerMap := make(map[string][]db.Trigger)
+	for rows.Next() {
+		var dbName, tableName string
+		var trigger db.Trigger
+		if err := rows.Scan(
+			&dbName,
+			&tableName,
+			&trigger.Name,
+			&trigger.Timing,
+			&trigger.Event,
+			&trigger.Body,
+		); err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s/%s", dbName, tableName)
+		triggerMap[key] = append(triggerMap[key], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return triggerMap, nil
+}
+
+// syncRoutines returns the dbName -> routineList map.
+func (driver *Driver) syncRoutines(ctx context.Context, excludedDatabaseList []string, databaseList []string) (map[string][]db.Routine, error) {
+	where, args := getSchemaWhere("r.ROUTINE_SCHEMA", excludedDatabaseList, databaseList)
+	// The parameter at position 0 is the return value of a function.
+	query := `
+			SELECT
+				r.ROUTINE_SCHEMA,
+				r.ROUTINE_NAME,
+				r.ROUTINE_TYPE,
+				IFNULL(GROUP_CONCAT(CONCAT_WS(' ', p.PARAMETER_MODE, p.PARAMETER_NAME, p.DTD_IDENTIFIER) ORDER BY p.ORDINAL_POSITION SEPARATOR ', '), ''),
+				IFNULL(r.DTD_IDENTIFIER, ''),
+				IFNULL(r.ROUTINE_DEFINITION, ''),
+				r.ROUTINE_COMMENT
+			FROM information_schema.ROUTINES r
+			LEFT JOIN information_schema.PARAMETERS p
+				ON p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA AND p.SPECIFIC_NAME = r.SPECIFIC_NAME AND p.ROUTINE_TYPE = r.ROUTINE_TYPE AND p.ORDINAL_POSITION > 0
+			WHERE ` + where + `
+			GROUP BY r.ROUTINE_SCHEMA, r.ROUTINE_NAME, r.ROUTINE_TYPE, r.DTD_IDENTIFIER, r.ROUTINE_DEFINITION, r.ROUTINE_COMMENT`
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	routineMap := make(map[string][]db.Routine)
+	for rows.Next() {
+		var dbName string
+		var routine db.Routine
+		if err := rows.Scan(
+			&dbName,
+			&routine.Name,
+			&routine.Type,
+			&routine.Arguments,
+			&routine.ReturnType,
+			&routine.Definition,
+			&routine.Comment,
+		); err != nil {
+			return nil, err
+		}
+
+		routineMap[dbName] = append(routineMap[dbName], routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return routineMap, nil
+}
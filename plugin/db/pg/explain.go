@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// pgPlan is the plan node of the Postgres JSON plan.
+type pgPlan struct {
+	NodeType     string   `json:"Node Type"`
+	Schema       string   `json:"Schema"`
+	RelationName string   `json:"Relation Name"`
+	IndexName    string   `json:"Index Name"`
+	PlanRows     float64  `json:"Plan Rows"`
+	TotalCost    float64  `json:"Total Cost"`
+	Plans        []pgPlan `json:"Plans"`
+}
+
+// Explain returns the query plan of the statement by EXPLAIN (FORMAT JSON).
+// VERBOSE is for the schema names of the relations.
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.PlanNode, error) {
+	rows, err := util.ExplainRows(ctx, driver.db, "EXPLAIN (FORMAT JSON, VERBOSE)", statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("expect one row of the JSON plan, got %d rows", len(rows))
+	}
+	return parsePgPlan([]byte(rows[0][0]))
+}
+
+// parsePgPlan parses the Postgres JSON plan.
+func parsePgPlan(data []byte) (*db.PlanNode, error) {
+	var plans []struct {
+		Plan pgPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal(data, &plans); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON plan, error: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("empty JSON plan")
+	}
+	return plans[0].Plan.toPlanNode(), nil
+}
+
+func (p *pgPlan) toPlanNode() *db.PlanNode {
+	node := &db.PlanNode{
+		Operation:     pgPlanOperation(p.NodeType),
+		Detail:        p.NodeType,
+		Index:         p.IndexName,
+		EstimatedRows: int64(p.PlanRows),
+		Cost:          p.TotalCost,
+	}
+	if p.RelationName != "" {
+		node.Table = p.RelationName
+		if p.Schema != "" {
+			node.Table = fmt.Sprintf("%s.%s", p.Schema, p.RelationName)
+		}
+	}
+	for i := range p.Plans {
+		node.Children = append(node.Children, p.Plans[i].toPlanNode())
+	}
+	return node
+}
+
+func pgPlanOperation(nodeType string) db.PlanOperation {
+	switch {
+	case nodeType == "Seq Scan":
+		return db.PlanOperationTableScan
+	case strings.Contains(nodeType, "Index"):
+		// Index Scan, Index Only Scan, Bitmap Index Scan.
+		return db.PlanOperationIndexScan
+	case nodeType == "Bitmap Heap Scan":
+		return db.PlanOperationIndexScan
+	case nodeType == "Nested Loop" || strings.HasSuffix(nodeType, "Join"):
+		return db.PlanOperationJoin
+	case strings.HasSuffix(nodeType, "Sort"):
+		return db.PlanOperationSort
+	case strings.HasSuffix(nodeType, "Aggregate") || nodeType == "Group":
+		return db.PlanOperationAggregate
+	case nodeType == "ModifyTable":
+		return db.PlanOperationModify
+	}
+	return db.PlanOperationOther
+}
@@ -30,6 +30,11 @@ import (
 //go:embed pg_migration_schema.sql
 var migrationSchema string
 
+const (
+	// cancelQueryTimeout is the timeout of canceling the query on the server.
+	cancelQueryTimeout = 10 * time.Second
+)
+
 var (
 	systemDatabases = map[string]bool{
 		"template0": true,
@@ -54,6 +59,8 @@ type Driver struct {
 	pgInstanceDir string
 	connectionCtx db.ConnectionContext
 	config        db.ConnectionConfig
+	// sshTunnel is nil if the database is connected directly.
+	sshTunnel *db.SSHTunnel
 
 	db           *sql.DB
 	baseDSN      string
@@ -61,6 +68,12 @@ type Driver struct {
 
 	// strictDatabase should be used only if the user gives only a database instead of a whole instance to access.
 	strictDatabase string
+
+	// rollbackMaxRowCount is the maximum rows of the rollback statement generated by Execute, Execute doesn't generate the rollback statement if it's 0.
+	rollbackMaxRowCount int
+	// rollbackStatement and rollbackErr are the rollback statement generated by the last Execute and the error generating it.
+	rollbackStatement string
+	rollbackErr       error
 }
 
 func newDriver(config db.DriverConfig) db.Driver {
@@ -76,6 +89,11 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 		return nil, fmt.Errorf("ssl-cert and ssl-key must be both set or unset")
 	}
 
+	// The config is pointed to the local end of the SSH tunnel, so pg_dump also connects through the tunnel.
+	sshTunnel, err := db.OpenSSHTunnel(&config, "5432")
+	if err != nil {
+		return nil, err
+	}
 	databaseName, dsn, err := guessDSN(
 		config.Username,
 		config.Password,
@@ -87,6 +105,9 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 		config.TLSConfig.SslKey,
 	)
 	if err != nil {
+		if sshTunnel != nil {
+			sshTunnel.Close()
+		}
 		return nil, err
 	}
 	if config.ReadOnly {
@@ -96,12 +117,16 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 	driver.baseDSN = dsn
 	driver.connectionCtx = connCtx
 	driver.config = config
+	driver.sshTunnel = sshTunnel
 	if config.StrictUseDb {
 		driver.strictDatabase = config.Database
 	}
 
 	db, err := sql.Open(driverName, dsn)
 	if err != nil {
+		if sshTunnel != nil {
+			sshTunnel.Close()
+		}
 		return nil, err
 	}
 	driver.db = db
@@ -176,7 +201,13 @@ func guessDSN(username, password, hostname, port, database, sslCA, sslCert, sslK
 
 // Close closes the driver.
 func (driver *Driver) Close(ctx context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		if tunnelErr := driver.sshTunnel.Close(); err == nil {
+			err = tunnelErr
+		}
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -209,8 +240,23 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// Capabilities returns the features supported by Postgres.
+func (*Driver) Capabilities() db.Capabilities {
+	return db.Capabilities{
+		TransactionalDDL:   true,
+		TransactionalDML:   true,
+		ReadOnlyConnection: true,
+		SchemaObjectKindList: []db.SchemaObjectKind{
+			db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey, db.SchemaObjectCheckConstraint,
+			db.SchemaObjectTrigger, db.SchemaObjectRoutine, db.SchemaObjectSequence, db.SchemaObjectExtension,
+		},
+		DumpFormat: db.DumpFormatSQL,
+		Explain:    true,
+	}
+}
+
 // SyncSchema syncs the schema.
-func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema, error) {
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
 	excludedDatabaseList := map[string]bool{
 		// Skip our internal "bytebase" database
 		"bytebase": true,
@@ -237,6 +283,10 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get databases: %s", err)
 	}
+	includedDatabaseList := make(map[string]bool)
+	for _, dbName := range databaseList {
+		includedDatabaseList[dbName] = true
+	}
 
 	var schemaList []*db.Schema
 	for _, database := range databases {
@@ -244,6 +294,9 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		if _, ok := excludedDatabaseList[dbName]; ok {
 			continue
 		}
+		if len(includedDatabaseList) > 0 && !includedDatabaseList[dbName] {
+			continue
+		}
 
 		var schema db.Schema
 		schema.Name = dbName
@@ -271,6 +324,20 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			indicesMap[key] = append(indicesMap[key], idx)
 		}
 
+		// Foreign keys, check constraints and triggers.
+		foreignKeysMap, err := getForeignKeys(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get foreign keys from database %q: %s", dbName, err)
+		}
+		checkConstraintsMap, err := getCheckConstraints(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get check constraints from database %q: %s", dbName, err)
+		}
+		triggersMap, err := getTriggers(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get triggers from database %q: %s", dbName, err)
+		}
+
 		// Table statements.
 		tables, err := getPgTables(txn)
 		if err != nil {
@@ -293,6 +360,7 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 				dbColumn.Nullable = col.isNullable
 				dbColumn.Collation = col.collationName
 				dbColumn.Comment = col.comment
+				dbColumn.Extra = col.identity
 				dbTable.ColumnList = append(dbTable.ColumnList, dbColumn)
 			}
 			indices := indicesMap[dbTable.Name]
@@ -304,10 +372,14 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 					dbIndex.Position = i + 1
 					dbIndex.Type = idx.methodType
 					dbIndex.Unique = idx.unique
+					dbIndex.Primary = idx.primary
 					dbIndex.Comment = idx.comment
 					dbTable.IndexList = append(dbTable.IndexList, dbIndex)
 				}
 			}
+			dbTable.ForeignKeyList = foreignKeysMap[dbTable.Name]
+			dbTable.CheckConstraintList = checkConstraintsMap[dbTable.Name]
+			dbTable.TriggerList = triggersMap[dbTable.Name]
 
 			schema.TableList = append(schema.TableList, dbTable)
 		}
@@ -332,6 +404,18 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 			return nil, nil, fmt.Errorf("failed to get extensions from database %q: %s", dbName, err)
 		}
 		schema.ExtensionList = extensions
+		// Functions and procedures.
+		routines, err := getRoutines(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get routines from database %q: %s", dbName, err)
+		}
+		schema.RoutineList = routines
+		// Sequences.
+		sequences, err := getSequences(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get sequences from database %q: %s", dbName, err)
+		}
+		schema.SequenceList = sequences
 
 		if err := txn.Commit(); err != nil {
 			return nil, nil, err
@@ -387,6 +471,13 @@ func (driver *Driver) getUserList(ctx context.Context) ([]*db.User, error) {
 
 // Execute executes a SQL statement.
 func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	// Only the statements executable in the transaction statement by statement can be executed with the rollback.
+	var rollbackTargetList []*rollbackTarget
+	if driver.rollbackMaxRowCount > 0 {
+		driver.rollbackStatement = ""
+		rollbackTargetList, driver.rollbackErr = parseRollbackTargets(statement, driver.rollbackMaxRowCount)
+	}
+
 	var remainingStmts []string
 	f := func(stmt string) error {
 		stmt = strings.TrimLeft(stmt, " \t")
@@ -440,7 +531,19 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 		return nil
 	}
 
-	tx, err := driver.db.BeginTx(ctx, nil)
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var pid int64
+	if err := conn.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid); err != nil {
+		return err
+	}
+	stop := driver.cancelBackendOnCancel(ctx, pid)
+	defer stop()
+
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -455,7 +558,13 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 		return err
 	}
 
-	if _, err := tx.ExecContext(ctx, strings.Join(remainingStmts, "\n")); err != nil {
+	if rollbackTargetList != nil {
+		rollbackStatement, rollbackErr, err := executeWithRollback(ctx, tx, rollbackTargetList, driver.rollbackMaxRowCount)
+		if err != nil {
+			return err
+		}
+		driver.rollbackStatement, driver.rollbackErr = rollbackStatement, rollbackErr
+	} else if _, err := tx.ExecContext(ctx, strings.Join(remainingStmts, "\n")); err != nil {
 		return err
 	}
 
@@ -465,6 +574,30 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 	return nil
 }
 
+// cancelBackendOnCancel cancels the query running on the backend once the context is canceled.
+// The returned function must be called after the query finishes.
+func (driver *Driver) cancelBackendOnCancel(ctx context.Context, pid int64) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Use a new context because ctx is already canceled.
+			cancelCtx, cancel := context.WithTimeout(context.Background(), cancelQueryTimeout)
+			defer cancel()
+			if _, err := driver.db.ExecContext(cancelCtx, "SELECT pg_cancel_backend($1)", pid); err != nil {
+				log.Warn("Failed to cancel the canceled query",
+					zap.Int64("pid", pid),
+					zap.Error(err),
+				)
+			}
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
 func getDatabaseInCreateDatabaseStatement(createDatabaseStatement string) (string, error) {
 	raw := strings.TrimRight(createDatabaseStatement, ";")
 	raw = strings.TrimPrefix(raw, "CREATE DATABASE")
@@ -1084,6 +1217,8 @@ type columnSchema struct {
 	isNullable             bool
 	collationName          string
 	comment                string
+	// identity is the identity clause such as "GENERATED ALWAYS AS IDENTITY" for identity columns.
+	identity string
 }
 
 // tableConstraint describes constraint schema of a pg table.
@@ -1109,6 +1244,7 @@ type indexSchema struct {
 	tableName  string
 	statement  string
 	unique     bool
+	primary    bool
 	// methodType such as btree.
 	methodType        string
 	columnExpressions []string
@@ -1117,7 +1253,7 @@ type indexSchema struct {
 
 // Statement returns the statement of a table column.
 func (c *columnSchema) Statement() string {
-	s := fmt.Sprintf("%s %s", quoteIdentifier(c.columnName), c.dataType)
+	s := fmt.Sprintf("%s %s", QuoteIdentifier(c.columnName), c.dataType)
 	if c.characterMaximumLength != "" {
 		s += fmt.Sprintf("(%s)", c.characterMaximumLength)
 	}
@@ -1183,8 +1319,8 @@ func getPgTables(txn *sql.Tx) ([]*tableSchema, error) {
 		if err := rows.Scan(&schemaname, &tablename, &tableowner, &tableSizeByte, &indexSizeByte); err != nil {
 			return nil, err
 		}
-		tbl.schemaName = quoteIdentifier(schemaname)
-		tbl.name = quoteIdentifier(tablename)
+		tbl.schemaName = QuoteIdentifier(schemaname)
+		tbl.name = QuoteIdentifier(tablename)
 		tbl.tableowner = tableowner
 		tbl.tableSizeByte = tableSizeByte
 		tbl.indexSizeByte = indexSizeByte
@@ -1252,6 +1388,8 @@ func getTableColumns(txn *sql.Tx, schemaName, tableName string) ([]*columnSchema
 		cols.collation_name,
 		cols.udt_schema,
 		cols.udt_name,
+		cols.is_identity,
+		cols.identity_generation,
 		(
 			SELECT
 					pg_catalog.col_description(c.oid, cols.ordinal_position::int)
@@ -1272,9 +1410,9 @@ func getTableColumns(txn *sql.Tx, schemaName, tableName string) ([]*columnSchema
 	var columns []*columnSchema
 	for rows.Next() {
 		var columnName, dataType, isNullable string
-		var characterMaximumLength, columnDefault, collationName, udtSchema, udtName, comment sql.NullString
+		var characterMaximumLength, columnDefault, collationName, udtSchema, udtName, isIdentity, identityGeneration, comment sql.NullString
 		var ordinalPosition int
-		if err := rows.Scan(&columnName, &dataType, &ordinalPosition, &characterMaximumLength, &columnDefault, &isNullable, &collationName, &udtSchema, &udtName, &comment); err != nil {
+		if err := rows.Scan(&columnName, &dataType, &ordinalPosition, &characterMaximumLength, &columnDefault, &isNullable, &collationName, &udtSchema, &udtName, &isIdentity, &identityGeneration, &comment); err != nil {
 			return nil, err
 		}
 		isNullBool, err := convertBoolFromYesNo(isNullable)
@@ -1291,6 +1429,9 @@ func getTableColumns(txn *sql.Tx, schemaName, tableName string) ([]*columnSchema
 			collationName:          collationName.String,
 			comment:                comment.String,
 		}
+		if isIdentity.String == "YES" {
+			c.identity = fmt.Sprintf("GENERATED %s AS IDENTITY", identityGeneration.String)
+		}
 		switch dataType {
 		case "USER-DEFINED":
 			c.dataType = fmt.Sprintf("%s.%s", udtSchema.String, udtName.String)
@@ -1335,7 +1476,7 @@ func getTableConstraints(txn *sql.Tx) (map[string][]*tableConstraint, error) {
 		if strings.Contains(constraint.tableName, ".") {
 			constraint.tableName = constraint.tableName[1+strings.Index(constraint.tableName, "."):]
 		}
-		constraint.schemaName, constraint.tableName, constraint.name = quoteIdentifier(constraint.schemaName), quoteIdentifier(constraint.tableName), quoteIdentifier(constraint.name)
+		constraint.schemaName, constraint.tableName, constraint.name = QuoteIdentifier(constraint.schemaName), QuoteIdentifier(constraint.tableName), QuoteIdentifier(constraint.name)
 		key := fmt.Sprintf("%s.%s", constraint.schemaName, constraint.tableName)
 		ret[key] = append(ret[key], &constraint)
 	}
@@ -1365,7 +1506,7 @@ func getViews(txn *sql.Tx) ([]*viewSchema, error) {
 		if !def.Valid {
 			return nil, fmt.Errorf("schema %q view %q has empty definition; please check whether proper privileges have been granted to Bytebase", view.schemaName, view.name)
 		}
-		view.schemaName, view.name, view.definition = quoteIdentifier(view.schemaName), quoteIdentifier(view.name), def.String
+		view.schemaName, view.name, view.definition = QuoteIdentifier(view.schemaName), QuoteIdentifier(view.name), def.String
 		views = append(views, &view)
 	}
 
@@ -1425,8 +1566,10 @@ func getExtensions(txn *sql.Tx) ([]db.Extension, error) {
 // getIndices gets all indices of a database.
 func getIndices(txn *sql.Tx) ([]*indexSchema, error) {
 	query := "" +
-		"SELECT schemaname, tablename, indexname, indexdef " +
-		"FROM pg_indexes WHERE schemaname NOT IN ('pg_catalog', 'information_schema');"
+		"SELECT idx.schemaname, idx.tablename, idx.indexname, idx.indexdef, COALESCE(i.indisprimary, false) " +
+		"FROM pg_indexes idx " +
+		"LEFT JOIN pg_index i ON i.indexrelid = format('%I.%I', idx.schemaname, idx.indexname)::regclass " +
+		"WHERE idx.schemaname NOT IN ('pg_catalog', 'information_schema');"
 
 	var indices []*indexSchema
 	rows, err := txn.Query(query)
@@ -1437,10 +1580,10 @@ func getIndices(txn *sql.Tx) ([]*indexSchema, error) {
 
 	for rows.Next() {
 		var idx indexSchema
-		if err := rows.Scan(&idx.schemaName, &idx.tableName, &idx.name, &idx.statement); err != nil {
+		if err := rows.Scan(&idx.schemaName, &idx.tableName, &idx.name, &idx.statement, &idx.primary); err != nil {
 			return nil, err
 		}
-		idx.schemaName, idx.tableName, idx.name = quoteIdentifier(idx.schemaName), quoteIdentifier(idx.tableName), quoteIdentifier(idx.name)
+		idx.schemaName, idx.tableName, idx.name = QuoteIdentifier(idx.schemaName), QuoteIdentifier(idx.tableName), QuoteIdentifier(idx.name)
 		idx.unique = strings.Contains(idx.statement, " UNIQUE INDEX ")
 		idx.methodType = getIndexMethodType(idx.statement)
 		idx.columnExpressions, err = getIndexColumnExpressions(idx.statement)
@@ -1531,8 +1674,9 @@ func getIndexColumnExpressions(stmt string) ([]string, error) {
 	return cols, nil
 }
 
-// quoteIdentifier will quote identifiers including keywords, capital characters, or special characters.
-func quoteIdentifier(s string) string {
+// QuoteIdentifier will quote identifiers including keywords, capital characters, or special characters.
+// The synced table and index names are in this form.
+func QuoteIdentifier(s string) string {
 	quote := false
 	if reserved[strings.ToUpper(s)] {
 		quote = true
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
 )
 
 func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
@@ -49,3 +51,83 @@ func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestParsePgPlan(t *testing.T) {
+	plan := `[{"Plan": {"Node Type": "ModifyTable", "Operation": "Update", "Schema": "public", "Relation Name": "t", "Plan Rows": 1000, "Total Cost": 35.5,
+		"Plans": [{"Node Type": "Seq Scan", "Parent Relationship": "Outer", "Schema": "public", "Relation Name": "t", "Plan Rows": 1000, "Total Cost": 35.5}]}}]`
+	got, err := parsePgPlan([]byte(plan))
+	require.NoError(t, err)
+	require.Equal(t, db.PlanOperationModify, got.Operation)
+	require.Equal(t, "public.t", got.Table)
+	require.Len(t, got.Children, 1)
+	scanList := got.FullTableScanList()
+	require.Len(t, scanList, 1)
+	require.Equal(t, "public.t", scanList[0].Table)
+	require.Equal(t, int64(1000), scanList[0].EstimatedRows)
+
+	plan = `[{"Plan": {"Node Type": "Index Scan", "Schema": "public", "Relation Name": "t", "Index Name": "t_pkey", "Plan Rows": 1, "Total Cost": 8.17}}]`
+	got, err = parsePgPlan([]byte(plan))
+	require.NoError(t, err)
+	require.Equal(t, db.PlanOperationIndexScan, got.Operation)
+	require.Equal(t, "t_pkey", got.Index)
+	require.Empty(t, got.FullTableScanList())
+
+	_, err = parsePgPlan([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestParseRollbackTargets(t *testing.T) {
+	a := require.New(t)
+	targetList, err := parseRollbackTargets(`SELECT 1;
+		INSERT INTO t (id) SELECT id FROM s ON CONFLICT DO NOTHING;
+		UPDATE public.t AS x SET name = 'a' WHERE x.id > 1;
+		DELETE FROM "T" WHERE id = 1;`, 100)
+	a.NoError(err)
+	a.Len(targetList, 4)
+	a.Equal(&rollbackTarget{
+		targetType: rollbackTargetSelect,
+		statement:  "SELECT 1",
+	}, targetList[0])
+	a.Equal(&rollbackTarget{
+		targetType: rollbackTargetInsert,
+		statement:  "\n\t\tINSERT INTO t (id) SELECT id FROM s ON CONFLICT DO NOTHING",
+		relation:   `"t"`,
+		query:      "INSERT INTO t (id) SELECT id FROM s ON CONFLICT DO NOTHING RETURNING row_to_json(t)",
+	}, targetList[1])
+	a.Equal(&rollbackTarget{
+		targetType:       rollbackTargetUpdate,
+		statement:        "\n\t\tUPDATE public.t AS x SET name = 'a' WHERE x.id > 1",
+		relation:         `"public"."t"`,
+		query:            "SELECT row_to_json(x) FROM public.t x WHERE x.id > 1 LIMIT 101 FOR UPDATE",
+		updateColumnList: []string{"name"},
+	}, targetList[2])
+	a.Equal(&rollbackTarget{
+		targetType: rollbackTargetDelete,
+		statement:  "\n\t\tDELETE FROM \"T\" WHERE id = 1",
+		relation:   `"T"`,
+		query:      `DELETE FROM "T" WHERE id = 1 RETURNING row_to_json("T")`,
+	}, targetList[3])
+
+	for _, statement := range []string{
+		"CREATE TABLE t (id int)",
+		"INSERT INTO t VALUES (1) ON CONFLICT (id) DO UPDATE SET name = 'a'",
+		"INSERT INTO t VALUES (1) RETURNING id",
+		"UPDATE t SET name = s.name FROM s WHERE t.id = s.id",
+		"DELETE FROM t USING s WHERE t.id = s.id",
+		"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d",
+	} {
+		_, err := parseRollbackTargets(statement, 100)
+		a.Error(err)
+	}
+}
+
+func TestRollbackStatement(t *testing.T) {
+	a := require.New(t)
+	row := `{"id":1,"name":"it's \"a\""}`
+	a.Equal(`INSERT INTO "public"."t" SELECT * FROM json_populate_record(NULL::"public"."t", E'{"id":1,"name":"it''s \\"a\\""}');`,
+		rollbackInsert(`"public"."t"`, row))
+	a.Equal(`UPDATE "t" SET "id" = bb_rollback_row."id", "name" = bb_rollback_row."name" FROM json_populate_record(NULL::"t", E'{"id":1,"name":"it''s \\"a\\""}') AS bb_rollback_row WHERE "t"."id" = bb_rollback_row."id";`,
+		rollbackUpdate(`"t"`, row, []string{"id", "name"}, []string{"id"}))
+	a.Equal(`DELETE FROM "t" USING json_populate_record(NULL::"t", E'{"id":1,"name":"it''s \\"a\\""}') AS bb_rollback_row WHERE "t"."id" = bb_rollback_row."id";`,
+		rollbackDelete(`"t"`, row, []string{"id"}))
+}
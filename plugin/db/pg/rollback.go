@@ -0,0 +1,376 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+const (
+	// rollbackRowAlias is the alias of the JSON row image in the rollback statements.
+	rollbackRowAlias = "bb_rollback_row"
+	// rollbackSavepoint is the savepoint before executing each statement with the rollback,
+	// so that the statement can be undone and executed again without the rollback if it fails to capture the rollback.
+	rollbackSavepoint = "bb_rollback"
+)
+
+// rollbackTargetType is the type of the statement executed with the rollback.
+type rollbackTargetType string
+
+const (
+	rollbackTargetSelect rollbackTargetType = "SELECT"
+	rollbackTargetInsert rollbackTargetType = "INSERT"
+	rollbackTargetUpdate rollbackTargetType = "UPDATE"
+	rollbackTargetDelete rollbackTargetType = "DELETE"
+)
+
+// rollbackTarget is a statement executed with the rollback.
+type rollbackTarget struct {
+	targetType rollbackTargetType
+	// statement is the original statement.
+	statement string
+	// relation is the quoted relation name, qualified by the schema if the statement does.
+	relation string
+	// query captures the rows of the statement as JSON.
+	// For UPDATE, it locks and selects the rows to update before executing the statement.
+	// For INSERT and DELETE, it's the statement itself returning the inserted or deleted rows.
+	query string
+	// updateColumnList is the columns updated by UPDATE.
+	updateColumnList []string
+}
+
+// EnableRollback makes Execute generate the statement reverting the executed statements, which is returned by GetRollbackStatement.
+// Execute runs the statements one by one in its transaction, and captures the rows of each statement right before or while executing it,
+// i.e. the before-images of UPDATE and DELETE, and the inserted rows of INSERT. The rows are identified by the primary key,
+// so the tables must have primary keys and UPDATE shouldn't update the primary keys.
+// Failing to generate the rollback statement doesn't fail Execute, e.g. if there are other statements than SELECT, INSERT, UPDATE and DELETE,
+// or more than maxRowCount affected rows.
+func (driver *Driver) EnableRollback(maxRowCount int) {
+	driver.rollbackMaxRowCount = maxRowCount
+}
+
+// GetRollbackStatement returns the rollback statement generated by the last Execute, and the error if it fails to generate the rollback statement.
+func (driver *Driver) GetRollbackStatement() (string, error) {
+	if driver.rollbackMaxRowCount <= 0 {
+		return "", fmt.Errorf("rollback isn't enabled")
+	}
+	return driver.rollbackStatement, driver.rollbackErr
+}
+
+// executeWithRollback executes the statements one by one in the transaction, and generates the statement reverting them.
+// If it fails to capture the rows of a statement, the statement is undone and executed again without the rollback,
+// and the following statements are executed without the rollback as well, the error is returned as rollbackErr.
+func executeWithRollback(ctx context.Context, tx *sql.Tx, targetList []*rollbackTarget, maxRowCount int) (rollbackStatement string, rollbackErr error, err error) {
+	var stmtList []string
+	for _, target := range targetList {
+		if rollbackErr == nil {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT "+rollbackSavepoint); err != nil {
+				return "", nil, err
+			}
+			targetStmtList, err := executeRollbackTarget(ctx, tx, target, maxRowCount-len(stmtList))
+			if err == nil {
+				if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+rollbackSavepoint); err != nil {
+					return "", nil, err
+				}
+				// Revert the statements in the reverse order.
+				stmtList = append(targetStmtList, stmtList...)
+				continue
+			}
+			rollbackErr = fmt.Errorf("failed to generate the rollback statement of %s statement, error: %w", target.targetType, err)
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+rollbackSavepoint); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, target.statement); err != nil {
+			return "", nil, err
+		}
+	}
+	if rollbackErr != nil || len(stmtList) == 0 {
+		return "", rollbackErr, nil
+	}
+	return strings.Join(stmtList, "\n") + "\n", nil, nil
+}
+
+// executeRollbackTarget executes the statement and returns the statements reverting it, it returns an error if more than maxRowCount rows are affected.
+func executeRollbackTarget(ctx context.Context, tx *sql.Tx, target *rollbackTarget, maxRowCount int) ([]string, error) {
+	if target.targetType == rollbackTargetSelect {
+		if _, err := tx.ExecContext(ctx, target.statement); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	columnList, primaryKeyList, err := getRollbackColumns(ctx, tx, target.relation)
+	if err != nil {
+		return nil, err
+	}
+	if len(primaryKeyList) == 0 {
+		return nil, fmt.Errorf("table %s has no primary key to identify the rows", target.relation)
+	}
+	for _, column := range target.updateColumnList {
+		for _, primaryKey := range primaryKeyList {
+			if column == primaryKey {
+				return nil, fmt.Errorf("the primary key %q of table %s is updated", column, target.relation)
+			}
+		}
+	}
+
+	rowList, err := selectRollbackRows(ctx, tx, target.query, maxRowCount)
+	if err != nil {
+		return nil, err
+	}
+	if target.targetType == rollbackTargetUpdate {
+		result, err := tx.ExecContext(ctx, target.statement)
+		if err != nil {
+			return nil, err
+		}
+		rowCount, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		// The locked rows still match the WHERE clause, but the rows inserted concurrently may match as well.
+		if rowCount != int64(len(rowList)) {
+			return nil, fmt.Errorf("%d rows are updated but %d rows are locked before the update", rowCount, len(rowList))
+		}
+	}
+
+	var stmtList []string
+	for _, row := range rowList {
+		switch target.targetType {
+		case rollbackTargetInsert:
+			stmtList = append(stmtList, rollbackDelete(target.relation, row, primaryKeyList))
+		case rollbackTargetUpdate:
+			stmtList = append(stmtList, rollbackUpdate(target.relation, row, columnList, primaryKeyList))
+		case rollbackTargetDelete:
+			stmtList = append(stmtList, rollbackInsert(target.relation, row))
+		}
+	}
+	return stmtList, nil
+}
+
+// parseRollbackTargets parses the statements, and composes the queries capturing the rows of the INSERT, UPDATE and DELETE statements.
+func parseRollbackTargets(statement string, maxRowCount int) ([]*rollbackTarget, error) {
+	res, err := pgquery.Parse(statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statement, error: %w", err)
+	}
+
+	var targetList []*rollbackTarget
+	for _, stmt := range res.Stmts {
+		text := statement[stmt.StmtLocation:]
+		if stmt.StmtLen > 0 {
+			text = text[:stmt.StmtLen]
+		}
+		var target *rollbackTarget
+		switch node := stmt.Stmt.Node.(type) {
+		case *pgquery.Node_SelectStmt:
+			// The WITH clause may contain the data-modifying statements.
+			if node.SelectStmt.IntoClause != nil || node.SelectStmt.WithClause != nil {
+				return nil, fmt.Errorf("rollback isn't supported for SELECT statements with INTO or WITH clauses")
+			}
+			target = &rollbackTarget{targetType: rollbackTargetSelect}
+		case *pgquery.Node_InsertStmt:
+			insertStmt := node.InsertStmt
+			if insertStmt.WithClause != nil || len(insertStmt.ReturningList) > 0 {
+				return nil, fmt.Errorf("rollback isn't supported for INSERT statements with WITH or RETURNING clauses")
+			}
+			// The rows updated by ON CONFLICT DO UPDATE are returned as well, but they can't be reverted by deleting them.
+			if insertStmt.OnConflictClause != nil && insertStmt.OnConflictClause.Action != pgquery.OnConflictAction_ONCONFLICT_NOTHING {
+				return nil, fmt.Errorf("rollback isn't supported for INSERT statements with ON CONFLICT DO UPDATE clauses")
+			}
+			insertStmt.ReturningList = []*pgquery.Node{newRowToJSONTarget(insertStmt.Relation)}
+			target, err = newRollbackTarget(rollbackTargetInsert, insertStmt.Relation, &pgquery.Node{Node: &pgquery.Node_InsertStmt{InsertStmt: insertStmt}})
+		case *pgquery.Node_UpdateStmt:
+			updateStmt := node.UpdateStmt
+			if updateStmt.WithClause != nil || len(updateStmt.FromClause) > 0 || len(updateStmt.ReturningList) > 0 {
+				return nil, fmt.Errorf("rollback isn't supported for UPDATE statements with WITH, FROM or RETURNING clauses")
+			}
+			selectStmt := &pgquery.SelectStmt{
+				TargetList:  []*pgquery.Node{newRowToJSONTarget(updateStmt.Relation)},
+				FromClause:  []*pgquery.Node{{Node: &pgquery.Node_RangeVar{RangeVar: updateStmt.Relation}}},
+				WhereClause: updateStmt.WhereClause,
+				LimitCount:  pgquery.MakeAConstIntNode(int64(maxRowCount+1), 0),
+				LimitOption: pgquery.LimitOption_LIMIT_OPTION_COUNT,
+				// Lock the rows so that they aren't changed by the other transactions before the update.
+				LockingClause: []*pgquery.Node{{Node: &pgquery.Node_LockingClause{LockingClause: &pgquery.LockingClause{
+					Strength:   pgquery.LockClauseStrength_LCS_FORUPDATE,
+					WaitPolicy: pgquery.LockWaitPolicy_LockWaitBlock,
+				}}}},
+				Op: pgquery.SetOperation_SETOP_NONE,
+			}
+			target, err = newRollbackTarget(rollbackTargetUpdate, updateStmt.Relation, &pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: selectStmt}})
+			if target != nil {
+				for _, node := range updateStmt.TargetList {
+					target.updateColumnList = append(target.updateColumnList, node.GetResTarget().GetName())
+				}
+			}
+		case *pgquery.Node_DeleteStmt:
+			deleteStmt := node.DeleteStmt
+			if deleteStmt.WithClause != nil || len(deleteStmt.UsingClause) > 0 || len(deleteStmt.ReturningList) > 0 {
+				return nil, fmt.Errorf("rollback isn't supported for DELETE statements with WITH, USING or RETURNING clauses")
+			}
+			deleteStmt.ReturningList = []*pgquery.Node{newRowToJSONTarget(deleteStmt.Relation)}
+			target, err = newRollbackTarget(rollbackTargetDelete, deleteStmt.Relation, &pgquery.Node{Node: &pgquery.Node_DeleteStmt{DeleteStmt: deleteStmt}})
+		default:
+			return nil, fmt.Errorf("rollback is only supported for INSERT, UPDATE and DELETE statements")
+		}
+		if err != nil {
+			return nil, err
+		}
+		target.statement = text
+		targetList = append(targetList, target)
+	}
+	return targetList, nil
+}
+
+// newRowToJSONTarget composes the target "row_to_json(ref)" of the whole row of the relation.
+// The relation keeps its alias so that the other clauses can still refer to it.
+func newRowToJSONTarget(relation *pgquery.RangeVar) *pgquery.Node {
+	ref := relation.Relname
+	if relation.Alias != nil && relation.Alias.Aliasname != "" {
+		ref = relation.Alias.Aliasname
+	}
+	return pgquery.MakeResTargetNodeWithVal(
+		pgquery.MakeFuncCallNode(
+			[]*pgquery.Node{pgquery.MakeStrNode("row_to_json")},
+			[]*pgquery.Node{pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(ref)}, 0)},
+			0,
+		),
+		0,
+	)
+}
+
+func newRollbackTarget(targetType rollbackTargetType, relation *pgquery.RangeVar, query *pgquery.Node) (*rollbackTarget, error) {
+	text, err := pgquery.Deparse(&pgquery.ParseResult{
+		Stmts: []*pgquery.RawStmt{{Stmt: query}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose the query capturing the rows of %s statement, error: %w", targetType, err)
+	}
+
+	name := quoteRollbackIdentifier(relation.Relname)
+	if relation.Schemaname != "" {
+		name = fmt.Sprintf("%s.%s", quoteRollbackIdentifier(relation.Schemaname), name)
+	}
+	return &rollbackTarget{
+		targetType: targetType,
+		relation:   name,
+		query:      text,
+	}, nil
+}
+
+// getRollbackColumns returns the columns and the primary key columns of the relation in the column order.
+func getRollbackColumns(ctx context.Context, tx *sql.Tx, relation string) ([]string, []string, error) {
+	query := `
+		SELECT a.attname, COALESCE(i.indisprimary, false)
+		FROM pg_catalog.pg_attribute a
+		LEFT JOIN pg_catalog.pg_index i ON i.indrelid = a.attrelid AND i.indisprimary AND a.attnum = ANY(i.indkey)
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`
+	rows, err := tx.QueryContext(ctx, query, relation)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var columnList, primaryKeyList []string
+	for rows.Next() {
+		var column string
+		var primary bool
+		if err := rows.Scan(&column, &primary); err != nil {
+			return nil, nil, util.FormatError(err)
+		}
+		columnList = append(columnList, column)
+		if primary {
+			primaryKeyList = append(primaryKeyList, column)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, util.FormatError(err)
+	}
+	return columnList, primaryKeyList, nil
+}
+
+// selectRollbackRows returns the JSON rows of the query, it returns an error if there are more than maxRowCount rows.
+func selectRollbackRows(ctx context.Context, tx *sql.Tx, query string, maxRowCount int) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var rowList []string
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return nil, util.FormatError(err)
+		}
+		rowList = append(rowList, row)
+		if len(rowList) > maxRowCount {
+			return nil, fmt.Errorf("the affected rows exceed the maximum rows of the rollback statement")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatError(err)
+	}
+	return rowList, nil
+}
+
+// rollbackInsert re-inserts the deleted row from its JSON before-image.
+func rollbackInsert(relation, row string) string {
+	return fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_record(NULL::%s, %s);", relation, relation, quoteRollbackLiteral(row))
+}
+
+// rollbackDelete deletes the inserted row by the primary key from its JSON image.
+func rollbackDelete(relation, row string, primaryKeyList []string) string {
+	var condList []string
+	for _, column := range primaryKeyList {
+		quoted := quoteRollbackIdentifier(column)
+		condList = append(condList, fmt.Sprintf("%s.%s = %s.%s", relation, quoted, rollbackRowAlias, quoted))
+	}
+	return fmt.Sprintf("DELETE FROM %s USING json_populate_record(NULL::%s, %s) AS %s WHERE %s;",
+		relation,
+		relation,
+		quoteRollbackLiteral(row),
+		rollbackRowAlias,
+		strings.Join(condList, " AND "),
+	)
+}
+
+// rollbackUpdate restores the updated row from its JSON before-image by the primary key.
+func rollbackUpdate(relation, row string, columnList, primaryKeyList []string) string {
+	var setList []string
+	for _, column := range columnList {
+		quoted := quoteRollbackIdentifier(column)
+		setList = append(setList, fmt.Sprintf("%s = %s.%s", quoted, rollbackRowAlias, quoted))
+	}
+	var condList []string
+	for _, column := range primaryKeyList {
+		quoted := quoteRollbackIdentifier(column)
+		condList = append(condList, fmt.Sprintf("%s.%s = %s.%s", relation, quoted, rollbackRowAlias, quoted))
+	}
+	return fmt.Sprintf("UPDATE %s SET %s FROM json_populate_record(NULL::%s, %s) AS %s WHERE %s;",
+		relation,
+		strings.Join(setList, ", "),
+		relation,
+		quoteRollbackLiteral(row),
+		rollbackRowAlias,
+		strings.Join(condList, " AND "),
+	)
+}
+
+func quoteRollbackIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+// quoteRollbackLiteral quotes the string as the escape string constant, which doesn't depend on standard_conforming_strings.
+func quoteRollbackLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `''`)
+	return fmt.Sprintf("E'%s'", s)
+}
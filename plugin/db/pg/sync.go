@@ -0,0 +1,206 @@
+package pg
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// referentialActions maps the pg_constraint confupdtype and confdeltype codes to the referential actions.
+var referentialActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// getForeignKeys returns the schemaName.tableName -> foreignKeyList map of a database.
+func getForeignKeys(txn *sql.Tx) (map[string][]db.ForeignKey, error) {
+	// The columns are aggregated as JSON arrays since pgx stdlib doesn't scan arrays into slices.
+	query := "" +
+		"SELECT n.nspname, cl.relname, c.conname, " +
+		"(SELECT json_agg(a.attname ORDER BY k.ord) FROM unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord) JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum)::text, " +
+		"fn.nspname, fcl.relname, " +
+		"(SELECT json_agg(a.attname ORDER BY k.ord) FROM unnest(c.confkey) WITH ORDINALITY AS k(attnum, ord) JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum)::text, " +
+		"c.confdeltype, c.confupdtype " +
+		"FROM pg_constraint c " +
+		"JOIN pg_class cl ON cl.oid = c.conrelid " +
+		"JOIN pg_namespace n ON n.oid = cl.relnamespace " +
+		"JOIN pg_class fcl ON fcl.oid = c.confrelid " +
+		"JOIN pg_namespace fn ON fn.oid = fcl.relnamespace " +
+		"WHERE c.contype = 'f' AND n.nspname NOT IN ('pg_catalog', 'information_schema') " +
+		"ORDER BY n.nspname, cl.relname, c.conname;"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[string][]db.ForeignKey)
+	for rows.Next() {
+		var schemaName, tableName, columns, referencedColumns, onDelete, onUpdate string
+		var fk db.ForeignKey
+		if err := rows.Scan(&schemaName, &tableName, &fk.Name, &columns, &fk.ReferencedSchema, &fk.ReferencedTable, &referencedColumns, &onDelete, &onUpdate); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(columns), &fk.ColumnList); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal columns of foreign key %q: %w", fk.Name, err)
+		}
+		if err := json.Unmarshal([]byte(referencedColumns), &fk.ReferencedColumnList); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal referenced columns of foreign key %q: %w", fk.Name, err)
+		}
+		fk.ReferencedSchema, fk.ReferencedTable = QuoteIdentifier(fk.ReferencedSchema), QuoteIdentifier(fk.ReferencedTable)
+		fk.OnDelete, fk.OnUpdate = referentialActions[onDelete], referentialActions[onUpdate]
+
+		key := fmt.Sprintf("%s.%s", QuoteIdentifier(schemaName), QuoteIdentifier(tableName))
+		ret[key] = append(ret[key], fk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// getCheckConstraints returns the schemaName.tableName -> checkConstraintList map of a database.
+func getCheckConstraints(txn *sql.Tx) (map[string][]db.CheckConstraint, error) {
+	query := "" +
+		"SELECT n.nspname, cl.relname, c.conname, pg_get_constraintdef(c.oid) " +
+		"FROM pg_constraint c " +
+		"JOIN pg_class cl ON cl.oid = c.conrelid " +
+		"JOIN pg_namespace n ON n.oid = cl.relnamespace " +
+		"WHERE c.contype = 'c' AND n.nspname NOT IN ('pg_catalog', 'information_schema') " +
+		"ORDER BY n.nspname, cl.relname, c.conname;"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[string][]db.CheckConstraint)
+	for rows.Next() {
+		var schemaName, tableName, def string
+		var check db.CheckConstraint
+		if err := rows.Scan(&schemaName, &tableName, &check.Name, &def); err != nil {
+			return nil, err
+		}
+		// The definition is in the form of "CHECK ((price > 0))".
+		check.Expression = strings.TrimPrefix(def, "CHECK ")
+
+		key := fmt.Sprintf("%s.%s", QuoteIdentifier(schemaName), QuoteIdentifier(tableName))
+		ret[key] = append(ret[key], check)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// getTriggers returns the schemaName.tableName -> triggerList map of a database.
+func getTriggers(txn *sql.Tx) (map[string][]db.Trigger, error) {
+	// information_schema.triggers has a row for each event of a trigger.
+	query := "" +
+		"SELECT event_object_schema, event_object_table, trigger_name, action_timing, " +
+		"string_agg(event_manipulation, ' OR ' ORDER BY event_manipulation), action_statement " +
+		"FROM information_schema.triggers " +
+		"WHERE event_object_schema NOT IN ('pg_catalog', 'information_schema') " +
+		"GROUP BY event_object_schema, event_object_table, trigger_name, action_timing, action_statement " +
+		"ORDER BY event_object_schema, event_object_table, trigger_name;"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[string][]db.Trigger)
+	for rows.Next() {
+		var schemaName, tableName string
+		var trigger db.Trigger
+		if err := rows.Scan(&schemaName, &tableName, &trigger.Name, &trigger.Timing, &trigger.Event, &trigger.Body); err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprintf("%s.%s", QuoteIdentifier(schemaName), QuoteIdentifier(tableName))
+		ret[key] = append(ret[key], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// getRoutines gets all functions and procedures of a database, excluding the ones installed by extensions.
+func getRoutines(txn *sql.Tx) ([]db.Routine, error) {
+	var versionNum int
+	if err := txn.QueryRow("SELECT current_setting('server_version_num')::int;").Scan(&versionNum); err != nil {
+		return nil, err
+	}
+	// Procedures and pg_proc.prokind are introduced in Postgres 11.
+	routineType := "'FUNCTION'"
+	routineFilter := "NOT p.proisagg AND NOT p.proiswindow"
+	if versionNum >= 110000 {
+		routineType = "CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END"
+		routineFilter = "p.prokind IN ('f', 'p')"
+	}
+	query := "" +
+		"SELECT n.nspname, p.proname, " + routineType + ", " +
+		"pg_get_function_identity_arguments(p.oid), COALESCE(pg_get_function_result(p.oid), ''), " +
+		"pg_get_functiondef(p.oid), COALESCE(obj_description(p.oid, 'pg_proc'), '') " +
+		"FROM pg_proc p " +
+		"JOIN pg_namespace n ON n.oid = p.pronamespace " +
+		"WHERE n.nspname NOT IN ('pg_catalog', 'information_schema') AND " + routineFilter + " " +
+		"AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e') " +
+		"ORDER BY n.nspname, p.proname;"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routines []db.Routine
+	for rows.Next() {
+		var schemaName, name string
+		var routine db.Routine
+		if err := rows.Scan(&schemaName, &name, &routine.Type, &routine.Arguments, &routine.ReturnType, &routine.Definition, &routine.Comment); err != nil {
+			return nil, err
+		}
+		routine.Name = fmt.Sprintf("%s.%s", QuoteIdentifier(schemaName), QuoteIdentifier(name))
+		routines = append(routines, routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return routines, nil
+}
+
+// getSequences gets all sequences of a database.
+func getSequences(txn *sql.Tx) ([]db.Sequence, error) {
+	query := "" +
+		"SELECT schemaname, sequencename, data_type::text, start_value, min_value, max_value, increment_by, cycle " +
+		"FROM pg_sequences " +
+		"WHERE schemaname NOT IN ('pg_catalog', 'information_schema') " +
+		"ORDER BY schemaname, sequencename;"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []db.Sequence
+	for rows.Next() {
+		var schemaName, name string
+		var sequence db.Sequence
+		if err := rows.Scan(&schemaName, &name, &sequence.DataType, &sequence.StartValue, &sequence.MinValue, &sequence.MaxValue, &sequence.Increment, &sequence.Cycle); err != nil {
+			return nil, err
+		}
+		sequence.Name = fmt.Sprintf("%s.%s", QuoteIdentifier(schemaName), QuoteIdentifier(name))
+		sequences = append(sequences, sequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
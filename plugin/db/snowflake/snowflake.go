@@ -17,6 +17,7 @@ import (
 
 	snow "github.com/snowflakedb/gosnowflake"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
 )
 
 //go:embed snowflake_migration_schema.sql
@@ -42,6 +43,8 @@ func init() {
 type Driver struct {
 	connectionCtx db.ConnectionContext
 	dbType        db.Type
+	// sshClient is nil if the database is connected directly.
+	sshClient *ssh.Client
 
 	db *sql.DB
 }
@@ -90,12 +93,30 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 		zap.String("environment", connCtx.EnvironmentName),
 		zap.String("database", connCtx.InstanceName),
 	)
-	db, err := sql.Open("snowflake", dsn)
-	if err != nil {
-		panic(err)
+	if !config.SSHConfig.IsEmpty() {
+		// Snowflake is connected over HTTPS, so we dial through the bastion host in the transport
+		// instead of forwarding a local port, which would fail the TLS verification of the Snowflake host.
+		cfg, err := snow.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		sshClient, err := config.SSHConfig.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		transport := snow.SnowflakeTransport.Clone()
+		transport.DialContext = db.DialContext(sshClient)
+		cfg.Transporter = transport
+		driver.sshClient = sshClient
+		driver.db = sql.OpenDB(snow.NewConnector(snow.SnowflakeDriver{}, *cfg))
+	} else {
+		sqldb, err := sql.Open("snowflake", dsn)
+		if err != nil {
+			panic(err)
+		}
+		driver.db = sqldb
 	}
 	driver.dbType = dbType
-	driver.db = db
 	driver.connectionCtx = connCtx
 
 	return driver, nil
@@ -103,7 +124,13 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.Connec
 
 // Close closes the driver.
 func (driver *Driver) Close(ctx context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshClient != nil {
+		if clientErr := driver.sshClient.Close(); err == nil {
+			err = clientErr
+		}
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -133,6 +160,15 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// Capabilities returns the features supported by Snowflake.
+func (*Driver) Capabilities() db.Capabilities {
+	return db.Capabilities{
+		TransactionalDML:     true,
+		SchemaObjectKindList: []db.SchemaObjectKind{db.SchemaObjectTable, db.SchemaObjectView},
+		DumpFormat:           db.DumpFormatSQL,
+	}
+}
+
 func (driver *Driver) useRole(ctx context.Context, role string) error {
 	query := fmt.Sprintf("USE ROLE %s", role)
 	if _, err := driver.db.ExecContext(ctx, query); err != nil {
@@ -142,7 +178,7 @@ func (driver *Driver) useRole(ctx context.Context, role string) error {
 }
 
 // SyncSchema synces the schema.
-func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema, error) {
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
 	// Query user info
 	if err := driver.useRole(ctx, accountAdminRole); err != nil {
 		return nil, nil, err
@@ -159,11 +195,19 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		return nil, nil, err
 	}
 
+	includedDatabaseList := make(map[string]bool)
+	for _, database := range databaseList {
+		includedDatabaseList[database] = true
+	}
+
 	var schemaList []*db.Schema
 	for _, database := range databases {
 		if database == bytebaseDatabase {
 			continue
 		}
+		if len(includedDatabaseList) > 0 && !includedDatabaseList[database] {
+			continue
+		}
 
 		var schema db.Schema
 		schema.Name = database
@@ -517,6 +561,11 @@ func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([
 	return util.Query(ctx, driver.db, statement, limit)
 }
 
+// Explain isn't supported for Snowflake.
+func (*Driver) Explain(_ context.Context, _ string) (*db.PlanNode, error) {
+	return nil, fmt.Errorf("explain isn't supported for Snowflake")
+}
+
 // NeedsSetupMigration returns whether it needs to setup migration.
 func (driver *Driver) NeedsSetupMigration(ctx context.Context) (bool, error) {
 	exist, err := driver.hasBytebaseDatabase(ctx)
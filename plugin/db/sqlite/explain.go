@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// Explain returns the query plan of the statement by EXPLAIN QUERY PLAN.
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.PlanNode, error) {
+	rows, err := util.ExplainRows(ctx, driver.db, "EXPLAIN QUERY PLAN", statement)
+	if err != nil {
+		return nil, err
+	}
+	return parseSQLitePlan(rows)
+}
+
+// parseSQLitePlan parses the rows of EXPLAIN QUERY PLAN, which are (id, parent, notused, detail).
+func parseSQLitePlan(rows [][]string) (*db.PlanNode, error) {
+	root := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    "QUERY PLAN",
+	}
+	nodeMap := make(map[string]*db.PlanNode)
+	for _, row := range rows {
+		if len(row) != 4 {
+			return nil, fmt.Errorf("expect 4 columns of the query plan, got %d", len(row))
+		}
+		id, parentID, detail := row[0], row[1], row[3]
+		node := sqlitePlanNode(detail)
+		nodeMap[id] = node
+		parent, ok := nodeMap[parentID]
+		if !ok {
+			parent = root
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return root, nil
+}
+
+// sqlitePlanNode parses the detail such as "SCAN t", "SEARCH TABLE t USING INDEX idx (a=?)" and "USE TEMP B-TREE FOR ORDER BY".
+func sqlitePlanNode(detail string) *db.PlanNode {
+	node := &db.PlanNode{
+		Operation: db.PlanOperationOther,
+		Detail:    detail,
+	}
+	fields := strings.Fields(detail)
+	switch {
+	case len(fields) >= 2 && (fields[0] == "SCAN" || fields[0] == "SEARCH"):
+		if detail == "SCAN CONSTANT ROW" || fields[1] == "SUBQUERY" {
+			return node
+		}
+		// SQLite before 3.36 has the "TABLE" keyword.
+		tableIndex := 1
+		if fields[1] == "TABLE" && len(fields) >= 3 {
+			tableIndex = 2
+		}
+		node.Table = fields[tableIndex]
+		for i, field := range fields {
+			if field == "INDEX" && i+1 < len(fields) {
+				node.Index = fields[i+1]
+			}
+		}
+		if fields[0] == "SCAN" && !strings.Contains(detail, " USING ") {
+			node.Operation = db.PlanOperationTableScan
+		} else {
+			node.Operation = db.PlanOperationIndexScan
+		}
+	case strings.HasPrefix(detail, "USE TEMP B-TREE FOR GROUP BY"):
+		node.Operation = db.PlanOperationAggregate
+	case strings.HasPrefix(detail, "USE TEMP B-TREE FOR"):
+		node.Operation = db.PlanOperationSort
+	}
+	return node
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"path"
+	"regexp"
 	"strings"
 
 	// embed will embeds the migration schema.
@@ -108,18 +109,36 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// Capabilities returns the features supported by SQLite.
+func (*Driver) Capabilities() db.Capabilities {
+	return db.Capabilities{
+		TransactionalDDL:     true,
+		TransactionalDML:     true,
+		SchemaObjectKindList: []db.SchemaObjectKind{db.SchemaObjectTable, db.SchemaObjectView, db.SchemaObjectIndex, db.SchemaObjectForeignKey, db.SchemaObjectTrigger},
+		DumpFormat:           db.DumpFormatSQL,
+		Explain:              true,
+	}
+}
+
 // SyncSchema syncs the schema.
-func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema, error) {
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.User, []*db.Schema, error) {
 	databases, err := driver.getDatabases()
 	if err != nil {
 		return nil, nil, err
 	}
+	includedDatabaseList := make(map[string]bool)
+	for _, dbName := range databaseList {
+		includedDatabaseList[dbName] = true
+	}
 
 	var schemaList []*db.Schema
 	for _, dbName := range databases {
 		if _, ok := excludedDatabaseList[dbName]; ok {
 			continue
 		}
+		if len(includedDatabaseList) > 0 && !includedDatabaseList[dbName] {
+			continue
+		}
 
 		var schema db.Schema
 		schema.Name = dbName
@@ -147,6 +166,13 @@ func (driver *Driver) SyncSchema(ctx context.Context) ([]*db.User, []*db.Schema,
 		if err != nil {
 			return nil, nil, err
 		}
+		triggers, err := getTriggers(txn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get triggers from database %q: %s", dbName, err)
+		}
+		for i := range tbls {
+			tbls[i].TriggerList = triggers[tbls[i].Name]
+		}
 		schema.TableList = tbls
 
 		views, err := getViews(txn)
@@ -234,6 +260,12 @@ func getTables(txn *sql.Tx, indicesMap map[string][]indexSchema) ([]db.Table, er
 			}
 		}
 
+		foreignKeys, err := getForeignKeys(txn, tbl.Name)
+		if err != nil {
+			return nil, err
+		}
+		tbl.ForeignKeyList = foreignKeys
+
 		tables = append(tables, tbl)
 	}
 	return tables, nil
@@ -287,6 +319,82 @@ func getViews(txn *sql.Tx) ([]db.View, error) {
 	return views, nil
 }
 
+// getForeignKeys gets the foreign keys of a table.
+// SQLite doesn't expose the names of foreign keys, so they are named by the table and their ids, e.g. "book_fk_0".
+func getForeignKeys(txn *sql.Tx, tableName string) ([]db.ForeignKey, error) {
+	// Get foreign keys: id, seq, table, from, to, on_update, on_delete, match.
+	query := fmt.Sprintf("pragma foreign_key_list(%s);", tableName)
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []db.ForeignKey
+	lastID := -1
+	for rows.Next() {
+		var id, seq int
+		var referencedTable, from, onUpdate, onDelete, match string
+		// The referenced column is NULL if the foreign key refers to the primary key implicitly.
+		var to sql.NullString
+		if err := rows.Scan(&id, &seq, &referencedTable, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return nil, err
+		}
+		// The columns of a multi-column foreign key are in consecutive rows with the same id.
+		if id != lastID {
+			foreignKeys = append(foreignKeys, db.ForeignKey{
+				Name:            fmt.Sprintf("%s_fk_%d", tableName, id),
+				ReferencedTable: referencedTable,
+				OnDelete:        onDelete,
+				OnUpdate:        onUpdate,
+			})
+			lastID = id
+		}
+		fk := &foreignKeys[len(foreignKeys)-1]
+		fk.ColumnList = append(fk.ColumnList, from)
+		fk.ReferencedColumnList = append(fk.ReferencedColumnList, to.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return foreignKeys, nil
+}
+
+// triggerRegexp matches the timing and the event of a CREATE TRIGGER statement.
+var triggerRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+.*?\s(BEFORE\s+|AFTER\s+|INSTEAD\s+OF\s+)?(DELETE|INSERT|UPDATE)\b`)
+
+// getTriggers returns the tableName -> triggerList map of a database.
+func getTriggers(txn *sql.Tx) (map[string][]db.Trigger, error) {
+	query := "SELECT name, tbl_name, sql FROM sqlite_schema WHERE type ='trigger';"
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	triggers := make(map[string][]db.Trigger)
+	for rows.Next() {
+		var tableName string
+		var trigger db.Trigger
+		if err := rows.Scan(&trigger.Name, &tableName, &trigger.Body); err != nil {
+			return nil, err
+		}
+		// The trigger fires before the event by default.
+		trigger.Timing = "BEFORE"
+		if matches := triggerRegexp.FindStringSubmatch(trigger.Body); matches != nil {
+			if matches[1] != "" {
+				trigger.Timing = strings.Join(strings.Fields(strings.ToUpper(matches[1])), " ")
+			}
+			trigger.Event = strings.ToUpper(matches[2])
+		}
+		triggers[tableName] = append(triggers[tableName], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
 func (driver *Driver) getDatabases() ([]string, error) {
 	files, err := ioutil.ReadDir(driver.dir)
 	if err != nil {
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetForeignKeysAndTriggers(t *testing.T) {
+	a := require.New(t)
+	sqldb, err := sql.Open("sqlite3", path.Join(t.TempDir(), "test.db"))
+	a.NoError(err)
+	defer sqldb.Close()
+
+	_, err = sqldb.Exec(`
+		CREATE TABLE author (id INTEGER, region TEXT, PRIMARY KEY (id, region));
+		CREATE TABLE book (
+			id INTEGER PRIMARY KEY,
+			author_id INTEGER,
+			author_region TEXT,
+			editor_id INTEGER REFERENCES author,
+			FOREIGN KEY (author_id, author_region) REFERENCES author(id, region) ON DELETE CASCADE
+		);
+		CREATE VIEW v_book AS SELECT * FROM book;
+		CREATE TRIGGER book_insert AFTER INSERT ON book BEGIN SELECT 1; END;
+		CREATE TRIGGER book_delete DELETE ON book BEGIN SELECT 1; END;
+		CREATE TRIGGER v_book_update INSTEAD OF UPDATE ON v_book BEGIN SELECT 1; END;
+	`)
+	a.NoError(err)
+
+	txn, err := sqldb.Begin()
+	a.NoError(err)
+	defer txn.Rollback()
+
+	foreignKeys, err := getForeignKeys(txn, "book")
+	a.NoError(err)
+	a.Equal([]db.ForeignKey{
+		{
+			Name:                 "book_fk_0",
+			ColumnList:           []string{"author_id", "author_region"},
+			ReferencedTable:      "author",
+			ReferencedColumnList: []string{"id", "region"},
+			OnDelete:             "CASCADE",
+			OnUpdate:             "NO ACTION",
+		},
+		{
+			Name:                 "book_fk_1",
+			ColumnList:           []string{"editor_id"},
+			ReferencedTable:      "author",
+			ReferencedColumnList: []string{""},
+			OnDelete:             "NO ACTION",
+			OnUpdate:             "NO ACTION",
+		},
+	}, foreignKeys)
+
+	triggers, err := getTriggers(txn)
+	a.NoError(err)
+	timingEvents := make(map[string]string)
+	for _, trigger := range append(triggers["book"], triggers["v_book"]...) {
+		timingEvents[trigger.Name] = trigger.Timing + " " + trigger.Event
+	}
+	a.Equal(map[string]string{
+		"book_insert":   "AFTER INSERT",
+		"book_delete":   "BEFORE DELETE",
+		"v_book_update": "INSTEAD OF UPDATE",
+	}, timingEvents)
+}
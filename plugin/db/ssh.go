@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/bytebase/bytebase/common/log"
+)
+
+const sshDialTimeout = 10 * time.Second
+
+// SSHConfig is the configuration for connecting to the database through an SSH tunnel on a bastion host.
+type SSHConfig struct {
+	Host string
+	// Port defaults to 22.
+	Port string
+	User string
+	// Password is used if PrivateKey is empty.
+	Password string
+	// PrivateKey is the PEM encoded private key.
+	PrivateKey string
+}
+
+// IsEmpty returns whether the SSH tunnel isn't configured.
+func (c SSHConfig) IsEmpty() bool {
+	return c.Host == ""
+}
+
+// NewClient connects to the bastion host.
+func (c SSHConfig) NewClient() (*ssh.Client, error) {
+	var auth ssh.AuthMethod
+	if c.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSH private key, error: %w", err)
+		}
+		auth = ssh.PublicKeys(signer)
+	} else {
+		auth = ssh.Password(c.Password)
+	}
+	port := c.Port
+	if port == "" {
+		port = "22"
+	}
+	client, err := ssh.Dial("tcp", net.JoinHostPort(c.Host, port), &ssh.ClientConfig{
+		User: c.User,
+		Auth: []ssh.AuthMethod{auth},
+		// The bastion host is configured by the workspace admin along with the credentials, and we don't store its host key.
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect SSH bastion host %s:%s with user %q, error: %w", c.Host, port, c.User, err)
+	}
+	return client, nil
+}
+
+// SSHTunnel forwards the connections to a local address through the bastion host to the database.
+// The local address can be used by the database clients, including the subprocesses such as pg_dump and mysqlbinlog.
+type SSHTunnel struct {
+	client     *ssh.Client
+	listener   net.Listener
+	remoteAddr string
+	wg         sync.WaitGroup
+}
+
+// OpenSSHTunnel opens an SSH tunnel to the database if the SSH tunnel is configured in the connection config,
+// and points the host and port of the connection config to the local end of the tunnel.
+// The defaultPort is used if the connection config doesn't specify the port.
+// It returns nil if the SSH tunnel isn't configured.
+func OpenSSHTunnel(config *ConnectionConfig, defaultPort string) (*SSHTunnel, error) {
+	if config.SSHConfig.IsEmpty() {
+		return nil, nil
+	}
+	if strings.HasPrefix(config.Host, "/") {
+		return nil, fmt.Errorf("unix socket %q can't be connected through SSH tunnel", config.Host)
+	}
+	port := config.Port
+	if port == "" {
+		port = defaultPort
+	}
+
+	client, err := config.SSHConfig.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to listen on the local end of SSH tunnel, error: %w", err)
+	}
+	tunnel := &SSHTunnel{
+		client:     client,
+		listener:   listener,
+		remoteAddr: net.JoinHostPort(config.Host, port),
+	}
+	tunnel.wg.Add(1)
+	go tunnel.serve()
+
+	config.Host, config.Port = tunnel.Host(), tunnel.Port()
+	return tunnel, nil
+}
+
+// Host returns the host of the local end of the tunnel.
+func (t *SSHTunnel) Host() string {
+	return "127.0.0.1"
+}
+
+// Port returns the port of the local end of the tunnel.
+func (t *SSHTunnel) Port() string {
+	return fmt.Sprintf("%d", t.listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Close closes the tunnel and all the connections through it.
+func (t *SSHTunnel) Close() error {
+	err := t.listener.Close()
+	// Closing the client also closes the forwarded connections.
+	if clientErr := t.client.Close(); err == nil {
+		err = clientErr
+	}
+	t.wg.Wait()
+	return err
+}
+
+func (t *SSHTunnel) serve() {
+	defer t.wg.Done()
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			// The listener is closed.
+			return
+		}
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			t.forward(local)
+		}()
+	}
+}
+
+func (t *SSHTunnel) forward(local net.Conn) {
+	defer local.Close()
+	remote, err := t.client.Dial("tcp", t.remoteAddr)
+	if err != nil {
+		log.Warn("Failed to connect the database through SSH tunnel", zap.String("address", t.remoteAddr), zap.Error(err))
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	copyConn := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go copyConn(remote, local)
+	go copyConn(local, remote)
+	// Either side closing ends the forwarding, and the deferred closes unblock the other copy.
+	<-done
+}
+
+// DialContext dials the address through the bastion host of the SSH client.
+// It's used by the drivers connecting the database with their own transports, such as Snowflake over HTTPS.
+func DialContext(client *ssh.Client) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		type result struct {
+			conn net.Conn
+			err  error
+		}
+		ch := make(chan result, 1)
+		go func() {
+			conn, err := client.Dial(network, addr)
+			ch <- result{conn: conn, err: err}
+		}()
+		select {
+		case <-ctx.Done():
+			go func() {
+				// Close the connection dialed after the context is done.
+				if r := <-ch; r.conn != nil {
+					r.conn.Close()
+				}
+			}()
+			return nil, ctx.Err()
+		case r := <-ch:
+			return r.conn, r.err
+		}
+	}
+}
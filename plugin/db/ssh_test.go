@@ -0,0 +1,161 @@
+package db
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestOpenSSHTunnelNotConfigured(t *testing.T) {
+	config := ConnectionConfig{Host: "localhost", Port: "3306"}
+	tunnel, err := OpenSSHTunnel(&config, "3306")
+	require.NoError(t, err)
+	require.Nil(t, tunnel)
+	require.Equal(t, "localhost", config.Host)
+	require.Equal(t, "3306", config.Port)
+}
+
+func TestOpenSSHTunnelUnixSocket(t *testing.T) {
+	config := ConnectionConfig{Host: "/tmp/mysql.sock", SSHConfig: SSHConfig{Host: "bastion"}}
+	_, err := OpenSSHTunnel(&config, "3306")
+	require.Error(t, err)
+}
+
+func TestOpenSSHTunnel(t *testing.T) {
+	// An echo server as the database behind the bastion host.
+	echoListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer echoListener.Close()
+	go func() {
+		for {
+			conn, err := echoListener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	sshListener := startSSHServer(t, "bytebase", "secret")
+	defer sshListener.Close()
+
+	echoHost, echoPort, err := net.SplitHostPort(echoListener.Addr().String())
+	require.NoError(t, err)
+	sshHost, sshPort, err := net.SplitHostPort(sshListener.Addr().String())
+	require.NoError(t, err)
+	config := ConnectionConfig{
+		Host: echoHost,
+		Port: echoPort,
+		SSHConfig: SSHConfig{
+			Host:     sshHost,
+			Port:     sshPort,
+			User:     "bytebase",
+			Password: "secret",
+		},
+	}
+	tunnel, err := OpenSSHTunnel(&config, "")
+	require.NoError(t, err)
+	require.NotNil(t, tunnel)
+	defer tunnel.Close()
+	require.Equal(t, tunnel.Host(), config.Host)
+	require.Equal(t, tunnel.Port(), config.Port)
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(config.Host, config.Port))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "ping", string(buf))
+
+	// Wrong password.
+	config.SSHConfig.Password = "wrong"
+	_, err = OpenSSHTunnel(&config, "")
+	require.Error(t, err)
+}
+
+// startSSHServer starts an SSH server supporting password authentication and "direct-tcpip" channels only.
+func startSSHServer(t *testing.T, user, password string) net.Listener {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if conn.User() == user && string(pass) == password {
+				return nil, nil
+			}
+			return nil, io.EOF
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSHConn(conn, serverConfig)
+		}
+	}()
+	return listener
+}
+
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		// RFC 4254 7.2.
+		var payload struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		remote, err := net.Dial("tcp", net.JoinHostPort(payload.Host, fmt.Sprintf("%d", payload.Port)))
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			remote.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			defer channel.Close()
+			defer remote.Close()
+			go func() {
+				_, _ = io.Copy(channel, remote)
+				_ = channel.CloseWrite()
+			}()
+			_, _ = io.Copy(remote, channel)
+		}()
+	}
+}
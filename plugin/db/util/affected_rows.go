@@ -0,0 +1,40 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+)
+
+// dmlStatementReg matches the DML statements, which are executed in the dry run.
+var dmlStatementReg = regexp.MustCompile(`(?i)^\s*(INSERT|UPDATE|DELETE|REPLACE|MERGE|WITH)\s`)
+
+// IsDMLStatement returns whether the statement is a DML statement which can be rolled back.
+func IsDMLStatement(statement string) bool {
+	return dmlStatementReg.MatchString(statement)
+}
+
+// CountAffectedRows executes the DML statements in a transaction which is always rolled back,
+// and returns the affected row count of each statement.
+// Note that the changes out of the transaction are still made, e.g. the sequence values and the rows of the non-transactional tables.
+func CountAffectedRows(ctx context.Context, sqldb *sql.DB, statementList []string) ([]int64, error) {
+	tx, err := sqldb.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var countList []int64
+	for _, statement := range statementList {
+		result, err := tx.ExecContext(ctx, statement)
+		if err != nil {
+			return nil, FormatErrorWithQuery(err, statement)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, FormatError(err)
+		}
+		countList = append(countList, count)
+	}
+	return countList, nil
+}
@@ -147,7 +147,12 @@ func ExecuteMigration(ctx context.Context, executor MigrationExecutor, m *db.Mig
 	startedNs := time.Now().UnixNano()
 
 	defer func() {
-		if err := EndMigration(ctx, executor, startedNs, insertedID, updatedSchema, databaseName, resErr == nil /*isDone*/); err != nil {
+		// Use a new context if the migration is canceled, so that the migration history is still recorded as failed.
+		endCtx := ctx
+		if ctx.Err() != nil {
+			endCtx = context.Background()
+		}
+		if err := EndMigration(endCtx, executor, startedNs, insertedID, updatedSchema, databaseName, resErr == nil /*isDone*/); err != nil {
 			log.Error("Failed to update migration history record",
 				zap.Error(err),
 				zap.Int64("migration_id", migrationHistoryID),
@@ -312,8 +317,6 @@ func Query(ctx context.Context, sqldb *sql.DB, statement string, limit int) ([]i
 		return nil, FormatError(err)
 	}
 
-	colCount := len(columnTypes)
-
 	var columnTypeNames []string
 	for _, v := range columnTypes {
 		// DatabaseTypeName returns the database system name of the column type.
@@ -321,9 +324,20 @@ func Query(ctx context.Context, sqldb *sql.DB, statement string, limit int) ([]i
 		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
 	}
 
+	data, err := readRows(rows, columnTypeNames, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{columnNames, columnTypeNames, data}, nil
+}
+
+// readRows reads at most limit rows from rows, or all the rows if limit <= 0.
+func readRows(rows *sql.Rows, columnTypeNames []string, limit int) ([]interface{}, error) {
+	colCount := len(columnTypeNames)
 	rowCount := 0
 	data := []interface{}{}
-	for rows.Next() {
+	for (limit <= 0 || rowCount < limit) && rows.Next() {
 		scanArgs := make([]interface{}, colCount)
 		for i, v := range columnTypeNames {
 			// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
@@ -346,7 +360,7 @@ func Query(ctx context.Context, sqldb *sql.DB, statement string, limit int) ([]i
 		}
 
 		rowData := []interface{}{}
-		for i := range columnTypes {
+		for i := range columnTypeNames {
 			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
 				rowData = append(rowData, v.Bool)
 				continue
@@ -373,12 +387,11 @@ func Query(ctx context.Context, sqldb *sql.DB, statement string, limit int) ([]i
 
 		data = append(data, rowData)
 		rowCount++
-		if rowCount == limit {
-			break
-		}
 	}
-
-	return []interface{}{columnNames, columnTypeNames, data}, nil
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+	return data, nil
 }
 
 // FindMigrationHistoryList will find the list of migration history.
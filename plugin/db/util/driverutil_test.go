@@ -68,3 +68,43 @@ func TestFromStoredVersion(t *testing.T) {
 		require.Equal(t, tc.wantSemanticVersionSuffix, gotSemanticVersionSuffix)
 	}
 }
+
+func TestSingleStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      string
+		wantErr   bool
+	}{
+		{"SELECT * FROM t", "SELECT * FROM t", false},
+		{" UPDATE t SET a = 1;\n", "UPDATE t SET a = 1", false},
+		{"SELECT 1; DROP TABLE t", "", true},
+		{";", "", true},
+	}
+	for _, test := range tests {
+		got, err := singleStatement(test.statement)
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+}
+
+func TestIsDMLStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{"UPDATE t SET a = 1", true},
+		{"  insert INTO t VALUES (1)", true},
+		{"DELETE\nFROM t", true},
+		{"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", true},
+		{"SELECT * FROM t", false},
+		{"DROP TABLE t", false},
+		{"UPDATES", false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, IsDMLStatement(test.statement), test.statement)
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ExplainRows runs the explainPrefix query such as "EXPLAIN FORMAT=JSON" for the statement in a read-only transaction,
+// and returns the result rows with NULL values as empty strings.
+func ExplainRows(ctx context.Context, sqldb *sql.DB, explainPrefix string, statement string) ([][]string, error) {
+	stmt, err := singleStatement(statement)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("%s %s", explainPrefix, stmt)
+
+	// EXPLAIN doesn't execute the statement, the rolled back transaction is for the defense in depth.
+	tx, err := sqldb.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	var result [][]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, FormatError(err)
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+	return result, nil
+}
+
+// singleStatement returns the statement without the trailing semicolon.
+// It rejects multiple statements so that nothing but the EXPLAIN query runs,
+// which may also reject a single statement having semicolons in the string literals.
+func singleStatement(statement string) (string, error) {
+	stmt := strings.TrimRight(strings.TrimSpace(statement), "; \t\n")
+	if stmt == "" {
+		return "", fmt.Errorf("empty statement")
+	}
+	if strings.Contains(stmt, ";") {
+		return "", fmt.Errorf("only a single statement can be explained")
+	}
+	return stmt, nil
+}
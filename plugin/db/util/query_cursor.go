@@ -0,0 +1,192 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// QueryCursor is a cursor over the result of a readonly / SELECT query.
+// Unlike Query, it doesn't materialize the whole result but reads the rows page by page,
+// and the running query can be cancelled on the engine.
+type QueryCursor struct {
+	dbType db.Type
+	sqldb  *sql.DB
+	conn   *sql.Conn
+	tx     *sql.Tx
+	rows   *sql.Rows
+	cancel context.CancelFunc
+	// connectionID is the engine side ID of the connection running the query, used to cancel the query.
+	// It is 0 if the engine doesn't support cancelling a query from another connection.
+	connectionID int64
+
+	// closed is set once the cursor is closed. It's accessed atomically instead of
+	// being guarded by mu, which may be held by a Fetch blocked on the running query.
+	closed int32
+	// mu guards rows, which may be read and closed concurrently.
+	mu   sync.Mutex
+	done bool
+
+	// ColumnNames is the names of the result columns.
+	ColumnNames []string
+	// ColumnTypeNames is the upper-cased database system names of the result column types.
+	ColumnTypeNames []string
+}
+
+// OpenQueryCursor executes a readonly / SELECT query and returns the cursor over its result.
+// The query keeps running after ctx is done until the cursor is closed, so ctx only bounds opening the cursor.
+func OpenQueryCursor(ctx context.Context, dbType db.Type, sqldb *sql.DB, statement string) (*QueryCursor, error) {
+	// The query outlives the request opening it, so it runs in its own context which is cancelled by Close.
+	queryCtx, cancel := context.WithCancel(context.Background())
+	cursor := &QueryCursor{
+		dbType: dbType,
+		sqldb:  sqldb,
+		cancel: cancel,
+	}
+	if err := cursor.open(ctx, queryCtx, statement); err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	return cursor, nil
+}
+
+func (c *QueryCursor) open(ctx context.Context, queryCtx context.Context, statement string) error {
+	// We pin a single connection so that we know which engine side connection to cancel.
+	conn, err := c.sqldb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+
+	if query := connectionIDQuery(c.dbType); query != "" {
+		if err := conn.QueryRowContext(ctx, query).Scan(&c.connectionID); err != nil {
+			return FormatErrorWithQuery(err, query)
+		}
+	}
+
+	// Not all sql engines support ReadOnly flag, so we will use tx rollback semantics to enforce readonly.
+	tx, err := conn.BeginTx(queryCtx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	c.tx = tx
+
+	rows, err := tx.QueryContext(queryCtx, statement)
+	if err != nil {
+		return FormatErrorWithQuery(err, statement)
+	}
+	c.rows = rows
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return FormatError(err)
+	}
+	c.ColumnNames = columnNames
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return FormatError(err)
+	}
+	for _, v := range columnTypes {
+		c.ColumnTypeNames = append(c.ColumnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
+	}
+	return nil
+}
+
+// Fetch reads the next page of at most limit rows.
+// It returns an empty page once all the rows have been read.
+func (c *QueryCursor) Fetch(limit int) ([]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return []interface{}{}, nil
+	}
+	data, err := readRows(c.rows, c.ColumnTypeNames, limit)
+	if err != nil {
+		c.done = true
+		return nil, err
+	}
+	if limit <= 0 || len(data) < limit {
+		c.done = true
+	}
+	return data, nil
+}
+
+// Done returns true if all the rows have been read.
+func (c *QueryCursor) Done() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
+
+// Cancel cancels the running query on the engine and closes the cursor.
+func (c *QueryCursor) Cancel(ctx context.Context) error {
+	// The connection may have been released, so we must not cancel whatever query it's running now.
+	if atomic.LoadInt32(&c.closed) == 1 {
+		return nil
+	}
+	var err error
+	if stmt := cancelQueryStatement(c.dbType, c.connectionID); stmt != "" {
+		// The connection running the query is busy, so we cancel it from another connection.
+		if _, execErr := c.sqldb.ExecContext(ctx, stmt); execErr != nil {
+			err = FormatErrorWithQuery(execErr, stmt)
+		}
+	}
+	// For the other engines, cancelling the context is the best we can do.
+	c.Close()
+	return err
+}
+
+// Close closes the cursor and releases the connection.
+func (c *QueryCursor) Close() {
+	atomic.StoreInt32(&c.closed, 1)
+	// Cancel the context first so that we don't wait for a running query to finish.
+	c.cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = true
+	if c.rows != nil {
+		c.rows.Close()
+	}
+	if c.tx != nil {
+		_ = c.tx.Rollback()
+	}
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// connectionIDQuery returns the query getting the engine side ID of the current connection,
+// or empty if the engine doesn't support cancelling a query from another connection.
+func connectionIDQuery(dbType db.Type) string {
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB:
+		return "SELECT CONNECTION_ID()"
+	case db.Postgres:
+		return "SELECT pg_backend_pid()"
+	}
+	return ""
+}
+
+// cancelQueryStatement returns the statement cancelling the running query of the connection.
+func cancelQueryStatement(dbType db.Type, connectionID int64) string {
+	if connectionID == 0 {
+		return ""
+	}
+	switch dbType {
+	case db.MySQL, db.MariaDB:
+		return fmt.Sprintf("KILL QUERY %d", connectionID)
+	case db.TiDB:
+		return fmt.Sprintf("KILL TIDB QUERY %d", connectionID)
+	case db.Postgres:
+		return fmt.Sprintf("SELECT pg_cancel_backend(%d)", connectionID)
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+
+	// Import sqlite3 driver.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestQueryCursor(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	sqldb, err := sql.Open("sqlite3", ":memory:")
+	a.NoError(err)
+	defer sqldb.Close()
+
+	_, err = sqldb.ExecContext(ctx, "CREATE TABLE book (id INTEGER PRIMARY KEY, name TEXT NULL); INSERT INTO book VALUES (1, 'a'), (2, NULL), (3, 'c');")
+	a.NoError(err)
+
+	cursor, err := OpenQueryCursor(ctx, db.SQLite, sqldb, "SELECT id, name FROM book ORDER BY id")
+	a.NoError(err)
+	defer cursor.Close()
+	a.Equal([]string{"id", "name"}, cursor.ColumnNames)
+	a.Equal([]string{"INTEGER", "TEXT"}, cursor.ColumnTypeNames)
+
+	page, err := cursor.Fetch(2)
+	a.NoError(err)
+	a.Equal([]interface{}{[]interface{}{int64(1), "a"}, []interface{}{int64(2), nil}}, page)
+	a.False(cursor.Done())
+
+	page, err = cursor.Fetch(2)
+	a.NoError(err)
+	a.Equal([]interface{}{[]interface{}{int64(3), "c"}}, page)
+	a.True(cursor.Done())
+
+	page, err = cursor.Fetch(2)
+	a.NoError(err)
+	a.Empty(page)
+}
+
+func TestQueryCursorCancel(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	sqldb, err := sql.Open("sqlite3", ":memory:")
+	a.NoError(err)
+	defer sqldb.Close()
+
+	cursor, err := OpenQueryCursor(ctx, db.SQLite, sqldb, "SELECT 1")
+	a.NoError(err)
+	err = cursor.Cancel(ctx)
+	a.NoError(err)
+	a.True(cursor.Done())
+
+	page, err := cursor.Fetch(10)
+	a.NoError(err)
+	a.Empty(page)
+}
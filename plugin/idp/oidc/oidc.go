@@ -0,0 +1,338 @@
+// Package oidc is the plugin for the OpenID Connect identity providers.
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// DefaultEmailClaim is the claim carrying the email of the user if the email claim is not configured.
+	DefaultEmailClaim = "email"
+	// DefaultNameClaim is the claim carrying the name of the user if the name claim is not configured.
+	DefaultNameClaim = "name"
+	// wellKnownPath is the path of the OpenID Connect discovery document relative to the issuer.
+	wellKnownPath = "/.well-known/openid-configuration"
+)
+
+// Config is the configuration of an OpenID Connect identity provider.
+type Config struct {
+	// DiscoveryURL is either the issuer URL or the URL of the discovery document, e.g. https://accounts.google.com/.well-known/openid-configuration.
+	DiscoveryURL string
+	ClientID     string
+	ClientSecret string
+	// Scopes are the scopes requested in addition to "openid".
+	Scopes []string
+	// EmailClaim is the claim carrying the email of the user, defaults to DefaultEmailClaim.
+	EmailClaim string
+	// NameClaim is the claim carrying the name of the user, defaults to DefaultNameClaim.
+	NameClaim string
+	// GroupClaim is the claim carrying the groups of the user. Groups are not read if it's empty.
+	GroupClaim string
+}
+
+// Metadata is the subset of the OpenID Connect discovery document used by Bytebase.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// UserInfo is the user info extracted from the ID token.
+type UserInfo struct {
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+}
+
+// Provider is an OpenID Connect identity provider.
+type Provider struct {
+	client   *http.Client
+	config   Config
+	metadata *Metadata
+}
+
+// NewProvider discovers the identity provider and returns the provider.
+func NewProvider(ctx context.Context, client *http.Client, config Config) (*Provider, error) {
+	if config.DiscoveryURL == "" {
+		return nil, fmt.Errorf("discovery URL is required")
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("client ID is required")
+	}
+	if config.EmailClaim == "" {
+		config.EmailClaim = DefaultEmailClaim
+	}
+	if config.NameClaim == "" {
+		config.NameClaim = DefaultNameClaim
+	}
+
+	discoveryURL := config.DiscoveryURL
+	if !strings.HasSuffix(discoveryURL, wellKnownPath) {
+		discoveryURL = strings.TrimSuffix(discoveryURL, "/") + wellKnownPath
+	}
+	metadata := &Metadata{}
+	if err := getJSON(ctx, client, discoveryURL, metadata); err != nil {
+		return nil, fmt.Errorf("failed to fetch the discovery document %q, error: %w", discoveryURL, err)
+	}
+	if metadata.Issuer == "" || metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("invalid discovery document %q, issuer, authorization_endpoint, token_endpoint and jwks_uri are required", discoveryURL)
+	}
+
+	return &Provider{
+		client:   client,
+		config:   config,
+		metadata: metadata,
+	}, nil
+}
+
+// Metadata returns the metadata of the identity provider.
+func (p *Provider) Metadata() *Metadata {
+	return p.metadata
+}
+
+// Scope returns the space-separated scope requested from the identity provider.
+func (p *Provider) Scope() string {
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint to redirect the user to.
+func (p *Provider) AuthCodeURL(state, redirectURL string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", redirectURL)
+	params.Set("scope", p.Scope())
+	params.Set("state", state)
+	sep := "?"
+	if strings.Contains(p.metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.metadata.AuthorizationEndpoint + sep + params.Encode()
+}
+
+// tokenResponse is the response of the token endpoint.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Login exchanges the authorization code for the ID token, verifies it and returns the user info in it.
+func (p *Provider) Login(ctx context.Context, code, redirectURL string) (*UserInfo, error) {
+	rawIDToken, err := p.exchangeToken(ctx, code, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := p.VerifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+	return p.userInfo(claims)
+}
+
+func (p *Provider) exchangeToken(ctx context.Context, code, redirectURL string) (string, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	params.Set("redirect_uri", redirectURL)
+	params.Set("client_id", p.config.ClientID)
+	params.Set("client_secret", p.config.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.metadata.TokenEndpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to construct token request, error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange the token, error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response body, error: %w", err)
+	}
+	token := &tokenResponse{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return "", fmt.Errorf("failed to unmarshal token response body %q, status code %d, error: %w", string(body), resp.StatusCode, err)
+	}
+	if token.Error != "" {
+		return "", fmt.Errorf("failed to exchange the token, error: %s, description: %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to exchange the token, status code %d", resp.StatusCode)
+	}
+	if token.IDToken == "" {
+		return "", fmt.Errorf("missing id_token in the token response")
+	}
+	return token.IDToken, nil
+}
+
+// VerifyIDToken verifies the signature, issuer, audience and expiration of the ID token and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string) (jwt.MapClaims, error) {
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodRS256.Name}}
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key kid=%q", kid)
+		}
+		return key, nil
+	}); err != nil {
+		return nil, fmt.Errorf("invalid ID token, error: %w", err)
+	}
+
+	// The expiration is verified by the parser, but it's not required in the claims.
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("invalid ID token, missing exp")
+	}
+	if !claims.VerifyIssuer(p.metadata.Issuer, true) {
+		return nil, fmt.Errorf("invalid ID token, issuer mismatch, expected %q", p.metadata.Issuer)
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("invalid ID token, audience mismatch, expected %q", p.config.ClientID)
+	}
+	return claims, nil
+}
+
+func (p *Provider) userInfo(claims jwt.MapClaims) (*UserInfo, error) {
+	userInfo := &UserInfo{}
+	userInfo.Subject, _ = claims["sub"].(string)
+	userInfo.Email, _ = claims[p.config.EmailClaim].(string)
+	if userInfo.Email == "" {
+		return nil, fmt.Errorf("missing email claim %q in the ID token", p.config.EmailClaim)
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified && p.config.EmailClaim == DefaultEmailClaim {
+		return nil, fmt.Errorf("email %q is not verified by the identity provider", userInfo.Email)
+	}
+	userInfo.Name, _ = claims[p.config.NameClaim].(string)
+	if userInfo.Name == "" {
+		userInfo.Name = userInfo.Email
+	}
+
+	if p.config.GroupClaim != "" {
+		switch groups := claims[p.config.GroupClaim].(type) {
+		case string:
+			userInfo.Groups = []string{groups}
+		case []interface{}:
+			for _, group := range groups {
+				if s, ok := group.(string); ok {
+					userInfo.Groups = append(userInfo.Groups, s)
+				}
+			}
+		}
+	}
+	return userInfo, nil
+}
+
+// jsonWebKey is a JSON Web Key in the key set.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetchKeys fetches the RSA signing keys of the identity provider, keyed by the kid.
+// We don't cache the keys because users don't log in often and the keys may be rotated at any time.
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	keySet := &struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := getJSON(ctx, p.client, p.metadata.JWKSURI, keySet); err != nil {
+		return nil, fmt.Errorf("failed to fetch the key set %q, error: %w", p.metadata.JWKSURI, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range keySet.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		publicKey, err := toRSAPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key kid=%q, error: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no RSA signing key in the key set %q", p.metadata.JWKSURI)
+	}
+	return keys, nil
+}
+
+func toRSAPublicKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus, error: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent, error: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("exponent is too large")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to construct GET %v, error: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to GET %v, error: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body, error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code %d, body %q", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal response body, error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/tests/fake"
+)
+
+const (
+	testClientID     = "bytebase"
+	testClientSecret = "bytebase-secret"
+	testRedirectURL  = "http://localhost:8080/oauth/callback"
+)
+
+func newTestProvider(t *testing.T, config Config) (*Provider, *fake.OIDC) {
+	a := require.New(t)
+	idp, err := fake.NewOIDC(0 /* port */, testClientID, testClientSecret)
+	a.NoError(err)
+	server := httptest.NewServer(idp.Echo)
+	t.Cleanup(server.Close)
+
+	config.DiscoveryURL = server.URL
+	if config.ClientID == "" {
+		config.ClientID = testClientID
+	}
+	if config.ClientSecret == "" {
+		config.ClientSecret = testClientSecret
+	}
+	provider, err := NewProvider(context.Background(), server.Client(), config)
+	a.NoError(err)
+	a.Equal(server.URL, provider.Metadata().Issuer)
+	return provider, idp
+}
+
+func TestLogin(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	provider, idp := newTestProvider(t, Config{
+		Scopes:     []string{"openid", "email", "profile"},
+		GroupClaim: "groups",
+	})
+
+	idp.AddAuthorizationCode("code1", map[string]interface{}{
+		"sub":            "1",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"name":           "Alice",
+		"groups":         []string{"dba", "engineering"},
+	})
+	userInfo, err := provider.Login(ctx, "code1", testRedirectURL)
+	a.NoError(err)
+	a.Equal(&UserInfo{
+		Subject: "1",
+		Email:   "alice@example.com",
+		Name:    "Alice",
+		Groups:  []string{"dba", "engineering"},
+	}, userInfo)
+
+	// The authorization code can only be exchanged once.
+	_, err = provider.Login(ctx, "code1", testRedirectURL)
+	a.Error(err)
+
+	// The email is required.
+	idp.AddAuthorizationCode("code2", map[string]interface{}{
+		"sub": "2",
+	})
+	_, err = provider.Login(ctx, "code2", testRedirectURL)
+	a.Error(err)
+
+	// The email must be verified if the identity provider tells.
+	idp.AddAuthorizationCode("code3", map[string]interface{}{
+		"sub":            "3",
+		"email":          "bob@example.com",
+		"email_verified": false,
+	})
+	_, err = provider.Login(ctx, "code3", testRedirectURL)
+	a.Error(err)
+}
+
+func TestLoginWithClaimMapping(t *testing.T) {
+	a := require.New(t)
+	provider, idp := newTestProvider(t, Config{
+		EmailClaim: "upn",
+		NameClaim:  "preferred_username",
+		GroupClaim: "roles",
+	})
+
+	idp.AddAuthorizationCode("code", map[string]interface{}{
+		"sub":                "1",
+		"upn":                "alice@corp.example.com",
+		"preferred_username": "alice",
+		"roles":              "owner",
+	})
+	userInfo, err := provider.Login(context.Background(), "code", testRedirectURL)
+	a.NoError(err)
+	a.Equal(&UserInfo{
+		Subject: "1",
+		Email:   "alice@corp.example.com",
+		Name:    "alice",
+		Groups:  []string{"owner"},
+	}, userInfo)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+
+	// The ID token issued to another client is rejected.
+	provider, idp := newTestProvider(t, Config{})
+	otherProvider, err := NewProvider(ctx, http.DefaultClient, Config{
+		DiscoveryURL: provider.Metadata().Issuer,
+		ClientID:     "other",
+		ClientSecret: testClientSecret,
+	})
+	a.NoError(err)
+	idp.AddAuthorizationCode("code1", map[string]interface{}{
+		"sub":   "1",
+		"email": "alice@example.com",
+	})
+	rawIDToken, err := provider.exchangeToken(ctx, "code1", testRedirectURL)
+	a.NoError(err)
+	_, err = provider.VerifyIDToken(ctx, rawIDToken)
+	a.NoError(err)
+	_, err = otherProvider.VerifyIDToken(ctx, rawIDToken)
+	a.Error(err)
+
+	// The expired ID token is rejected.
+	idp.AddAuthorizationCode("code2", map[string]interface{}{
+		"sub":   "1",
+		"email": "alice@example.com",
+		"exp":   time.Now().Add(-time.Minute).Unix(),
+	})
+	rawIDToken, err = provider.exchangeToken(ctx, "code2", testRedirectURL)
+	a.NoError(err)
+	_, err = provider.VerifyIDToken(ctx, rawIDToken)
+	a.Error(err)
+
+	// The tampered ID token is rejected.
+	_, err = provider.VerifyIDToken(ctx, rawIDToken[:len(rawIDToken)-4]+"AAAA")
+	a.Error(err)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	a := require.New(t)
+	provider, _ := newTestProvider(t, Config{
+		Scopes: []string{"email", "openid", "profile"},
+	})
+
+	authCodeURL, err := url.Parse(provider.AuthCodeURL("state", testRedirectURL))
+	a.NoError(err)
+	a.Equal(provider.Metadata().AuthorizationEndpoint, authCodeURL.Scheme+"://"+authCodeURL.Host+authCodeURL.Path)
+	query := authCodeURL.Query()
+	a.Equal("code", query.Get("response_type"))
+	a.Equal(testClientID, query.Get("client_id"))
+	a.Equal(testRedirectURL, query.Get("redirect_uri"))
+	a.Equal("openid email profile", query.Get("scope"))
+	a.Equal("state", query.Get("state"))
+}
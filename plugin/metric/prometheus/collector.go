@@ -0,0 +1,92 @@
+package prometheus
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/metric"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// namespace is the prefix of the metric names exposed to Prometheus.
+	namespace = "bytebase"
+	// collectTimeout is the timeout for a metric collector to collect the metrics in a scrape.
+	collectTimeout = 10 * time.Second
+)
+
+var _ prom.Collector = (*Collector)(nil)
+
+// Collector exposes the metrics collected by the metric collectors to Prometheus as gauges.
+// The metrics are collected in every scrape.
+type Collector struct {
+	collectors map[metric.Name]metric.Collector
+}
+
+// NewCollector creates a new Prometheus collector.
+func NewCollector() *Collector {
+	return &Collector{
+		collectors: make(map[metric.Name]metric.Collector),
+	}
+}
+
+// Register will register a metric collector.
+func (c *Collector) Register(metricName metric.Name, collector metric.Collector) {
+	c.collectors[metricName] = collector
+}
+
+// Describe implements prometheus.Collector.
+// It sends no descriptor, which makes the collector unchecked, because the label values are only known after collecting.
+func (*Collector) Describe(chan<- *prom.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	for name, collector := range c.collectors {
+		metricList, err := collector.Collect(ctx)
+		if err != nil {
+			log.Error("Failed to collect metric",
+				zap.String("collector", string(name)),
+				zap.Error(err),
+			)
+			continue
+		}
+		for _, m := range metricList {
+			labelNames := make([]string, 0, len(m.Labels))
+			for labelName := range m.Labels {
+				labelNames = append(labelNames, labelName)
+			}
+			sort.Strings(labelNames)
+			labelValues := make([]string, 0, len(labelNames))
+			for _, labelName := range labelNames {
+				labelValues = append(labelValues, m.Labels[labelName])
+			}
+
+			desc := prom.NewDesc(FQName(m.Name), string(m.Name), labelNames, nil)
+			promMetric, err := prom.NewConstMetric(desc, prom.GaugeValue, float64(m.Value), labelValues...)
+			if err != nil {
+				log.Error("Failed to convert metric",
+					zap.String("metric", string(m.Name)),
+					zap.Error(err),
+				)
+				continue
+			}
+			ch <- promMetric
+		}
+	}
+}
+
+// FQName converts the metric name to the fully-qualified Prometheus metric name,
+// e.g. "bb.task.count" to "bytebase_task_count".
+func FQName(name metric.Name) string {
+	s := strings.TrimPrefix(string(name), "bb.")
+	s = strings.NewReplacer(".", "_", "-", "_").Replace(s)
+	return prom.BuildFQName(namespace, "", s)
+}
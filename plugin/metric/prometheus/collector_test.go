@@ -0,0 +1,74 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/metric"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCollector struct {
+	metricList []*metric.Metric
+	err        error
+}
+
+func (c *fakeCollector) Collect(context.Context) ([]*metric.Metric, error) {
+	return c.metricList, c.err
+}
+
+func TestCollector(t *testing.T) {
+	a := require.New(t)
+	c := NewCollector()
+	c.Register("bb.task.count", &fakeCollector{
+		metricList: []*metric.Metric{
+			{
+				Name:   "bb.task.count",
+				Value:  3,
+				Labels: map[string]string{"type": "bb.task.general", "status": "DONE"},
+			},
+			{
+				Name:   "bb.task.count",
+				Value:  1,
+				Labels: map[string]string{"type": "bb.task.database.backup", "status": "FAILED"},
+			},
+		},
+	})
+	c.Register("bb.anomaly.count", &fakeCollector{
+		err: errors.New("failed to count anomalies"),
+	})
+
+	want := `
+# HELP bytebase_task_count bb.task.count
+# TYPE bytebase_task_count gauge
+bytebase_task_count{status="DONE",type="bb.task.general"} 3
+bytebase_task_count{status="FAILED",type="bb.task.database.backup"} 1
+`
+	// The metrics of the failed collector are skipped.
+	err := testutil.CollectAndCompare(c, strings.NewReader(want))
+	a.NoError(err)
+}
+
+func TestFQName(t *testing.T) {
+	tests := []struct {
+		name metric.Name
+		want string
+	}{
+		{
+			name: "bb.task.count",
+			want: "bytebase_task_count",
+		},
+		{
+			name: "bb.task-check.count",
+			want: "bytebase_task_check_count",
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, FQName(test.name))
+	}
+}
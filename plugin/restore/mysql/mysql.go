@@ -75,6 +75,10 @@ type Restore struct {
 
 // New creates a new instance of Restore
 func New(driver *mysql.Driver, instance *mysqlutil.Instance, connCfg db.ConnectionConfig, binlogDir string) *Restore {
+	// The mysql and mysqlbinlog subprocesses connect through the SSH tunnel of the driver if any.
+	if sshTunnel := driver.GetSSHTunnel(); sshTunnel != nil {
+		connCfg.Host, connCfg.Port = sshTunnel.Host(), sshTunnel.Port()
+	}
 	return &Restore{
 		driver:    driver,
 		mysqlutil: instance,
@@ -103,6 +107,10 @@ func (r *Restore) replayBinlog(ctx context.Context, originalDatabase, pitrDataba
 		// Stop reading the binary log at the first event having a timestamp equal to or later than the datetime argument.
 		"--stop-datetime", formatDateTime(targetTs),
 	}
+	if r.driver.GetDbType() == db.MariaDB {
+		// MariaDB doesn't have the GTID_NEXT variable set by mysqlbinlog for the MySQL GTIDs, and its own GTIDs are unknown to mysqlbinlog.
+		mysqlbinlogArgs = append(mysqlbinlogArgs, "--skip-gtids")
+	}
 
 	mysqlbinlogArgs = append(mysqlbinlogArgs, replayBinlogPaths...)
 
@@ -351,6 +359,29 @@ func (r *Restore) GetLatestBackupBeforeOrEqualTs(ctx context.Context, backupList
 
 }
 
+// GetLatestBackup returns the backup with the latest binlog position, or nil if no backup has the binlog position.
+// It's the backup that GetLatestBackupBeforeOrEqualTs returns for the current time, which the PITR to the latest time depends on.
+// Unlike GetLatestBackupBeforeOrEqualTs, it compares the binlog positions instead of the binlog event timestamps,
+// so the binlog files don't need to be downloaded.
+func GetLatestBackup(backupList []*api.Backup) (*api.Backup, error) {
+	var latest *api.Backup
+	var latestSeq int64
+	for _, b := range backupList {
+		if b.Payload.BinlogInfo.IsEmpty() {
+			continue
+		}
+		seq, err := getBinlogNameSeq(b.Payload.BinlogInfo.FileName)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil || seq > latestSeq || (seq == latestSeq && b.Payload.BinlogInfo.Position > latest.Payload.BinlogInfo.Position) {
+			latest = b
+			latestSeq = seq
+		}
+	}
+	return latest, nil
+}
+
 // The backupList must 1 to 1 maps to the eventTsList, and the sorting order is not required.
 func getLatestBackupBeforeOrEqualTsImpl(backupList []*api.Backup, eventTsList []int64, targetTs int64) (*api.Backup, error) {
 	var maxEventTsLETargetTs int64
@@ -755,12 +786,29 @@ func checkVersionForPITR(version string) error {
 	return nil
 }
 
-// CheckServerVersionForPITR checks that the MySQL server version meets the requirements of PITR.
+// checks the MariaDB version is >=10.3, the oldest MariaDB version still maintained.
+// The version is like "10.6.8-MariaDB-log".
+func checkMariaDBVersionForPITR(version string) error {
+	v, err := semver.ParseTolerant(strings.SplitN(version, "-", 2)[0])
+	if err != nil {
+		return err
+	}
+	v10dot3 := semver.MustParse("10.3.0")
+	if v.LT(v10dot3) {
+		return fmt.Errorf("MariaDB version %s is not supported for PITR; the minimum supported version is 10.3", version)
+	}
+	return nil
+}
+
+// CheckServerVersionForPITR checks that the MySQL or MariaDB server version meets the requirements of PITR.
 func (r *Restore) CheckServerVersionForPITR(ctx context.Context) error {
 	value, err := r.getServerVariable(ctx, "version")
 	if err != nil {
 		return err
 	}
+	if r.driver.GetDbType() == db.MariaDB {
+		return checkMariaDBVersionForPITR(value)
+	}
 	if err := checkVersionForPITR(value); err != nil {
 		return err
 	}
@@ -832,6 +880,8 @@ func (r *Restore) CheckBinlogEnabled(ctx context.Context) error {
 }
 
 // CheckBinlogRowFormat checks whether the binlog format is ROW.
+// For MariaDB, whose default binlog format is MIXED, it also checks that the binlog isn't compressed,
+// since mysqlbinlog can't decode the compressed events of MariaDB.
 func (r *Restore) CheckBinlogRowFormat(ctx context.Context) error {
 	value, err := r.getServerVariable(ctx, "binlog_format")
 	if err != nil {
@@ -840,6 +890,15 @@ func (r *Restore) CheckBinlogRowFormat(ctx context.Context) error {
 	if strings.ToUpper(value) != "ROW" {
 		return fmt.Errorf("binlog format is not ROW but %s", value)
 	}
+	if r.driver.GetDbType() == db.MariaDB {
+		compress, err := r.getServerVariable(ctx, "log_bin_compress")
+		if err != nil {
+			return err
+		}
+		if strings.ToUpper(compress) != "OFF" {
+			return fmt.Errorf("binlog compression is not supported for PITR, please set log_bin_compress to OFF")
+		}
+	}
 	return nil
 }
 
@@ -128,6 +128,40 @@ func TestCheckVersionForPITR(t *testing.T) {
 	}
 }
 
+func TestCheckMariaDBVersionForPITR(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		version string
+		err     bool
+	}{
+		{
+			version: "10.2.44-MariaDB",
+			err:     true,
+		},
+		{
+			version: "10.3.35-MariaDB-log",
+			err:     false,
+		},
+		{
+			version: "10.6.8-MariaDB-1:10.6.8+maria~focal",
+			err:     false,
+		},
+		{
+			version: "invalid.semver",
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		err := checkMariaDBVersionForPITR(test.version)
+		if test.err {
+			a.Error(err)
+		} else {
+			a.NoError(err)
+		}
+	}
+}
+
 func TestGetBinlogFileNameSeqNumber(t *testing.T) {
 	a := require.New(t)
 	tests := []struct {
@@ -299,6 +333,75 @@ func TestGetLatestBackupBeforeOrEqualTsImpl(t *testing.T) {
 	}
 }
 
+func TestGetLatestBackup(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		backupList   []*api.Backup
+		targetBackup *api.Backup
+		err          bool
+	}{
+		// normal case
+		{
+			backupList: []*api.Backup{
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000001", Position: 20}}},
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000002", Position: 10}}},
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000001", Position: 10}}},
+			},
+			targetBackup: &api.Backup{
+				Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000002", Position: 10}},
+			},
+			err: false,
+		},
+		// the binlog file sequence numbers are compared as numbers
+		{
+			backupList: []*api.Backup{
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.999999", Position: 20}}},
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.1000000", Position: 10}}},
+			},
+			targetBackup: &api.Backup{
+				Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.1000000", Position: 10}},
+			},
+			err: false,
+		},
+		// backup with empty binlog info does not count
+		{
+			backupList: []*api.Backup{
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{}}},
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000001", Position: 10}}},
+			},
+			targetBackup: &api.Backup{
+				Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog.000001", Position: 10}},
+			},
+			err: false,
+		},
+		// no backup with binlog info
+		{
+			backupList: []*api.Backup{
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{}}},
+			},
+			targetBackup: nil,
+			err:          false,
+		},
+		// invalid binlog file name
+		{
+			backupList: []*api.Backup{
+				{Payload: api.BackupPayload{BinlogInfo: api.BinlogInfo{FileName: "binlog000001", Position: 10}}},
+			},
+			targetBackup: nil,
+			err:          true,
+		},
+	}
+	for _, test := range tests {
+		backup, err := GetLatestBackup(test.backupList)
+		a.Equal(test.targetBackup, backup)
+		if test.err {
+			a.Error(err)
+		} else {
+			a.NoError(err)
+		}
+	}
+}
+
 func TestGetReplayBinlogPathList(t *testing.T) {
 	a := require.New(t)
 	tests := []struct {
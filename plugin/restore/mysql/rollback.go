@@ -0,0 +1,512 @@
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/resources/mysqlutil"
+	"go.uber.org/zap"
+)
+
+// rowEventType is the type of the row event decoded by mysqlbinlog.
+type rowEventType string
+
+const (
+	rowEventInsert rowEventType = "INSERT"
+	rowEventUpdate rowEventType = "UPDATE"
+	rowEventDelete rowEventType = "DELETE"
+)
+
+// rowEvent is a row change decoded by "mysqlbinlog --verbose".
+// The values are the literals printed by mysqlbinlog ordered by the column position.
+type rowEvent struct {
+	eventType rowEventType
+	table     string
+	// before is the row image before the change, i.e. the WHERE part of UPDATE and DELETE.
+	before []string
+	// after is the row image after the change, i.e. the SET part of INSERT and UPDATE.
+	after []string
+}
+
+// tableColumn is the column metadata used to format the row values.
+type tableColumn struct {
+	name       string
+	dataType   string
+	columnType string
+	primary    bool
+}
+
+// GenerateRollbackSQL generates the SQL reverting the row changes of the database between the binlog coordinations,
+// by decoding the row events of the local binlog files. The binlog files must have been downloaded, and the binlog must
+// use the ROW format with the FULL row image.
+// Only the row changes made by the connection threadID, i.e. the CONNECTION_ID() of the session executing the changes, are reverted,
+// and it returns an error if a row change can't be attributed to a connection.
+// The rollback SQL reverts the row changes in the reverse order, and it returns an error if there are more than maxRowCount changed rows.
+func (r *Restore) GenerateRollbackSQL(ctx context.Context, database string, startBinlogInfo, endBinlogInfo api.BinlogInfo, threadID int64, maxRowCount int) (string, error) {
+	if threadID <= 0 {
+		return "", fmt.Errorf("invalid thread ID %d to filter the binlog events", threadID)
+	}
+	binlogPaths, err := getBinlogRangeList(startBinlogInfo, endBinlogInfo, r.binlogDir)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{
+		// Decode the row events to the pseudo SQL statements instead of the BINLOG statements.
+		"--base64-output=DECODE-ROWS",
+		"--verbose",
+		// List entries for just this database.
+		"--database", database,
+		// Start decoding the binary log at the log position, this option applies to the first log file named on the command line.
+		"--start-position", fmt.Sprintf("%d", startBinlogInfo.Position),
+		// Stop decoding the binary log at the log position, this option applies to the last log file named on the command line.
+		"--stop-position", fmt.Sprintf("%d", endBinlogInfo.Position),
+	}
+	args = append(args, binlogPaths...)
+
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.mysqlutil.GetPath(mysqlutil.MySQLBinlog), args...)
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = &buf
+	if err := cmd.Run(); err != nil {
+		log.Error("mysqlbinlog command fails", zap.String("cmd", cmd.String()), zap.Error(err))
+		return "", fmt.Errorf("mysqlbinlog command[%s] fails, error[%w]", cmd.String(), err)
+	}
+
+	eventList, err := parseRowEvents(&buf, threadID)
+	if err != nil {
+		return "", err
+	}
+	if len(eventList) > maxRowCount {
+		return "", fmt.Errorf("%d rows are changed, exceeding the maximum %d rows of the rollback SQL", len(eventList), maxRowCount)
+	}
+
+	columnMap := make(map[string][]tableColumn)
+	for _, event := range eventList {
+		if _, ok := columnMap[event.table]; ok {
+			continue
+		}
+		columnList, err := r.getTableColumnList(ctx, database, event.table)
+		if err != nil {
+			return "", err
+		}
+		columnMap[event.table] = columnList
+	}
+	return generateRollbackSQL(eventList, columnMap)
+}
+
+// getBinlogRangeList returns the paths of the local binlog files from the start binlog file to the end binlog file.
+func getBinlogRangeList(startBinlogInfo, endBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
+	startSeq, err := getBinlogNameSeq(startBinlogInfo.FileName)
+	if err != nil {
+		return nil, err
+	}
+	endSeq, err := getBinlogNameSeq(endBinlogInfo.FileName)
+	if err != nil {
+		return nil, err
+	}
+	binlogFiles, err := getSortedLocalBinlogFiles(binlogDir)
+	if err != nil {
+		return nil, err
+	}
+	var rangeFiles []BinlogFile
+	for _, binlogFile := range binlogFiles {
+		if binlogFile.Seq >= startSeq && binlogFile.Seq <= endSeq {
+			rangeFiles = append(rangeFiles, binlogFile)
+		}
+	}
+	if len(rangeFiles) != int(endSeq-startSeq+1) || !binlogFilesAreContinuous(rangeFiles) {
+		return nil, fmt.Errorf("local binlog files from %s to %s are incomplete", startBinlogInfo.FileName, endBinlogInfo.FileName)
+	}
+	var paths []string
+	for _, binlogFile := range rangeFiles {
+		paths = append(paths, filepath.Join(binlogDir, binlogFile.Name))
+	}
+	return paths, nil
+}
+
+func (r *Restore) getTableColumnList(ctx context.Context, database, table string) ([]tableColumn, error) {
+	db, err := r.driver.GetDbConnection(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE, COLUMN_KEY
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`
+	rows, err := db.QueryContext(ctx, query, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columnList []tableColumn
+	for rows.Next() {
+		var column tableColumn
+		var columnKey string
+		if err := rows.Scan(&column.name, &column.dataType, &column.columnType, &columnKey); err != nil {
+			return nil, err
+		}
+		column.dataType = strings.ToLower(column.dataType)
+		column.columnType = strings.ToLower(column.columnType)
+		column.primary = columnKey == "PRI"
+		columnList = append(columnList, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columnList) == 0 {
+		return nil, fmt.Errorf("table %q not found in database %q", table, database)
+	}
+	return columnList, nil
+}
+
+// parseRowEvents parses the row events made by the connection threadID from the "mysqlbinlog --verbose" output, which looks like:
+//
+//	#220701 10:00:00 server id 1  end_log_pos 310 CRC32 0x5e3a1c0d 	Query	thread_id=8	exec_time=0	error_code=0
+//	BEGIN
+//	...
+//	### UPDATE `db`.`t`
+//	### WHERE
+//	###   @1=1
+//	###   @2='old'
+//	### SET
+//	###   @1=1
+//	###   @2='new'
+//
+// The row events don't carry the thread ID themselves, they belong to the thread of the BEGIN query event of the transaction.
+func parseRowEvents(output *bytes.Buffer, threadID int64) ([]*rowEvent, error) {
+	var eventList []*rowEvent
+	var event *rowEvent
+	// values points to the row image being parsed.
+	var values *[]string
+	// eventThreadID is the thread ID of the last query event, or -1 if there isn't any.
+	eventThreadID := int64(-1)
+	// skip is true if the row event being parsed is made by the other connections.
+	skip := false
+	newEvent := func(eventType rowEventType, table string) error {
+		if eventThreadID < 0 {
+			return fmt.Errorf("failed to find the thread of the %s row event on table %q", eventType, table)
+		}
+		event, values = nil, nil
+		skip = eventThreadID != threadID
+		if !skip {
+			event = &rowEvent{eventType: eventType, table: table}
+			eventList = append(eventList, event)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "### ") {
+			if strings.HasPrefix(line, "#") {
+				id, ok, err := parseEventThreadID(line)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					eventThreadID = id
+				}
+			}
+			continue
+		}
+		line = strings.TrimPrefix(line, "### ")
+		switch {
+		case strings.HasPrefix(line, "INSERT INTO "):
+			if err := newEvent(rowEventInsert, parseEventTable(strings.TrimPrefix(line, "INSERT INTO "))); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "UPDATE "):
+			if err := newEvent(rowEventUpdate, parseEventTable(strings.TrimPrefix(line, "UPDATE "))); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "DELETE FROM "):
+			if err := newEvent(rowEventDelete, parseEventTable(strings.TrimPrefix(line, "DELETE FROM "))); err != nil {
+				return nil, err
+			}
+		case skip:
+			// The row image of the other connections.
+		case line == "WHERE" && event != nil:
+			values = &event.before
+		case line == "SET" && event != nil:
+			values = &event.after
+		case strings.HasPrefix(line, "  @") && values != nil:
+			column := strings.TrimPrefix(line, "  @")
+			i := strings.Index(column, "=")
+			if i < 0 {
+				return nil, fmt.Errorf("invalid mysqlbinlog row event line: %q", line)
+			}
+			position, err := strconv.Atoi(column[:i])
+			if err != nil || position != len(*values)+1 {
+				return nil, fmt.Errorf("invalid column position in mysqlbinlog row event line: %q", line)
+			}
+			*values = append(*values, stripValueComment(column[i+1:]))
+		default:
+			return nil, fmt.Errorf("invalid mysqlbinlog row event line: %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return eventList, nil
+}
+
+// parseEventThreadID parses the thread ID from the event header line like "#220701 10:00:00 server id 1 ... Query	thread_id=8	exec_time=0	error_code=0".
+// It returns false if the event header doesn't have the thread ID.
+func parseEventThreadID(line string) (int64, bool, error) {
+	i := strings.Index(line, "thread_id=")
+	if i < 0 {
+		return 0, false, nil
+	}
+	s := line[i+len("thread_id="):]
+	if j := strings.IndexAny(s, " \t"); j >= 0 {
+		s = s[:j]
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid thread ID in mysqlbinlog event header: %q", line)
+	}
+	return id, true, nil
+}
+
+// parseEventTable returns the table name from the quoted `db`.`table`.
+func parseEventTable(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "`.`"); i >= 0 {
+		s = s[i+2:]
+	}
+	return strings.Trim(s, "`")
+}
+
+// stripValueComment strips the trailing type comment printed by "mysqlbinlog -vv", e.g. "1 /* INT meta=0 nullable=0 is_null=0 */".
+func stripValueComment(value string) string {
+	if !strings.HasSuffix(value, "*/") {
+		return value
+	}
+	if i := strings.LastIndex(value, " /* "); i >= 0 {
+		return value[:i]
+	}
+	return value
+}
+
+// generateRollbackSQL generates the statements reverting the row events in the reverse order.
+func generateRollbackSQL(eventList []*rowEvent, columnMap map[string][]tableColumn) (string, error) {
+	var buf strings.Builder
+	for i := len(eventList) - 1; i >= 0; i-- {
+		event := eventList[i]
+		columnList := columnMap[event.table]
+		var stmt string
+		var err error
+		switch event.eventType {
+		case rowEventInsert:
+			stmt, err = generateDelete(event.table, columnList, event.after)
+		case rowEventUpdate:
+			stmt, err = generateUpdate(event.table, columnList, event.before, event.after)
+		case rowEventDelete:
+			stmt, err = generateInsert(event.table, columnList, event.before)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to generate the rollback statement of %s on table %q, error: %w", event.eventType, event.table, err)
+		}
+		if _, err := buf.WriteString(stmt + "\n"); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+func generateInsert(table string, columnList []tableColumn, row []string) (string, error) {
+	valueList, err := formatRowValues(columnList, row)
+	if err != nil {
+		return "", err
+	}
+	var nameList []string
+	for _, column := range columnList {
+		nameList = append(nameList, quoteIdentifier(column.name))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", quoteIdentifier(table), strings.Join(nameList, ", "), strings.Join(valueList, ", ")), nil
+}
+
+func generateUpdate(table string, columnList []tableColumn, before, after []string) (string, error) {
+	valueList, err := formatRowValues(columnList, before)
+	if err != nil {
+		return "", err
+	}
+	where, err := generateWhere(columnList, after)
+	if err != nil {
+		return "", err
+	}
+	var setList []string
+	for i, column := range columnList {
+		setList = append(setList, fmt.Sprintf("%s = %s", quoteIdentifier(column.name), valueList[i]))
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s LIMIT 1;", quoteIdentifier(table), strings.Join(setList, ", "), where), nil
+}
+
+func generateDelete(table string, columnList []tableColumn, row []string) (string, error) {
+	where, err := generateWhere(columnList, row)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT 1;", quoteIdentifier(table), where), nil
+}
+
+// generateWhere generates the condition matching the row by the primary key if any, or else by the columns except the inexact floating-point ones.
+func generateWhere(columnList []tableColumn, row []string) (string, error) {
+	valueList, err := formatRowValues(columnList, row)
+	if err != nil {
+		return "", err
+	}
+	hasPrimaryKey := false
+	for _, column := range columnList {
+		if column.primary {
+			hasPrimaryKey = true
+			break
+		}
+	}
+	var condList []string
+	for i, column := range columnList {
+		if hasPrimaryKey && !column.primary {
+			continue
+		}
+		if !hasPrimaryKey && (column.dataType == "float" || column.dataType == "double") {
+			continue
+		}
+		// The NULL-safe equal matches the NULL values.
+		condList = append(condList, fmt.Sprintf("%s <=> %s", quoteIdentifier(column.name), valueList[i]))
+	}
+	if len(condList) == 0 {
+		return "", fmt.Errorf("no column to identify the row")
+	}
+	return strings.Join(condList, " AND "), nil
+}
+
+func formatRowValues(columnList []tableColumn, row []string) ([]string, error) {
+	if len(row) != len(columnList) {
+		return nil, fmt.Errorf("the row has %d columns but the table has %d columns, the binlog_row_image should be FULL and the table schema shouldn't be changed", len(row), len(columnList))
+	}
+	var valueList []string
+	for i, column := range columnList {
+		value, err := formatValue(column, row[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to format the value of column %q, error: %w", column.name, err)
+		}
+		valueList = append(valueList, value)
+	}
+	return valueList, nil
+}
+
+// formatValue formats the value printed by mysqlbinlog as the SQL literal of the column.
+func formatValue(column tableColumn, value string) (string, error) {
+	switch {
+	case value == "NULL":
+		return value, nil
+	case strings.HasPrefix(value, "'"):
+		s, err := unquoteValue(value)
+		if err != nil {
+			return "", err
+		}
+		literal := quoteString(s)
+		if column.dataType == "json" {
+			literal = fmt.Sprintf("CAST(%s AS JSON)", literal)
+		}
+		return literal, nil
+	case strings.HasPrefix(value, "b'"):
+		// BIT values.
+		return value, nil
+	}
+
+	// Numeric values.
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return "", fmt.Errorf("invalid value %q", value)
+	}
+	if column.dataType == "timestamp" {
+		// mysqlbinlog prints TIMESTAMP values as the Unix timestamps.
+		return fmt.Sprintf("FROM_UNIXTIME(%s)", value), nil
+	}
+	if strings.HasPrefix(value, "-") && strings.Contains(column.columnType, "unsigned") {
+		// mysqlbinlog prints the unsigned integers as the signed ones.
+		bits, ok := map[string]uint{"tinyint": 8, "smallint": 16, "mediumint": 24, "int": 32, "bigint": 64}[column.dataType]
+		if ok {
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return "", fmt.Errorf("invalid integer %q", value)
+			}
+			u := uint64(v)
+			if bits < 64 {
+				u &= 1<<bits - 1
+			}
+			return strconv.FormatUint(u, 10), nil
+		}
+	}
+	return value, nil
+}
+
+// unquoteValue unquotes the string printed by mysqlbinlog, which escapes the control characters, quotes and backslashes as "\xNN".
+func unquoteValue(value string) (string, error) {
+	if len(value) < 2 || !strings.HasSuffix(value, "'") {
+		return "", fmt.Errorf("invalid string %q", value)
+	}
+	value = value[1 : len(value)-1]
+	var buf bytes.Buffer
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) && value[i+1] == 'x' {
+			b, err := hex.DecodeString(value[i+2 : i+4])
+			if err != nil {
+				return "", fmt.Errorf("invalid escape in string %q", value)
+			}
+			buf.Write(b)
+			i += 3
+			continue
+		}
+		buf.WriteByte(value[i])
+	}
+	return buf.String(), nil
+}
+
+// quoteString quotes the string as the SQL literal, or the hexadecimal literal for the binary data.
+func quoteString(s string) string {
+	if !utf8.ValidString(s) {
+		return fmt.Sprintf("X'%s'", hex.EncodeToString([]byte(s)))
+	}
+	var buf strings.Builder
+	buf.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'':
+			buf.WriteString(`\'`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case 0:
+			buf.WriteString(`\0`)
+		case '\x1a':
+			buf.WriteString(`\Z`)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('\'')
+	return buf.String()
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
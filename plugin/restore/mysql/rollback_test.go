@@ -0,0 +1,149 @@
+package mysql
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRowEvents(t *testing.T) {
+	a := require.New(t)
+	output := `# at 4
+#220701 10:00:00 server id 1  end_log_pos 125 CRC32 0x3bc4e7f1 	Start: binlog v 4, server v 8.0.28 created 220701 10:00:00
+#220701 10:00:01 server id 1  end_log_pos 310 CRC32 0x5e3a1c0d 	Query	thread_id=8	exec_time=0	error_code=0
+BEGIN
+/*!*/;
+#220701 10:00:01 server id 1  end_log_pos 390 CRC32 0x1b2d5c21 	Update_rows: table id 90 flags: STMT_END_F
+### UPDATE ` + "`db`.`t`" + `
+### WHERE
+###   @1=1
+###   @2='it\x27s'
+### SET
+###   @1=1
+###   @2='new' /* VARSTRING(80) meta=80 nullable=1 is_null=0 */
+### INSERT INTO ` + "`db`.`t`" + `
+### SET
+###   @1=2
+###   @2=NULL
+### DELETE FROM ` + "`db`.`t`" + `
+### WHERE
+###   @1=3
+###   @2='a\x0ab'
+COMMIT/*!*/;
+#220701 10:00:02 server id 1  end_log_pos 510 CRC32 0x6c2f9a13 	Query	thread_id=9	exec_time=0	error_code=0
+BEGIN
+/*!*/;
+#220701 10:00:02 server id 1  end_log_pos 600 CRC32 0x2d4b7e05 	Delete_rows: table id 90 flags: STMT_END_F
+### DELETE FROM ` + "`db`.`t`" + `
+### WHERE
+###   @1=4
+###   @2='other'
+COMMIT/*!*/;
+`
+	eventList, err := parseRowEvents(bytes.NewBufferString(output), 8)
+	a.NoError(err)
+	a.Equal([]*rowEvent{
+		{eventType: rowEventUpdate, table: "t", before: []string{"1", `'it\x27s'`}, after: []string{"1", "'new'"}},
+		{eventType: rowEventInsert, table: "t", after: []string{"2", "NULL"}},
+		{eventType: rowEventDelete, table: "t", before: []string{"3", `'a\x0ab'`}},
+	}, eventList)
+
+	// The row events of the other connections are skipped.
+	eventList, err = parseRowEvents(bytes.NewBufferString(output), 9)
+	a.NoError(err)
+	a.Equal([]*rowEvent{
+		{eventType: rowEventDelete, table: "t", before: []string{"4", "'other'"}},
+	}, eventList)
+
+	_, err = parseRowEvents(bytes.NewBufferString("#220701 10:00:01 server id 1 	Query	thread_id=8\n### UPDATE `db`.`t`\n### WHERE\n###   @2=1\n"), 8)
+	a.Error(err)
+	// The row events without the thread can't be attributed to the connection.
+	_, err = parseRowEvents(bytes.NewBufferString("### DELETE FROM `db`.`t`\n### WHERE\n###   @1=1\n"), 8)
+	a.Error(err)
+}
+
+func TestGenerateRollbackSQL(t *testing.T) {
+	a := require.New(t)
+	eventList := []*rowEvent{
+		{eventType: rowEventInsert, table: "t", after: []string{"1", "'a'", "1656633600", "-1"}},
+		{eventType: rowEventUpdate, table: "t", before: []string{"2", `'it\x27s'`, "NULL", "1"}, after: []string{"2", "'b'", "NULL", "1"}},
+		{eventType: rowEventDelete, table: "t", before: []string{"3", `'a\x0ab'`, "NULL", "2"}},
+	}
+	columnMap := map[string][]tableColumn{
+		"t": {
+			{name: "id", dataType: "int", columnType: "int", primary: true},
+			{name: "name", dataType: "varchar", columnType: "varchar(20)"},
+			{name: "ts", dataType: "timestamp", columnType: "timestamp"},
+			{name: "flag", dataType: "tinyint", columnType: "tinyint unsigned"},
+		},
+	}
+	rollback, err := generateRollbackSQL(eventList, columnMap)
+	a.NoError(err)
+	a.Equal("INSERT INTO `t` (`id`, `name`, `ts`, `flag`) VALUES (3, 'a\\nb', NULL, 2);\n"+
+		"UPDATE `t` SET `id` = 2, `name` = 'it\\'s', `ts` = NULL, `flag` = 1 WHERE `id` <=> 2 LIMIT 1;\n"+
+		"DELETE FROM `t` WHERE `id` <=> 1 LIMIT 1;\n", rollback)
+
+	// Without the primary key, the row is matched by the columns except the floating-point ones.
+	columnMap = map[string][]tableColumn{
+		"t": {
+			{name: "id", dataType: "int", columnType: "int"},
+			{name: "score", dataType: "double", columnType: "double"},
+		},
+	}
+	rollback, err = generateRollbackSQL([]*rowEvent{{eventType: rowEventInsert, table: "t", after: []string{"1", "1.5"}}}, columnMap)
+	a.NoError(err)
+	a.Equal("DELETE FROM `t` WHERE `id` <=> 1 LIMIT 1;\n", rollback)
+
+	// The row image isn't FULL.
+	_, err = generateRollbackSQL([]*rowEvent{{eventType: rowEventInsert, table: "t", after: []string{"1"}}}, columnMap)
+	a.Error(err)
+}
+
+func TestFormatValue(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		column   tableColumn
+		value    string
+		expected string
+	}{
+		{
+			column:   tableColumn{dataType: "varchar"},
+			value:    `'back\x5cslash'`,
+			expected: `'back\\slash'`,
+		},
+		{
+			column:   tableColumn{dataType: "varbinary"},
+			value:    `'\xff\x00'`,
+			expected: "X'ff00'",
+		},
+		{
+			column:   tableColumn{dataType: "json"},
+			value:    `'{"a": 1}'`,
+			expected: `CAST('{"a": 1}' AS JSON)`,
+		},
+		{
+			column:   tableColumn{dataType: "bigint", columnType: "bigint unsigned"},
+			value:    "-1",
+			expected: "18446744073709551615",
+		},
+		{
+			column:   tableColumn{dataType: "bit", columnType: "bit(4)"},
+			value:    "b'0101'",
+			expected: "b'0101'",
+		},
+		{
+			column:   tableColumn{dataType: "date"},
+			value:    "'2022:07:01'",
+			expected: "'2022:07:01'",
+		},
+	}
+	for _, test := range tests {
+		value, err := formatValue(test.column, test.value)
+		a.NoError(err)
+		a.Equal(test.expected, value)
+	}
+
+	_, err := formatValue(tableColumn{dataType: "int"}, "abc")
+	a.Error(err)
+}
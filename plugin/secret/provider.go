@@ -0,0 +1,44 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+func init() {
+	Register("file", &fileProvider{})
+	Register("env", &envProvider{})
+}
+
+// fileProvider resolves "file:///run/secrets/x" to the content of the file, e.g. the Docker and Kubernetes secrets mounted as files.
+type fileProvider struct{}
+
+func (*fileProvider) Resolve(_ context.Context, ref *url.URL) (string, error) {
+	if ref.Host != "" && ref.Host != "localhost" {
+		return "", fmt.Errorf("file reference must be an absolute path like file:///run/secrets/x")
+	}
+	content, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	// The secret files usually end with a newline which isn't part of the secret.
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// envProvider resolves "env://NAME" to the value of the environment variable NAME of the Bytebase process.
+type envProvider struct{}
+
+func (*envProvider) Resolve(_ context.Context, ref *url.URL) (string, error) {
+	name := ref.Host
+	if name == "" {
+		return "", fmt.Errorf("env reference must be like env://NAME")
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q isn't set", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,106 @@
+// Package secret resolves the secret references of credentials from the external secret providers.
+//
+// A data source password can be a reference like "vault://path#key", "file:///run/secrets/x" or "env://NAME"
+// instead of the secret itself, so that the secret lives in the secret manager rather than in Bytebase.
+package secret
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTL is how long a resolved secret is cached, so that rotating a secret in the provider takes effect after at most cacheTTL.
+const cacheTTL = 5 * time.Minute
+
+// Provider resolves the secret references of a scheme.
+type Provider interface {
+	// Resolve returns the secret referenced by ref.
+	Resolve(ctx context.Context, ref *url.URL) (string, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]Provider)
+
+	cacheMu sync.Mutex
+	cache   = make(map[string]*cacheEntry)
+)
+
+type cacheEntry struct {
+	secret   string
+	expireAt time.Time
+}
+
+// Register makes a secret provider available by the scheme of the references.
+func Register(scheme string, provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if provider == nil {
+		panic("secret: Register provider is nil")
+	}
+	if _, dup := providers[scheme]; dup {
+		panic("secret: Register called twice for provider " + scheme)
+	}
+	providers[scheme] = provider
+}
+
+func getProvider(value string) (string, Provider) {
+	i := strings.Index(value, "://")
+	if i <= 0 {
+		return "", nil
+	}
+	scheme := value[:i]
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return scheme, providers[scheme]
+}
+
+// IsReference returns whether the value is a reference of a registered secret provider.
+func IsReference(value string) bool {
+	_, provider := getProvider(value)
+	return provider != nil
+}
+
+// Resolution is the result of resolving a value.
+type Resolution struct {
+	// Secret is the resolved secret, or the value itself if it isn't a reference.
+	Secret string
+	// Reference is the secret reference, empty if the value isn't a reference.
+	Reference string
+	// Cached is true if the secret is served from the cache without consulting the provider.
+	Cached bool
+}
+
+// Resolve resolves the value if it's a secret reference, otherwise returns the value as is.
+// The resolved secrets are cached for a while to avoid consulting the provider on every connection.
+func Resolve(ctx context.Context, value string) (*Resolution, error) {
+	scheme, provider := getProvider(value)
+	if provider == nil {
+		return &Resolution{Secret: value}, nil
+	}
+
+	cacheMu.Lock()
+	entry, ok := cache[value]
+	cacheMu.Unlock()
+	if ok && time.Now().Before(entry.expireAt) {
+		return &Resolution{Secret: entry.secret, Reference: value, Cached: true}, nil
+	}
+
+	ref, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s secret reference, error: %w", scheme, err)
+	}
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s secret reference %q, error: %w", scheme, value, err)
+	}
+
+	cacheMu.Lock()
+	cache[value] = &cacheEntry{secret: secret, expireAt: time.Now().Add(cacheTTL)}
+	cacheMu.Unlock()
+	return &Resolution{Secret: secret, Reference: value}, nil
+}
@@ -0,0 +1,103 @@
+package secret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	// Plain values are returned as is.
+	resolution, err := Resolve(ctx, "p@ss://word")
+	require.NoError(t, err)
+	require.Equal(t, &Resolution{Secret: "p@ss://word"}, resolution)
+	require.False(t, IsReference("p@ss://word"))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0600))
+	ref := "file://" + path
+	require.True(t, IsReference(ref))
+	resolution, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, &Resolution{Secret: "from-file", Reference: ref}, resolution)
+
+	// The secret is cached.
+	require.NoError(t, os.WriteFile(path, []byte("rotated"), 0600))
+	resolution, err = Resolve(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, &Resolution{Secret: "from-file", Reference: ref, Cached: true}, resolution)
+
+	os.Setenv("BB_TEST_SECRET_PASSWORD", "from-env")
+	defer os.Unsetenv("BB_TEST_SECRET_PASSWORD")
+	resolution, err = Resolve(ctx, "env://BB_TEST_SECRET_PASSWORD")
+	require.NoError(t, err)
+	require.Equal(t, "from-env", resolution.Secret)
+
+	_, err = Resolve(ctx, "env://BB_TEST_SECRET_NOT_EXIST")
+	require.Error(t, err)
+	_, err = Resolve(ctx, "file://"+filepath.Join(dir, "not-exist"))
+	require.Error(t, err)
+}
+
+func TestVaultProvider(t *testing.T) {
+	// A stand-in for the Vault HTTP API.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/mysql":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"kv2-secret"},"metadata":{"version":1}}}`))
+		case "/v1/kv/mysql":
+			_, _ = w.Write([]byte(`{"data":{"password":"kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"VAULT_ADDR":  server.URL,
+		"VAULT_TOKEN": "root",
+	}
+	provider := &vaultProvider{
+		getenv: func(name string) string { return env[name] },
+		client: server.Client(),
+	}
+	ctx := context.Background()
+	resolve := func(ref string) (string, error) {
+		u, err := url.Parse(ref)
+		require.NoError(t, err)
+		return provider.Resolve(ctx, u)
+	}
+
+	secret, err := resolve("vault://secret/data/mysql#password")
+	require.NoError(t, err)
+	require.Equal(t, "kv2-secret", secret)
+	secret, err = resolve("vault://kv/mysql#password")
+	require.NoError(t, err)
+	require.Equal(t, "kv1-secret", secret)
+
+	_, err = resolve("vault://secret/data/mysql#user")
+	require.Error(t, err)
+	_, err = resolve("vault://secret/data/postgres#password")
+	require.Error(t, err)
+	_, err = resolve("vault://secret/data/mysql")
+	require.Error(t, err)
+
+	env["VAULT_TOKEN"] = "wrong"
+	_, err = resolve("vault://secret/data/mysql#password")
+	require.Error(t, err)
+}
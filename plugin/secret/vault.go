@@ -0,0 +1,93 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const vaultTimeout = 10 * time.Second
+
+func init() {
+	Register("vault", &vaultProvider{
+		getenv: os.Getenv,
+		client: &http.Client{Timeout: vaultTimeout},
+	})
+}
+
+// vaultProvider resolves "vault://path#key" by reading the secret at path from a HashiCorp Vault compatible HTTP API,
+// e.g. "vault://secret/data/mysql#password" for the key "password" of the KV version 2 secret "mysql" mounted at "secret".
+// Both KV version 1 and version 2 secrets are supported.
+//
+// The Vault server is configured by the same environment variables as the Vault CLI:
+// VAULT_ADDR, VAULT_TOKEN and the optional VAULT_NAMESPACE.
+type vaultProvider struct {
+	getenv func(string) string
+	client *http.Client
+}
+
+func (p *vaultProvider) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	path := strings.Trim(ref.Host+ref.Path, "/")
+	key := ref.Fragment
+	if path == "" || key == "" {
+		return "", fmt.Errorf("vault reference must be like vault://path#key")
+	}
+	address := p.getenv("VAULT_ADDR")
+	if address == "" {
+		return "", fmt.Errorf("VAULT_ADDR isn't set")
+	}
+	token := p.getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN isn't set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", strings.TrimRight(address, "/"), path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := p.getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret %q, error: %w", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault response, error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret %q, status: %s, body: %s", path, resp.Status, body)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("failed to unmarshal vault response, error: %w", err)
+	}
+	data := secret.Data
+	// KV version 2 nests the secret data along with the metadata.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %q", key, path)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of vault secret %q isn't a string", key, path)
+	}
+	return s, nil
+}
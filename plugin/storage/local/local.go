@@ -0,0 +1,75 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bytebase/bytebase/plugin/storage"
+)
+
+var (
+	_ storage.Storage = (*Storage)(nil)
+)
+
+// Storage is the storage keeping the files in a local directory.
+type Storage struct {
+	dir string
+}
+
+// New creates a storage keeping the files under dir.
+func New(dir string) *Storage {
+	return &Storage{
+		dir: dir,
+	}
+}
+
+// Upload stores the content of r as key.
+func (s *Storage) Upload(ctx context.Context, key string, r io.Reader) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", path, err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", path, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write file %q: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close file %q: %w", path, err)
+	}
+	return nil
+}
+
+// Download opens the file stored as key.
+func (s *Storage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	path := s.path(key)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	return f, nil
+}
+
+// Delete removes the file stored as key.
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	path := s.path(key)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file %q: %w", path, err)
+	}
+	return nil
+}
+
+// path returns the file path of key.
+// An absolute key is used as is, since the backups taken by earlier versions may record the absolute path.
+func (s *Storage) path(key string) string {
+	if filepath.IsAbs(key) {
+		return key
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
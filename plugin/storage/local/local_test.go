@@ -0,0 +1,46 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := New(dir)
+
+	key := "backup/db/101/prod-backup.sql"
+	err := s.Upload(ctx, key, strings.NewReader("CREATE TABLE t(id INT);"))
+	a.NoError(err)
+	content, err := os.ReadFile(filepath.Join(dir, "backup", "db", "101", "prod-backup.sql"))
+	a.NoError(err)
+	a.Equal("CREATE TABLE t(id INT);", string(content))
+
+	r, err := s.Download(ctx, key)
+	a.NoError(err)
+	content, err = io.ReadAll(r)
+	a.NoError(err)
+	a.NoError(r.Close())
+	a.Equal("CREATE TABLE t(id INT);", string(content))
+
+	// An absolute key is used as is.
+	r, err = s.Download(ctx, filepath.Join(dir, key))
+	a.NoError(err)
+	a.NoError(r.Close())
+
+	err = s.Delete(ctx, key)
+	a.NoError(err)
+	_, err = s.Download(ctx, key)
+	a.Error(err)
+	// Deleting a key which doesn't exist isn't an error.
+	err = s.Delete(ctx, key)
+	a.NoError(err)
+}
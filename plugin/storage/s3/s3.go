@@ -0,0 +1,121 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/bytebase/bytebase/plugin/storage"
+)
+
+var (
+	_ storage.Storage = (*Storage)(nil)
+)
+
+// Config is the configuration of an S3-compatible storage.
+type Config struct {
+	// Endpoint is the URL of the S3-compatible service, e.g. "https://minio.example.com:9000".
+	// The AWS S3 endpoint of the region is used if it's empty.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is prepended to the keys, so that a bucket can be shared.
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses the bucket by path instead of subdomain, which is required by most self-hosted services.
+	UsePathStyle bool
+}
+
+// Storage is the storage keeping the files in an S3-compatible bucket.
+type Storage struct {
+	client   *s3.Client
+	uploader *manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+// New creates a storage keeping the files in the bucket of config.
+func New(config Config) (*Storage, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 access key ID and secret access key are required")
+	}
+	region := config.Region
+	if region == "" {
+		// Most S3-compatible services ignore the region, but the request signature requires one.
+		region = "us-east-1"
+	}
+
+	options := s3.Options{
+		Region:       region,
+		Credentials:  credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+		UsePathStyle: config.UsePathStyle,
+	}
+	if config.Endpoint != "" {
+		options.EndpointResolver = s3.EndpointResolverFromURL(config.Endpoint)
+	}
+	client := s3.New(options)
+	return &Storage{
+		client: client,
+		// The uploader uploads a large file in parts, so that we don't need to know the size beforehand.
+		uploader: manager.NewUploader(client),
+		bucket:   config.Bucket,
+		prefix:   strings.Trim(config.Prefix, "/"),
+	}, nil
+}
+
+// Upload uploads the content of r as key.
+func (s *Storage) Upload(ctx context.Context, key string, r io.Reader) error {
+	objectKey := s.objectKey(key)
+	if _, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+		Body:   r,
+	}); err != nil {
+		return fmt.Errorf("failed to upload %q to S3 bucket %q: %w", objectKey, s.bucket, err)
+	}
+	return nil
+}
+
+// Download downloads the content stored as key.
+func (s *Storage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectKey := s.objectKey(key)
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %q from S3 bucket %q: %w", objectKey, s.bucket, err)
+	}
+	return output.Body, nil
+}
+
+// Delete deletes the content stored as key.
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	objectKey := s.objectKey(key)
+	if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	}); err != nil {
+		return fmt.Errorf("failed to delete %q from S3 bucket %q: %w", objectKey, s.bucket, err)
+	}
+	return nil
+}
+
+func (s *Storage) objectKey(key string) string {
+	key = strings.TrimLeft(path.Clean("/"+key), "/")
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + "/" + key
+}
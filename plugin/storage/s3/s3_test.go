@@ -0,0 +1,47 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectKey(t *testing.T) {
+	tests := []struct {
+		prefix string
+		key    string
+		want   string
+	}{
+		{
+			prefix: "",
+			key:    "backup/db/101/prod-backup.sql",
+			want:   "backup/db/101/prod-backup.sql",
+		},
+		{
+			prefix: "bytebase",
+			key:    "backup/db/101/prod-backup.sql",
+			want:   "bytebase/backup/db/101/prod-backup.sql",
+		},
+		{
+			prefix: "/bytebase/prod/",
+			key:    "/backup/db/101/prod-backup.sql",
+			want:   "bytebase/prod/backup/db/101/prod-backup.sql",
+		},
+		{
+			prefix: "bytebase",
+			key:    "backup/../../prod-backup.sql",
+			want:   "bytebase/prod-backup.sql",
+		},
+	}
+
+	for _, test := range tests {
+		s, err := New(Config{
+			Bucket:          "bucket",
+			Prefix:          test.prefix,
+			AccessKeyID:     "access-key-id",
+			SecretAccessKey: "secret-access-key",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, test.want, s.objectKey(test.key))
+	}
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage is the interface of a backup storage, which stores the backup files by their keys.
+// A key is a slash-separated relative path such as "backup/db/101/prod-backup.sql".
+type Storage interface {
+	// Upload reads r until EOF and stores the content as key, overwriting the existing one.
+	Upload(ctx context.Context, key string, r io.Reader) error
+	// Download returns the content stored as key. The caller must close the returned reader.
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the content stored as key. It's not an error if key doesn't exist.
+	Delete(ctx context.Context, key string) error
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -42,8 +43,125 @@ func newProvider(config vcs.ProviderConfig) vcs.Provider {
 }
 
 // APIURL returns the API URL path of GitHub.
-func (p *Provider) APIURL(string) string {
-	return apiURL
+// GitHub Enterprise Server serves the REST API under "/api/v3" of the instance URL.
+func (p *Provider) APIURL(instanceURL string) string {
+	if instanceURL == "" || instanceURL == "https://github.com" {
+		return apiURL
+	}
+	return fmt.Sprintf("%s/api/v3", strings.TrimSuffix(instanceURL, "/"))
+}
+
+// WebhookType is the GitHub webhook event type, sent in the "X-GitHub-Event" header.
+type WebhookType string
+
+const (
+	// WebhookPush is the webhook type for push.
+	WebhookPush WebhookType = "push"
+	// WebhookPing is the webhook type for ping, which GitHub sends when a new webhook is created.
+	WebhookPing WebhookType = "ping"
+)
+
+func (e WebhookType) String() string {
+	switch e {
+	case WebhookPush:
+		return "push"
+	case WebhookPing:
+		return "ping"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// WebhookInfo is the API message for webhook info.
+type WebhookInfo struct {
+	ID int `json:"id"`
+}
+
+// WebhookConfig is the API message for webhook configuration.
+type WebhookConfig struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	// Secret is used by GitHub to sign the payload in the "X-Hub-Signature-256" header.
+	Secret      string `json:"secret,omitempty"`
+	InsecureSSL string `json:"insecure_ssl"`
+}
+
+// WebhookCreateOrUpdate is the API message for creating or updating a webhook.
+type WebhookCreateOrUpdate struct {
+	Config WebhookConfig `json:"config"`
+	Events []string      `json:"events"`
+	Active bool          `json:"active"`
+}
+
+// WebhookRepository is the API message for webhook repository.
+type WebhookRepository struct {
+	ID       int    `json:"id"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// WebhookPusher is the API message for webhook pusher.
+type WebhookPusher struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommitAuthor is the API message for webhook commit author.
+type WebhookCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommit is the API message for webhook commit.
+type WebhookCommit struct {
+	ID        string              `json:"id"`
+	Distinct  bool                `json:"distinct"`
+	Message   string              `json:"message"`
+	Timestamp string              `json:"timestamp"`
+	URL       string              `json:"url"`
+	Author    WebhookCommitAuthor `json:"author"`
+	Added     []string            `json:"added"`
+}
+
+// WebhookPushEvent is the API message for webhook push event.
+type WebhookPushEvent struct {
+	Ref        string            `json:"ref"`
+	Repository WebhookRepository `json:"repository"`
+	Pusher     WebhookPusher     `json:"pusher"`
+	Commits    []WebhookCommit   `json:"commits"`
+	// Deleted is true if the push deletes the ref.
+	Deleted bool `json:"deleted"`
+}
+
+// Repository represents a GitHub API response for a repository.
+type Repository struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	HTMLURL     string `json:"html_url"`
+	Permissions struct {
+		Admin bool `json:"admin"`
+	} `json:"permissions"`
+}
+
+// FileCommit represents a GitHub API request for creating or updating a repository file.
+type FileCommit struct {
+	Message string `json:"message"`
+	// Content is the base64 encoded file content.
+	Content string `json:"content"`
+	Branch  string `json:"branch,omitempty"`
+	// SHA is the blob SHA of the file being replaced, required when updating a file.
+	SHA string `json:"sha,omitempty"`
+}
+
+// File represents a GitHub API response for a repository file.
+type File struct {
+	Encoding string `json:"encoding"`
+	Size     int64  `json:"size"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	SHA      string `json:"sha"`
 }
 
 // User represents a GitHub API response for a user.
@@ -112,8 +230,8 @@ type Commit struct {
 }
 
 // FetchCommitByID fetches the commit data by its ID from the repository.
-func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, commitID string) (*vcs.Commit, error) {
-	url := fmt.Sprintf("%s/repos/%s/git/commits/%s", apiURL, repositoryID, commitID)
+func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*vcs.Commit, error) {
+	url := fmt.Sprintf("%s/repos/%s/git/commits/%s", p.APIURL(instanceURL), repositoryID, commitID)
 	code, body, err := oauth.Get(
 		ctx,
 		p.client,
@@ -217,9 +335,80 @@ func (p *Provider) ExchangeOAuthToken(ctx context.Context, _ string, oauthExchan
 	return oauthResp.toVCSOAuthToken(), nil
 }
 
-// FetchAllRepositoryList fetches all repositories where the authenticated user has a maintainer role.
+// FetchAllRepositoryList fetches all repositories where the authenticated user has an admin role.
 func (p *Provider) FetchAllRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string) ([]*vcs.Repository, error) {
-	return nil, errors.New("not implemented yet")
+	repoList := []*vcs.Repository{}
+	// page is the current requesting page index, starts from 1.
+	// perPage is the number of items to list per page (default: 30, max: 100).
+	// refer: https://docs.github.com/en/rest/guides/traversing-with-pagination
+	page := 1
+	perPage := 100
+
+	for {
+		temp, count, err := p.fetchOffsetRepositoryList(ctx, oauthCtx, instanceURL, page, perPage)
+		if err != nil {
+			return nil, err
+		}
+
+		repoList = append(repoList, temp...)
+		if count < perPage {
+			break
+		}
+		page++
+	}
+
+	return repoList, nil
+}
+
+// fetchOffsetRepositoryList fetches repositories at page `page` with `perPage` items per page.
+// It returns the repositories the authenticated user is an admin of, along with the number of
+// repositories on the page before filtering.
+func (p *Provider) fetchOffsetRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string, page, perPage int) ([]*vcs.Repository, int, error) {
+	requestURL := fmt.Sprintf("%s/user/repos?page=%d&per_page=%d", p.APIURL(instanceURL), page, perPage)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		return nil, 0, common.Errorf(common.NotFound, errors.New("failed to fetch repository list from GitHub.com, not found"))
+	} else if code >= 300 {
+		return nil, 0, fmt.Errorf("failed to fetch repository list from GitHub.com, status code: %d, body: %s", code, body)
+	}
+
+	var githubRepos []Repository
+	if err := json.Unmarshal([]byte(body), &githubRepos); err != nil {
+		return nil, 0, errors.Wrap(err, "unmarshal")
+	}
+
+	var repos []*vcs.Repository
+	for _, r := range githubRepos {
+		// We will use user's token to create webhook in the repository, which requires the
+		// token owner to be the repository admin.
+		if !r.Permissions.Admin {
+			continue
+		}
+		repos = append(repos, &vcs.Repository{
+			ID:       r.ID,
+			Name:     r.Name,
+			FullPath: r.FullName,
+			WebURL:   r.HTMLURL,
+		})
+	}
+	return repos, len(githubRepos), nil
 }
 
 // FetchRepositoryFileList fetch the files from repository tree
@@ -229,37 +418,278 @@ func (p *Provider) FetchRepositoryFileList(ctx context.Context, oauthCtx common.
 
 // CreateFile creates a file.
 func (p *Provider) CreateFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommit vcs.FileCommitCreate) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	return p.putFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, FileCommit{
+		Message: fileCommit.CommitMessage,
+		Content: base64.StdEncoding.EncodeToString([]byte(fileCommit.Content)),
+		Branch:  fileCommit.Branch,
+	})
 }
 
 // OverwriteFile overwrite the content of a file.
+//
+// GitHub detects conflicting writes by the blob SHA of the file instead of the
+// last commit ID, so we check the last commit ID of the file ourselves before
+// overwriting it with the blob SHA we read.
 func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommit vcs.FileCommitCreate) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	file, err := p.readFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, fileCommit.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s from GitHub repository %s before overwriting: %w", filePath, repositoryID, err)
+	}
+	if fileCommit.LastCommitID != "" {
+		lastCommitID, err := p.fetchLastCommitID(ctx, oauthCtx, instanceURL, repositoryID, filePath, fileCommit.Branch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch last commit of file %s from GitHub repository %s before overwriting: %w", filePath, repositoryID, err)
+		}
+		if lastCommitID != fileCommit.LastCommitID {
+			return fmt.Errorf("failed to overwrite file %s in GitHub repository %s, the file has been changed by commit %s since commit %s", filePath, repositoryID, lastCommitID, fileCommit.LastCommitID)
+		}
+	}
+
+	return p.putFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, FileCommit{
+		Message: fileCommit.CommitMessage,
+		Content: base64.StdEncoding.EncodeToString([]byte(fileCommit.Content)),
+		Branch:  fileCommit.Branch,
+		SHA:     file.SHA,
+	})
 }
 
 // ReadFileMeta reads the file metadata.
 func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
-	return nil, errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	file, err := p.readFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file metadata %s from GitHub repository %s: %w", filePath, repositoryID, err)
+	}
+
+	// The contents API only returns the blob SHA, the last commit ID has to be fetched separately.
+	lastCommitID, err := p.fetchLastCommitID(ctx, oauthCtx, instanceURL, repositoryID, filePath, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file metadata %s from GitHub repository %s: %w", filePath, repositoryID, err)
+	}
+
+	return &vcs.FileMeta{
+		Name:         file.Name,
+		Path:         file.Path,
+		Size:         file.Size,
+		LastCommitID: lastCommitID,
+	}, nil
 }
 
 // ReadFileContent reads the file content.
 func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
-	return "", errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	file, err := p.readFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file content %s from GitHub repository %s: %w", filePath, repositoryID, err)
+	}
+	return file.Content, nil
+}
+
+// readFile reads the file data including metadata and the decoded content.
+func (p *Provider) readFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*File, error) {
+	requestURL := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", p.APIURL(instanceURL), repositoryID, escapePath(filePath), url.QueryEscape(ref))
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to read file %s from GitHub repository %s, not found", filePath, repositoryID))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to read file %s from GitHub repository %s, status code: %d, body: %s", filePath, repositoryID, code, body)
+	}
+
+	file := &File{}
+	if err := json.Unmarshal([]byte(body), file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	if file.Encoding == "base64" {
+		// GitHub wraps the base64 encoded content into lines of 60 characters.
+		content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode file content")
+		}
+		file.Content = string(content)
+	}
+	return file, nil
+}
+
+// fetchLastCommitID fetches the ID of the last commit touching the file on the ref.
+func (p *Provider) fetchLastCommitID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
+	requestURL := fmt.Sprintf("%s/repos/%s/commits?path=%s&sha=%s&per_page=1", p.APIURL(instanceURL), repositoryID, url.QueryEscape(filePath), url.QueryEscape(ref))
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commits of file %s from GitHub repository %s, not found", filePath, repositoryID))
+	} else if code >= 300 {
+		return "", fmt.Errorf("failed to fetch commits of file %s from GitHub repository %s, status code: %d, body: %s", filePath, repositoryID, code, body)
+	}
+
+	var commits []Commit
+	if err := json.Unmarshal([]byte(body), &commits); err != nil {
+		return "", errors.Wrap(err, "unmarshal")
+	}
+	if len(commits) == 0 {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commits of file %s from GitHub repository %s, no commit found", filePath, repositoryID))
+	}
+	return commits[0].SHA, nil
+}
+
+// putFile creates or updates a file with the GitHub contents API.
+func (p *Provider) putFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommit FileCommit) error {
+	body, err := json.Marshal(fileCommit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal file commit: %w", err)
+	}
+
+	requestURL := fmt.Sprintf("%s/repos/%s/contents/%s", p.APIURL(instanceURL), repositoryID, escapePath(filePath))
+	code, respBody, err := oauth.Put(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write file %s to GitHub repository %s: %w", filePath, repositoryID, err)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to write file %s to GitHub repository %s, status code: %d, body: %s", filePath, repositoryID, code, respBody)
+	}
+	return nil
+}
+
+// escapePath escapes each segment of the file path to be used in the URL path.
+func escapePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
 
-// CreateWebhook creates a webhook in a GitLab project.
+// CreateWebhook creates a webhook in a GitHub repository.
 func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, payload []byte) (string, error) {
-	return "", errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	requestURL := fmt.Sprintf("%s/repos/%s/hooks", p.APIURL(instanceURL), repositoryID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create webhook for repository %s from GitHub: %w", repositoryID, err)
+	}
+
+	if code >= 300 {
+		return "", fmt.Errorf("failed to create webhook for repository %s from GitHub, status code: %d, body: %s", repositoryID, code, body)
+	}
+
+	webhookInfo := &WebhookInfo{}
+	if err := json.Unmarshal([]byte(body), webhookInfo); err != nil {
+		return "", fmt.Errorf("failed to unmarshal create webhook response for repository %s from GitHub: %w", repositoryID, err)
+	}
+	return strconv.Itoa(webhookInfo.ID), nil
 }
 
-// PatchWebhook patches a webhook in a GitLab project.
+// PatchWebhook patches a webhook in a GitHub repository.
 func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string, payload []byte) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	requestURL := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to patch webhook ID %s for repository %s from GitHub: %w", webhookID, repositoryID, err)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to patch webhook ID %s for repository %s from GitHub, status code: %d, body: %s", webhookID, repositoryID, code, body)
+	}
+	return nil
 }
 
-// DeleteWebhook deletes a webhook in a GitLab project.
+// DeleteWebhook deletes a webhook in a GitHub repository.
 func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/bytebase/bytebase/issues/928
+	requestURL := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, body, err := oauth.Delete(
+		ctx,
+		p.client,
+		requestURL,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook ID %s for repository %s from GitHub: %w", webhookID, repositoryID, err)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to delete webhook ID %s for repository %s from GitHub, status code: %d, body: %s", webhookID, repositoryID, code, body)
+	}
+	return nil
 }
 
 // oauthContext is the request context for refreshing oauth token.
@@ -53,10 +53,13 @@ func aclMiddleware(s *Server, ce *casbin.Enforcer, next echo.HandlerFunc, readon
 		if member.RowStatus == api.Archived {
 			return echo.NewHTTPError(http.StatusUnauthorized, "This user has been deactivated by the admin")
 		}
+		if isAPITokenReadOnly(c) && method != "GET" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "The API token is read-only")
+		}
 
-		// If the request is trying to GET/PATCH/DELETE itself, we will change the method signature to
+		// If the request is trying to GET/POST/PATCH/DELETE itself, we will change the method signature to
 		// XXX_SELF so that the policy can differentiate between XXX and XXX_SELF
-		if method == "GET" || method == "PATCH" || method == "DELETE" {
+		if method == "GET" || method == "POST" || method == "PATCH" || method == "DELETE" {
 			if isSelf, err := isOperatingSelf(ctx, c, s, principalID, method); err != nil {
 				return err
 			} else if isSelf {
@@ -94,6 +97,8 @@ func isOperatingSelf(ctx context.Context, c echo.Context, s *Server, curPrincipa
 	switch method {
 	case http.MethodGet:
 		return isGettingSelf(ctx, c, s, curPrincipalID)
+	case http.MethodPost:
+		return isCreatingSelf(ctx, c, s, curPrincipalID)
 	case http.MethodPatch, http.MethodDelete:
 		return isUpdatingSelf(ctx, c, s, curPrincipalID)
 	default:
@@ -116,6 +121,16 @@ func isGettingSelf(_ context.Context, c echo.Context, _ *Server, curPrincipalID
 		}
 
 		return userID == curPrincipalID, nil
+	} else if strings.HasPrefix(c.Path(), "/api/principal/:principalID/token") {
+		return c.Param("principalID") == strconv.Itoa(curPrincipalID), nil
+	}
+
+	return false, nil
+}
+
+func isCreatingSelf(_ context.Context, c echo.Context, _ *Server, curPrincipalID int) (bool, error) {
+	if strings.HasPrefix(c.Path(), "/api/principal/:principalID/token") {
+		return c.Param("principalID") == strconv.Itoa(curPrincipalID), nil
 	}
 
 	return false, nil
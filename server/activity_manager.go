@@ -202,6 +202,15 @@ func (m *ActivityManager) getWebhookContext(ctx context.Context, activity *api.A
 		default:
 			title = "Updated issue"
 		}
+	case api.ActivityPipelineTaskApprove:
+		approve := &api.ActivityPipelineTaskApprovePayload{}
+		if err := json.Unmarshal([]byte(activity.Payload), approve); err != nil {
+			log.Warn("Failed to post webhook event after approving the issue task, failed to unmarshal payload",
+				zap.String("issue_name", meta.issue.Name),
+				zap.Error(err))
+			return webhookCtx, err
+		}
+		title = fmt.Sprintf("Task approval step %d/%d (%d/%d approvals) - %s", approve.Step, approve.StepCount, approve.ApprovalCount, approve.RequiredApprovalCount, approve.TaskName)
 	case api.ActivityPipelineTaskStatusUpdate:
 		update := &api.ActivityPipelineTaskStatusUpdatePayload{}
 		if err := json.Unmarshal([]byte(activity.Payload), update); err != nil {
@@ -245,6 +254,9 @@ func (m *ActivityManager) getWebhookContext(ctx context.Context, activity *api.A
 		case api.TaskFailed:
 			level = webhook.WebhookError
 			title = "Task failed - " + task.Name
+		case api.TaskCanceled:
+			level = webhook.WebhookWarn
+			title = "Task canceled - " + task.Name
 		}
 	}
 
@@ -286,6 +298,8 @@ func shouldPostInbox(activity *api.Activity, createType api.ActivityType) (bool,
 		return true, nil
 	case api.ActivityPipelineTaskEarliestAllowedTimeUpdate:
 		return true, nil
+	case api.ActivityPipelineTaskApprove:
+		return true, nil
 	case api.ActivityPipelineTaskStatusUpdate:
 		update := new(api.ActivityPipelineTaskStatusUpdatePayload)
 		if err := json.Unmarshal([]byte(activity.Payload), update); err != nil {
@@ -455,8 +455,10 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 			// Ignore if backup setting has been changed after the max age.
 			if backupSetting.UpdatedTs < time.Now().Add(-backupMaxAge).Unix() {
 				status := api.BackupStatusDone
+				rowStatus := api.Normal
 				backupFind := &api.BackupFind{
 					DatabaseID: &database.ID,
+					RowStatus:  &rowStatus,
 					Status:     &status,
 				}
 				backupList, err := s.server.store.FindBackup(ctx, backupFind)
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonapi"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/store"
+)
+
+const (
+	// apiTokenByteLength is the number of the random bytes in an API token.
+	apiTokenByteLength = 32
+	// apiTokenLastUsedInterval is the interval to update the last used time of an API token,
+	// so that we don't write to the database on every request.
+	apiTokenLastUsedInterval = 1 * time.Minute
+	// bearerPrefix is the prefix of the Authorization header carrying a bearer token.
+	bearerPrefix = "Bearer "
+)
+
+func (s *Server) registerAPITokenRoutes(g *echo.Group) {
+	g.POST("/principal/:principalID/token", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		// Otherwise, a leaked API token could mint new tokens which survive its revocation.
+		if isAPITokenRequest(c) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "API token can't be used to create API tokens, please sign in to create one")
+		}
+		principalID, err := strconv.Atoi(c.Param("principalID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("principalID"))).SetInternal(err)
+		}
+		principal, member, httpErr := s.getAPITokenPrincipal(ctx, principalID)
+		if httpErr != nil {
+			return httpErr
+		}
+
+		apiTokenCreate := &api.APITokenCreate{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, apiTokenCreate); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create API token request").SetInternal(err)
+		}
+		if apiTokenCreate.Name == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "API token name must not be empty")
+		}
+		if apiTokenCreate.Scope.String() == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid API token scope: %q", apiTokenCreate.Scope))
+		}
+		if apiTokenCreate.ExpiresTs != 0 && apiTokenCreate.ExpiresTs <= time.Now().Unix() {
+			return echo.NewHTTPError(http.StatusBadRequest, "API token expiration time must be in the future")
+		}
+
+		token, tokenHash, err := generateAPIToken()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate API token").SetInternal(err)
+		}
+		apiTokenCreate.CreatorID = c.Get(getPrincipalIDContextKey()).(int)
+		apiTokenCreate.PrincipalID = principalID
+		apiTokenCreate.TokenHash = tokenHash
+
+		apiToken, err := s.store.CreateAPIToken(ctx, apiTokenCreate)
+		if err != nil {
+			if common.ErrorCode(err) == common.NotImplemented {
+				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err)).SetInternal(err)
+			}
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create API token").SetInternal(err)
+		}
+		// The token is only returned once, since we only store its hash.
+		apiToken.Token = token
+
+		if err := s.createAPITokenActivity(ctx, api.ActivityMemberAPITokenCreate, apiTokenCreate.CreatorID, principal, member, apiToken); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create activity after creating API token: %d", apiToken.ID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiToken); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal create API token response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/principal/:principalID/token", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principalID, err := strconv.Atoi(c.Param("principalID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("principalID"))).SetInternal(err)
+		}
+
+		rowStatus := api.Normal
+		apiTokenList, err := s.store.FindAPIToken(ctx, &api.APITokenFind{
+			PrincipalID: &principalID,
+			RowStatus:   &rowStatus,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch API token list for user ID: %d", principalID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiTokenList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal API token list response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.DELETE("/principal/:principalID/token/:tokenID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principalID, err := strconv.Atoi(c.Param("principalID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("principalID"))).SetInternal(err)
+		}
+		tokenID, err := strconv.Atoi(c.Param("tokenID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Token ID is not a number: %s", c.Param("tokenID"))).SetInternal(err)
+		}
+		principal, member, httpErr := s.getAPITokenPrincipal(ctx, principalID)
+		if httpErr != nil {
+			return httpErr
+		}
+
+		apiToken, err := s.store.GetAPITokenByID(ctx, tokenID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch API token ID: %d", tokenID)).SetInternal(err)
+		}
+		if apiToken == nil || apiToken.PrincipalID != principalID {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("API token ID not found: %d", tokenID))
+		}
+		if apiToken.RowStatus == api.Archived {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("API token ID has already been revoked: %d", tokenID))
+		}
+
+		// Revoke the token by archiving it, so that we still know the token in the audit activities.
+		updaterID := c.Get(getPrincipalIDContextKey()).(int)
+		rowStatus := string(api.Archived)
+		apiToken, err = s.store.PatchAPIToken(ctx, &api.APITokenPatch{
+			ID:        tokenID,
+			UpdaterID: updaterID,
+			RowStatus: &rowStatus,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to revoke API token ID: %d", tokenID)).SetInternal(err)
+		}
+
+		if err := s.createAPITokenActivity(ctx, api.ActivityMemberAPITokenRevoke, updaterID, principal, member, apiToken); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create activity after revoking API token: %d", apiToken.ID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiToken); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal revoke API token response").SetInternal(err)
+		}
+		return nil
+	})
+}
+
+// getAPITokenPrincipal gets the principal owning the API tokens and its member.
+func (s *Server) getAPITokenPrincipal(ctx context.Context, principalID int) (*api.Principal, *api.Member, *echo.HTTPError) {
+	principal, err := s.store.GetPrincipalByID(ctx, principalID)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch principal ID: %v", principalID)).SetInternal(err)
+	}
+	if principal == nil {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("User ID not found: %d", principalID))
+	}
+	if principal.Type != api.EndUser && principal.Type != api.ServiceAccount {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("User ID cannot own API tokens: %d", principalID))
+	}
+	member, err := s.store.GetMemberByPrincipalID(ctx, principalID)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch member for user ID: %d", principalID)).SetInternal(err)
+	}
+	if member == nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("User ID is not a member: %d", principalID))
+	}
+	return principal, member, nil
+}
+
+// createAPITokenActivity records the audit activity for creating or revoking an API token.
+func (s *Server) createAPITokenActivity(ctx context.Context, activityType api.ActivityType, creatorID int, principal *api.Principal, member *api.Member, apiToken *api.APIToken) error {
+	bytes, err := json.Marshal(api.ActivityMemberAPITokenCreateRevokePayload{
+		PrincipalID:    principal.ID,
+		PrincipalName:  principal.Name,
+		PrincipalEmail: principal.Email,
+		TokenID:        apiToken.ID,
+		TokenName:      apiToken.Name,
+		Scope:          apiToken.Scope,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to construct activity payload: %w", err)
+	}
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   creatorID,
+		ContainerID: member.ID,
+		Type:        activityType,
+		Level:       api.ActivityInfo,
+		Payload:     string(bytes),
+	}
+	if _, err := s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// generateAPIToken generates a random API token and its hash to store.
+func generateAPIToken() (string, string, error) {
+	b := make([]byte, apiTokenByteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := api.APITokenPrefix + hex.EncodeToString(b)
+	return token, hashAPIToken(token), nil
+}
+
+// hashAPIToken hashes the API token.
+// The token has enough entropy, so we use a fast hash instead of a password hash like bcrypt to look it up on every request.
+func hashAPIToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// getBearerToken returns the bearer token in the Authorization header, or an empty string if there isn't one.
+func getBearerToken(c echo.Context) string {
+	authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(authorization, bearerPrefix))
+}
+
+// authenticateAPIToken validates the API token and returns it.
+func authenticateAPIToken(ctx context.Context, principalStore *store.Store, token string) (*api.APIToken, error) {
+	if !strings.HasPrefix(token, api.APITokenPrefix) {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid API token")
+	}
+	apiToken, err := principalStore.GetAPITokenByHash(ctx, hashAPIToken(token))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Server error to find API token").SetInternal(err)
+	}
+	if apiToken == nil || apiToken.RowStatus != api.Normal {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or revoked API token")
+	}
+	now := time.Now()
+	if apiToken.ExpiresTs != 0 && apiToken.ExpiresTs <= now.Unix() {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Expired API token")
+	}
+
+	// Make sure the user still exists.
+	user, err := principalStore.GetPrincipalByID(ctx, apiToken.PrincipalID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Server error to find user ID: %d", apiToken.PrincipalID)).SetInternal(err)
+	}
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Failed to find user ID: %d", apiToken.PrincipalID))
+	}
+
+	if now.Sub(time.Unix(apiToken.LastUsedTs, 0)) >= apiTokenLastUsedInterval {
+		lastUsedTs := now.Unix()
+		if _, err := principalStore.PatchAPIToken(ctx, &api.APITokenPatch{
+			ID:         apiToken.ID,
+			UpdaterID:  api.SystemBotID,
+			LastUsedTs: &lastUsedTs,
+		}); err != nil {
+			// Failing to record the last used time shouldn't fail the request.
+			log.Warn("Failed to update the last used time of API token",
+				zap.Int("id", apiToken.ID),
+				zap.Error(err))
+		}
+	}
+	return apiToken, nil
+}
+
+// isAPITokenRequest returns true if the request is authenticated by an API token instead of the cookie session.
+func isAPITokenRequest(c echo.Context) bool {
+	_, ok := c.Get(getAPITokenScopeContextKey()).(api.APITokenScope)
+	return ok
+}
+
+// isAPITokenReadOnly returns true if the request is authenticated by a READ_ONLY API token.
+func isAPITokenReadOnly(c echo.Context) bool {
+	scope, ok := c.Get(getAPITokenScopeContextKey()).(api.APITokenScope)
+	return ok && scope == api.APITokenScopeReadOnly
+}
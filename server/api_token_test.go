@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestGenerateAPIToken(t *testing.T) {
+	a := require.New(t)
+	token, tokenHash, err := generateAPIToken()
+	a.NoError(err)
+	a.True(strings.HasPrefix(token, api.APITokenPrefix))
+	a.Equal(len(api.APITokenPrefix)+2*apiTokenByteLength, len(token))
+	a.Equal(hashAPIToken(token), tokenHash)
+	a.NotContains(tokenHash, token)
+
+	another, anotherHash, err := generateAPIToken()
+	a.NoError(err)
+	a.NotEqual(token, another)
+	a.NotEqual(tokenHash, anotherHash)
+}
+
+func TestGetBearerToken(t *testing.T) {
+	tests := []struct {
+		authorization string
+		want          string
+	}{
+		{
+			authorization: "",
+			want:          "",
+		},
+		{
+			authorization: "Bearer bbp_123",
+			want:          "bbp_123",
+		},
+		{
+			authorization: "Bearer  bbp_123 ",
+			want:          "bbp_123",
+		},
+		{
+			authorization: "Basic dXNlcjpwYXNz",
+			want:          "",
+		},
+	}
+
+	e := echo.New()
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/project", nil)
+		if test.authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, test.authorization)
+		}
+		c := e.NewContext(req, httptest.NewRecorder())
+		got := getBearerToken(c)
+		if got != test.want {
+			t.Errorf("getBearerToken(%q) = %q, want %q", test.authorization, got, test.want)
+		}
+	}
+}
+
+func TestIsAPITokenRequest(t *testing.T) {
+	a := require.New(t)
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/principal/101/token", nil), httptest.NewRecorder())
+	a.False(isAPITokenRequest(c))
+	a.False(isAPITokenReadOnly(c))
+
+	c.Set(getAPITokenScopeContextKey(), api.APITokenScopeReadWrite)
+	a.True(isAPITokenRequest(c))
+	a.False(isAPITokenReadOnly(c))
+
+	c.Set(getAPITokenScopeContextKey(), api.APITokenScopeReadOnly)
+	a.True(isAPITokenRequest(c))
+	a.True(isAPITokenReadOnly(c))
+}
@@ -46,6 +46,33 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/auth/provider/oidc", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		config, err := s.getOIDCConfig(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch OIDC config").SetInternal(err)
+		}
+		if config == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "OIDC auth provider is not configured")
+		}
+		provider, err := newOIDCProvider(ctx, config)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to discover OIDC identity provider").SetInternal(err)
+		}
+
+		oidcAuthProvider := &api.OIDCAuthProvider{
+			AuthorizationEndpoint: provider.Metadata().AuthorizationEndpoint,
+			ClientID:              config.ClientID,
+			Scope:                 provider.Scope(),
+			// we do not return secret to the frontend for safety concern
+		}
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, oidcAuthProvider); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal OIDC auth provider").SetInternal(err)
+		}
+		return nil
+	})
+
 	g.POST("/auth/login/:auth_provider", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		var user *api.Principal
@@ -67,6 +94,9 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 				if user == nil {
 					return echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("User not found: %s", login.Email))
 				}
+				if user.Type == api.ServiceAccount {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Service account can only authenticate with API tokens")
+				}
 
 				// Compare the stored hashed password, with the hashed version of the password that was received.
 				if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(login.Password)); err != nil {
@@ -88,16 +118,6 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("vcs do not exist, name: %v, ID: %v", gitlabLogin.Name, gitlabLogin.Name)).SetInternal(err)
 				}
 
-				// We need to attach the RedirectURL in the get token process of oauth,
-				// and the RedirectURL needs to be consistent with the RedirectURL in the get code process.
-				// The frontend get it through window.location.origin in the get code process,
-				// so port 80 needs to be cropped when the backend splices the RedirectURL.
-				var redirectURL string
-				if s.profile.FrontendPort == 80 {
-					redirectURL = fmt.Sprintf("%s/oauth/callback", s.profile.FrontendHost)
-				} else {
-					redirectURL = fmt.Sprintf("%s:%d/oauth/callback", s.profile.FrontendHost, s.profile.FrontendPort)
-				}
 				// exchange OAuth Token
 				oauthToken, err := vcs.Get(vcsFound.Type, vcs.ProviderConfig{}).ExchangeOAuthToken(
 					ctx,
@@ -106,7 +126,7 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 						ClientID:     vcsFound.ApplicationID,
 						ClientSecret: vcsFound.Secret,
 						Code:         gitlabLogin.Code,
-						RedirectURL:  redirectURL,
+						RedirectURL:  s.getOAuthRedirectURL(),
 					},
 				)
 				if err != nil {
@@ -157,6 +177,20 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 					}
 				}
 			}
+		case api.PrincipalAuthProviderOIDC:
+			{
+				oidcLogin := &api.OIDCLogin{}
+				if err := jsonapi.UnmarshalPayload(c.Request().Body, oidcLogin); err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, "Malformed OIDC login request").SetInternal(err)
+				}
+				var httpError *echo.HTTPError
+				user, httpError = s.tryOIDCLogin(ctx, oidcLogin)
+				if httpError != nil {
+					return httpError
+				}
+			}
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported auth provider: %s", authProvider))
 		}
 
 		// test the status of this user
@@ -218,6 +252,12 @@ func (s *Server) registerAuthRoutes(g *echo.Group) {
 }
 
 func trySignUp(ctx context.Context, s *Server, signUp *api.SignUp, creatorID int) (*api.Principal, *echo.HTTPError) {
+	return trySignUpWithRole(ctx, s, signUp, creatorID, "" /* role */)
+}
+
+// trySignUpWithRole signs up the user with the role, or Developer if the role is empty.
+// The user is always granted Owner if there is no existing Owner member.
+func trySignUpWithRole(ctx context.Context, s *Server, signUp *api.SignUp, creatorID int, role api.Role) (*api.Principal, *echo.HTTPError) {
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(signUp.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate password hash").SetInternal(err)
@@ -249,9 +289,10 @@ func trySignUp(ctx context.Context, s *Server, signUp *api.SignUp, creatorID int
 	}
 
 	// Grant the member Owner role if there is no existing Owner member.
-	role := api.Developer
 	if len(memberList) == 0 {
 		role = api.Owner
+	} else if role == "" {
+		role = api.Developer
 	}
 	memberCreate := &api.MemberCreate{
 		CreatorID:   creatorID,
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	restoremysql "github.com/bytebase/bytebase/plugin/restore/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	// The backups are taken at most hourly, so there is no need to prune them more often.
+	backupPruneInterval = time.Duration(1) * time.Hour
+)
+
+// NewBackupPruner creates a new backup pruner.
+func NewBackupPruner(server *Server) *BackupPruner {
+	return &BackupPruner{
+		server: server,
+	}
+}
+
+// BackupPruner is the backup pruner deleting the backups expired by the retention rule of the backup plan policy.
+type BackupPruner struct {
+	server *Server
+}
+
+// Run is the runner for backup pruner.
+func (s *BackupPruner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(backupPruneInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug("Backup pruner started", zap.Duration("interval", backupPruneInterval))
+	for {
+		select {
+		case <-ticker.C:
+			log.Debug("New backup prune round started...")
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = fmt.Errorf("%v", r)
+						}
+						log.Error("Backup pruner PANIC RECOVER", zap.Error(err))
+					}
+				}()
+
+				s.prune(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// prune prunes the expired backups of all the databases whose environment has the retention rule.
+func (s *BackupPruner) prune(ctx context.Context) {
+	envList, err := s.server.store.FindEnvironment(ctx, &api.EnvironmentFind{})
+	if err != nil {
+		log.Error("Failed to retrieve environment list", zap.Error(err))
+		return
+	}
+
+	for _, env := range envList {
+		policy, err := s.server.store.GetBackupPlanPolicyByEnvID(ctx, env.ID)
+		if err != nil {
+			log.Error("Failed to retrieve backup policy",
+				zap.String("environment", env.Name),
+				zap.Error(err))
+			continue
+		}
+		if policy.Retention == nil {
+			continue
+		}
+
+		instanceList, err := s.server.store.FindInstance(ctx, &api.InstanceFind{EnvironmentID: &env.ID})
+		if err != nil {
+			log.Error("Failed to retrieve instance list",
+				zap.String("environment", env.Name),
+				zap.Error(err))
+			continue
+		}
+		for _, instance := range instanceList {
+			dbList, err := s.server.store.FindDatabase(ctx, &api.DatabaseFind{InstanceID: &instance.ID})
+			if err != nil {
+				log.Error("Failed to retrieve database list",
+					zap.String("instance", instance.Name),
+					zap.Error(err))
+				continue
+			}
+			for _, database := range dbList {
+				if err := s.pruneDatabaseBackup(ctx, database, policy.Retention); err != nil {
+					log.Error("Failed to prune database backups",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// pruneDatabaseBackup deletes the backup files of the database expired by the retention rule, and archives the backups.
+func (s *BackupPruner) pruneDatabaseBackup(ctx context.Context, database *api.Database, retention *api.BackupRetention) error {
+	// Only the successful backups are pruned, the pending ones are being taken and the failed ones don't have backup files.
+	status := api.BackupStatusDone
+	rowStatus := api.Normal
+	backupList, err := s.server.store.FindBackup(ctx, &api.BackupFind{
+		DatabaseID: &database.ID,
+		RowStatus:  &rowStatus,
+		Status:     &status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find backups: %w", err)
+	}
+
+	// PITR restores the latest backup and replays the binlog after it, so we must keep it.
+	pitrBackup, err := restoremysql.GetLatestBackup(backupList)
+	if err != nil {
+		return fmt.Errorf("failed to get the backup for PITR: %w", err)
+	}
+
+	for _, backup := range getExpiredBackupList(backupList, retention, time.Now()) {
+		if pitrBackup != nil && backup.ID == pitrBackup.ID {
+			log.Debug("Skip pruning the backup for PITR",
+				zap.String("database", database.Name),
+				zap.String("backup", backup.Name))
+			continue
+		}
+		if err := s.pruneBackup(ctx, database, backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBackup deletes the backup file and archives the backup.
+func (s *BackupPruner) pruneBackup(ctx context.Context, database *api.Database, backup *api.Backup) error {
+	log.Debug("Prune backup",
+		zap.String("database", database.Name),
+		zap.String("backup", backup.Name))
+
+	backupStorage, err := s.server.getBackupStorage(ctx, backup.StorageBackend, database.Instance.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	// Delete the file before archiving the backup, so that the file is deleted in the next round if we fail in the middle.
+	if err := backupStorage.Delete(ctx, backup.Path); err != nil {
+		return fmt.Errorf("failed to delete backup %q: %w", backup.Name, err)
+	}
+
+	archived := string(api.Archived)
+	if _, err := s.server.store.PatchBackup(ctx, &api.BackupPatch{
+		ID:        backup.ID,
+		UpdaterID: api.SystemBotID,
+		RowStatus: &archived,
+	}); err != nil {
+		return fmt.Errorf("failed to archive backup %q: %w", backup.Name, err)
+	}
+	return nil
+}
+
+// getExpiredBackupList returns the backups which aren't kept by any rule of the retention.
+func getExpiredBackupList(backupList []*api.Backup, retention *api.BackupRetention, now time.Time) []*api.Backup {
+	// Sort the backups from the latest to the earliest.
+	sortedList := make([]*api.Backup, len(backupList))
+	copy(sortedList, backupList)
+	sort.SliceStable(sortedList, func(i, j int) bool {
+		if sortedList[i].CreatedTs != sortedList[j].CreatedTs {
+			return sortedList[i].CreatedTs > sortedList[j].CreatedTs
+		}
+		return sortedList[i].ID > sortedList[j].ID
+	})
+
+	keepSinceTs := now.AddDate(0, 0, -retention.KeepDays).Unix()
+	weekCount, monthCount := 0, 0
+	lastWeek, lastMonth := "", ""
+	var expiredList []*api.Backup
+	for i, backup := range sortedList {
+		keep := false
+		if i < retention.KeepLast {
+			keep = true
+		}
+		if retention.KeepDays > 0 && backup.CreatedTs >= keepSinceTs {
+			keep = true
+		}
+
+		// The first backup met of a week or month is its last backup.
+		createdTime := time.Unix(backup.CreatedTs, 0).UTC()
+		year, week := createdTime.ISOWeek()
+		if weekKey := fmt.Sprintf("%d-W%d", year, week); weekKey != lastWeek {
+			lastWeek = weekKey
+			if weekCount < retention.KeepWeekly {
+				weekCount++
+				keep = true
+			}
+		}
+		if monthKey := createdTime.Format("2006-01"); monthKey != lastMonth {
+			lastMonth = monthKey
+			if monthCount < retention.KeepMonthly {
+				monthCount++
+				keep = true
+			}
+		}
+
+		if !keep {
+			expiredList = append(expiredList, backup)
+		}
+	}
+	return expiredList
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExpiredBackupList(t *testing.T) {
+	now := time.Date(2022, time.June, 15, 12, 0, 0, 0, time.UTC)
+	// One backup per day from 2022-03-01 to 2022-06-15, with ID increasing with the creation time.
+	var backupList []*api.Backup
+	for ts, id := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC), 1; !ts.After(now); ts, id = ts.AddDate(0, 0, 1), id+1 {
+		backupList = append(backupList, &api.Backup{ID: id, CreatedTs: ts.Unix()})
+	}
+	backupID := func(ts time.Time) int {
+		for _, backup := range backupList {
+			if backup.CreatedTs == ts.Unix() {
+				return backup.ID
+			}
+		}
+		return 0
+	}
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2022, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		retention *api.BackupRetention
+		// keptList is the creation time of the backups kept.
+		keptList []time.Time
+	}{
+		{
+			name:      "keep last",
+			retention: &api.BackupRetention{KeepLast: 2},
+			keptList:  []time.Time{day(time.June, 15), day(time.June, 14)},
+		},
+		{
+			name:      "keep days",
+			retention: &api.BackupRetention{KeepDays: 2},
+			keptList:  []time.Time{day(time.June, 15), day(time.June, 14), day(time.June, 13)},
+		},
+		{
+			name:      "keep weekly",
+			retention: &api.BackupRetention{KeepWeekly: 3},
+			// 2022-06-15 is Wednesday, and the last backups of the previous weeks are taken on Sunday.
+			keptList: []time.Time{day(time.June, 15), day(time.June, 12), day(time.June, 5)},
+		},
+		{
+			name:      "keep monthly",
+			retention: &api.BackupRetention{KeepMonthly: 3},
+			keptList:  []time.Time{day(time.June, 15), day(time.May, 31), day(time.April, 30)},
+		},
+		{
+			name:      "combined",
+			retention: &api.BackupRetention{KeepLast: 1, KeepWeekly: 2, KeepMonthly: 2},
+			keptList:  []time.Time{day(time.June, 15), day(time.June, 12), day(time.May, 31)},
+		},
+	}
+
+	for _, test := range tests {
+		keptIDSet := map[int]bool{}
+		for _, ts := range test.keptList {
+			keptIDSet[backupID(ts)] = true
+		}
+		var wantIDList []int
+		for _, backup := range backupList {
+			if !keptIDSet[backup.ID] {
+				wantIDList = append(wantIDList, backup.ID)
+			}
+		}
+
+		var gotIDList []int
+		for _, backup := range getExpiredBackupList(backupList, test.retention, now) {
+			gotIDList = append(gotIDList, backup.ID)
+		}
+		assert.ElementsMatch(t, wantIDList, gotIDList, test.name)
+	}
+}
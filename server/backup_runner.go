@@ -117,7 +117,8 @@ func (s *BackupRunner) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 func (s *BackupRunner) scheduleBackupTask(ctx context.Context, database *api.Database, backupName string) error {
 	path := getBackupRelativeFilePath(database.ID, backupName)
-	if err := createBackupDirectory(s.server.profile.DataDir, database.ID); err != nil {
+	storageBackend, err := s.server.getBackupStorageBackend(ctx, database.Instance.EnvironmentID)
+	if err != nil {
 		return err
 	}
 
@@ -147,7 +148,7 @@ func (s *BackupRunner) scheduleBackupTask(ctx context.Context, database *api.Dat
 		Name:                    backupName,
 		Type:                    api.BackupTypeAutomatic,
 		MigrationHistoryVersion: migrationHistoryVersion,
-		StorageBackend:          api.BackupStorageBackendLocal,
+		StorageBackend:          storageBackend,
 		Path:                    path,
 	}
 	backupNew, err := s.server.store.CreateBackup(ctx, backupCreate)
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/storage"
+	"github.com/bytebase/bytebase/plugin/storage/local"
+	"github.com/bytebase/bytebase/plugin/storage/s3"
+)
+
+// getBackupStorageBackend returns the storage backend of the new backups taken in the environment.
+func (s *Server) getBackupStorageBackend(ctx context.Context, environmentID int) (api.BackupStorageBackend, error) {
+	policy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, environmentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get backup storage policy of environment %d: %w", environmentID, err)
+	}
+	return policy.StorageBackend, nil
+}
+
+// getBackupStorage returns the storage of the backups stored in the backend, which are taken in the environment.
+func (s *Server) getBackupStorage(ctx context.Context, backend api.BackupStorageBackend, environmentID int) (storage.Storage, error) {
+	switch backend {
+	case api.BackupStorageBackendLocal:
+		return local.New(s.profile.DataDir), nil
+	case api.BackupStorageBackendS3:
+		policy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, environmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup storage policy of environment %d: %w", environmentID, err)
+		}
+		// We don't require the policy to still use S3, so that the existing backups can be restored after switching back to local storage.
+		if policy.S3 == nil {
+			return nil, fmt.Errorf("S3 backup storage isn't configured for environment %d", environmentID)
+		}
+		return s3.New(s3.Config{
+			Endpoint:        policy.S3.Endpoint,
+			Region:          policy.S3.Region,
+			Bucket:          policy.S3.Bucket,
+			Prefix:          policy.S3.Prefix,
+			AccessKeyID:     policy.S3.AccessKeyID,
+			SecretAccessKey: policy.S3.SecretAccessKey,
+			UsePathStyle:    policy.S3.UsePathStyle,
+		})
+	}
+	return nil, fmt.Errorf("unsupported backup storage backend %q", backend)
+}
@@ -57,6 +57,9 @@ type Profile struct {
 	PgURL string
 	// MetricConnectionKey is the connection key for metric.
 	MetricConnectionKey string
+	// MasterKey is the key encrypting the data key of the credentials such as data source passwords.
+	// The credentials are stored in plaintext if it's empty.
+	MasterKey []byte
 }
 
 func (prof *Profile) useEmbedDB() bool {
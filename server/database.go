@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/jsonapi"
 	"github.com/labstack/echo/v4"
@@ -16,6 +17,8 @@ import (
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/diff"
+	"github.com/bytebase/bytebase/plugin/secret"
 )
 
 func (s *Server) registerDatabaseRoutes(g *echo.Group) {
@@ -428,6 +431,36 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		}
 		table.IndexList = indexList
 
+		foreignKeyFind := &api.ForeignKeyFind{
+			DatabaseID: &id,
+			TableID:    &table.ID,
+		}
+		foreignKeyList, err := s.store.FindForeignKey(ctx, foreignKeyFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch foreign key list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
+		}
+		table.ForeignKeyList = foreignKeyList
+
+		checkConstraintFind := &api.CheckConstraintFind{
+			DatabaseID: &id,
+			TableID:    &table.ID,
+		}
+		checkConstraintList, err := s.store.FindCheckConstraint(ctx, checkConstraintFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch check constraint list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
+		}
+		table.CheckConstraintList = checkConstraintList
+
+		triggerFind := &api.TriggerFind{
+			DatabaseID: &id,
+			TableID:    &table.ID,
+		}
+		triggerList, err := s.store.FindTrigger(ctx, triggerFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch trigger list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
+		}
+		table.TriggerList = triggerList
+
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 		if err := jsonapi.MarshalPayload(c.Response().Writer, table); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch table response: %v", id)).SetInternal(err)
@@ -457,6 +490,50 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/database/:id/routine", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("id"))).SetInternal(err)
+		}
+
+		routineFind := &api.RoutineFind{
+			DatabaseID: &id,
+		}
+		routineList, err := s.store.FindRoutine(ctx, routineFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch routine list for database ID: %d", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, routineList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch routine list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/database/:id/sequence", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("id"))).SetInternal(err)
+		}
+
+		sequenceFind := &api.SequenceFind{
+			DatabaseID: &id,
+		}
+		sequenceList, err := s.store.FindSequence(ctx, sequenceFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch sequence list for database ID: %d", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, sequenceList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch sequence list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
 	g.GET("/database/:id/extension", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("id"))
@@ -479,6 +556,61 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/database/:id/diff", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("id"))).SetInternal(err)
+		}
+		targetIDStr := c.QueryParam("target")
+		targetID, err := strconv.Atoi(targetIDStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter target is not a number: %s", targetIDStr)).SetInternal(err)
+		}
+
+		source, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", id)).SetInternal(err)
+		}
+		if source == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
+		}
+		target, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &targetID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", targetID)).SetInternal(err)
+		}
+		if target == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", targetID))
+		}
+		if source.Instance.Engine != target.Instance.Engine {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot diff database %q of engine %s with database %q of engine %s", source.Name, source.Instance.Engine, target.Name, target.Instance.Engine))
+		}
+
+		sourceSchema, err := s.getDatabaseSchema(ctx, source)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to sync schema for database %q", source.Name)).SetInternal(err)
+		}
+		targetSchema, err := s.getDatabaseSchema(ctx, target)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to sync schema for database %q", target.Name)).SetInternal(err)
+		}
+		stmts, err := diff.SchemaDiff(source.Instance.Engine, sourceSchema, targetSchema)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+
+		schemaDiff := &api.DatabaseSchemaDiff{
+			SourceDatabaseID: source.ID,
+			TargetDatabaseID: target.ID,
+			Statement:        strings.Join(stmts, "\n"),
+		}
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, schemaDiff); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal database schema diff response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
 	g.POST("/database/:id/backup", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("id"))
@@ -500,9 +632,11 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
 		}
 
-		if err := createBackupDirectory(s.profile.DataDir, database.ID); err != nil {
-			return err
+		storageBackend, err := s.getBackupStorageBackend(ctx, database.Instance.EnvironmentID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get backup storage backend for database %q", database.Name)).SetInternal(err)
 		}
+		backupCreate.StorageBackend = storageBackend
 		path := getBackupRelativeFilePath(database.ID, backupCreate.Name)
 		backupCreate.Path = path
 
@@ -589,8 +723,11 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
 		}
 
+		// The archived backups have been pruned, so they can't be restored.
+		rowStatus := api.Normal
 		backupFind := &api.BackupFind{
 			DatabaseID: &id,
+			RowStatus:  &rowStatus,
 		}
 		backupList, err := s.store.FindBackup(ctx, backupFind)
 		if err != nil {
@@ -737,6 +874,9 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 
 		dataSource, err := s.store.CreateDataSource(ctx, dataSourceCreate)
 		if err != nil {
+			if common.ErrorCode(err) == common.NotImplemented {
+				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err)).SetInternal(err)
+			}
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create data source").SetInternal(err)
 		}
 
@@ -805,6 +945,9 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 
 		dataSourceNew, err := s.store.PatchDataSource(ctx, dataSourcePatch)
 		if err != nil {
+			if common.ErrorCode(err) == common.NotImplemented {
+				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err)).SetInternal(err)
+			}
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update data source with ID %d", dataSourceID)).SetInternal(err)
 		}
 
@@ -897,6 +1040,27 @@ func getAdminDatabaseDriver(ctx context.Context, instance *api.Instance, databas
 	return driver, nil
 }
 
+// getDatabaseSchema syncs and returns the current schema of the database from its instance.
+// Only the given database is synced rather than all databases on the instance.
+func (s *Server) getDatabaseSchema(ctx context.Context, database *api.Database) (*db.Schema, error) {
+	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, s.pgInstanceDir)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	_, schemaList, err := driver.SyncSchema(ctx, database.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, schema := range schemaList {
+		if schema.Name == database.Name {
+			return schema, nil
+		}
+	}
+	return nil, common.Errorf(common.NotFound, fmt.Errorf("database %q not found on instance %q", database.Name, database.Instance.Name))
+}
+
 // getConnectionConfig returns the connection config of the `databaseName` on `instance`.
 func getConnectionConfig(ctx context.Context, instance *api.Instance, databaseName string) (db.ConnectionConfig, error) {
 	adminDataSource := api.DataSourceFromInstanceWithType(instance, api.Admin)
@@ -904,15 +1068,59 @@ func getConnectionConfig(ctx context.Context, instance *api.Instance, databaseNa
 		return db.ConnectionConfig{}, common.Errorf(common.Internal, fmt.Errorf("admin data source not found for instance %d", instance.ID))
 	}
 
+	password, err := resolveDataSourcePassword(ctx, instance, adminDataSource)
+	if err != nil {
+		return db.ConnectionConfig{}, err
+	}
+
 	return db.ConnectionConfig{
-		Username: adminDataSource.Username,
-		Password: adminDataSource.Password,
-		Host:     instance.Host,
-		Port:     instance.Port,
-		Database: databaseName,
+		Username:  adminDataSource.Username,
+		Password:  password,
+		Host:      instance.Host,
+		Port:      instance.Port,
+		Database:  databaseName,
+		SSHConfig: getSSHConfig(adminDataSource),
 	}, nil
 }
 
+// resolveDataSourcePassword resolves the password of the data source if it's a reference of an external secret provider.
+// Each access to the secret provider is logged for audit, without the secret itself.
+func resolveDataSourcePassword(ctx context.Context, instance *api.Instance, dataSource *api.DataSource) (string, error) {
+	resolution, err := secret.Resolve(ctx, dataSource.Password)
+	if err != nil {
+		log.Warn("Failed to resolve the secret reference of data source password",
+			zap.String("instance", instance.Name),
+			zap.String("dataSource", dataSource.Name),
+			zap.Error(err),
+		)
+		return "", common.Errorf(common.DbConnectionFailure, fmt.Errorf("failed to resolve the password of data source %q on instance %q: %w", dataSource.Name, instance.Name, err))
+	}
+	if resolution.Reference != "" {
+		logFunc := log.Info
+		if resolution.Cached {
+			logFunc = log.Debug
+		}
+		logFunc("Resolved the secret reference of data source password",
+			zap.String("instance", instance.Name),
+			zap.String("dataSource", dataSource.Name),
+			zap.String("reference", resolution.Reference),
+			zap.Bool("cached", resolution.Cached),
+		)
+	}
+	return resolution.Secret, nil
+}
+
+// getSSHConfig returns the SSH tunnel config of the data source.
+func getSSHConfig(dataSource *api.DataSource) db.SSHConfig {
+	return db.SSHConfig{
+		Host:       dataSource.SSHHost,
+		Port:       dataSource.SSHPort,
+		User:       dataSource.SSHUser,
+		Password:   dataSource.SSHPassword,
+		PrivateKey: dataSource.SSHPrivateKey,
+	}
+}
+
 // We'd like to use read-only data source whenever possible, but fallback to admin data source if there's no read-only data source.
 // Upon successful return, caller MUST call driver.Close, otherwise, it will leak the database connection.
 func tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, databaseName string) (db.Driver, error) {
@@ -925,18 +1133,24 @@ func tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, d
 		return nil, common.Errorf(common.Internal, fmt.Errorf("data source not found for instance %d", instance.ID))
 	}
 
+	password, err := resolveDataSourcePassword(ctx, instance, dataSource)
+	if err != nil {
+		return nil, err
+	}
+
 	driver, err := getDatabaseDriver(
 		ctx,
 		instance.Engine,
 		// We don't need postgres installation for query.
 		db.DriverConfig{},
 		db.ConnectionConfig{
-			Username: dataSource.Username,
-			Password: dataSource.Password,
-			Host:     instance.Host,
-			Port:     instance.Port,
-			Database: databaseName,
-			ReadOnly: true,
+			Username:  dataSource.Username,
+			Password:  password,
+			Host:      instance.Host,
+			Port:      instance.Port,
+			Database:  databaseName,
+			ReadOnly:  true,
+			SSHConfig: getSSHConfig(dataSource),
 		},
 		db.ConnectionContext{
 			EnvironmentName: instance.Environment.Name,
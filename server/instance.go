@@ -39,6 +39,9 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 			if common.ErrorCode(err) == common.Conflict {
 				return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Instance name already exists: %s", instanceCreate.Name))
 			}
+			if common.ErrorCode(err) == common.NotImplemented {
+				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err)).SetInternal(err)
+			}
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create instance").SetInternal(err)
 		}
 
@@ -99,6 +102,11 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 		if instance == nil {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", id))
 		}
+		capabilities, err := db.GetCapabilities(instance.Engine)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get capabilities of engine %s", instance.Engine)).SetInternal(err)
+		}
+		instance.Capabilities = &capabilities
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 		if err := jsonapi.MarshalPayload(c.Response().Writer, instance); err != nil {
@@ -476,7 +476,7 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 					"Failed to create issue, database owner is required for postgres",
 				)
 			}
-		case db.SQLite:
+		case db.SQLite, db.DuckDB:
 			// no-op.
 		default:
 			if c.CharacterSet == "" {
@@ -543,6 +543,9 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 			if backup == nil {
 				return nil, fmt.Errorf("backup not found with ID[%d]", c.BackupID)
 			}
+			if backup.RowStatus == api.Archived {
+				return nil, fmt.Errorf("backup %q has been pruned by the retention policy", backup.Name)
+			}
 			restorePayload := api.TaskDatabaseRestorePayload{}
 			restorePayload.DatabaseName = c.DatabaseName
 			restorePayload.BackupID = c.BackupID
@@ -620,6 +623,13 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 		if database == nil {
 			return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database ID not found: %d", c.DatabaseID))
 		}
+		capabilities, err := db.GetCapabilities(database.Instance.Engine)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get capabilities of engine %s", database.Instance.Engine)).SetInternal(err)
+		}
+		if !capabilities.PITR {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Point-in-time recovery isn't supported for %s", database.Instance.Engine))
+		}
 
 		taskStatus, err := s.getPipelineApprovalPolicyForEnv(ctx, database.Instance.EnvironmentID)
 		if err != nil {
@@ -834,6 +844,13 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 			if database == nil {
 				return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("database ID not found: %d", detail.DatabaseID))
 			}
+			capabilities, err := db.GetCapabilities(database.Instance.Engine)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to get capabilities of engine %s", database.Instance.Engine)).SetInternal(err)
+			}
+			if !capabilities.GhOst {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("gh-ost isn't supported for database %q of engine %s", database.Name, database.Instance.Engine))
+			}
 
 			taskStatus, err := s.getPipelineApprovalPolicyForEnv(ctx, database.Instance.EnvironmentID)
 			if err != nil {
@@ -867,14 +884,25 @@ func getUpdateTask(database *api.Database, migrationType db.MigrationType, vcsPu
 	case db.Data:
 		taskName = fmt.Sprintf("Update %q data", database.Name)
 	}
-	payload := api.TaskDatabaseSchemaUpdatePayload{}
-	payload.MigrationType = migrationType
-	payload.Statement = d.Statement
-	payload.SchemaVersion = schemaVersion
-	if vcsPushEvent != nil {
+	var bytes []byte
+	var err error
+	if migrationType == db.Data {
+		payload := api.TaskDatabaseDataUpdatePayload{}
+		payload.Statement = d.Statement
+		payload.SchemaVersion = schemaVersion
 		payload.VCSPushEvent = vcsPushEvent
+		payload.Idempotent = d.Idempotent
+		bytes, err = json.Marshal(payload)
+	} else {
+		payload := api.TaskDatabaseSchemaUpdatePayload{}
+		payload.MigrationType = migrationType
+		payload.Statement = d.Statement
+		payload.SchemaVersion = schemaVersion
+		if vcsPushEvent != nil {
+			payload.VCSPushEvent = vcsPushEvent
+		}
+		bytes, err = json.Marshal(payload)
 	}
-	bytes, err := json.Marshal(payload)
 	if err != nil {
 		errMsg := fmt.Sprintf("Failed to marshal database schema update payload: %v", err)
 		if migrationType == db.Data {
@@ -1003,7 +1031,7 @@ func getDatabaseNameAndStatement(dbType db.Type, databaseName, characterSet, col
 
 	var stmt string
 	switch dbType {
-	case db.MySQL, db.TiDB:
+	case db.MySQL, db.TiDB, db.MariaDB:
 		stmt = fmt.Sprintf("CREATE DATABASE `%s` CHARACTER SET %s COLLATE %s;", databaseName, characterSet, collation)
 		if schema != "" {
 			stmt = fmt.Sprintf("%s\nUSE `%s`;\n%s", stmt, databaseName, schema)
@@ -1037,8 +1065,8 @@ func getDatabaseNameAndStatement(dbType db.Type, databaseName, characterSet, col
 		if schema != "" {
 			stmt = fmt.Sprintf("%s\nUSE DATABASE %s;\n%s", stmt, databaseName, schema)
 		}
-	case db.SQLite:
-		// This is a fake CREATE DATABASE and USE statement since a single SQLite file represents a database. Engine driver will recognize it and establish a connection to create the sqlite file representing the database.
+	case db.SQLite, db.DuckDB:
+		// This is a fake CREATE DATABASE and USE statement since a single SQLite or DuckDB file represents a database. Engine driver will recognize it and establish a connection to create the sqlite file representing the database.
 		stmt = fmt.Sprintf("CREATE DATABASE '%s';", databaseName)
 		if schema != "" {
 			stmt = fmt.Sprintf("%s\nUSE `%s`;\n%s", stmt, databaseName, schema)
@@ -1070,7 +1098,17 @@ func (s *Server) changeIssueStatus(ctx context.Context, issue *api.Issue, newSta
 		for _, stage := range issue.Pipeline.StageList {
 			for _, task := range stage.TaskList {
 				if task.Status == api.TaskRunning {
-					if _, err := s.changeTaskStatus(ctx, task, api.TaskCanceled, updaterID); err != nil {
+					taskStatusPatch := &api.TaskStatusPatch{
+						ID:        task.ID,
+						UpdaterID: updaterID,
+						Status:    api.TaskCanceled,
+					}
+					cancelTask := s.changeTaskStatusWithPatch
+					// The task scheduler doesn't run in the readonly mode, so there is no running executor to stop.
+					if s.TaskScheduler != nil {
+						cancelTask = s.TaskScheduler.CancelTask
+					}
+					if _, err := cancelTask(ctx, task, taskStatusPatch); err != nil {
 						return nil, fmt.Errorf("failed to cancel issue: %v, failed to cancel task: %v, error: %w", issue.Name, task.Name, err)
 					}
 				}
@@ -43,6 +43,9 @@ const (
 	// The key name used to store principal id in the context
 	// principal id is extracted from the jwt token subject field.
 	principalIDContextKey = "principal-id"
+	// The key name used to store the scope of the API token in the context
+	// if the request is authenticated by an API token.
+	apiTokenScopeContextKey = "api-token-scope"
 )
 
 // Claims creates a struct that will be encoded to a JWT.
@@ -56,6 +59,10 @@ func getPrincipalIDContextKey() string {
 	return principalIDContextKey
 }
 
+func getAPITokenScopeContextKey() string {
+	return apiTokenScopeContextKey
+}
+
 // GenerateTokensAndSetCookies generates jwt token and saves it to the http-only cookie.
 func GenerateTokensAndSetCookies(c echo.Context, user *api.Principal, mode common.ReleaseMode, secret string) error {
 	accessToken, err := generateAccessToken(user, mode, secret)
@@ -177,6 +184,22 @@ func JWTMiddleware(principalStore *store.Store, next echo.HandlerFunc, mode comm
 			return next(c)
 		}
 
+		// Automations such as CI authenticate with the API token in the Authorization header instead of the cookie.
+		if token := getBearerToken(c); token != "" {
+			// The api_token table only exists in the dev schema for now.
+			if mode != common.ReleaseModeDev {
+				return echo.NewHTTPError(http.StatusUnauthorized, "API token isn't supported in release mode yet")
+			}
+			apiToken, err := authenticateAPIToken(c.Request().Context(), principalStore, token)
+			if err != nil {
+				return err
+			}
+			// Stores principalID and the scope of the API token into context.
+			c.Set(getPrincipalIDContextKey(), apiToken.PrincipalID)
+			c.Set(getAPITokenScopeContextKey(), apiToken.Scope)
+			return next(c)
+		}
+
 		cookie, err := c.Cookie(accessTokenCookieName)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusUnauthorized, "Missing access token")
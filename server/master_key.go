@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/crypto"
+	"github.com/bytebase/bytebase/resources/postgres"
+	"github.com/bytebase/bytebase/store"
+)
+
+// getWrappedDataKey returns the data key wrapped by the master key, or empty if the credentials are stored in plaintext.
+func getWrappedDataKey(ctx context.Context, store *store.Store) (string, error) {
+	settingName := api.SettingSecurityDataKey
+	settingList, err := store.FindSetting(ctx, &api.SettingFind{Name: &settingName})
+	if err != nil {
+		return "", err
+	}
+	if len(settingList) == 0 {
+		return "", nil
+	}
+	return settingList[0].Value, nil
+}
+
+// initDataKey enables the encryption of the credentials if the master key is supplied.
+// The data key is generated on the first start with the master key, and the credentials stored in plaintext before are encrypted.
+func initDataKey(ctx context.Context, store *store.Store, masterKey []byte, readonly bool) error {
+	wrapped, err := getWrappedDataKey(ctx, store)
+	if err != nil {
+		return err
+	}
+	if len(masterKey) == 0 {
+		if wrapped != "" {
+			return fmt.Errorf("the credentials are encrypted, but the master key isn't supplied")
+		}
+		return nil
+	}
+
+	var dataKey []byte
+	if wrapped == "" {
+		if readonly {
+			log.Warn("The credentials are kept in plaintext in readonly mode until the server is started in non-readonly mode with the master key")
+			return nil
+		}
+		if dataKey, err = crypto.GenerateKey(); err != nil {
+			return err
+		}
+		if wrapped, err = crypto.WrapDataKey(masterKey, dataKey); err != nil {
+			return err
+		}
+		if _, err := store.PatchSetting(ctx, &api.SettingPatch{
+			UpdaterID: api.SystemBotID,
+			Name:      api.SettingSecurityDataKey,
+			Value:     wrapped,
+		}); err != nil {
+			return err
+		}
+		log.Info("Generated the data key for encrypting the credentials")
+	} else if dataKey, err = crypto.UnwrapDataKey(masterKey, wrapped); err != nil {
+		return err
+	}
+	if err := store.SetDataKey(dataKey); err != nil {
+		return err
+	}
+
+	if readonly {
+		return nil
+	}
+	// Encrypt the credentials written before the encryption was enabled. It's a no-op once all the credentials are encrypted.
+	count, err := store.EncryptCredentials(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the credentials stored in plaintext, error: %w", err)
+	}
+	if count > 0 {
+		log.Info("Encrypted the credentials stored in plaintext", zap.Int("dataSourceCount", count))
+	}
+	return nil
+}
+
+// RotateMasterKey re-wraps the data key of the credentials with the new master key.
+// The credentials themselves aren't re-encrypted since the data key isn't changed.
+// It opens the metadata database directly, so the server must be stopped if using the embedded Postgres.
+func RotateMasterKey(ctx context.Context, prof Profile, newMasterKey []byte) error {
+	if len(prof.MasterKey) == 0 {
+		return fmt.Errorf("the current master key must be supplied")
+	}
+
+	resourceDir := common.GetResourceDir(prof.DataDir)
+	pgInstance, err := postgres.Install(resourceDir, common.GetPostgresDataDir(prof.DataDir), prof.PgUser)
+	if err != nil {
+		return err
+	}
+	var metaDB *store.MetadataDB
+	if prof.useEmbedDB() {
+		metaDB = store.NewMetadataDBWithEmbedPg(pgInstance, prof.PgUser, prof.DataDir, prof.DemoDataDir, prof.Mode)
+	} else {
+		metaDB = store.NewMetadataDBWithExternalPg(pgInstance, prof.PgURL, prof.DemoDataDir, prof.Mode)
+	}
+	defer metaDB.Close()
+	storeDB, err := metaDB.Connect(prof.DatastorePort, false /* readonly */, prof.Version)
+	if err != nil {
+		return fmt.Errorf("cannot new db: %w", err)
+	}
+	if err := storeDB.Open(ctx); err != nil {
+		return fmt.Errorf("cannot open db: %w", err)
+	}
+	st := store.New(storeDB, NewCacheService())
+	defer st.Close()
+
+	wrapped, err := getWrappedDataKey(ctx, st)
+	if err != nil {
+		return err
+	}
+	if wrapped == "" {
+		return fmt.Errorf("the credentials aren't encrypted yet, start the server with the master key to encrypt them first")
+	}
+	dataKey, err := crypto.UnwrapDataKey(prof.MasterKey, wrapped)
+	if err != nil {
+		return err
+	}
+	newWrapped, err := crypto.WrapDataKey(newMasterKey, dataKey)
+	if err != nil {
+		return err
+	}
+	if _, err := st.PatchSetting(ctx, &api.SettingPatch{
+		UpdaterID: api.SystemBotID,
+		Name:      api.SettingSecurityDataKey,
+		Value:     newWrapped,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/idp/oidc"
+)
+
+// roleRank is the rank of the roles used to pick the highest role when the user is in several mapped groups.
+var roleRank = map[api.Role]int{
+	api.Developer: 1,
+	api.DBA:       2,
+	api.Owner:     3,
+}
+
+// getOIDCConfig returns the config of the OpenID Connect auth provider, or nil if it's not configured.
+func (s *Server) getOIDCConfig(ctx context.Context) (*api.OIDCConfig, error) {
+	settingName := api.SettingAuthOIDC
+	settingList, err := s.store.FindSetting(ctx, &api.SettingFind{Name: &settingName})
+	if err != nil {
+		return nil, err
+	}
+	if len(settingList) == 0 || settingList[0].Value == "" {
+		return nil, nil
+	}
+	return unmarshalOIDCConfig(settingList[0].Value)
+}
+
+// unmarshalOIDCConfig unmarshals and validates the config of the OpenID Connect auth provider.
+func unmarshalOIDCConfig(value string) (*api.OIDCConfig, error) {
+	config := &api.OIDCConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OIDC config, error: %w", err)
+	}
+	if config.DiscoveryURL == "" {
+		return nil, fmt.Errorf("OIDC discovery URL is required")
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("OIDC client ID is required")
+	}
+	for group, role := range config.GroupRoleMapping {
+		if _, ok := roleRank[role]; !ok {
+			return nil, fmt.Errorf("invalid role %q for group %q", role, group)
+		}
+	}
+	return config, nil
+}
+
+// newOIDCProvider discovers the OpenID Connect identity provider with the config.
+func newOIDCProvider(ctx context.Context, config *api.OIDCConfig) (*oidc.Provider, error) {
+	return oidc.NewProvider(ctx, &http.Client{}, oidc.Config{
+		DiscoveryURL: config.DiscoveryURL,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Scopes:       config.Scopes,
+		EmailClaim:   config.EmailClaim,
+		NameClaim:    config.NameClaim,
+		GroupClaim:   config.GroupClaim,
+	})
+}
+
+// getOIDCRole returns the highest role mapped from the groups, or an empty role if none of the groups is mapped.
+func getOIDCRole(config *api.OIDCConfig, groups []string) api.Role {
+	var role api.Role
+	for _, group := range groups {
+		if mapped, ok := config.GroupRoleMapping[group]; ok && roleRank[mapped] > roleRank[role] {
+			role = mapped
+		}
+	}
+	return role
+}
+
+// getOAuthRedirectURL returns the redirect URL of the OAuth flows.
+// We need to attach the RedirectURL in the get token process of oauth,
+// and the RedirectURL needs to be consistent with the RedirectURL in the get code process.
+// The frontend get it through window.location.origin in the get code process,
+// so port 80 needs to be cropped when the backend splices the RedirectURL.
+func (s *Server) getOAuthRedirectURL() string {
+	if s.profile.FrontendPort == 80 {
+		return fmt.Sprintf("%s/oauth/callback", s.profile.FrontendHost)
+	}
+	return fmt.Sprintf("%s:%d/oauth/callback", s.profile.FrontendHost, s.profile.FrontendPort)
+}
+
+// tryOIDCLogin authenticates the user with the authorization code granted by the OpenID Connect identity provider,
+// and provisions the user on the first login.
+func (s *Server) tryOIDCLogin(ctx context.Context, oidcLogin *api.OIDCLogin) (*api.Principal, *echo.HTTPError) {
+	config, err := s.getOIDCConfig(ctx)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch OIDC config").SetInternal(err)
+	}
+	if config == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "OIDC auth provider is not configured")
+	}
+	provider, err := newOIDCProvider(ctx, config)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to discover OIDC identity provider").SetInternal(err)
+	}
+	userInfo, err := provider.Login(ctx, oidcLogin.Code, s.getOAuthRedirectURL())
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Failed to login via OIDC").SetInternal(err)
+	}
+
+	user, err := s.store.GetPrincipalByEmail(ctx, userInfo.Email)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to authenticate user").SetInternal(err)
+	}
+	if user != nil {
+		if user.Type != api.EndUser {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Principal %s can't login via OIDC", userInfo.Email))
+		}
+		return user, nil
+	}
+
+	// If user login via OIDC at the first time, we will generate a random password.
+	// The random password is supposed to be not guessable. If users want to login
+	// via password, they need to set the new password from the profile page.
+	signUp := &api.SignUp{
+		Email:    userInfo.Email,
+		Password: common.RandomString(20),
+		Name:     userInfo.Name,
+	}
+	return trySignUpWithRole(ctx, s, signUp, api.SystemBotID, getOIDCRole(config, userInfo.Groups))
+}
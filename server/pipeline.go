@@ -11,11 +11,16 @@ import (
 func (s *Server) ScheduleNextTaskIfNeeded(ctx context.Context, pipeline *api.Pipeline) (*api.Task, error) {
 	for _, stage := range pipeline.StageList {
 		for _, task := range stage.TaskList {
-			// Should short circuit upon reaching RUNNING or FAILED task.
-			if task.Status == api.TaskRunning || task.Status == api.TaskFailed {
+			// Should short circuit upon reaching RUNNING, FAILED or CANCELED task.
+			if task.Status == api.TaskRunning || task.Status == api.TaskCanceled {
 				return nil, nil
 			}
 
+			// The FAILED task may be retried automatically by the task retry policy.
+			if task.Status == api.TaskFailed {
+				return s.TaskScheduler.RetryIfNeeded(ctx, task)
+			}
+
 			skipIfAlreadyTerminated := true
 			if task.Status == api.TaskPendingApproval {
 				return s.TaskCheckScheduler.ScheduleCheckIfNeeded(ctx, task, api.SystemBotID, skipIfAlreadyTerminated)
@@ -33,7 +33,7 @@ func (s *Server) hasAccessToUpsertPolicy(policyUpsert *api.PolicyUpsert) error {
 		if !s.feature(api.FeatureApprovalPolicy) {
 			return fmt.Errorf(api.FeatureApprovalPolicy.AccessErrorMessage())
 		}
-	case api.PolicyTypeBackupPlan:
+	case api.PolicyTypeBackupPlan, api.PolicyTypeBackupStorage:
 		if !s.feature(api.FeatureBackupPolicy) {
 			return fmt.Errorf(api.FeatureBackupPolicy.AccessErrorMessage())
 		}
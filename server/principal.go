@@ -22,12 +22,22 @@ func (s *Server) registerPrincipalRoutes(g *echo.Group) {
 		}
 
 		principalCreate.CreatorID = c.Get(getPrincipalIDContextKey()).(int)
-		principalCreate.Type = api.EndUser
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(principalCreate.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate password hash").SetInternal(err)
+		switch principalCreate.Type {
+		case "", api.EndUser:
+			principalCreate.Type = api.EndUser
+			passwordHash, err := bcrypt.GenerateFromPassword([]byte(principalCreate.Password), bcrypt.DefaultCost)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate password hash").SetInternal(err)
+			}
+			principalCreate.PasswordHash = string(passwordHash)
+		case api.ServiceAccount:
+			// Service accounts have no password and can only authenticate with API tokens.
+			if principalCreate.Password != "" {
+				return echo.NewHTTPError(http.StatusBadRequest, "Service account cannot have a password")
+			}
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid principal type: %q", principalCreate.Type))
 		}
-		principalCreate.PasswordHash = string(passwordHash)
 
 		principal, err := s.store.CreatePrincipal(ctx, principalCreate)
 		if err != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	vcsPlugin "github.com/bytebase/bytebase/plugin/vcs"
+	"github.com/bytebase/bytebase/plugin/vcs/github"
 	"github.com/bytebase/bytebase/plugin/vcs/gitlab"
 )
 
@@ -240,6 +241,22 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
 			}
+		} else if vcs.Type == vcsPlugin.GitHubCom {
+			// GitHub webhooks can't filter push events by branch, the branch filter is applied when receiving the push event.
+			webhookPost := github.WebhookCreateOrUpdate{
+				Config: github.WebhookConfig{
+					URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, gitHubWebhookPath, repositoryCreate.WebhookEndpointID),
+					ContentType: "json",
+					Secret:      repositoryCreate.WebhookSecretToken,
+					InsecureSSL: "1",
+				},
+				Events: []string{string(github.WebhookPush)},
+				Active: true,
+			}
+			webhookCreatePayload, err = json.Marshal(webhookPost)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
+			}
 		}
 
 		webhookID, err := vcsPlugin.Get(vcs.Type, vcsPlugin.ProviderConfig{}).CreateWebhook(
@@ -388,6 +405,22 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 				if err != nil {
 					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal put request for updating webhook %s for project ID: %v", repo.ExternalWebhookID, projectID)).SetInternal(err)
 				}
+			} else if vcs.Type == vcsPlugin.GitHubCom {
+				webhookPatch := github.WebhookCreateOrUpdate{
+					Config: github.WebhookConfig{
+						URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, gitHubWebhookPath, updatedRepo.WebhookEndpointID),
+						ContentType: "json",
+						// GitHub overwrites the whole config, so we need to keep the secret.
+						Secret:      updatedRepo.WebhookSecretToken,
+						InsecureSSL: "1",
+					},
+					Events: []string{string(github.WebhookPush)},
+					Active: true,
+				}
+				webhookPatchPayload, err = json.Marshal(webhookPatch)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal patch request for updating webhook %s for project ID: %v", repo.ExternalWebhookID, projectID)).SetInternal(err)
+				}
 			}
 
 			err = vcsPlugin.Get(vcs.Type, vcsPlugin.ProviderConfig{}).PatchWebhook(
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/metric"
+	metricCollector "github.com/bytebase/bytebase/metric/collector"
+	"github.com/bytebase/bytebase/plugin/metric/prometheus"
+	"github.com/bytebase/bytebase/store"
+
+	"github.com/labstack/echo/v4"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// prometheusNamespace is the prefix of the metric names exposed to Prometheus.
+	prometheusNamespace = "bytebase"
+)
+
+// prometheusMetrics is the server internal metrics exposed to Prometheus.
+type prometheusMetrics struct {
+	registry *prom.Registry
+
+	taskQueueDepth      prom.Gauge
+	taskRunDuration     *prom.HistogramVec
+	taskCheckRunCount   *prom.CounterVec
+	taskCheckResult     *prom.CounterVec
+	backupDuration      *prom.HistogramVec
+	backupSize          *prom.HistogramVec
+	schemaSyncDuration  *prom.HistogramVec
+	httpRequestDuration *prom.HistogramVec
+}
+
+// newPrometheusMetrics creates the server internal metrics, and registers them together with the metric collectors.
+func newPrometheusMetrics(store *store.Store) *prometheusMetrics {
+	m := &prometheusMetrics{
+		registry: prom.NewRegistry(),
+		taskQueueDepth: prom.NewGauge(prom.GaugeOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "task_scheduler",
+			Name:      "queue_depth",
+			Help:      "Number of tasks waiting in the task queue for the task concurrency limits.",
+		}),
+		taskRunDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "task",
+			Name:      "run_duration_seconds",
+			Help:      "Duration of the task runs by task type and result status.",
+			// From 100ms to about 7 hours.
+			Buckets: prom.ExponentialBuckets(0.1, 4, 10),
+		}, []string{"type", "status"}),
+		taskCheckRunCount: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "task_check",
+			Name:      "runs_total",
+			Help:      "Number of the task check runs by task check type and run status.",
+		}, []string{"type", "status"}),
+		taskCheckResult: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "task_check",
+			Name:      "results_total",
+			Help:      "Number of the task check results by task check type and result status.",
+		}, []string{"type", "status"}),
+		backupDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "backup",
+			Name:      "duration_seconds",
+			Help:      "Duration of the database backups by storage backend and backup status.",
+			// From 100ms to about 7 hours.
+			Buckets: prom.ExponentialBuckets(0.1, 4, 10),
+		}, []string{"storage_backend", "status"}),
+		backupSize: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "backup",
+			Name:      "size_bytes",
+			Help:      "Size of the successful database backups by storage backend.",
+			// From 1KiB to 256GiB.
+			Buckets: prom.ExponentialBuckets(1024, 4, 15),
+		}, []string{"storage_backend"}),
+		schemaSyncDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "schema_sync",
+			Name:      "duration_seconds",
+			Help:      "Duration of syncing the instance schema by instance.",
+			Buckets:   prom.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"instance"}),
+		httpRequestDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: prometheusNamespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the HTTP requests by method, route and status code.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"method", "route", "code"}),
+	}
+
+	collector := prometheus.NewCollector()
+	collector.Register(metric.InstanceCountMetricName, metricCollector.NewInstanceCountCollector(store))
+	collector.Register(metric.IssueCountMetricName, metricCollector.NewIssueCountCollector(store))
+	collector.Register(metric.ProjectCountMetricName, metricCollector.NewProjectCountCollector(store))
+	collector.Register(metric.PolicyCountMetricName, metricCollector.NewPolicyCountCollector(store))
+	collector.Register(metric.TaskCountMetricName, metricCollector.NewTaskCountCollector(store))
+	collector.Register(metric.DatabaseCountMetricName, metricCollector.NewDatabaseCountCollector(store))
+	collector.Register(metric.SheetCountMetricName, metricCollector.NewSheetCountCollector(store))
+	collector.Register(metric.MemberCountMetricName, metricCollector.NewMemberCountCollector(store))
+	collector.Register(metric.AnomalyCountMetricName, metricCollector.NewAnomalyCountCollector(store))
+
+	m.registry.MustRegister(
+		prom.NewGoCollector(),
+		prom.NewProcessCollector(prom.ProcessCollectorOpts{}),
+		collector,
+		m.taskQueueDepth,
+		m.taskRunDuration,
+		m.taskCheckRunCount,
+		m.taskCheckResult,
+		m.backupDuration,
+		m.backupSize,
+		m.schemaSyncDuration,
+		m.httpRequestDuration,
+	)
+	return m
+}
+
+// registerPrometheusEndpoint adds the /metrics route for Prometheus to scrape.
+func (m *prometheusMetrics) registerPrometheusEndpoint(e *echo.Echo) {
+	e.GET("/metrics", echo.WrapHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})))
+}
+
+// middleware observes the latency of the HTTP requests.
+func (m *prometheusMetrics) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		// The error hasn't been written to the response yet, so we derive the status code from it.
+		code := c.Response().Status
+		if err != nil {
+			code = http.StatusInternalServerError
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				code = httpErr.Code
+			}
+		}
+		// Use the route instead of the URI path to bound the cardinality of the label.
+		m.httpRequestDuration.WithLabelValues(c.Request().Method, c.Path(), strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// observeTaskCheckRun counts the task check run and its results.
+func (m *prometheusMetrics) observeTaskCheckRun(checkType api.TaskCheckType, checkResultList []api.TaskCheckResult, err error) {
+	if err != nil {
+		m.taskCheckRunCount.WithLabelValues(string(checkType), string(api.TaskCheckRunFailed)).Inc()
+		return
+	}
+	m.taskCheckRunCount.WithLabelValues(string(checkType), string(api.TaskCheckRunDone)).Inc()
+	for _, checkResult := range checkResultList {
+		m.taskCheckResult.WithLabelValues(string(checkType), string(checkResult.Status)).Inc()
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMiddleware(t *testing.T) {
+	a := require.New(t)
+	m := newPrometheusMetrics(nil /* store */)
+	e := echo.New()
+	e.Use(m.middleware)
+	e.GET("/api/database/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "database not found")
+		}
+		return c.String(http.StatusOK, "OK")
+	})
+
+	for _, id := range []string{"1", "2", "0"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/database/"+id, nil))
+	}
+
+	// The requests are counted by the route, and the status code is derived from the returned error.
+	// Gather the HTTP metrics only, because the metric collectors require the store.
+	registry := prom.NewRegistry()
+	registry.MustRegister(m.httpRequestDuration)
+	metricFamilyList, err := registry.Gather()
+	a.NoError(err)
+	requestCount := map[string]uint64{}
+	for _, metricFamily := range metricFamilyList {
+		if metricFamily.GetName() != "bytebase_http_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			a.Equal("GET", labels["method"])
+			a.Equal("/api/database/:id", labels["route"])
+			requestCount[labels["code"]] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+	a.Equal(map[string]uint64{"200": 2, "404": 1}, requestCount)
+}
@@ -78,7 +78,9 @@ func (s *SchemaSyncer) Run(ctx context.Context, wg *sync.WaitGroup) {
 							delete(runningTasks, instance.ID)
 							mu.Unlock()
 						}()
+						start := time.Now()
 						resultSet := s.server.syncEngineVersionAndSchema(ctx, instance)
+						s.server.metrics.schemaSyncDuration.WithLabelValues(instance.Name).Observe(time.Since(start).Seconds())
 						if resultSet.Error != "" {
 							log.Debug("Failed to sync instance",
 								zap.Int("id", instance.ID),
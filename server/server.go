@@ -40,11 +40,16 @@ type Server struct {
 	MetricReporter     *MetricReporter
 	SchemaSyncer       *SchemaSyncer
 	BackupRunner       *BackupRunner
+	BackupPruner       *BackupPruner
 	AnomalyScanner     *AnomalyScanner
 	runnerWG           sync.WaitGroup
 
 	ActivityManager *ActivityManager
 
+	querySessionManager *querySessionManager
+
+	metrics *prometheusMetrics
+
 	LicenseService enterpriseAPI.LicenseService
 	subscription   enterpriseAPI.Subscription
 
@@ -76,8 +81,9 @@ var casbinDeveloperPolicy string
 // NewServer creates a server.
 func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	s := &Server{
-		profile:   prof,
-		startedTs: time.Now().Unix(),
+		profile:             prof,
+		startedTs:           time.Now().Unix(),
+		querySessionManager: newQuerySessionManager(),
 	}
 
 	// Display config
@@ -158,6 +164,10 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	}
 	s.secret = config.secret
 
+	if err := initDataKey(ctx, storeInstance, prof.MasterKey, prof.Readonly); err != nil {
+		return nil, fmt.Errorf("failed to init data key: %w", err)
+	}
+
 	e := echo.New()
 	e.Debug = prof.Debug
 	e.HideBanner = true
@@ -171,6 +181,8 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	embedFrontend(e)
 	s.e = e
 
+	s.metrics = newPrometheusMetrics(s.store)
+
 	if !prof.Readonly {
 		// Task scheduler
 		taskScheduler := NewTaskScheduler(s)
@@ -184,7 +196,7 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		schemaUpdateExecutor := NewSchemaUpdateTaskExecutor()
 		taskScheduler.Register(api.TaskDatabaseSchemaUpdate, schemaUpdateExecutor)
 
-		dataUpdateExecutor := NewDataUpdateTaskExecutor()
+		dataUpdateExecutor := NewDataUpdateTaskExecutor(s.mysqlutil)
 		taskScheduler.Register(api.TaskDatabaseDataUpdate, dataUpdateExecutor)
 
 		backupDBExecutor := NewDatabaseBackupTaskExecutor()
@@ -220,6 +232,12 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		statementCompositeExecutor := NewTaskCheckStatementAdvisorCompositeExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementAdvise, statementCompositeExecutor)
 
+		statementFullTableScanExecutor := NewTaskCheckStatementFullTableScanExecutor()
+		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementFullTableScan, statementFullTableScanExecutor)
+
+		statementAffectedRowsExecutor := NewTaskCheckStatementAffectedRowsExecutor()
+		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementAffectedRows, statementAffectedRowsExecutor)
+
 		databaseConnectExecutor := NewTaskCheckDatabaseConnectExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseConnect, databaseConnectExecutor)
 
@@ -229,6 +247,9 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		timingExecutor := NewTaskCheckTimingExecutor()
 		taskCheckScheduler.Register(api.TaskCheckGeneralEarliestAllowedTime, timingExecutor)
 
+		maintenanceWindowExecutor := NewTaskCheckMaintenanceWindowExecutor()
+		taskCheckScheduler.Register(api.TaskCheckGeneralMaintenanceWindow, maintenanceWindowExecutor)
+
 		s.TaskCheckScheduler = taskCheckScheduler
 
 		// Schema syncer
@@ -237,6 +258,9 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		// Backup runner
 		s.BackupRunner = NewBackupRunner(s, prof.BackupRunnerInterval)
 
+		// Backup pruner
+		s.BackupPruner = NewBackupPruner(s)
+
 		// Anomaly scanner
 		s.AnomalyScanner = NewAnomalyScanner(s)
 
@@ -255,6 +279,7 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 				`"status":${status},"error":"${error}"}` + "\n",
 		}))
 	}
+	e.Use(s.metrics.middleware)
 	e.Use(recoverMiddleware)
 
 	webhookGroup := e.Group("/hook")
@@ -284,6 +309,7 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	s.registerAuthRoutes(apiGroup)
 	s.registerOAuthRoutes(apiGroup)
 	s.registerPrincipalRoutes(apiGroup)
+	s.registerAPITokenRoutes(apiGroup)
 	s.registerMemberRoutes(apiGroup)
 	s.registerPolicyRoutes(apiGroup)
 	s.registerProjectRoutes(apiGroup)
@@ -295,6 +321,7 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	s.registerIssueRoutes(apiGroup)
 	s.registerIssueSubscriberRoutes(apiGroup)
 	s.registerTaskRoutes(apiGroup)
+	s.registerTaskApprovalRoutes(apiGroup)
 	s.registerStageRoutes(apiGroup)
 	s.registerActivityRoutes(apiGroup)
 	s.registerInboxRoutes(apiGroup)
@@ -309,6 +336,8 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	e.GET("/healthz", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK!\n")
 	})
+	// Register Prometheus metrics endpoint.
+	s.metrics.registerPrometheusEndpoint(e)
 	// Register pprof endpoints.
 	registerPProfEndpoints(e)
 
@@ -400,6 +429,26 @@ func (s *Server) initSetting(ctx context.Context, store *store.Store) (*config,
 		return nil, err
 	}
 
+	// initial OpenID Connect auth provider, which is disabled by default
+	if _, err = store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   api.SystemBotID,
+		Name:        api.SettingAuthOIDC,
+		Value:       "",
+		Description: "The OpenID Connect auth provider config in JSON format, empty to disable.",
+	}); err != nil {
+		return nil, err
+	}
+
+	// initial data key of the credentials, which is generated when the master key is first supplied
+	if _, err = store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   api.SystemBotID,
+		Name:        api.SettingSecurityDataKey,
+		Value:       "",
+		Description: "The data key encrypting the credentials, wrapped by the master key. Empty if the credentials are stored in plaintext.",
+	}); err != nil {
+		return nil, err
+	}
+
 	return conf, nil
 }
 
@@ -417,6 +466,8 @@ func (s *Server) Run(ctx context.Context) error {
 		s.runnerWG.Add(1)
 		go s.BackupRunner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
+		go s.BackupPruner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
 		go s.AnomalyScanner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
 
@@ -460,6 +511,11 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	// Wait for all runners to exit.
 	s.runnerWG.Wait()
 
+	// Cancel the running queries of SQL editor.
+	if s.querySessionManager != nil {
+		s.querySessionManager.closeAll(ctx)
+	}
+
 	// Close db connection
 	if s.store != nil {
 		if err := s.store.Close(); err != nil {
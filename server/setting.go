@@ -59,6 +59,18 @@ func (s *Server) registerSettingRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformed update setting request").SetInternal(err)
 		}
 
+		if settingPatch.Name == api.SettingAuthOIDC {
+			if !s.feature(api.Feature3rdPartyAuth) {
+				return echo.NewHTTPError(http.StatusForbidden, api.Feature3rdPartyAuth.AccessErrorMessage())
+			}
+			// An empty value disables the OpenID Connect auth provider.
+			if settingPatch.Value != "" {
+				if _, err := unmarshalOIDCConfig(settingPatch.Value); err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid OIDC config: %v", err)).SetInternal(err)
+				}
+			}
+		}
+
 		setting, err := s.store.PatchSetting(ctx, settingPatch)
 		if err != nil {
 			if common.ErrorCode(err) == common.NotFound {
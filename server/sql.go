@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/plugin/db/util"
+	"github.com/bytebase/bytebase/plugin/secret"
 )
 
 func (s *Server) registerSQLRoutes(g *echo.Group) {
@@ -44,16 +46,50 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			}
 			password = adminPassword
 		}
+		// The password can be a reference of an external secret provider.
+		resolution, err := secret.Resolve(ctx, password)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		if resolution.Reference != "" {
+			log.Info("Resolved the secret reference of password for testing connection",
+				zap.String("host", connectionInfo.Host),
+				zap.String("reference", resolution.Reference),
+				zap.Bool("cached", resolution.Cached),
+			)
+		}
+		password = resolution.Secret
+
+		sshConfig := db.SSHConfig{
+			Host:       connectionInfo.SSHHost,
+			Port:       connectionInfo.SSHPort,
+			User:       connectionInfo.SSHUser,
+			Password:   connectionInfo.SSHPassword,
+			PrivateKey: connectionInfo.SSHPrivateKey,
+		}
+		// Similar to the password, the SSH credentials aren't transferred back to client either.
+		if !sshConfig.IsEmpty() && sshConfig.Password == "" && sshConfig.PrivateKey == "" && connectionInfo.InstanceID != nil {
+			adminType := api.Admin
+			adminDataSource, err := s.store.GetDataSource(ctx, &api.DataSourceFind{InstanceID: connectionInfo.InstanceID, Type: &adminType})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to retrieve admin data source for instance: %d", *connectionInfo.InstanceID)).SetInternal(err)
+			}
+			if adminDataSource != nil {
+				sshConfig.Password = adminDataSource.SSHPassword
+				sshConfig.PrivateKey = adminDataSource.SSHPrivateKey
+			}
+		}
 
 		db, err := db.Open(
 			ctx,
 			connectionInfo.Engine,
 			db.DriverConfig{},
 			db.ConnectionConfig{
-				Username: connectionInfo.Username,
-				Password: password,
-				Host:     connectionInfo.Host,
-				Port:     connectionInfo.Port,
+				Username:  connectionInfo.Username,
+				Password:  password,
+				Host:      connectionInfo.Host,
+				Port:      connectionInfo.Port,
+				SSHConfig: sshConfig,
 			},
 			db.ConnectionContext{},
 		)
@@ -148,51 +184,8 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			return json.Marshal(rowSet)
 		}()
 
-		{
-			errMessage := ""
-			activityLevel := api.ActivityInfo
-			if err != nil {
-				errMessage = err.Error()
-				activityLevel = api.ActivityError
-			}
-
-			activityBytes, err := json.Marshal(api.ActivitySQLEditorQueryPayload{
-				Statement:    exec.Statement,
-				DurationNs:   time.Now().UnixNano() - start,
-				InstanceName: instance.Name,
-				DatabaseName: exec.DatabaseName,
-				Error:        errMessage,
-			})
-
-			if err != nil {
-				log.Warn("Failed to marshal activity after executing sql statement",
-					zap.String("database_name", exec.DatabaseName),
-					zap.String("instance_name", instance.Name),
-					zap.String("statement", exec.Statement),
-					zap.Error(err))
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
-			}
-
-			activityCreate := &api.ActivityCreate{
-				CreatorID:   c.Get(getPrincipalIDContextKey()).(int),
-				Type:        api.ActivitySQLEditorQuery,
-				ContainerID: exec.InstanceID,
-				Level:       activityLevel,
-				Comment: fmt.Sprintf("Executed `%q` in database %q of instance %q.",
-					exec.Statement, exec.DatabaseName, instance.Name),
-				Payload: string(activityBytes),
-			}
-
-			_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
-
-			if err != nil {
-				log.Warn("Failed to create activity after executing sql statement",
-					zap.String("database_name", exec.DatabaseName),
-					zap.String("instance_name", instance.Name),
-					zap.String("statement", exec.Statement),
-					zap.Error(err))
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create activity").SetInternal(err)
-			}
+		if err := s.createSQLEditorQueryActivity(ctx, c.Get(getPrincipalIDContextKey()).(int), instance, exec.DatabaseName, exec.Statement, time.Now().UnixNano()-start, err); err != nil {
+			return err
 		}
 
 		resultSet := &api.SQLResultSet{}
@@ -223,6 +216,231 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+
+	g.POST("/sql/explain", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		explain := &api.SQLExplain{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, explain); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request").SetInternal(err)
+		}
+
+		if explain.InstanceID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing instanceId")
+		}
+		if len(explain.Statement) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing sql statement")
+		}
+		if !validateSQLExplainStatement(explain.Statement) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, only support a single SELECT, INSERT, UPDATE or DELETE sql statement")
+		}
+
+		instance, err := s.store.GetInstanceByID(ctx, explain.InstanceID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", explain.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", explain.InstanceID))
+		}
+		capabilities, err := db.GetCapabilities(instance.Engine)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get capabilities of engine %s", instance.Engine)).SetInternal(err)
+		}
+		if !capabilities.Explain {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Explain isn't supported for %s", instance.Engine))
+		}
+
+		bytes, err := func() ([]byte, error) {
+			driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, explain.DatabaseName)
+			if err != nil {
+				return nil, err
+			}
+			defer driver.Close(ctx)
+
+			plan, err := driver.Explain(ctx, explain.Statement)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(plan)
+		}()
+
+		result := &api.SQLExplainResult{}
+		if err == nil {
+			result.Plan = string(bytes)
+		} else {
+			result.Error = err.Error()
+			log.Debug("Failed to explain statement",
+				zap.Error(err),
+				zap.String("statement", explain.Statement),
+			)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, result); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sql explain result response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.POST("/sql/query-session", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		sessionCreate := &api.SQLQuerySessionCreate{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, sessionCreate); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create query session request").SetInternal(err)
+		}
+
+		if sessionCreate.InstanceID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create query session request, missing instanceId")
+		}
+		if len(sessionCreate.Statement) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create query session request, missing sql statement")
+		}
+		if !validateSQLSelectStatement(sessionCreate.Statement) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create query session request, only support SELECT sql statement")
+		}
+
+		instance, err := s.store.GetInstanceByID(ctx, sessionCreate.InstanceID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", sessionCreate.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", sessionCreate.InstanceID))
+		}
+
+		creatorID := c.Get(getPrincipalIDContextKey()).(int)
+		start := time.Now().UnixNano()
+		session, queryErr := s.querySessionManager.open(ctx, creatorID, instance, sessionCreate.DatabaseName, sessionCreate.Statement)
+		if err := s.createSQLEditorQueryActivity(ctx, creatorID, instance, sessionCreate.DatabaseName, sessionCreate.Statement, time.Now().UnixNano()-start, queryErr); err != nil {
+			if session != nil {
+				_ = s.querySessionManager.cancel(ctx, session.id)
+			}
+			return err
+		}
+
+		querySession := &api.SQLQuerySession{
+			InstanceID:   instance.ID,
+			DatabaseName: sessionCreate.DatabaseName,
+			Statement:    sessionCreate.Statement,
+		}
+		if queryErr == nil {
+			querySession.ID = session.id
+			querySession.ColumnNames = session.cursor.ColumnNames
+			querySession.ColumnTypeNames = session.cursor.ColumnTypeNames
+		} else {
+			querySession.Error = queryErr.Error()
+			log.Debug("Failed to start query session",
+				zap.Error(queryErr),
+				zap.String("statement", sessionCreate.Statement),
+			)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, querySession); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal query session response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/sql/query-session/:id/page", func(c echo.Context) error {
+		id := c.Param("id")
+		cursor := 0
+		if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+			v, err := strconv.Atoi(cursorStr)
+			if err != nil || v < 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cursor is not a non-negative integer: %s", cursorStr))
+			}
+			cursor = v
+		}
+		limit := defaultQueryPageSize
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			v, err := strconv.Atoi(limitStr)
+			if err != nil || v <= 0 || v > maxQueryPageSize {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Limit should be an integer between 1 and %d: %s", maxQueryPageSize, limitStr))
+			}
+			limit = v
+		}
+
+		session := s.querySessionManager.get(id, c.Get(getPrincipalIDContextKey()).(int))
+		if session == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Query session not found: %s", id))
+		}
+
+		page, err := session.fetch(cursor, limit)
+		if err != nil {
+			return err
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, page); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal query page response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.DELETE("/sql/query-session/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id := c.Param("id")
+		if session := s.querySessionManager.get(id, c.Get(getPrincipalIDContextKey()).(int)); session == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Query session not found: %s", id))
+		}
+
+		if err := s.querySessionManager.cancel(ctx, id); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to cancel query session: %s", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return nil
+	})
+}
+
+// createSQLEditorQueryActivity creates the activity of executing the query in SQL editor.
+// queryErr is the error executing the query, if any.
+func (s *Server) createSQLEditorQueryActivity(ctx context.Context, creatorID int, instance *api.Instance, databaseName string, statement string, durationNs int64, queryErr error) error {
+	errMessage := ""
+	activityLevel := api.ActivityInfo
+	if queryErr != nil {
+		errMessage = queryErr.Error()
+		activityLevel = api.ActivityError
+	}
+
+	activityBytes, err := json.Marshal(api.ActivitySQLEditorQueryPayload{
+		Statement:    statement,
+		DurationNs:   durationNs,
+		InstanceName: instance.Name,
+		DatabaseName: databaseName,
+		Error:        errMessage,
+	})
+
+	if err != nil {
+		log.Warn("Failed to marshal activity after executing sql statement",
+			zap.String("database_name", databaseName),
+			zap.String("instance_name", instance.Name),
+			zap.String("statement", statement),
+			zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+	}
+
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   creatorID,
+		Type:        api.ActivitySQLEditorQuery,
+		ContainerID: instance.ID,
+		Level:       activityLevel,
+		Comment: fmt.Sprintf("Executed `%q` in database %q of instance %q.",
+			statement, databaseName, instance.Name),
+		Payload: string(activityBytes),
+	}
+
+	_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
+
+	if err != nil {
+		log.Warn("Failed to create activity after executing sql statement",
+			zap.String("database_name", databaseName),
+			zap.String("instance_name", instance.Name),
+			zap.String("statement", statement),
+			zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create activity").SetInternal(err)
+	}
+	return nil
 }
 
 func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.Instance) (rs *api.SQLResultSet) {
@@ -391,6 +609,7 @@ func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.I
 							Position:   index.Position,
 							Type:       index.Type,
 							Unique:     index.Unique,
+							Primary:    index.Primary,
 							Visible:    index.Visible,
 							Comment:    index.Comment,
 						}
@@ -399,6 +618,55 @@ func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.I
 						}
 					}
 				}
+
+				// Foreign key
+				for _, foreignKey := range table.ForeignKeyList {
+					foreignKeyCreate := &api.ForeignKeyCreate{
+						CreatorID:            api.SystemBotID,
+						DatabaseID:           database.ID,
+						TableID:              upsertedTable.ID,
+						Name:                 foreignKey.Name,
+						ColumnList:           foreignKey.ColumnList,
+						ReferencedSchema:     foreignKey.ReferencedSchema,
+						ReferencedTable:      foreignKey.ReferencedTable,
+						ReferencedColumnList: foreignKey.ReferencedColumnList,
+						OnDelete:             foreignKey.OnDelete,
+						OnUpdate:             foreignKey.OnUpdate,
+					}
+					if _, err := s.store.CreateForeignKey(ctx, foreignKeyCreate); err != nil {
+						return fmt.Errorf("failed to sync foreign key for instance: %s, database: %s, table: %s. Failed to import new foreign key: %s. Error %w", instance.Name, database.Name, upsertedTable.Name, foreignKey.Name, err)
+					}
+				}
+
+				// Check constraint
+				for _, checkConstraint := range table.CheckConstraintList {
+					checkConstraintCreate := &api.CheckConstraintCreate{
+						CreatorID:  api.SystemBotID,
+						DatabaseID: database.ID,
+						TableID:    upsertedTable.ID,
+						Name:       checkConstraint.Name,
+						Expression: checkConstraint.Expression,
+					}
+					if _, err := s.store.CreateCheckConstraint(ctx, checkConstraintCreate); err != nil {
+						return fmt.Errorf("failed to sync check constraint for instance: %s, database: %s, table: %s. Failed to import new check constraint: %s. Error %w", instance.Name, database.Name, upsertedTable.Name, checkConstraint.Name, err)
+					}
+				}
+
+				// Trigger
+				for _, trigger := range table.TriggerList {
+					triggerCreate := &api.TriggerCreate{
+						CreatorID:  api.SystemBotID,
+						DatabaseID: database.ID,
+						TableID:    upsertedTable.ID,
+						Name:       trigger.Name,
+						Timing:     trigger.Timing,
+						Event:      trigger.Event,
+						Body:       trigger.Body,
+					}
+					if _, err := s.store.CreateTrigger(ctx, triggerCreate); err != nil {
+						return fmt.Errorf("failed to sync trigger for instance: %s, database: %s, table: %s. Failed to import new trigger: %s. Error %w", instance.Name, database.Name, upsertedTable.Name, trigger.Name, err)
+					}
+				}
 				return nil
 			}
 
@@ -437,6 +705,46 @@ func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.I
 				return nil
 			}
 
+			var recreateRoutineSchema = func(database *api.Database, routine db.Routine) error {
+				// Routine
+				routineCreate := &api.RoutineCreate{
+					CreatorID:  api.SystemBotID,
+					DatabaseID: database.ID,
+					Name:       routine.Name,
+					Type:       routine.Type,
+					Arguments:  routine.Arguments,
+					ReturnType: routine.ReturnType,
+					Definition: routine.Definition,
+					Comment:    routine.Comment,
+				}
+				if _, err := s.store.CreateRoutine(ctx, routineCreate); err != nil {
+					return fmt.Errorf("failed to sync routine for instance: %s, database: %s. Failed to import new routine: %s. Error %w", instance.Name, database.Name, routine.Name, err)
+				}
+				return nil
+			}
+
+			var recreateSequenceSchema = func(database *api.Database, sequence db.Sequence) error {
+				// Sequence
+				sequenceCreate := &api.SequenceCreate{
+					CreatorID:  api.SystemBotID,
+					DatabaseID: database.ID,
+					Name:       sequence.Name,
+					DataType:   sequence.DataType,
+					StartValue: sequence.StartValue,
+					MinValue:   sequence.MinValue,
+					MaxValue:   sequence.MaxValue,
+					Increment:  sequence.Increment,
+					Cycle:      sequence.Cycle,
+				}
+				if _, err := s.store.CreateSequence(ctx, sequenceCreate); err != nil {
+					if common.ErrorCode(err) == common.Conflict {
+						return fmt.Errorf("failed to sync sequence for instance: %s, database: %s. Sequence name already exists: %s", instance.Name, database.Name, sequence.Name)
+					}
+					return fmt.Errorf("failed to sync sequence for instance: %s, database: %s. Failed to import new sequence: %s. Error %w", instance.Name, database.Name, sequence.Name, err)
+				}
+				return nil
+			}
+
 			instanceUserList, err := s.store.FindInstanceUserByInstanceID(ctx, instance.ID)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch user list for instance: %v", instance.ID)).SetInternal(err)
@@ -571,6 +879,34 @@ func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.I
 							return err
 						}
 					}
+
+					routineDelete := &api.RoutineDelete{
+						DatabaseID: dbPatched.ID,
+					}
+					if err := s.store.DeleteRoutine(ctx, routineDelete); err != nil {
+						return fmt.Errorf("failed to sync database for instance: %s. Failed to reset routine info for database: %s. Error %w", instance.Name, dbPatched.Name, err)
+					}
+
+					for _, routine := range schema.RoutineList {
+						err = recreateRoutineSchema(dbPatched, routine)
+						if err != nil {
+							return err
+						}
+					}
+
+					sequenceDelete := &api.SequenceDelete{
+						DatabaseID: dbPatched.ID,
+					}
+					if err := s.store.DeleteSequence(ctx, sequenceDelete); err != nil {
+						return fmt.Errorf("failed to sync database for instance: %s. Failed to reset sequence info for database: %s. Error %w", instance.Name, dbPatched.Name, err)
+					}
+
+					for _, sequence := range schema.SequenceList {
+						err = recreateSequenceSchema(dbPatched, sequence)
+						if err != nil {
+							return err
+						}
+					}
 				} else {
 					// Case 2, only appear in the synced db schema
 					databaseCreate := &api.DatabaseCreate{
@@ -611,6 +947,20 @@ func (s *Server) syncEngineVersionAndSchema(ctx context.Context, instance *api.I
 							return err
 						}
 					}
+
+					for _, routine := range schema.RoutineList {
+						err = recreateRoutineSchema(database, routine)
+						if err != nil {
+							return err
+						}
+					}
+
+					for _, sequence := range schema.SequenceList {
+						err = recreateSequenceSchema(database, sequence)
+						if err != nil {
+							return err
+						}
+					}
 				}
 			}
 
@@ -695,3 +1045,29 @@ func validateSQLSelectStatement(sqlStatement string) bool {
 	}
 	return false
 }
+
+func validateSQLExplainStatement(sqlStatement string) bool {
+	// Check if the query has only one statement.
+	count := 0
+	sc := bufio.NewScanner(strings.NewReader(sqlStatement))
+	if err := util.ApplyMultiStatements(sc, func(_ string) error {
+		count++
+		return nil
+	}); err != nil {
+		return false
+	}
+	if count != 1 {
+		return false
+	}
+
+	// Allow the statements reading or changing the data only, and the statement is explained without execution.
+	whiteListRegs := []string{`^SELECT\s+?`, `^WITH\s+?`, `^INSERT\s+?`, `^UPDATE\s+?`, `^DELETE\s+?`}
+	formatedStr := strings.ToUpper(strings.TrimSpace(sqlStatement))
+	for _, reg := range whiteListRegs {
+		matchResult, _ := regexp.MatchString(reg, formatedStr)
+		if matchResult {
+			return true
+		}
+	}
+	return false
+}
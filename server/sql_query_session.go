@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+const (
+	// defaultQueryPageSize is the number of rows in a query result page if the limit isn't specified.
+	defaultQueryPageSize = 100
+	// maxQueryPageSize is the maximum number of rows in a query result page.
+	maxQueryPageSize = 1000
+	// querySessionIdleTimeout is how long a query session is kept without being fetched.
+	querySessionIdleTimeout = 10 * time.Minute
+)
+
+// querySession is a query started in SQL editor, whose result is fetched page by page.
+type querySession struct {
+	id        string
+	creatorID int
+	driver    db.Driver
+	cursor    *util.QueryCursor
+
+	// lastAccessTs is guarded by the mu of querySessionManager.
+	lastAccessTs time.Time
+
+	// mu guards the fields below and serializes fetching the pages.
+	mu sync.Mutex
+	// nextCursor is the offset of the next row to read.
+	nextCursor int
+	// lastPage is the last fetched page, which is returned again if the client retries fetching it.
+	lastPage *api.SQLQueryPage
+	closed   bool
+}
+
+// fetch fetches the page of at most limit rows starting from cursor.
+// The rows can only be read forward, except that the last page can be fetched again.
+func (session *querySession) fetch(cursor int, limit int) (*api.SQLQueryPage, error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if session.lastPage != nil && cursor == session.lastPage.Cursor {
+		return session.lastPage, nil
+	}
+	if cursor != session.nextCursor {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cursor %d is out of range, the query result can only be read forward from cursor %d", cursor, session.nextCursor))
+	}
+
+	page := &api.SQLQueryPage{
+		ID:              session.id,
+		Cursor:          cursor,
+		NextCursor:      cursor,
+		ColumnNames:     session.cursor.ColumnNames,
+		ColumnTypeNames: session.cursor.ColumnTypeNames,
+		Data:            "[]",
+	}
+	data, err := session.cursor.Fetch(limit)
+	if err != nil {
+		page.Done = true
+		page.Error = err.Error()
+	} else {
+		bytes, err := json.Marshal(data)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal query result").SetInternal(err)
+		}
+		page.Data = string(bytes)
+		page.NextCursor = cursor + len(data)
+		page.Done = session.cursor.Done()
+	}
+
+	session.nextCursor = page.NextCursor
+	session.lastPage = page
+	if page.Done {
+		// Release the connection as soon as all the rows are read. The session is kept so
+		// that the last page can be fetched again until the session is deleted or expires.
+		session.close(context.Background())
+	}
+	return page, nil
+}
+
+// close closes the cursor and the driver of the session. The caller must hold mu.
+func (session *querySession) close(ctx context.Context) {
+	if session.closed {
+		return
+	}
+	session.closed = true
+	session.cursor.Close()
+	session.driver.Close(ctx)
+}
+
+// querySessionManager keeps the query sessions started in SQL editor.
+type querySessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*querySession
+}
+
+func newQuerySessionManager() *querySessionManager {
+	return &querySessionManager{
+		sessions: make(map[string]*querySession),
+	}
+}
+
+// open starts the query and returns the session of it.
+func (m *querySessionManager) open(ctx context.Context, creatorID int, instance *api.Instance, databaseName string, statement string) (*querySession, error) {
+	m.closeIdle(ctx)
+
+	driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	sqldb, err := driver.GetDbConnection(ctx, databaseName)
+	if err != nil {
+		driver.Close(ctx)
+		return nil, err
+	}
+	cursor, err := util.OpenQueryCursor(ctx, instance.Engine, sqldb, statement)
+	if err != nil {
+		driver.Close(ctx)
+		return nil, err
+	}
+
+	session := &querySession{
+		id:           uuid.New().String(),
+		creatorID:    creatorID,
+		driver:       driver,
+		cursor:       cursor,
+		lastAccessTs: time.Now(),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.id] = session
+	return session, nil
+}
+
+// get returns the session created by the creator, or nil if not found.
+func (m *querySessionManager) get(id string, creatorID int) *querySession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok || session.creatorID != creatorID {
+		return nil
+	}
+	session.lastAccessTs = time.Now()
+	return session
+}
+
+// cancel cancels the running query of the session on the engine and removes the session.
+func (m *querySessionManager) cancel(ctx context.Context, id string) error {
+	m.mu.Lock()
+	session, ok := m.sessions[id]
+	delete(m.sessions, id)
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	// Cancelling doesn't wait for mu, a page being fetched is interrupted by the cancellation.
+	err := session.cursor.Cancel(ctx)
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.close(ctx)
+	return err
+}
+
+// closeIdle closes and removes the sessions which are not accessed for querySessionIdleTimeout.
+func (m *querySessionManager) closeIdle(ctx context.Context) {
+	var idleList []string
+	m.mu.Lock()
+	for id, session := range m.sessions {
+		if time.Since(session.lastAccessTs) > querySessionIdleTimeout {
+			idleList = append(idleList, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, id := range idleList {
+		log.Debug("Closing idle query session", zap.String("id", id))
+		if err := m.cancel(ctx, id); err != nil {
+			log.Warn("Failed to cancel idle query session", zap.String("id", id), zap.Error(err))
+		}
+	}
+}
+
+// closeAll cancels and removes all the sessions.
+func (m *querySessionManager) closeAll(ctx context.Context) {
+	m.mu.Lock()
+	var idList []string
+	for id := range m.sessions {
+		idList = append(idList, id)
+	}
+	m.mu.Unlock()
+
+	for _, id := range idList {
+		if err := m.cancel(ctx, id); err != nil {
+			log.Warn("Failed to cancel query session", zap.String("id", id), zap.Error(err))
+		}
+	}
+}
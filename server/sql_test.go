@@ -94,3 +94,46 @@ func TestValidateSQLSelectStatement(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateSQLExplainStatement(t *testing.T) {
+	tests := []struct {
+		sqlStatement string
+		want         bool
+	}{
+		{
+			sqlStatement: "SELECT * FROM test",
+			want:         true,
+		},
+		{
+			sqlStatement: "  update test SET a = 1 WHERE id = 1;",
+			want:         true,
+		},
+		{
+			sqlStatement: "DELETE FROM test",
+			want:         true,
+		},
+		{
+			sqlStatement: "WITH t AS (SELECT 1) SELECT * FROM t",
+			want:         true,
+		},
+		{
+			sqlStatement: "DROP TABLE test",
+			want:         false,
+		},
+		{
+			sqlStatement: "EXPLAIN SELECT * FROM test",
+			want:         false,
+		},
+		{
+			sqlStatement: "UPDATE test SET a = 1;\nDROP TABLE test;",
+			want:         false,
+		},
+	}
+
+	for _, test := range tests {
+		result := validateSQLExplainStatement(test.sqlStatement)
+		if result != test.want {
+			t.Errorf("Validate SQLStatement %q: got result %v, want %v.", test.sqlStatement, result, test.want)
+		}
+	}
+}
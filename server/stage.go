@@ -34,25 +34,46 @@ func (s *Server) registerStageRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformed update stage tasks status request").SetInternal(err)
 		}
 
-		if err := s.validateIssueAssignee(ctx, currentPrincipalID, pipelineID); err != nil {
-			return err
-		}
-
 		tasks, err := s.store.FindTask(ctx, &api.TaskFind{PipelineID: &pipelineID, StageID: &stageID}, true /* returnOnErr */)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get tasks").SetInternal(err)
 		}
+
+		// All tasks in a stage belong to the same environment.
+		var approvalStepList []*api.ApprovalStep
+		if stageAllTaskStatusPatch.Status == api.TaskPending && len(tasks) > 0 {
+			approvalStepList, err = s.getApprovalStepList(ctx, tasks[0].Instance.EnvironmentID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch approval policy").SetInternal(err)
+			}
+		}
+		// The approvers of the approval steps don't have to be the assignee.
+		if len(approvalStepList) == 0 {
+			if err := s.validateIssueAssignee(ctx, currentPrincipalID, pipelineID); err != nil {
+				return err
+			}
+		}
+
 		var tasksPatched []*api.Task
 		for _, task := range tasks {
-			taskPatched, err := s.changeTaskStatusWithPatch(ctx, task, &api.TaskStatusPatch{
+			taskStatusPatch := &api.TaskStatusPatch{
 				ID:        task.ID,
 				UpdaterID: stageAllTaskStatusPatch.UpdaterID,
 				Status:    stageAllTaskStatusPatch.Status,
-			})
+			}
+			var taskPatched *api.Task
+			if len(approvalStepList) > 0 {
+				taskPatched, err = s.approveTask(ctx, task, approvalStepList, taskStatusPatch)
+			} else {
+				taskPatched, err = s.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+			}
 			if err != nil {
 				if common.ErrorCode(err) == common.Invalid {
 					return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err))
 				}
+				if common.ErrorCode(err) == common.NotAuthorized {
+					return echo.NewHTTPError(http.StatusUnauthorized, common.ErrorMessage(err))
+				}
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update task \"%v\" status", task.Name)).SetInternal(err)
 			}
 			tasksPatched = append(tasksPatched, taskPatched)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/jsonapi"
 	"github.com/labstack/echo/v4"
@@ -145,6 +146,13 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update task \"%v\"", task.Name)).SetInternal(err)
 		}
 
+		// The approvals were given to the previous statement, so the approval workflow starts over.
+		if taskPatched.Status == api.TaskPendingApproval && oldStatement != newStatement {
+			if err := s.store.DeleteTaskApproval(ctx, &api.TaskApprovalDelete{TaskID: taskPatched.ID}); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to reset approvals after updating task statement: %v", taskPatched.Name)).SetInternal(err)
+			}
+		}
+
 		// create an activity and trigger task check for statement update
 		if taskPatched.Type == api.TaskDatabaseSchemaUpdate || taskPatched.Type == api.TaskDatabaseDataUpdate {
 			if oldStatement != newStatement {
@@ -178,8 +186,8 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create activity after updating task statement: %v", taskPatched.Name)).SetInternal(err)
 				}
 
-				// For now, we supported MySQL and TiDB dialect check
-				if taskPatched.Database.Instance.Engine == db.MySQL || taskPatched.Database.Instance.Engine == db.TiDB {
+				// For now, we supported MySQL, TiDB, MariaDB and Postgres dialect check
+				if taskPatched.Database.Instance.Engine == db.MySQL || taskPatched.Database.Instance.Engine == db.TiDB || taskPatched.Database.Instance.Engine == db.MariaDB || taskPatched.Database.Instance.Engine == db.Postgres {
 					payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
 						Statement: *taskPatch.Statement,
 						DbType:    taskPatched.Database.Instance.Engine,
@@ -301,15 +309,62 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Task not found with ID %d", taskID))
 		}
 
-		if err := s.validateIssueAssignee(ctx, currentPrincipalID, task.PipelineID); err != nil {
-			return err
+		var approvalStepList []*api.ApprovalStep
+		if task.Status == api.TaskPendingApproval && taskStatusPatch.Status == api.TaskPending {
+			approvalStepList, err = s.getApprovalStepList(ctx, task.Instance.EnvironmentID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch approval policy").SetInternal(err)
+			}
+		}
+		// The approvers of the approval steps don't have to be the assignee.
+		// Without the approval steps, the assignee approves the task even if the assignee is the issue creator.
+		if len(approvalStepList) == 0 {
+			if err := s.validateIssueAssignee(ctx, currentPrincipalID, task.PipelineID); err != nil {
+				return err
+			}
+		}
+
+		if taskStatusPatch.Status == api.TaskRunning {
+			policy, err := s.store.GetMaintenanceWindowPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch maintenance window policy").SetInternal(err)
+			}
+			now := time.Now()
+			open, _, err := policy.IsOpen(now)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to evaluate maintenance window policy").SetInternal(err)
+			}
+			if !open {
+				message, err := nextMaintenanceWindowMessage(policy, now)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to evaluate maintenance window policy").SetInternal(err)
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Task %q is outside the maintenance window. %s", task.Name, message))
+			}
 		}
 
-		taskPatched, err := s.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+		var taskPatched *api.Task
+		if len(approvalStepList) > 0 {
+			taskPatched, err = s.approveTask(ctx, task, approvalStepList, taskStatusPatch)
+		} else if taskStatusPatch.Status == api.TaskRunning {
+			// The manual run and retry are subject to the task concurrency limits as well.
+			taskPatched, err = s.TaskScheduler.RunTask(ctx, task, taskStatusPatch)
+		} else if task.Status == api.TaskRunning && taskStatusPatch.Status == api.TaskCanceled {
+			// Canceling the running task stops its executor, which kills the in-flight query on the database.
+			taskPatched, err = s.TaskScheduler.CancelTask(ctx, task, taskStatusPatch)
+		} else {
+			taskPatched, err = s.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+		}
 		if err != nil {
 			if common.ErrorCode(err) == common.Invalid {
 				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err))
 			}
+			if common.ErrorCode(err) == common.NotAuthorized {
+				return echo.NewHTTPError(http.StatusUnauthorized, common.ErrorMessage(err))
+			}
+			if common.ErrorCode(err) == common.Conflict {
+				return echo.NewHTTPError(http.StatusConflict, common.ErrorMessage(err))
+			}
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update task \"%v\" status", task.Name)).SetInternal(err)
 		}
 
@@ -347,6 +402,114 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+
+	// Lists the tasks waiting for the task concurrency limits in the queue order.
+	g.GET("/task-queue", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		// The task scheduler doesn't run in the readonly mode.
+		itemList := []*api.TaskQueueItem{}
+		if s.TaskScheduler != nil {
+			var err error
+			itemList, err = s.TaskScheduler.getTaskQueue(ctx)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch task queue").SetInternal(err)
+			}
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, itemList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal task queue response").SetInternal(err)
+		}
+		return nil
+	})
+
+	// Creates a data update issue from the rollback statement generated by the done data update task.
+	g.POST("/pipeline/:pipelineID/task/:taskID/rollback-issue", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		taskID, err := strconv.Atoi(c.Param("taskID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Task ID is not a number: %s", c.Param("taskID"))).SetInternal(err)
+		}
+
+		task, err := s.store.GetTaskByID(ctx, taskID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch task ID: %v", taskID)).SetInternal(err)
+		}
+		if task == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Task not found with ID %d", taskID))
+		}
+		if task.Type != api.TaskDatabaseDataUpdate || task.Status != api.TaskDone || task.Database == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Task %q isn't a done data update task", task.Name))
+		}
+
+		rollbackStatement, err := getTaskRollbackStatement(task)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Task %q has no rollback statement, error: %v", task.Name, err))
+		}
+
+		issue, err := s.store.GetIssueByPipelineID(ctx, task.PipelineID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch issue with pipeline ID: %d", task.PipelineID)).SetInternal(err)
+		}
+		if issue == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Issue not found with pipeline ID: %d", task.PipelineID))
+		}
+
+		createContext, err := json.Marshal(&api.UpdateSchemaContext{
+			MigrationType: db.Data,
+			DetailList: []*api.UpdateSchemaDetail{
+				{
+					DatabaseID: task.Database.ID,
+					Statement:  rollbackStatement,
+				},
+			},
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct issue create context payload").SetInternal(err)
+		}
+		issueCreate := &api.IssueCreate{
+			ProjectID:     issue.ProjectID,
+			Name:          fmt.Sprintf("Rollback %q", task.Name),
+			Type:          api.IssueDatabaseDataUpdate,
+			Description:   fmt.Sprintf("Rollback the data update task %q of issue #%d.", task.Name, issue.ID),
+			AssigneeID:    issue.AssigneeID,
+			CreateContext: string(createContext),
+		}
+		rollbackIssue, err := s.createIssue(ctx, issueCreate, c.Get(getPrincipalIDContextKey()).(int))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create rollback issue").SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, rollbackIssue); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal create rollback issue response").SetInternal(err)
+		}
+		return nil
+	})
+}
+
+// getTaskRollbackStatement returns the rollback statement of the latest done task run.
+func getTaskRollbackStatement(task *api.Task) (string, error) {
+	var taskRun *api.TaskRun
+	for _, run := range task.TaskRunList {
+		if run.Status == api.TaskRunDone && (taskRun == nil || run.ID > taskRun.ID) {
+			taskRun = run
+		}
+	}
+	if taskRun == nil {
+		return "", fmt.Errorf("done task run not found")
+	}
+	result := &api.TaskRunResultPayload{}
+	if err := json.Unmarshal([]byte(taskRun.Result), result); err != nil {
+		return "", fmt.Errorf("invalid task run result, error: %w", err)
+	}
+	if result.RollbackError != "" {
+		return "", fmt.Errorf("%s", result.RollbackError)
+	}
+	if result.RollbackStatement == "" {
+		return "", fmt.Errorf("no row is changed")
+	}
+	return result.RollbackStatement, nil
 }
 
 func (s *Server) validateIssueAssignee(ctx context.Context, currentPrincipalID, pipelineID int) error {
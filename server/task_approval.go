@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/jsonapi"
+	"github.com/labstack/echo/v4"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+func (s *Server) registerTaskApprovalRoutes(g *echo.Group) {
+	g.GET("/pipeline/:pipelineID/task/:taskID/approval", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		taskID, err := strconv.Atoi(c.Param("taskID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Task ID is not a number: %s", c.Param("taskID"))).SetInternal(err)
+		}
+
+		taskApprovalList, err := s.store.FindTaskApproval(ctx, &api.TaskApprovalFind{TaskID: &taskID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch approvals for task ID %d", taskID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, taskApprovalList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal task approval list response for task ID %d", taskID)).SetInternal(err)
+		}
+		return nil
+	})
+}
+
+// getApprovalStepList returns the approval steps of the environment.
+// It's empty if the tasks are approved by the issue assignee.
+func (s *Server) getApprovalStepList(ctx context.Context, environmentID int) ([]*api.ApprovalStep, error) {
+	// The task_approval table only exists in the dev schema for now, so the tasks are approved by the issue assignee in release mode.
+	if s.profile.Mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	policy, err := s.store.GetPipelineApprovalPolicy(ctx, environmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policy for environment ID %v, error %w", environmentID, err)
+	}
+	if policy.Value != api.PipelineApprovalValueManualAlways {
+		return nil, nil
+	}
+	return policy.StepList, nil
+}
+
+// getCurrentApprovalStep returns the 0-based index of the first approval step which hasn't got the required approvals.
+// It returns the number of the steps if all steps are approved.
+func getCurrentApprovalStep(approvalStepList []*api.ApprovalStep, taskApprovalList []*api.TaskApproval) int {
+	approvalCount := make(map[int]int)
+	for _, taskApproval := range taskApprovalList {
+		approvalCount[taskApproval.Step]++
+	}
+	for i, step := range approvalStepList {
+		if approvalCount[i] < step.ApprovalCount {
+			return i
+		}
+	}
+	return len(approvalStepList)
+}
+
+// isApprover returns true if the principal is one of the approvers of the approval step.
+func (s *Server) isApprover(ctx context.Context, step *api.ApprovalStep, principalID int, projectID int) (bool, error) {
+	switch step.Type {
+	case api.ApprovalStepTypeWorkspaceRole:
+		principal, err := s.store.GetPrincipalByID(ctx, principalID)
+		if err != nil {
+			return false, err
+		}
+		return principal != nil && principal.Role == api.Role(step.Role), nil
+	case api.ApprovalStepTypeProjectRole:
+		projectMemberList, err := s.store.FindProjectMember(ctx, &api.ProjectMemberFind{ProjectID: &projectID})
+		if err != nil {
+			return false, err
+		}
+		for _, projectMember := range projectMemberList {
+			if projectMember.PrincipalID == principalID && projectMember.Role == step.Role {
+				return true, nil
+			}
+		}
+		return false, nil
+	case api.ApprovalStepTypeGroup:
+		for _, memberID := range step.MemberIDList {
+			if memberID == principalID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, nil
+}
+
+// approveTask records the approval of the PENDING_APPROVAL task for the current approval step.
+// The task moves to PENDING after all approval steps get the required approvals in order.
+// Unlike the approval by the issue assignee, where the creator may assign the issue to self,
+// the approval steps require the approvals from others, so the issue creator can't approve.
+func (s *Server) approveTask(ctx context.Context, task *api.Task, approvalStepList []*api.ApprovalStep, taskStatusPatch *api.TaskStatusPatch) (*api.Task, error) {
+	if task.Status != api.TaskPendingApproval {
+		return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("task %q is not waiting for approval", task.Name)}
+	}
+	issue, err := s.store.GetIssueByPipelineID(ctx, task.PipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch containing issue for task %q, error: %w", task.Name, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue not found by pipeline ID: %d", task.PipelineID)
+	}
+	approverID := taskStatusPatch.UpdaterID
+	if issue.CreatorID == approverID {
+		return nil, &common.Error{Code: common.NotAuthorized, Err: fmt.Errorf("the issue creator can't approve task %q", task.Name)}
+	}
+
+	taskApprovalList, err := s.store.FindTaskApproval(ctx, &api.TaskApprovalFind{TaskID: &task.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, taskApproval := range taskApprovalList {
+		if taskApproval.CreatorID == approverID {
+			return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("task %q has already been approved by you", task.Name)}
+		}
+	}
+
+	stepIndex := getCurrentApprovalStep(approvalStepList, taskApprovalList)
+	if stepIndex < len(approvalStepList) {
+		step := approvalStepList[stepIndex]
+		ok, err := s.isApprover(ctx, step, approverID, issue.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, &common.Error{Code: common.NotAuthorized, Err: fmt.Errorf("approval step %d of %d of task %q requires the approval from %s", stepIndex+1, len(approvalStepList), task.Name, step.ApproverName())}
+		}
+
+		taskApproval, err := s.store.CreateTaskApproval(ctx, &api.TaskApprovalCreate{
+			CreatorID: approverID,
+			TaskID:    task.ID,
+			StageID:   task.StageID,
+			Step:      stepIndex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		taskApprovalList = append(taskApprovalList, taskApproval)
+
+		approvalCount := 0
+		for _, taskApproval := range taskApprovalList {
+			if taskApproval.Step == stepIndex {
+				approvalCount++
+			}
+		}
+		payload, err := json.Marshal(api.ActivityPipelineTaskApprovePayload{
+			TaskID:                task.ID,
+			Step:                  stepIndex + 1,
+			StepCount:             len(approvalStepList),
+			ApprovalCount:         approvalCount,
+			RequiredApprovalCount: step.ApprovalCount,
+			Approver:              step.ApproverName(),
+			IssueName:             issue.Name,
+			TaskName:              task.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal activity after approving task %q, error: %w", task.Name, err)
+		}
+		activityCreate := &api.ActivityCreate{
+			CreatorID:   approverID,
+			ContainerID: issue.ID,
+			Type:        api.ActivityPipelineTaskApprove,
+			Level:       api.ActivityInfo,
+			Payload:     string(payload),
+		}
+		if taskStatusPatch.Comment != nil {
+			activityCreate.Comment = *taskStatusPatch.Comment
+		}
+		if _, err := s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{issue: issue}); err != nil {
+			return nil, err
+		}
+	}
+
+	if getCurrentApprovalStep(approvalStepList, taskApprovalList) < len(approvalStepList) {
+		// Wait for the approvals of the remaining steps.
+		return task, nil
+	}
+	// The comment has been recorded in the approval activity.
+	statusPatch := *taskStatusPatch
+	statusPatch.Comment = nil
+	return s.changeTaskStatusWithPatch(ctx, task, &statusPatch)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestGetCurrentApprovalStep(t *testing.T) {
+	approvalStepList := []*api.ApprovalStep{
+		{Type: api.ApprovalStepTypeProjectRole, Role: "OWNER", ApprovalCount: 1},
+		{Type: api.ApprovalStepTypeWorkspaceRole, Role: "DBA", ApprovalCount: 2},
+	}
+	tests := []struct {
+		name             string
+		taskApprovalList []*api.TaskApproval
+		want             int
+	}{
+		{
+			"noApproval",
+			nil,
+			0,
+		},
+		{
+			"firstStepApproved",
+			[]*api.TaskApproval{{Step: 0}},
+			1,
+		},
+		{
+			"secondStepPartiallyApproved",
+			[]*api.TaskApproval{{Step: 0}, {Step: 1}},
+			1,
+		},
+		{
+			"allStepsApproved",
+			[]*api.TaskApproval{{Step: 0}, {Step: 1}, {Step: 1}},
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, getCurrentApprovalStep(approvalStepList, test.taskApprovalList))
+		})
+	}
+	require.Equal(t, 0, getCurrentApprovalStep(nil, nil))
+}
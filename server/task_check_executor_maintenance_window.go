@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// NewTaskCheckMaintenanceWindowExecutor creates a task check maintenance window executor.
+func NewTaskCheckMaintenanceWindowExecutor() TaskCheckExecutor {
+	return &TaskCheckMaintenanceWindowExecutor{}
+}
+
+// TaskCheckMaintenanceWindowExecutor is the task check maintenance window executor.
+type TaskCheckMaintenanceWindowExecutor struct {
+}
+
+// Run will run the task check maintenance window executor once.
+func (exec *TaskCheckMaintenanceWindowExecutor) Run(ctx context.Context, server *Server, taskCheckRun *api.TaskCheckRun) (result []api.TaskCheckResult, err error) {
+	task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if task == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("task not found for ID %v", taskCheckRun.TaskID))
+	}
+	policy, err := server.store.GetMaintenanceWindowPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+
+	if len(policy.WindowList) == 0 {
+		return []api.TaskCheckResult{
+			{
+				Status:  api.TaskCheckStatusSuccess,
+				Code:    common.Ok,
+				Title:   "OK",
+				Content: "Maintenance window unset",
+			},
+		}, nil
+	}
+
+	now := time.Now()
+	open, end, err := policy.IsOpen(now)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if open {
+		return []api.TaskCheckResult{
+			{
+				Status:  api.TaskCheckStatusSuccess,
+				Code:    common.Ok,
+				Title:   "OK",
+				Content: fmt.Sprintf("Within the maintenance window until %s (UTC+0000)", end.Format(dataFormat)),
+			},
+		}, nil
+	}
+
+	content, err := nextMaintenanceWindowMessage(policy, now)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	return []api.TaskCheckResult{
+		{
+			Status:  api.TaskCheckStatusError,
+			Code:    common.TaskOutsideMaintenanceWindow,
+			Title:   "Outside maintenance window",
+			Content: content,
+		},
+	}, nil
+}
+
+// nextMaintenanceWindowMessage describes the next maintenance window after t.
+func nextMaintenanceWindowMessage(policy *api.MaintenanceWindowPolicy, t time.Time) (string, error) {
+	start, end, ok, err := policy.NextWindow(t)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "There is no maintenance window in the next year", nil
+	}
+	return fmt.Sprintf("Need to wait until the next maintenance window: %s - %s (UTC+0000)", start.Format(dataFormat), end.Format(dataFormat)), nil
+}
+
+// isInMaintenanceWindow returns true if the task can start running now under the maintenance window policy of its environment.
+func (s *Server) isInMaintenanceWindow(ctx context.Context, task *api.Task) (bool, error) {
+	policy, err := s.store.GetMaintenanceWindowPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+	if err != nil {
+		return false, err
+	}
+	open, _, err := policy.IsOpen(time.Now())
+	if err != nil {
+		return false, err
+	}
+	return open, nil
+}
@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/bytebase/bytebase/api"
 	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
 )
 
 // NewTaskCheckMigrationSchemaExecutor creates a task check migration schema executor.
@@ -61,12 +63,27 @@ func (exec *TaskCheckMigrationSchemaExecutor) Run(ctx context.Context, server *S
 		}, nil
 	}
 
-	return []api.TaskCheckResult{
+	result = []api.TaskCheckResult{
 		{
 			Status:  api.TaskCheckStatusSuccess,
 			Code:    common.Ok,
 			Title:   "OK",
 			Content: fmt.Sprintf("Instance %q has setup migration schema", instance.Name),
 		},
-	}, nil
+	}
+	if task.Type == api.TaskDatabaseSchemaUpdate && !driver.Capabilities().TransactionalDDL {
+		payload := &api.TaskDatabaseSchemaUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return []api.TaskCheckResult{}, common.Errorf(common.Invalid, fmt.Errorf("invalid database schema update payload: %w", err))
+		}
+		if payload.MigrationType == db.Migrate {
+			result = append(result, api.TaskCheckResult{
+				Status:  api.TaskCheckStatusWarn,
+				Code:    common.TaskCheckNonTransactionalDDL,
+				Title:   "Non-transactional DDL",
+				Content: fmt.Sprintf("%s doesn't support transactional DDL, the schema may be partially changed if the migration fails", instance.Engine),
+			})
+		}
+	}
+	return result, nil
 }
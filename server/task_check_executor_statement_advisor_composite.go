@@ -133,66 +133,90 @@ func getAdvisorTypeByRule(ruleType advisor.SchemaReviewRuleType, engine db.Type)
 	switch ruleType {
 	case advisor.SchemaRuleStatementRequireWhere:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLWhereRequirement, nil
+		case db.Postgres:
+			return advisor.PostgreSQLWhereRequirement, nil
 		}
 	case advisor.SchemaRuleStatementNoLeadingWildcardLike:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNoLeadingWildcardLike, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNoLeadingWildcardLike, nil
 		}
 	case advisor.SchemaRuleStatementNoSelectAll:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNoSelectAll, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNoSelectAll, nil
 		}
 	case advisor.SchemaRuleSchemaBackwardCompatibility:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLMigrationCompatibility, nil
+		case db.Postgres:
+			return advisor.PostgreSQLMigrationCompatibility, nil
 		}
 	case advisor.SchemaRuleTableNaming:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNamingTableConvention, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNamingTableConvention, nil
 		}
 	case advisor.SchemaRuleIDXNaming:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNamingIndexConvention, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNamingIndexConvention, nil
 		}
 	case advisor.SchemaRuleUKNaming:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNamingUKConvention, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNamingUKConvention, nil
 		}
 	case advisor.SchemaRuleFKNaming:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNamingFKConvention, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNamingFKConvention, nil
 		}
 	case advisor.SchemaRuleColumnNaming:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLNamingColumnConvention, nil
+		case db.Postgres:
+			return advisor.PostgreSQLNamingColumnConvention, nil
 		}
 	case advisor.SchemaRuleRequiredColumn:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLColumnRequirement, nil
+		case db.Postgres:
+			return advisor.PostgreSQLColumnRequirement, nil
 		}
 	case advisor.SchemaRuleColumnNotNull:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLColumnNoNull, nil
+		case db.Postgres:
+			return advisor.PostgreSQLColumnNoNull, nil
 		}
 	case advisor.SchemaRuleTableRequirePK:
 		switch engine {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB:
 			return advisor.MySQLTableRequirePK, nil
+		case db.Postgres:
+			return advisor.PostgreSQLTableRequirePK, nil
 		}
 	case advisor.SchemaRuleMySQLEngine:
-		if engine == db.MySQL {
+		if engine == db.MySQL || engine == db.MariaDB {
 			return advisor.MySQLUseInnoDB, nil
 		}
 	}
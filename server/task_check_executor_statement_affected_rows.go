@@ -0,0 +1,203 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+const (
+	// affectedRowsDryRunTimeout is the timeout of the dry run, the affected rows are estimated by the query plans after it.
+	affectedRowsDryRunTimeout = 1 * time.Minute
+)
+
+// transactionalTableEngines is the MySQL storage engines supporting transactions.
+var transactionalTableEngines = map[string]bool{
+	"INNODB":  true,
+	"ROCKSDB": true,
+	"TOKUDB":  true,
+}
+
+// NewTaskCheckStatementAffectedRowsExecutor creates a task check statement affected rows executor.
+func NewTaskCheckStatementAffectedRowsExecutor() TaskCheckExecutor {
+	return &TaskCheckStatementAffectedRowsExecutor{}
+}
+
+// TaskCheckStatementAffectedRowsExecutor is the task check executor counting the rows affected by the DML statements.
+// The statements are executed in a transaction which is always rolled back, or explained if the rollback isn't possible.
+type TaskCheckStatementAffectedRowsExecutor struct {
+}
+
+// Run will run the task check statement affected rows executor once.
+func (exec *TaskCheckStatementAffectedRowsExecutor) Run(ctx context.Context, server *Server, taskCheckRun *api.TaskCheckRun) (result []api.TaskCheckResult, err error) {
+	payload := &api.TaskCheckDatabaseStatementAdvisePayload{}
+	if err := json.Unmarshal([]byte(taskCheckRun.Payload), payload); err != nil {
+		return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid check statement affected rows payload: %w", err))
+	}
+
+	task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if task == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("task not found for ID %v", taskCheckRun.TaskID))
+	}
+	database, err := server.store.GetDatabase(ctx, &api.DatabaseFind{ID: task.DatabaseID})
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if database == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("database ID not found %v", task.DatabaseID))
+	}
+	policy, err := server.store.GetAffectedRowLimitPolicyByEnvID(ctx, database.Instance.EnvironmentID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("failed to get affected row limit policy, error: %w", err))
+	}
+
+	var statementList []string
+	sc := bufio.NewScanner(strings.NewReader(payload.Statement))
+	if err := util.ApplyMultiStatements(sc, func(statement string) error {
+		if util.IsDMLStatement(statement) {
+			statementList = append(statementList, statement)
+		}
+		return nil
+	}); err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Invalid, fmt.Errorf("failed to split statement: %w", err))
+	}
+	if len(statementList) == 0 {
+		return []api.TaskCheckResult{
+			{
+				Status:  api.TaskCheckStatusSuccess,
+				Code:    common.Ok,
+				Title:   "OK",
+				Content: "No DML statement",
+			},
+		}, nil
+	}
+
+	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, server.pgInstanceDir)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.DbConnectionFailure, err)
+	}
+	defer driver.Close(ctx)
+
+	capabilities := driver.Capabilities()
+	dryRun := capabilities.TransactionalDML
+	if dryRun {
+		hasNonTransactionalTable, err := hasNonTransactionalTable(ctx, server, database.ID)
+		if err != nil {
+			return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+		}
+		dryRun = !hasNonTransactionalTable
+	}
+
+	if dryRun {
+		sqldb, err := driver.GetDbConnection(ctx, database.Name)
+		if err != nil {
+			return []api.TaskCheckResult{}, common.Errorf(common.DbConnectionFailure, err)
+		}
+		dryRunCtx, cancel := context.WithTimeout(ctx, affectedRowsDryRunTimeout)
+		countList, err := util.CountAffectedRows(dryRunCtx, sqldb, statementList)
+		cancel()
+		if err == nil {
+			for i, statement := range statementList {
+				result = append(result, affectedRowsResult(policy, statement, countList[i], false /* estimated */))
+			}
+			return result, nil
+		}
+		if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(dryRunCtx.Err(), context.DeadlineExceeded) {
+			return []api.TaskCheckResult{
+				{
+					Status:  api.TaskCheckStatusWarn,
+					Code:    common.DbExecutionError,
+					Title:   "Failed to dry run statements",
+					Content: err.Error(),
+				},
+			}, nil
+		}
+		// Fall back to the estimation for the long running statements.
+	}
+
+	if !capabilities.Explain {
+		return []api.TaskCheckResult{
+			{
+				Status:  api.TaskCheckStatusWarn,
+				Code:    common.TaskCheckAffectedRowsUnknown,
+				Title:   "Affected rows unknown",
+				Content: fmt.Sprintf("Affected rows can't be counted for %s without rollback or explain", database.Instance.Engine),
+			},
+		}, nil
+	}
+	for _, statement := range statementList {
+		plan, err := driver.Explain(ctx, statement)
+		if err != nil {
+			result = append(result, api.TaskCheckResult{
+				Status:  api.TaskCheckStatusWarn,
+				Code:    common.DbExecutionError,
+				Title:   "Failed to explain statement",
+				Content: fmt.Sprintf("%q: %s", statement, err.Error()),
+			})
+			continue
+		}
+		result = append(result, affectedRowsResult(policy, statement, maxEstimatedRows(plan), true /* estimated */))
+	}
+	return result, nil
+}
+
+// affectedRowsResult returns the check result of the statement, which is an error if the count exceeds the policy limit.
+func affectedRowsResult(policy *api.AffectedRowLimitPolicy, statement string, count int64, estimated bool) api.TaskCheckResult {
+	content := fmt.Sprintf("%q affects %d rows", statement, count)
+	if estimated {
+		content = fmt.Sprintf("%q is estimated to affect at most %d rows", statement, count)
+	}
+	if policy.MaxRowCount > 0 && count > policy.MaxRowCount {
+		return api.TaskCheckResult{
+			Status:  api.TaskCheckStatusError,
+			Code:    common.TaskCheckAffectedRowLimitExceeded,
+			Title:   fmt.Sprintf("Affected rows exceed the limit %d", policy.MaxRowCount),
+			Content: content,
+		}
+	}
+	return api.TaskCheckResult{
+		Status:  api.TaskCheckStatusSuccess,
+		Code:    common.Ok,
+		Title:   fmt.Sprintf("%d affected rows", count),
+		Content: content,
+	}
+}
+
+// maxEstimatedRows returns the max estimated rows of the plan nodes, which is the upper bound of the affected rows.
+func maxEstimatedRows(plan *db.PlanNode) int64 {
+	rows := plan.EstimatedRows
+	for _, child := range plan.Children {
+		if childRows := maxEstimatedRows(child); childRows > rows {
+			rows = childRows
+		}
+	}
+	return rows
+}
+
+// hasNonTransactionalTable returns whether the database has tables of the non-transactional storage engines such as MyISAM in the synced schema,
+// whose changes can't be rolled back.
+func hasNonTransactionalTable(ctx context.Context, server *Server, databaseID int) (bool, error) {
+	tableList, err := server.store.FindTable(ctx, &api.TableFind{DatabaseID: &databaseID})
+	if err != nil {
+		return false, fmt.Errorf("failed to find tables of database %d, error: %w", databaseID, err)
+	}
+	for _, table := range tableList {
+		// Engine is only synced for MySQL, TiDB, MariaDB and ClickHouse, and views don't have engines.
+		if table.Engine != "" && !transactionalTableEngines[strings.ToUpper(table.Engine)] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
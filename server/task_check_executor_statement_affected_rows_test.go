@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestAffectedRowsResult(t *testing.T) {
+	noLimit := &api.AffectedRowLimitPolicy{}
+	result := affectedRowsResult(noLimit, "DELETE FROM t", 1000000, false /* estimated */)
+	require.Equal(t, api.TaskCheckStatusSuccess, result.Status)
+	require.Equal(t, `"DELETE FROM t" affects 1000000 rows`, result.Content)
+
+	limit := &api.AffectedRowLimitPolicy{MaxRowCount: 1000}
+	result = affectedRowsResult(limit, "DELETE FROM t", 1000, false /* estimated */)
+	require.Equal(t, api.TaskCheckStatusSuccess, result.Status)
+	result = affectedRowsResult(limit, "DELETE FROM t", 1001, true /* estimated */)
+	require.Equal(t, api.TaskCheckStatusError, result.Status)
+	require.Equal(t, common.TaskCheckAffectedRowLimitExceeded, result.Code)
+	require.Equal(t, `"DELETE FROM t" is estimated to affect at most 1001 rows`, result.Content)
+}
+
+func TestMaxEstimatedRows(t *testing.T) {
+	plan := &db.PlanNode{
+		Operation: db.PlanOperationModify,
+		Children: []*db.PlanNode{
+			{Operation: db.PlanOperationJoin, EstimatedRows: 10, Children: []*db.PlanNode{
+				{Operation: db.PlanOperationTableScan, EstimatedRows: 500},
+				{Operation: db.PlanOperationIndexScan, EstimatedRows: 1},
+			}},
+		},
+	}
+	require.Equal(t, int64(500), maxEstimatedRows(plan))
+}
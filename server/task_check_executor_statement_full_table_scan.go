@@ -0,0 +1,120 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// fullTableScanRowCountThreshold is the row count of the large tables, whose full table scans are flagged.
+const fullTableScanRowCountThreshold = 100000
+
+// NewTaskCheckStatementFullTableScanExecutor creates a task check statement full table scan executor.
+func NewTaskCheckStatementFullTableScanExecutor() TaskCheckExecutor {
+	return &TaskCheckStatementFullTableScanExecutor{}
+}
+
+// TaskCheckStatementFullTableScanExecutor is the task check executor flagging the full table scans on large tables by the query plans of the statements.
+type TaskCheckStatementFullTableScanExecutor struct {
+}
+
+// Run will run the task check statement full table scan executor once.
+func (exec *TaskCheckStatementFullTableScanExecutor) Run(ctx context.Context, server *Server, taskCheckRun *api.TaskCheckRun) (result []api.TaskCheckResult, err error) {
+	payload := &api.TaskCheckDatabaseStatementAdvisePayload{}
+	if err := json.Unmarshal([]byte(taskCheckRun.Payload), payload); err != nil {
+		return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid check statement full table scan payload: %w", err))
+	}
+
+	task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if task == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("task not found for ID %v", taskCheckRun.TaskID))
+	}
+	database, err := server.store.GetDatabase(ctx, &api.DatabaseFind{ID: task.DatabaseID})
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, err)
+	}
+	if database == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, fmt.Errorf("database ID not found %v", task.DatabaseID))
+	}
+
+	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, server.pgInstanceDir)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.DbConnectionFailure, err)
+	}
+	defer driver.Close(ctx)
+
+	result = []api.TaskCheckResult{}
+	sc := bufio.NewScanner(strings.NewReader(payload.Statement))
+	if err := util.ApplyMultiStatements(sc, func(statement string) error {
+		plan, err := driver.Explain(ctx, statement)
+		if err != nil {
+			// The statements may not be explainable before the previous ones are applied, so we don't block the task.
+			result = append(result, api.TaskCheckResult{
+				Status:  api.TaskCheckStatusWarn,
+				Code:    common.DbExecutionError,
+				Title:   "Failed to explain statement",
+				Content: fmt.Sprintf("%q: %s", statement, err.Error()),
+			})
+			return nil
+		}
+		for _, scan := range plan.FullTableScanList() {
+			rowCount, err := getTableRowCount(ctx, server, database.ID, scan.Table)
+			if err != nil {
+				return err
+			}
+			// Fall back to the estimation of the plan for the tables not synced yet.
+			if rowCount < 0 {
+				rowCount = scan.EstimatedRows
+			}
+			if rowCount < fullTableScanRowCountThreshold {
+				continue
+			}
+			result = append(result, api.TaskCheckResult{
+				Status:  api.TaskCheckStatusWarn,
+				Code:    common.TaskCheckFullTableScan,
+				Title:   fmt.Sprintf("Full table scan on %q", scan.Table),
+				Content: fmt.Sprintf("%q scans the whole table %q with about %d rows", statement, scan.Table, rowCount),
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, common.Errorf(common.Internal, fmt.Errorf("failed to explain statement: %w", err))
+	}
+
+	if len(result) == 0 {
+		result = append(result, api.TaskCheckResult{
+			Status:  api.TaskCheckStatusSuccess,
+			Code:    common.Ok,
+			Title:   "OK",
+			Content: fmt.Sprintf("No full table scan on the tables with %d or more rows", fullTableScanRowCountThreshold),
+		})
+	}
+	return result, nil
+}
+
+// getTableRowCount returns the row count of the table in the synced schema, or -1 if the table isn't found.
+func getTableRowCount(ctx context.Context, server *Server, databaseID int, tableName string) (int64, error) {
+	if tableName == "" {
+		return -1, nil
+	}
+	table, err := server.store.GetTable(ctx, &api.TableFind{
+		DatabaseID: &databaseID,
+		Name:       &tableName,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get table %q, error: %w", tableName, err)
+	}
+	if table == nil {
+		return -1, nil
+	}
+	return table.RowCount, nil
+}
@@ -89,6 +89,7 @@ func (s *TaskCheckScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 							mu.Unlock()
 						}()
 						checkResultList, err := executor.Run(ctx, s.server, taskCheckRun)
+						s.server.metrics.observeTaskCheckRun(taskCheckRun.Type, checkResultList, err)
 
 						if err == nil {
 							bytes, err := json.Marshal(api.TaskCheckRunResultPayload{
@@ -218,6 +219,55 @@ func (s *TaskCheckScheduler) shouldScheduleTimingTaskCheck(ctx context.Context,
 	return false, nil
 }
 
+// Returns true if the environment has maintenance windows and we meet either of the following conditions:
+//  1. No task check has run before, or user explicitly wants to reschedule the check.
+//  2. The maintenance window has opened or closed since the latest check, so we need to rerun the check to reflect it.
+func (s *TaskCheckScheduler) shouldScheduleMaintenanceWindowTaskCheck(ctx context.Context, task *api.Task, forceSchedule bool) (bool, error) {
+	policy, err := s.server.store.GetMaintenanceWindowPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+	if err != nil {
+		return false, err
+	}
+
+	statusList := []api.TaskCheckRunStatus{api.TaskCheckRunDone, api.TaskCheckRunFailed, api.TaskCheckRunRunning}
+	taskCheckType := api.TaskCheckGeneralMaintenanceWindow
+	taskCheckRunFind := &api.TaskCheckRunFind{
+		TaskID:     &task.ID,
+		Type:       &taskCheckType,
+		StatusList: &statusList,
+		Latest:     true,
+	}
+	taskCheckRunList, err := s.server.store.FindTaskCheckRun(ctx, taskCheckRunFind)
+	if err != nil {
+		return false, err
+	}
+
+	// If there is not any task check scheduled before, we should only schedule one if the environment has maintenance windows.
+	if len(taskCheckRunList) == 0 {
+		return len(policy.WindowList) > 0, nil
+	}
+
+	if forceSchedule {
+		return true, nil
+	}
+
+	if taskCheckRunList[0].Status != api.TaskCheckRunDone {
+		return false, nil
+	}
+	open, _, err := policy.IsOpen(time.Now())
+	if err != nil {
+		return false, err
+	}
+	checkResult := &api.TaskCheckRunResultPayload{}
+	if err := json.Unmarshal([]byte(taskCheckRunList[0].Result), checkResult); err != nil {
+		return false, err
+	}
+	// Rerun the check if the latest run has no result to compare with.
+	if len(checkResult.ResultList) == 0 {
+		return true, nil
+	}
+	return (checkResult.ResultList[0].Status == api.TaskCheckStatusSuccess) != open, nil
+}
+
 // ScheduleCheckIfNeeded schedules a check if needed.
 func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *api.Task, creatorID int, skipIfAlreadyTerminated bool) (*api.Task, error) {
 	// the following block is for timing task check
@@ -248,6 +298,26 @@ func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *ap
 		}
 	}
 
+	// the following block is for maintenance window task check
+	{
+		flag, err := s.shouldScheduleMaintenanceWindowTaskCheck(ctx, task, !skipIfAlreadyTerminated /* forceSchedule */)
+		if err != nil {
+			return nil, err
+		}
+
+		if flag {
+			_, err = s.server.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+				CreatorID:               creatorID,
+				TaskID:                  task.ID,
+				Type:                    api.TaskCheckGeneralMaintenanceWindow,
+				SkipIfAlreadyTerminated: false,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if task.Type == api.TaskDatabaseSchemaUpdate || task.Type == api.TaskDatabaseDataUpdate {
 		statement := ""
 
@@ -293,8 +363,8 @@ func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *ap
 			return nil, err
 		}
 
-		// For now we only supported MySQL dialect syntax and compatibility check
-		if database.Instance.Engine == db.MySQL || database.Instance.Engine == db.TiDB {
+		// For now we only supported MySQL dialect and Postgres syntax and compatibility check
+		if database.Instance.Engine == db.MySQL || database.Instance.Engine == db.TiDB || database.Instance.Engine == db.MariaDB || database.Instance.Engine == db.Postgres {
 			payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
 				Statement: statement,
 				DbType:    database.Instance.Engine,
@@ -316,9 +386,51 @@ func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *ap
 			}
 		}
 
+		if task.Type == api.TaskDatabaseDataUpdate {
+			payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
+				Statement: statement,
+				DbType:    database.Instance.Engine,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal statement affected rows payload: %v, err: %w", task.Name, err)
+			}
+			if _, err := s.server.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+				CreatorID:               creatorID,
+				TaskID:                  task.ID,
+				Type:                    api.TaskCheckDatabaseStatementAffectedRows,
+				Payload:                 string(payload),
+				SkipIfAlreadyTerminated: skipIfAlreadyTerminated,
+			}); err != nil {
+				return nil, err
+			}
+
+			capabilities, err := db.GetCapabilities(database.Instance.Engine)
+			if err != nil {
+				return nil, err
+			}
+			if capabilities.Explain {
+				payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
+					Statement: statement,
+					DbType:    database.Instance.Engine,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal statement full table scan payload: %v, err: %w", task.Name, err)
+				}
+				if _, err := s.server.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+					CreatorID:               creatorID,
+					TaskID:                  task.ID,
+					Type:                    api.TaskCheckDatabaseStatementFullTableScan,
+					Payload:                 string(payload),
+					SkipIfAlreadyTerminated: skipIfAlreadyTerminated,
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		if s.server.feature(api.FeatureSchemaReviewPolicy) &&
-			// For now we only supported MySQL dialect schema review check.
-			(database.Instance.Engine == db.MySQL || database.Instance.Engine == db.TiDB) {
+			// For now we only supported MySQL dialect and Postgres schema review check.
+			(database.Instance.Engine == db.MySQL || database.Instance.Engine == db.TiDB || database.Instance.Engine == db.MariaDB || database.Instance.Engine == db.Postgres) {
 			policyID, err := s.server.store.GetSchemaReviewPolicyIDByEnvID(ctx, task.Instance.EnvironmentID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get schema review policy ID for task: %v, in environment: %v, err: %w", task.Name, task.Instance.EnvironmentID, err)
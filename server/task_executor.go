@@ -122,15 +122,20 @@ func preMigration(ctx context.Context, server *Server, task *api.Task, migration
 }
 
 func executeMigration(ctx context.Context, pgInstanceDir string, task *api.Task, statement string, mi *db.MigrationInfo) (migrationID int64, schema string, err error) {
-	statement = strings.TrimSpace(statement)
-	databaseName := task.Database.Name
-
-	driver, err := getAdminDatabaseDriver(ctx, task.Instance, databaseName, pgInstanceDir)
+	driver, err := getAdminDatabaseDriver(ctx, task.Instance, task.Database.Name, pgInstanceDir)
 	if err != nil {
 		return 0, "", err
 	}
 	defer driver.Close(ctx)
 
+	return executeMigrationWithDriver(ctx, driver, task, statement, mi)
+}
+
+// executeMigrationWithDriver executes the migration by the driver, which is opened on the task database by the caller.
+func executeMigrationWithDriver(ctx context.Context, driver db.Driver, task *api.Task, statement string, mi *db.MigrationInfo) (migrationID int64, schema string, err error) {
+	statement = strings.TrimSpace(statement)
+	databaseName := task.Database.Name
+
 	log.Debug("Start migration...",
 		zap.String("instance", task.Instance.Name),
 		zap.String("database", databaseName),
@@ -331,7 +336,7 @@ func findRepositoryByTask(ctx context.Context, server *Server, task *api.Task) (
 // Writes back the latest schema to the repository after migration
 // Returns the commit id on success.
 func writeBackLatestSchema(ctx context.Context, server *Server, repository *api.Repository, pushEvent *vcsPlugin.PushEvent, mi *db.MigrationInfo, branch string, latestSchemaFile string, schema string, bytebaseURL string) (string, error) {
-	schemaFileMeta, err := vcsPlugin.Get(vcsPlugin.GitLabSelfHost, vcsPlugin.ProviderConfig{}).ReadFileMeta(
+	schemaFileMeta, err := vcsPlugin.Get(repository.VCS.Type, vcsPlugin.ProviderConfig{}).ReadFileMeta(
 		ctx,
 		common.OauthContext{
 			ClientID:     repository.VCS.ApplicationID,
@@ -378,7 +383,7 @@ func writeBackLatestSchema(ctx context.Context, server *Server, repository *api.
 			zap.String("schema_file", latestSchemaFile),
 		)
 
-		err := vcsPlugin.Get(vcsPlugin.GitLabSelfHost, vcsPlugin.ProviderConfig{}).CreateFile(
+		err := vcsPlugin.Get(repository.VCS.Type, vcsPlugin.ProviderConfig{}).CreateFile(
 			ctx,
 			common.OauthContext{
 				ClientID:     repository.VCS.ApplicationID,
@@ -402,7 +407,7 @@ func writeBackLatestSchema(ctx context.Context, server *Server, repository *api.
 		)
 
 		schemaFileCommit.LastCommitID = schemaFileMeta.LastCommitID
-		err := vcsPlugin.Get(vcsPlugin.GitLabSelfHost, vcsPlugin.ProviderConfig{}).OverwriteFile(
+		err := vcsPlugin.Get(repository.VCS.Type, vcsPlugin.ProviderConfig{}).OverwriteFile(
 			ctx,
 			common.OauthContext{
 				ClientID:     repository.VCS.ApplicationID,
@@ -422,7 +427,7 @@ func writeBackLatestSchema(ctx context.Context, server *Server, repository *api.
 	}
 
 	// VCS such as GitLab API doesn't return the commit on write, so we have to call ReadFileMeta again
-	schemaFileMeta, err = vcsPlugin.Get(vcsPlugin.GitLabSelfHost, vcsPlugin.ProviderConfig{}).ReadFileMeta(
+	schemaFileMeta, err = vcsPlugin.Get(repository.VCS.Type, vcsPlugin.ProviderConfig{}).ReadFileMeta(
 		ctx,
 		common.OauthContext{
 			ClientID:     repository.VCS.ApplicationID,
@@ -6,16 +6,30 @@ import (
 	"fmt"
 
 	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
+	pluginmysql "github.com/bytebase/bytebase/plugin/db/mysql"
+	"github.com/bytebase/bytebase/plugin/db/pg"
+	restoremysql "github.com/bytebase/bytebase/plugin/restore/mysql"
+	"github.com/bytebase/bytebase/resources/mysqlutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// rollbackMaxRowCount is the maximum number of the changed rows that the rollback statement reverts.
+	rollbackMaxRowCount = 10000
 )
 
 // NewDataUpdateTaskExecutor creates a data update (DML) task executor.
-func NewDataUpdateTaskExecutor() TaskExecutor {
-	return &SchemaUpdateTaskExecutor{}
+func NewDataUpdateTaskExecutor(instance *mysqlutil.Instance) TaskExecutor {
+	return &DataUpdateTaskExecutor{
+		mysqlutil: instance,
+	}
 }
 
 // DataUpdateTaskExecutor is the data update (DML) task executor.
 type DataUpdateTaskExecutor struct {
+	mysqlutil *mysqlutil.Instance
 }
 
 // RunOnce will run the data update (DML) task executor once.
@@ -25,5 +39,122 @@ func (exec *DataUpdateTaskExecutor) RunOnce(ctx context.Context, server *Server,
 		return true, nil, fmt.Errorf("invalid database data update payload: %w", err)
 	}
 
-	return runMigration(ctx, server, task, db.Data, payload.Statement, payload.SchemaVersion, payload.VCSPushEvent)
+	mi, err := preMigration(ctx, server, task, db.Data, payload.Statement, payload.SchemaVersion, payload.VCSPushEvent)
+	if err != nil {
+		return true, nil, err
+	}
+
+	driver, err := getAdminDatabaseDriver(ctx, task.Instance, task.Database.Name, server.pgInstanceDir)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+
+	// The rollback statement is best-effort, failing to generate it doesn't fail the data update.
+	rollback := exec.beginRollback(ctx, server, task, driver)
+
+	migrationID, schema, err := executeMigrationWithDriver(ctx, driver, task, payload.Statement, mi)
+	if err != nil {
+		return true, nil, err
+	}
+	rollbackStatement, rollbackErr := rollback.generate(ctx)
+
+	terminated, result, err = postMigration(ctx, server, task, payload.VCSPushEvent, mi, migrationID, schema)
+	if err != nil {
+		return terminated, result, err
+	}
+	result.RollbackStatement = rollbackStatement
+	if rollbackErr != nil {
+		log.Warn("Failed to generate the rollback statement for the data update",
+			zap.Int("task_id", task.ID),
+			zap.Error(rollbackErr),
+		)
+		result.RollbackError = rollbackErr.Error()
+	}
+	return terminated, result, nil
+}
+
+// dataUpdateRollback captures the rows changed by a data update executed by the driver.
+// For MySQL, it records the binlog coordinations around the data update, and decodes the row events of the executing connection later.
+// For Postgres, the driver captures the rows in the transaction executing the data update.
+type dataUpdateRollback struct {
+	task   *api.Task
+	driver db.Driver
+	err    error
+
+	mysqlRestore    *restoremysql.Restore
+	startBinlogInfo api.BinlogInfo
+}
+
+// beginRollback prepares capturing the rows, it must be called right before executing the data update by the driver.
+func (exec *DataUpdateTaskExecutor) beginRollback(ctx context.Context, server *Server, task *api.Task, driver db.Driver) *dataUpdateRollback {
+	rollback := &dataUpdateRollback{task: task, driver: driver}
+	switch task.Instance.Engine {
+	case db.MySQL, db.MariaDB:
+		rollback.err = rollback.beginMySQL(ctx, server, exec.mysqlutil)
+	case db.Postgres:
+		rollback.err = rollback.beginPostgres()
+	default:
+		rollback.err = fmt.Errorf("rollback isn't supported for %s", task.Instance.Engine)
+	}
+	return rollback
+}
+
+func (r *dataUpdateRollback) beginMySQL(ctx context.Context, server *Server, instance *mysqlutil.Instance) error {
+	mysqlDriver, ok := r.driver.(*pluginmysql.Driver)
+	if !ok {
+		return fmt.Errorf("[internal] cast driver to mysql.Driver failed")
+	}
+	connCfg, err := getConnectionConfig(ctx, r.task.Instance, r.task.Database.Name)
+	if err != nil {
+		return err
+	}
+	dataDir := server.profile.DataDir
+	if err := createBinlogDir(dataDir, r.task.Instance.ID); err != nil {
+		return err
+	}
+	r.mysqlRestore = restoremysql.New(mysqlDriver, instance, connCfg, getBinlogAbsDir(dataDir, r.task.Instance.ID))
+	if err := r.mysqlRestore.CheckBinlogEnabled(ctx); err != nil {
+		return err
+	}
+	if err := r.mysqlRestore.CheckBinlogRowFormat(ctx); err != nil {
+		return err
+	}
+
+	r.startBinlogInfo, err = mysqlDriver.GetBinlogInfo(ctx)
+	return err
+}
+
+func (r *dataUpdateRollback) beginPostgres() error {
+	pgDriver, ok := r.driver.(*pg.Driver)
+	if !ok {
+		return fmt.Errorf("[internal] cast driver to pg.Driver failed")
+	}
+	pgDriver.EnableRollback(rollbackMaxRowCount)
+	return nil
+}
+
+// generate returns the rollback statement, it must be called right after executing the data update.
+func (r *dataUpdateRollback) generate(ctx context.Context) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	if r.mysqlRestore == nil {
+		return r.driver.(*pg.Driver).GetRollbackStatement()
+	}
+
+	mysqlDriver := r.driver.(*pluginmysql.Driver)
+	// Only the row events of the connection executing the data update are reverted, not the ones of the other sessions.
+	threadID := mysqlDriver.GetExecuteConnectionID()
+	if threadID == 0 {
+		return "", fmt.Errorf("failed to find the connection executing the data update")
+	}
+	endBinlogInfo, err := mysqlDriver.GetBinlogInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := r.mysqlRestore.IncrementalFetchAllBinlogFiles(ctx); err != nil {
+		return "", err
+	}
+	return r.mysqlRestore.GenerateRollbackSQL(ctx, r.task.Database.Name, r.startBinlogInfo, endBinlogInfo, threadID, rollbackMaxRowCount)
 }
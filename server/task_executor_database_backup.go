@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/bytebase/bytebase/api"
 	"github.com/bytebase/bytebase/common/log"
@@ -41,7 +43,8 @@ func (exec *DatabaseBackupTaskExecutor) RunOnce(ctx context.Context, server *Ser
 		zap.String("backup", backup.Name),
 	)
 
-	backupPayload, backupErr := exec.backupDatabase(ctx, task.Instance, task.Database.Name, backup, server.profile.DataDir, server.pgInstanceDir)
+	start := time.Now()
+	backupPayload, backupErr := exec.backupDatabase(ctx, server, task.Instance, task.Database.Name, backup)
 	// Update the status of the backup.
 	newBackupStatus := string(api.BackupStatusDone)
 	comment := ""
@@ -50,12 +53,13 @@ func (exec *DatabaseBackupTaskExecutor) RunOnce(ctx context.Context, server *Ser
 		comment = backupErr.Error()
 		backupPayload = "{}"
 	}
+	server.metrics.backupDuration.WithLabelValues(string(backup.StorageBackend), newBackupStatus).Observe(time.Since(start).Seconds())
 	if _, err := server.store.PatchBackup(ctx, &api.BackupPatch{
 		ID:        backup.ID,
-		Status:    newBackupStatus,
+		Status:    &newBackupStatus,
 		UpdaterID: api.SystemBotID,
-		Comment:   comment,
-		Payload:   backupPayload,
+		Comment:   &comment,
+		Payload:   &backupPayload,
 	}); err != nil {
 		return true, nil, fmt.Errorf("failed to patch backup: %w", err)
 	}
@@ -69,28 +73,64 @@ func (exec *DatabaseBackupTaskExecutor) RunOnce(ctx context.Context, server *Ser
 	}, nil
 }
 
-// backupDatabase will take a backup of a database.
-func (exec *DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, instance *api.Instance, databaseName string, backup *api.Backup, dataDir, pgInstanceDir string) (string, error) {
-	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, pgInstanceDir)
+// backupDatabase will take a backup of a database and upload it to the backup storage.
+func (exec *DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) (string, error) {
+	backupStorage, err := server.getBackupStorage(ctx, backup.StorageBackend, instance.EnvironmentID)
 	if err != nil {
 		return "", err
 	}
-	defer driver.Close(ctx)
 
-	f, err := os.Create(filepath.Join(dataDir, backup.Path))
-	if err != nil {
-		return "", fmt.Errorf("failed to open backup path: %s", backup.Path)
-	}
-	defer f.Close()
-
-	payload, err := driver.Dump(ctx, databaseName, f, false /* schemaOnly */)
+	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, server.pgInstanceDir)
 	if err != nil {
 		return "", err
 	}
+	defer driver.Close(ctx)
 
+	// Stream the dump to the storage, so that we don't keep a local copy of the backup.
+	pr, pw := io.Pipe()
+	cw := &countingWriter{w: pw}
+	var payload string
+	var dumpErr error
+	dumpDone := make(chan struct{})
+	go func() {
+		defer close(dumpDone)
+		payload, dumpErr = driver.Dump(ctx, databaseName, cw, false /* schemaOnly */)
+		// A nil error closes the writer with EOF, which completes the upload.
+		pw.CloseWithError(dumpErr)
+	}()
+	uploadErr := backupStorage.Upload(ctx, backup.Path, pr)
+	// Unblock the dump if the upload fails halfway.
+	pr.CloseWithError(uploadErr)
+	<-dumpDone
+
+	if dumpErr != nil || uploadErr != nil {
+		if err := backupStorage.Delete(ctx, backup.Path); err != nil {
+			log.Warn("Failed to delete the incomplete backup",
+				zap.String("backup", backup.Name),
+				zap.String("path", backup.Path),
+				zap.Error(err))
+		}
+		if dumpErr != nil {
+			return "", dumpErr
+		}
+		return "", fmt.Errorf("failed to upload backup to %s storage: %w", backup.StorageBackend, uploadErr)
+	}
+	server.metrics.backupSize.WithLabelValues(string(backup.StorageBackend)).Observe(float64(cw.n))
 	return payload, nil
 }
 
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
 // Get backup dir relative to the data dir.
 func getBackupRelativeDir(databaseID int) string {
 	return filepath.Join("backup", "db", fmt.Sprintf("%d", databaseID))
@@ -101,18 +141,6 @@ func getBackupRelativeFilePath(databaseID int, name string) string {
 	return filepath.Join(dir, fmt.Sprintf("%s.sql", name))
 }
 
-func getBackupAbsFilePath(dataDir string, databaseID int, name string) string {
-	path := getBackupRelativeFilePath(databaseID, name)
-	return filepath.Join(dataDir, path)
-}
-
-// Create backup directory for database.
-func createBackupDirectory(dataDir string, databaseID int) error {
-	dir := getBackupRelativeDir(databaseID)
-	absDir := filepath.Join(dataDir, dir)
-	return os.MkdirAll(absDir, os.ModePerm)
-}
-
 func getBinlogRelativeDir(instanceID int) string {
 	return filepath.Join("backup", "instance", fmt.Sprintf("%d", instanceID))
 }
@@ -5,8 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/bytebase/bytebase/api"
@@ -40,6 +38,9 @@ func (exec *DatabaseRestoreTaskExecutor) RunOnce(ctx context.Context, server *Se
 	if backup == nil {
 		return true, nil, fmt.Errorf("backup with ID[%d] not found", payload.BackupID)
 	}
+	if backup.RowStatus == api.Archived {
+		return true, nil, fmt.Errorf("backup %q has been pruned by the retention policy", backup.Name)
+	}
 
 	sourceDatabase, err := server.store.GetDatabase(ctx, &api.DatabaseFind{ID: &backup.DatabaseID})
 	if err != nil {
@@ -70,7 +71,7 @@ func (exec *DatabaseRestoreTaskExecutor) RunOnce(ctx context.Context, server *Se
 	)
 
 	// Restore the database to the target database.
-	if err := exec.restoreDatabase(ctx, targetDatabase.Instance, targetDatabase.Name, backup, server.profile.DataDir, server.pgInstanceDir); err != nil {
+	if err := exec.restoreDatabase(ctx, server, targetDatabase.Instance, targetDatabase.Name, backup, sourceDatabase.Instance.EnvironmentID); err != nil {
 		return true, nil, err
 	}
 
@@ -104,25 +105,25 @@ func (exec *DatabaseRestoreTaskExecutor) RunOnce(ctx context.Context, server *Se
 	}, nil
 }
 
-// restoreDatabase will restore the database from a backup
-func (exec *DatabaseRestoreTaskExecutor) restoreDatabase(ctx context.Context, instance *api.Instance, databaseName string, backup *api.Backup, dataDir, pgInstanceDir string) error {
-	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, pgInstanceDir)
+// restoreDatabase will restore the database from a backup taken in the environment of backupEnvironmentID.
+func (exec *DatabaseRestoreTaskExecutor) restoreDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup, backupEnvironmentID int) error {
+	backupStorage, err := server.getBackupStorage(ctx, backup.StorageBackend, backupEnvironmentID)
 	if err != nil {
 		return err
 	}
-	defer driver.Close(ctx)
 
-	backupPath := backup.Path
-	if !filepath.IsAbs(backupPath) {
-		backupPath = filepath.Join(dataDir, backupPath)
+	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, server.pgInstanceDir)
+	if err != nil {
+		return err
 	}
+	defer driver.Close(ctx)
 
-	f, err := os.OpenFile(backupPath, os.O_RDONLY, os.ModePerm)
+	r, err := backupStorage.Download(ctx, backup.Path)
 	if err != nil {
-		return fmt.Errorf("failed to open backup file at %s: %w", backupPath, err)
+		return fmt.Errorf("failed to open backup file at %s: %w", backup.Path, err)
 	}
-	defer f.Close()
-	sc := bufio.NewScanner(f)
+	defer r.Close()
+	sc := bufio.NewScanner(r)
 
 	if err := driver.Restore(ctx, sc); err != nil {
 		return fmt.Errorf("failed to restore backup: %w", err)
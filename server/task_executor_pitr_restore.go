@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/bytebase/bytebase/api"
@@ -45,7 +44,7 @@ func (exec *PITRRestoreTaskExecutor) RunOnce(ctx context.Context, server *Server
 	}
 	defer driver.Close(ctx)
 
-	if err := exec.doPITRRestore(ctx, task, server.store, driver, server.profile.DataDir, payload.PointInTimeTs); err != nil {
+	if err := exec.doPITRRestore(ctx, task, server, driver, payload.PointInTimeTs); err != nil {
 		log.Error("Failed to do PITR restore", zap.Error(err))
 		return true, nil, err
 	}
@@ -57,17 +56,19 @@ func (exec *PITRRestoreTaskExecutor) RunOnce(ctx context.Context, server *Server
 	}, nil
 }
 
-func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *api.Task, store *store.Store, driver db.Driver, dataDir string, targetTs int64) error {
+func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *api.Task, server *Server, driver db.Driver, targetTs int64) error {
 	instance := task.Instance
 	database := task.Database
+	dataDir := server.profile.DataDir
 
-	issue, err := getIssueByPipelineID(ctx, store, task.PipelineID)
+	issue, err := getIssueByPipelineID(ctx, server.store, task.PipelineID)
 	if err != nil {
 		return err
 	}
 
 	backupStatus := api.BackupStatusDone
-	backupList, err := store.FindBackup(ctx, &api.BackupFind{DatabaseID: task.DatabaseID, Status: &backupStatus})
+	rowStatus := api.Normal
+	backupList, err := server.store.FindBackup(ctx, &api.BackupFind{DatabaseID: task.DatabaseID, RowStatus: &rowStatus, Status: &backupStatus})
 	if err != nil {
 		return err
 	}
@@ -108,13 +109,16 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *ap
 		return fmt.Errorf("failed to get latest backup before or equal to %s, error: %w", dateTime, err)
 	}
 	log.Debug("Got latest backup before or equal to targetTs", zap.String("backup", backup.Name))
-	backupFileName := getBackupAbsFilePath(dataDir, backup.DatabaseID, backup.Name)
-	backupFile, err := os.OpenFile(backupFileName, os.O_RDONLY, os.ModePerm)
+	backupStorage, err := server.getBackupStorage(ctx, backup.StorageBackend, instance.EnvironmentID)
 	if err != nil {
-		return fmt.Errorf("failed to open backup file: %s, error: %w", backupFileName, err)
+		return err
+	}
+	backupFile, err := backupStorage.Download(ctx, backup.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %s, error: %w", backup.Path, err)
 	}
 	defer backupFile.Close()
-	log.Debug("Successfully opened backup file", zap.String("filename", backupFileName))
+	log.Debug("Successfully opened backup file", zap.String("filename", backup.Path))
 
 	log.Debug("Start creating and restoring PITR database",
 		zap.String("instance", instance.Name),
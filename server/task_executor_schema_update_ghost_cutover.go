@@ -49,12 +49,17 @@ func (exec *SchemaUpdateGhostCutoverTaskExecutor) RunOnce(ctx context.Context, s
 	}
 
 	ticker := time.NewTicker(time.Second * 1)
+	defer ticker.Stop()
 
-	for range ticker.C {
-		if _, err := os.Stat(socketFilename); err != nil {
-			break
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := os.Stat(socketFilename); err != nil {
+				return true, &api.TaskRunResultPayload{Detail: "cutover done"}, nil
+			}
+		case <-ctx.Done():
+			// The postpone flag file is already removed, so gh-ost may still complete the cutover.
+			return true, nil, fmt.Errorf("stopped waiting for the gh-ost cutover, the cutover may still complete")
 		}
 	}
-
-	return true, &api.TaskRunResultPayload{Detail: "cutover done"}, nil
 }
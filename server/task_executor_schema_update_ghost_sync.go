@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -22,6 +24,11 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// ghostCommandTimeout is the timeout of sending an interactive command to gh-ost.
+	ghostCommandTimeout = 10 * time.Second
+)
+
 // NewSchemaUpdateGhostSyncTaskExecutor creates a schema update (gh-ost) sync task executor.
 func NewSchemaUpdateGhostSyncTaskExecutor() TaskExecutor {
 	return &SchemaUpdateGhostSyncTaskExecutor{}
@@ -158,31 +165,45 @@ func runGhostMigration(ctx context.Context, server *Server, task *api.Task, stat
 	}
 
 	syncDone := make(chan struct{})
-	syncError := make(chan error)
+	// syncResult is buffered because gh-ost keeps running until cutover after the task run returns.
+	syncResult := make(chan error, 1)
+	syncCanceled := make(chan struct{})
 
 	go func() {
-		migrationID, schema, err := executeSync(ctx, task, mi, statement, syncDone)
+		// gh-ost outlives the task run, so it can't use the task run context.
+		ctx := context.Background()
+		migrationID, schema, err := executeSync(ctx, task, mi, statement, syncDone, syncCanceled)
 		if err != nil {
 			log.Error("failed to execute schema update gh-ost sync executeSync", zap.Error(err))
-			syncError <- fmt.Errorf("failed to execute schema update gh-ost sync executeSync, error: %w", err)
+			syncResult <- fmt.Errorf("failed to execute schema update gh-ost sync executeSync, error: %w", err)
 			return
 		}
 		_, _, err = postMigration(ctx, server, task, vcsPushEvent, mi, migrationID, schema)
 		if err != nil {
 			log.Error("failed to execute schema update gh-ost sync postMigration", zap.Error(err))
 		}
+		syncResult <- nil
 	}()
 
 	select {
 	case <-syncDone:
 		return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
-	case err := <-syncError:
-		return true, nil, err
+	case err := <-syncResult:
+		if err != nil {
+			return true, nil, err
+		}
+		return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
+	case <-ctx.Done():
+		// The task is canceled before synced, abort gh-ost and wait for it to stop.
+		close(syncCanceled)
+		if err := <-syncResult; err != nil {
+			return true, nil, err
+		}
+		return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
 	}
-
 }
 
-func executeSync(ctx context.Context, task *api.Task, mi *db.MigrationInfo, statement string, syncDone chan<- struct{}) (migrationHistoryID int64, updatedSchema string, resErr error) {
+func executeSync(ctx context.Context, task *api.Task, mi *db.MigrationInfo, statement string, syncDone chan<- struct{}, syncCanceled <-chan struct{}) (migrationHistoryID int64, updatedSchema string, resErr error) {
 	statement = strings.TrimSpace(statement)
 
 	driver, err := getAdminDatabaseDriver(ctx, task.Instance, task.Database.Name, "" /* pgInstanceDir */)
@@ -219,7 +240,7 @@ func executeSync(ctx context.Context, task *api.Task, mi *db.MigrationInfo, stat
 			)
 		}
 	}()
-	if err = executeGhost(task, startedNs, statement, syncDone); err != nil {
+	if err = executeGhost(task, startedNs, statement, syncDone, syncCanceled); err != nil {
 		return -1, "", err
 	}
 
@@ -231,7 +252,7 @@ func executeSync(ctx context.Context, task *api.Task, mi *db.MigrationInfo, stat
 	return insertedID, afterSchemaBuf.String(), nil
 }
 
-func executeGhost(task *api.Task, startedNs int64, statement string, syncDone chan<- struct{}) error {
+func executeGhost(task *api.Task, startedNs int64, statement string, syncDone chan<- struct{}, syncCanceled <-chan struct{}) error {
 	instance := task.Instance
 	databaseName := task.Database.Name
 
@@ -244,6 +265,7 @@ func executeGhost(task *api.Task, startedNs int64, statement string, syncDone ch
 	if adminDataSource == nil {
 		return common.Errorf(common.Internal, fmt.Errorf("admin data source not found for instance %d", instance.ID))
 	}
+	socketFilename := getSocketFilename(task.ID, task.Database.ID, databaseName, tableName)
 
 	migrationContext, err := newMigrationContext(ghostConfig{
 		host:                 instance.Host,
@@ -253,7 +275,7 @@ func executeGhost(task *api.Task, startedNs int64, statement string, syncDone ch
 		database:             databaseName,
 		table:                tableName,
 		alterStatement:       statement,
-		socketFilename:       getSocketFilename(task.ID, task.Database.ID, databaseName, tableName),
+		socketFilename:       socketFilename,
 		postponeFlagFilename: getPostponeFlagFilename(task.ID, task.Database.ID, databaseName, tableName),
 		noop:                 false,
 		// On the source and each replica, you must set the server_id system variable to establish a unique replication ID. For each server, you should pick a unique positive integer in the range from 1 to 2^32 − 1, and each ID must be different from every other ID in use by any other source or replica in the replication topology. Example: server-id=3.
@@ -265,6 +287,10 @@ func executeGhost(task *api.Task, startedNs int64, statement string, syncDone ch
 		return fmt.Errorf("failed to init migrationContext for gh-ost, error: %w", err)
 	}
 
+	// gh-ost exits the process on the fatal errors by default, we abort the migration instead.
+	logger := newGhostLogger()
+	migrationContext.Log = logger
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	migrator := logic.NewMigrator(migrationContext)
@@ -285,8 +311,85 @@ func executeGhost(task *api.Task, startedNs int64, statement string, syncDone ch
 		}
 	}(ctx, migrationContext)
 
-	if err = migrator.Migrate(); err != nil {
-		return fmt.Errorf("failed to run gh-ost, error: %w", err)
+	migrateResult := make(chan error, 1)
+	go func() {
+		migrateResult <- migrator.Migrate()
+	}()
+
+	select {
+	case err := <-migrateResult:
+		if err != nil {
+			return fmt.Errorf("failed to run gh-ost, error: %w", err)
+		}
+		return nil
+	case err := <-logger.fatal:
+		return fmt.Errorf("gh-ost is aborted, please drop the gh-ost tables before trying again, error: %w", err)
+	case <-syncCanceled:
+		// gh-ost doesn't support aborting the migration gracefully, so we throttle it to stop copying rows, and then panic it.
+		// The panic is reported to the logger, and the throttled gh-ost stays idle.
+		if err := sendGhostCommand(socketFilename, "throttle"); err != nil {
+			log.Warn("failed to throttle gh-ost", zap.Error(err))
+		}
+		if err := sendGhostCommand(socketFilename, fmt.Sprintf("panic=%s", tableName)); err != nil {
+			return fmt.Errorf("failed to abort gh-ost, error: %w", err)
+		}
+		select {
+		case err := <-logger.fatal:
+			return fmt.Errorf("gh-ost is canceled, please drop the gh-ost tables before trying again, error: %w", err)
+		case err := <-migrateResult:
+			if err != nil {
+				return fmt.Errorf("failed to run gh-ost, error: %w", err)
+			}
+			return nil
+		}
+	}
+}
+
+// sendGhostCommand sends the interactive command to gh-ost through its socket.
+func sendGhostCommand(socketFilename, command string) error {
+	conn, err := net.DialTimeout("unix", socketFilename, ghostCommandTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(ghostCommandTimeout)); err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		return err
+	}
+	// Wait for gh-ost to handle the command, it closes the connection after responding.
+	_, err = io.Copy(io.Discard, conn)
+	return err
+}
+
+// ghostLogger is the gh-ost logger which reports the fatal errors instead of exiting the process.
+type ghostLogger struct {
+	base.Logger
+	fatal chan error
+}
+
+func newGhostLogger() *ghostLogger {
+	return &ghostLogger{
+		Logger: base.NewDefaultLogger(),
+		// gh-ost reports the fatal error once and then waits forever.
+		fatal: make(chan error, 1),
+	}
+}
+
+func (l *ghostLogger) Fatal(args ...interface{}) error {
+	return l.Fatale(fmt.Errorf("%s", fmt.Sprint(args...)))
+}
+
+func (l *ghostLogger) Fatalf(format string, args ...interface{}) error {
+	return l.Fatale(fmt.Errorf(format, args...))
+}
+
+func (l *ghostLogger) Fatale(err error) error {
+	log.Error("gh-ost fatal error", zap.Error(err))
+	select {
+	case l.fatal <- err:
+	default:
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// taskQueue is the FIFO queue of the tasks which have passed all checks but wait for the task concurrency limits.
+// The tasks are queued across the pipelines in the order they become ready to run, so that a large pipeline can't starve the others.
+type taskQueue struct {
+	mu        sync.Mutex
+	entryList []*taskQueueEntry
+}
+
+type taskQueueEntry struct {
+	task       *api.Task
+	enqueuedTs int64
+	// active is true if the task is still ready to run in the current scheduling round.
+	active bool
+}
+
+// push appends the task to the queue, or refreshes it if it's already queued.
+func (q *taskQueue) push(task *api.Task) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, entry := range q.entryList {
+		if entry.task.ID == task.ID {
+			entry.task = task
+			entry.active = true
+			return
+		}
+	}
+	q.entryList = append(q.entryList, &taskQueueEntry{
+		task:       task,
+		enqueuedTs: time.Now().Unix(),
+		active:     true,
+	})
+}
+
+// remove removes the task from the queue.
+func (q *taskQueue) remove(taskID int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, entry := range q.entryList {
+		if entry.task.ID == taskID {
+			q.entryList = append(q.entryList[:i], q.entryList[i+1:]...)
+			return
+		}
+	}
+}
+
+// beginRound marks all queued tasks inactive, the tasks still ready to run will be pushed again during the scheduling round.
+func (q *taskQueue) beginRound() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, entry := range q.entryList {
+		entry.active = false
+	}
+}
+
+// endRound removes the tasks which are no longer ready to run, e.g. canceled or failing the checks.
+func (q *taskQueue) endRound() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entryList []*taskQueueEntry
+	for _, entry := range q.entryList {
+		if entry.active {
+			entryList = append(entryList, entry)
+		}
+	}
+	q.entryList = entryList
+}
+
+// len returns the number of the queued tasks.
+func (q *taskQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entryList)
+}
+
+func (q *taskQueue) list() []taskQueueEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var entryList []taskQueueEntry
+	for _, entry := range q.entryList {
+		entryList = append(entryList, *entry)
+	}
+	return entryList
+}
+
+// admit returns true if the task can start running within the task concurrency limits of its environment.
+// Otherwise, the task waits in the task queue.
+func (s *TaskScheduler) admit(ctx context.Context, task *api.Task) (bool, error) {
+	if task.Instance == nil {
+		return false, fmt.Errorf("instance ID not found %v", task.InstanceID)
+	}
+	policy, err := s.server.store.GetTaskConcurrencyPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+	if err != nil {
+		return false, err
+	}
+	if policy.MaxRunningTaskCount == 0 && policy.MaxRunningTaskCountPerInstance == 0 {
+		return true, nil
+	}
+
+	s.queue.push(task)
+	itemList, err := s.getTaskQueue(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, item := range itemList {
+		if item.ID == task.ID {
+			return item.Reason == "", nil
+		}
+	}
+	return false, nil
+}
+
+// RunTask starts running the task on the user request, e.g. run or retry, within the task concurrency limits.
+// It returns a Conflict error if the limits are reached, and the PENDING task keeps waiting in the task queue.
+func (s *TaskScheduler) RunTask(ctx context.Context, task *api.Task, taskStatusPatch *api.TaskStatusPatch) (*api.Task, error) {
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+	admitted, err := s.admit(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if !admitted {
+		// Only the PENDING tasks are queued by the scheduler, the others shouldn't hold a queue position.
+		if task.Status != api.TaskPending {
+			s.queue.remove(task.ID)
+		}
+		return nil, &common.Error{Code: common.Conflict, Err: fmt.Errorf("task %q can't start running because the task concurrency limits are reached, please try again later", task.Name)}
+	}
+
+	updatedTask, err := s.server.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+	if err != nil {
+		return nil, err
+	}
+	s.queue.remove(task.ID)
+
+	return updatedTask, nil
+}
+
+// getTaskQueue assigns the free running slots to the queued tasks in the FIFO order.
+// The tasks getting a slot have no waiting reason.
+func (s *TaskScheduler) getTaskQueue(ctx context.Context) ([]*api.TaskQueueItem, error) {
+	entryList := s.queue.list()
+	if len(entryList) == 0 {
+		return []*api.TaskQueueItem{}, nil
+	}
+
+	taskStatusList := []api.TaskStatus{api.TaskRunning}
+	taskFind := &api.TaskFind{
+		StatusList: &taskStatusList,
+	}
+	runningTaskList, err := s.server.store.FindTask(ctx, taskFind, false)
+	if err != nil {
+		return nil, err
+	}
+	instanceRunningCount := make(map[int]int)
+	environmentRunningCount := make(map[int]int)
+	for _, task := range runningTaskList {
+		if task.Instance == nil {
+			continue
+		}
+		instanceRunningCount[task.InstanceID]++
+		environmentRunningCount[task.Instance.EnvironmentID]++
+	}
+
+	policyMap := make(map[int]*api.TaskConcurrencyPolicy)
+	var itemList []*api.TaskQueueItem
+	for i, entry := range entryList {
+		task := entry.task
+		environmentID := task.Instance.EnvironmentID
+		policy, ok := policyMap[environmentID]
+		if !ok {
+			policy, err = s.server.store.GetTaskConcurrencyPolicyByEnvID(ctx, environmentID)
+			if err != nil {
+				return nil, err
+			}
+			policyMap[environmentID] = policy
+		}
+
+		reason := ""
+		if limit := policy.MaxRunningTaskCountPerInstance; limit > 0 && instanceRunningCount[task.InstanceID] >= limit {
+			reason = fmt.Sprintf("Instance %q has reached the limit of %d running tasks", task.Instance.Name, limit)
+		} else if limit := policy.MaxRunningTaskCount; limit > 0 && environmentRunningCount[environmentID] >= limit {
+			reason = fmt.Sprintf("Environment %q has reached the limit of %d running tasks", task.Instance.Environment.Name, limit)
+		} else {
+			// Reserve the slot so that the tasks queued later can't take it.
+			instanceRunningCount[task.InstanceID]++
+			environmentRunningCount[environmentID]++
+		}
+
+		itemList = append(itemList, &api.TaskQueueItem{
+			ID:            task.ID,
+			PipelineID:    task.PipelineID,
+			InstanceID:    task.InstanceID,
+			EnvironmentID: environmentID,
+			Name:          task.Name,
+			Position:      i + 1,
+			EnqueuedTs:    entry.enqueuedTs,
+			Reason:        reason,
+		})
+	}
+	return itemList, nil
+}
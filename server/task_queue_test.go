@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestTaskQueue(t *testing.T) {
+	taskIDs := func(q *taskQueue) []int {
+		var ids []int
+		for _, entry := range q.list() {
+			ids = append(ids, entry.task.ID)
+		}
+		return ids
+	}
+
+	q := &taskQueue{}
+	q.push(&api.Task{ID: 3})
+	q.push(&api.Task{ID: 1})
+	q.push(&api.Task{ID: 2})
+	// Pushing a queued task keeps its position.
+	q.push(&api.Task{ID: 3})
+	require.Equal(t, []int{3, 1, 2}, taskIDs(q))
+
+	q.remove(1)
+	require.Equal(t, []int{3, 2}, taskIDs(q))
+
+	// The tasks not pushed again during the round are no longer ready to run.
+	q.beginRound()
+	q.push(&api.Task{ID: 2})
+	q.push(&api.Task{ID: 4})
+	q.endRound()
+	require.Equal(t, []int{2, 4}, taskIDs(q))
+	require.Equal(t, 2, q.len())
+}
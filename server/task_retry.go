@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+var (
+	// transientMySQLErrorNumbers is the MySQL error numbers which may succeed on retry.
+	// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+	transientMySQLErrorNumbers = map[uint16]bool{
+		1040: true, // ER_CON_COUNT_ERROR, too many connections.
+		1205: true, // ER_LOCK_WAIT_TIMEOUT.
+		1213: true, // ER_LOCK_DEADLOCK.
+		2006: true, // CR_SERVER_GONE_ERROR.
+		2013: true, // CR_SERVER_LOST.
+	}
+	// transientPostgresSQLStates is the Postgres SQLSTATE codes which may succeed on retry, in addition to the connection exception class 08.
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	transientPostgresSQLStates = map[string]bool{
+		"40001": true, // serialization_failure.
+		"40P01": true, // deadlock_detected.
+		"53300": true, // too_many_connections.
+		"55P03": true, // lock_not_available.
+		"57P01": true, // admin_shutdown.
+		"57P02": true, // crash_shutdown.
+		"57P03": true, // cannot_connect_now.
+	}
+)
+
+// taskErrorCode returns the error code of the failed task run.
+// The drivers return the transient database errors as the generic execution errors, so we classify them by the driver error codes.
+func taskErrorCode(err error) common.Code {
+	code := common.ErrorCode(err)
+	if code != common.DbConnectionFailure && isTransientDBError(err) {
+		return common.DbTransientError
+	}
+	return code
+}
+
+// isTransientDBError returns true if the error is a transient database error, e.g. connection reset, lock wait timeout and deadlock.
+func isTransientDBError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return transientMySQLErrorNumbers[mysqlErr.Number]
+	}
+	// The Postgres driver error reports the SQLSTATE code.
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		sqlState := pgErr.SQLState()
+		return strings.HasPrefix(sqlState, "08") || transientPostgresSQLStates[sqlState]
+	}
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTaskIdempotent returns true if the task is safe to run again after a failure.
+// They are the backup, the baseline schema update which syncs the current schema without applying any statement,
+// and the data update flagged as idempotent.
+func isTaskIdempotent(task *api.Task) bool {
+	switch task.Type {
+	case api.TaskDatabaseBackup:
+		return true
+	case api.TaskDatabaseSchemaUpdate:
+		payload := &api.TaskDatabaseSchemaUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return false
+		}
+		return payload.MigrationType == db.Baseline
+	case api.TaskDatabaseDataUpdate:
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return false
+		}
+		return payload.Idempotent
+	}
+	return false
+}
+
+// isRetryableCode returns true if the task run failing with the code can be retried by the task retry policy.
+func isRetryableCode(policy *api.TaskRetryPolicy, code common.Code) bool {
+	if code == common.DbConnectionFailure || code == common.DbTransientError {
+		return true
+	}
+	for _, retryableCode := range policy.RetryableCodeList {
+		if code == retryableCode {
+			return true
+		}
+	}
+	return false
+}
+
+// getFailedAttempts returns the latest task run and the number of the consecutive failed runs.
+// The counting stops at the run started by a user, e.g. the manual retry, so that the user retry gets the full attempts again.
+func getFailedAttempts(task *api.Task) (*api.TaskRun, int) {
+	taskRunList := make([]*api.TaskRun, len(task.TaskRunList))
+	copy(taskRunList, task.TaskRunList)
+	sort.Slice(taskRunList, func(i, j int) bool {
+		return taskRunList[i].ID > taskRunList[j].ID
+	})
+	if len(taskRunList) == 0 {
+		return nil, 0
+	}
+
+	count := 0
+	for _, taskRun := range taskRunList {
+		if taskRun.Status != api.TaskRunFailed {
+			break
+		}
+		count++
+		if taskRun.CreatorID != api.SystemBotID {
+			break
+		}
+	}
+	return taskRunList[0], count
+}
+
+// getTaskRetryBackoff returns the delay before retrying the task which has failed the attempts.
+func getTaskRetryBackoff(policy *api.TaskRetryPolicy, attempts int) time.Duration {
+	backoff := time.Duration(policy.BackoffSeconds) * time.Second
+	maxBackoff := time.Duration(policy.MaxBackoffSeconds) * time.Second
+	for i := 1; i < attempts; i++ {
+		if maxBackoff > 0 && backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// RetryIfNeeded retries the FAILED task if the task retry policy of its environment allows it.
+// Each retry starts a new task run. The retries stop after the max attempts or when the task fails with a non-retryable error.
+func (s *TaskScheduler) RetryIfNeeded(ctx context.Context, task *api.Task) (*api.Task, error) {
+	if task.Status != api.TaskFailed || !isTaskIdempotent(task) {
+		return task, nil
+	}
+	if task.Instance == nil {
+		return nil, fmt.Errorf("instance ID not found %v", task.InstanceID)
+	}
+	policy, err := s.server.store.GetTaskRetryPolicyByEnvID(ctx, task.Instance.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if policy.MaxAttempts <= 1 {
+		return task, nil
+	}
+
+	lastTaskRun, attempts := getFailedAttempts(task)
+	if lastTaskRun == nil || attempts == 0 || attempts >= policy.MaxAttempts {
+		return task, nil
+	}
+	if !isRetryableCode(policy, lastTaskRun.Code) {
+		return task, nil
+	}
+	if time.Since(time.Unix(lastTaskRun.UpdatedTs, 0)) < getTaskRetryBackoff(policy, attempts) {
+		return task, nil
+	}
+
+	open, err := s.server.isInMaintenanceWindow(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return task, nil
+	}
+
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+	admitted, err := s.admit(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if !admitted {
+		return task, nil
+	}
+
+	task, err = s.renewTaskSchemaVersion(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	comment := fmt.Sprintf("Retry the failed task automatically, attempt %d of %d.", attempts+1, policy.MaxAttempts)
+	taskStatusPatch := &api.TaskStatusPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Status:    api.TaskRunning,
+		Comment:   &comment,
+	}
+	updatedTask, err := s.server.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+	if err != nil {
+		return nil, err
+	}
+	s.queue.remove(task.ID)
+
+	return updatedTask, nil
+}
+
+// renewTaskSchemaVersion updates the schema version of the migration task before the retry,
+// otherwise we will get migration history version conflict with the failed attempt.
+func (s *TaskScheduler) renewTaskSchemaVersion(ctx context.Context, task *api.Task) (*api.Task, error) {
+	var payload interface{}
+	switch task.Type {
+	case api.TaskDatabaseSchemaUpdate:
+		schemaUpdatePayload := &api.TaskDatabaseSchemaUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), schemaUpdatePayload); err != nil {
+			return nil, fmt.Errorf("invalid database schema update payload: %w", err)
+		}
+		schemaUpdatePayload.SchemaVersion = common.DefaultMigrationVersion()
+		payload = schemaUpdatePayload
+	case api.TaskDatabaseDataUpdate:
+		dataUpdatePayload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), dataUpdatePayload); err != nil {
+			return nil, fmt.Errorf("invalid database data update payload: %w", err)
+		}
+		dataUpdatePayload.SchemaVersion = common.DefaultMigrationVersion()
+		payload = dataUpdatePayload
+	default:
+		return task, nil
+	}
+
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	payloadStr := string(bytes)
+	taskPatch := &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadStr,
+	}
+	return s.server.store.PatchTask(ctx, taskPatch)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+type fakePgError struct {
+	sqlState string
+}
+
+func (e *fakePgError) Error() string {
+	return fmt.Sprintf("ERROR (SQLSTATE %s)", e.sqlState)
+}
+
+func (e *fakePgError) SQLState() string {
+	return e.sqlState
+}
+
+func TestTaskErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want common.Code
+	}{
+		{
+			"mysqlDeadlock",
+			common.Errorf(common.DbExecutionError, fmt.Errorf("failed to execute: %w", &mysql.MySQLError{Number: 1213, Message: "Deadlock found"})),
+			common.DbTransientError,
+		},
+		{
+			"mysqlSyntaxError",
+			common.Errorf(common.DbExecutionError, &mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"}),
+			common.DbExecutionError,
+		},
+		{
+			"pgLockNotAvailable",
+			fmt.Errorf("failed to execute: %w", &fakePgError{sqlState: "55P03"}),
+			common.DbTransientError,
+		},
+		{
+			"pgConnectionException",
+			&fakePgError{sqlState: "08006"},
+			common.DbTransientError,
+		},
+		{
+			"pgUniqueViolation",
+			&fakePgError{sqlState: "23505"},
+			common.Internal,
+		},
+		{
+			"badConnection",
+			fmt.Errorf("failed to execute: %w", mysql.ErrInvalidConn),
+			common.DbTransientError,
+		},
+		{
+			"connectionFailure",
+			common.Errorf(common.DbConnectionFailure, fmt.Errorf("connection refused")),
+			common.DbConnectionFailure,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, taskErrorCode(test.err))
+		})
+	}
+}
+
+func TestGetTaskRetryBackoff(t *testing.T) {
+	policy := &api.TaskRetryPolicy{
+		BackoffSeconds:    30,
+		MaxBackoffSeconds: 100,
+	}
+	require.Equal(t, 30*time.Second, getTaskRetryBackoff(policy, 1))
+	require.Equal(t, 60*time.Second, getTaskRetryBackoff(policy, 2))
+	require.Equal(t, 100*time.Second, getTaskRetryBackoff(policy, 3))
+	require.Equal(t, 100*time.Second, getTaskRetryBackoff(policy, 10))
+
+	policy.MaxBackoffSeconds = 0
+	require.Equal(t, 240*time.Second, getTaskRetryBackoff(policy, 4))
+}
+
+func TestGetFailedAttempts(t *testing.T) {
+	userID := 101
+	task := &api.Task{
+		TaskRunList: []*api.TaskRun{
+			{ID: 4, CreatorID: api.SystemBotID, Status: api.TaskRunFailed},
+			{ID: 1, CreatorID: api.SystemBotID, Status: api.TaskRunFailed},
+			{ID: 3, CreatorID: userID, Status: api.TaskRunFailed},
+			{ID: 2, CreatorID: api.SystemBotID, Status: api.TaskRunFailed},
+		},
+	}
+	// The runs before the manual retry don't count.
+	lastTaskRun, attempts := getFailedAttempts(task)
+	require.Equal(t, 4, lastTaskRun.ID)
+	require.Equal(t, 2, attempts)
+
+	task.TaskRunList = append(task.TaskRunList, &api.TaskRun{ID: 5, CreatorID: api.SystemBotID, Status: api.TaskRunCanceled})
+	lastTaskRun, attempts = getFailedAttempts(task)
+	require.Equal(t, 5, lastTaskRun.ID)
+	require.Equal(t, 0, attempts)
+}
@@ -24,6 +24,8 @@ const (
 func NewTaskScheduler(server *Server) *TaskScheduler {
 	return &TaskScheduler{
 		executors: make(map[api.TaskType]TaskExecutor),
+		queue:     &taskQueue{},
+		running:   make(map[int]*runningTask),
 		server:    server,
 	}
 }
@@ -31,23 +33,29 @@ func NewTaskScheduler(server *Server) *TaskScheduler {
 // TaskScheduler is the task scheduler.
 type TaskScheduler struct {
 	executors map[api.TaskType]TaskExecutor
+	queue     *taskQueue
+	// admitMu serializes the admission of the tasks, so that the concurrency limits aren't exceeded by the concurrent scheduling.
+	admitMu sync.Mutex
+	// running is the running task executors by the task ID.
+	running   map[int]*runningTask
+	runningMu sync.Mutex
 
 	server *Server
 }
 
+// runningTask is a running task executor.
+type runningTask struct {
+	cancel context.CancelFunc
+	// cancelPatch is the status patch from the user canceling the task, it's nil if the task isn't canceled.
+	cancelPatch *api.TaskStatusPatch
+}
+
 // Run will run the task scheduler.
 func (s *TaskScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(taskSchedulerInterval)
 	defer ticker.Stop()
 	defer wg.Done()
 	log.Debug(fmt.Sprintf("Task scheduler started and will run every %v", taskSchedulerInterval))
-	tasks := struct {
-		running map[int]bool // task id set
-		mu      sync.RWMutex
-	}{
-		running: make(map[int]bool),
-		mu:      sync.RWMutex{},
-	}
 	for {
 		select {
 		case <-ticker.C:
@@ -65,6 +73,7 @@ func (s *TaskScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 				ctx := context.Background()
 
 				// Inspect all open pipelines and schedule the next PENDING task if applicable
+				s.queue.beginRound()
 				pipelineStatus := api.PipelineOpen
 				pipelineFind := &api.PipelineFind{
 					Status: &pipelineStatus,
@@ -86,6 +95,8 @@ func (s *TaskScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 						)
 					}
 				}
+				s.queue.endRound()
+				s.server.metrics.taskQueueDepth.Set(float64(s.queue.len()))
 
 				// Inspect all running tasks
 				taskStatusList := []api.TaskStatus{api.TaskRunning}
@@ -132,22 +143,37 @@ func (s *TaskScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 						continue
 					}
 
-					tasks.mu.Lock()
-					if _, ok := tasks.running[task.ID]; ok {
-						tasks.mu.Unlock()
+					executorCtx, run, err := s.startRunningTask(ctx, task)
+					if err != nil {
+						log.Error("Failed to start running task",
+							zap.Int("id", task.ID),
+							zap.Error(err))
+						continue
+					}
+					if run == nil {
 						continue
 					}
-					tasks.running[task.ID] = true
-					tasks.mu.Unlock()
 
 					go func(task *api.Task) {
 						defer func() {
-							tasks.mu.Lock()
-							delete(tasks.running, task.ID)
-							tasks.mu.Unlock()
+							s.runningMu.Lock()
+							delete(s.running, task.ID)
+							s.runningMu.Unlock()
+							run.cancel()
 						}()
-						done, result, err := RunTaskExecutorOnce(ctx, executor, s.server, task)
+						start := time.Now()
+						done, result, err := RunTaskExecutorOnce(executorCtx, executor, s.server, task)
+						// The executor context is canceled by the user if the scheduler context is still alive.
+						if ctx.Err() == nil && executorCtx.Err() != nil && (!done || err != nil) {
+							s.recordCanceledTask(ctx, task, run, err)
+							return
+						}
 						if done {
+							status := api.TaskDone
+							if err != nil {
+								status = api.TaskFailed
+							}
+							s.server.metrics.taskRunDuration.WithLabelValues(string(task.Type), string(status)).Observe(time.Since(start).Seconds())
 							if err == nil {
 								bytes, err := json.Marshal(*result)
 								if err != nil {
@@ -193,7 +219,7 @@ func (s *TaskScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 									)
 									return
 								}
-								code := common.ErrorCode(err)
+								code := taskErrorCode(err)
 								result := string(bytes)
 								taskStatusPatch := &api.TaskStatusPatch{
 									ID:        task.ID,
@@ -252,6 +278,16 @@ func (s *TaskScheduler) ScheduleIfNeeded(ctx context.Context, task *api.Task) (*
 		}
 	}
 
+	// The maintenance window is evaluated at scheduling time instead of relying on the latest task check,
+	// because the window may have closed since the check ran.
+	open, err := s.server.isInMaintenanceWindow(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return task, nil
+	}
+
 	// only schema update or data update task has required task check
 	if task.Type == api.TaskDatabaseSchemaUpdate || task.Type == api.TaskDatabaseDataUpdate {
 		pass, err := s.server.passCheck(ctx, s.server, task, api.TaskCheckDatabaseConnect)
@@ -270,6 +306,17 @@ func (s *TaskScheduler) ScheduleIfNeeded(ctx context.Context, task *api.Task) (*
 			return task, nil
 		}
 
+		// The affected row limit policy is enforced for the data update tasks.
+		if task.Type == api.TaskDatabaseDataUpdate {
+			pass, err = s.server.passCheck(ctx, s.server, task, api.TaskCheckDatabaseStatementAffectedRows)
+			if err != nil {
+				return nil, err
+			}
+			if !pass {
+				return task, nil
+			}
+		}
+
 		instance, err := s.server.store.GetInstanceByID(ctx, task.InstanceID)
 		if err != nil {
 			return nil, err
@@ -277,8 +324,8 @@ func (s *TaskScheduler) ScheduleIfNeeded(ctx context.Context, task *api.Task) (*
 		if instance == nil {
 			return nil, fmt.Errorf("instance ID not found %v", task.InstanceID)
 		}
-		// For now we only supported MySQL dialect syntax and compatibility check
-		if instance.Engine == db.MySQL || instance.Engine == db.TiDB {
+		// For now we only supported MySQL dialect and Postgres syntax and compatibility check
+		if instance.Engine == db.MySQL || instance.Engine == db.TiDB || instance.Engine == db.MariaDB || instance.Engine == db.Postgres {
 			pass, err = s.server.passCheck(ctx, s.server, task, api.TaskCheckDatabaseStatementSyntax)
 			if err != nil {
 				return nil, err
@@ -298,14 +345,108 @@ func (s *TaskScheduler) ScheduleIfNeeded(ctx context.Context, task *api.Task) (*
 			}
 		}
 	}
+
+	// The task has passed all checks, it waits in the task queue if the concurrency limits are reached.
+	s.admitMu.Lock()
+	defer s.admitMu.Unlock()
+	admitted, err := s.admit(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if !admitted {
+		return task, nil
+	}
+
 	updatedTask, err := s.server.changeTaskStatus(ctx, task, api.TaskRunning, api.SystemBotID)
 	if err != nil {
 		return nil, err
 	}
+	s.queue.remove(task.ID)
 
 	return updatedTask, nil
 }
 
+// startRunningTask registers the task executor about to run and returns the executor context.
+// It returns nil if the executor is already running or the task is no longer RUNNING.
+func (s *TaskScheduler) startRunningTask(ctx context.Context, task *api.Task) (context.Context, *runningTask, error) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	if _, ok := s.running[task.ID]; ok {
+		return nil, nil, nil
+	}
+	// The task may have been canceled since we fetched it.
+	latest, err := s.server.store.GetTaskByID(ctx, task.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest == nil || latest.Status != api.TaskRunning {
+		return nil, nil, nil
+	}
+	executorCtx, cancel := context.WithCancel(ctx)
+	run := &runningTask{
+		cancel: cancel,
+	}
+	s.running[task.ID] = run
+	return executorCtx, run, nil
+}
+
+// CancelTask cancels the RUNNING task.
+// If the task executor is running, it signals the executor to stop and returns the task still RUNNING,
+// the scheduler records the task as CANCELED after the executor stops.
+// Otherwise, it marks the task as CANCELED directly.
+func (s *TaskScheduler) CancelTask(ctx context.Context, task *api.Task, taskStatusPatch *api.TaskStatusPatch) (*api.Task, error) {
+	s.runningMu.Lock()
+	run, ok := s.running[task.ID]
+	if !ok {
+		defer s.runningMu.Unlock()
+		return s.server.changeTaskStatusWithPatch(ctx, task, taskStatusPatch)
+	}
+	run.cancelPatch = taskStatusPatch
+	run.cancel()
+	s.runningMu.Unlock()
+
+	return task, nil
+}
+
+// recordCanceledTask marks the task canceled by the user as CANCELED with the executor error.
+func (s *TaskScheduler) recordCanceledTask(ctx context.Context, task *api.Task, run *runningTask, executorErr error) {
+	s.runningMu.Lock()
+	taskStatusPatch := api.TaskStatusPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Status:    api.TaskCanceled,
+	}
+	if run.cancelPatch != nil {
+		taskStatusPatch = *run.cancelPatch
+	}
+	s.runningMu.Unlock()
+
+	detail := "The task is canceled"
+	if executorErr != nil {
+		detail = fmt.Sprintf("The task is canceled: %v", executorErr)
+	}
+	bytes, err := json.Marshal(api.TaskRunResultPayload{
+		Detail: detail,
+	})
+	if err != nil {
+		log.Error("Failed to marshal task run result",
+			zap.Int("task_id", task.ID),
+			zap.String("type", string(task.Type)),
+			zap.Error(err),
+		)
+		return
+	}
+	result := string(bytes)
+	taskStatusPatch.Result = &result
+	if _, err := s.server.changeTaskStatusWithPatch(ctx, task, &taskStatusPatch); err != nil {
+		log.Error("Failed to mark task as CANCELED",
+			zap.Int("id", task.ID),
+			zap.String("name", task.Name),
+			zap.Error(err),
+		)
+	}
+}
+
 func (s *TaskScheduler) isTaskBlocked(ctx context.Context, task *api.Task) (bool, error) {
 	for _, blockingTaskIDString := range task.BlockedBy {
 		blockingTaskID, err := strconv.Atoi(blockingTaskIDString)
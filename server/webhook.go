@@ -2,6 +2,9 @@ package server
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,11 +23,13 @@ import (
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/plugin/vcs"
+	"github.com/bytebase/bytebase/plugin/vcs/github"
 	"github.com/bytebase/bytebase/plugin/vcs/gitlab"
 )
 
 var (
 	gitLabWebhookPath = "hook/gitlab"
+	gitHubWebhookPath = "hook/github"
 )
 
 func (s *Server) registerWebhookRoutes(g *echo.Group) {
@@ -46,18 +51,9 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push", pushEvent.ObjectKind))
 		}
 
-		webhookEndpointID := c.Param("id")
-		repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{WebhookEndpointID: &webhookEndpointID})
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to respond webhook event for endpoint: %v", webhookEndpointID)).SetInternal(err)
-		}
-		if repo == nil {
-			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Endpoint not found: %v", webhookEndpointID))
-		}
-
-		if repo.VCS == nil {
-			err := fmt.Errorf("VCS not found for ID: %v", repo.VCSID)
-			return echo.NewHTTPError(http.StatusInternalServerError, err).SetInternal(err)
+			return err
 		}
 
 		if c.Request().Header.Get("X-Gitlab-Token") != repo.WebhookSecretToken {
@@ -81,26 +77,11 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 
 			for _, added := range commit.AddedList {
 				addedEscaped := common.EscapeForLogging(added)
-				log.Debug("Processing added file...",
-					zap.String("file", addedEscaped),
-				)
-
-				if !strings.HasPrefix(addedEscaped, repo.BaseDirectory) {
-					log.Debug("Ignored committed file, not under base directory.", zap.String("file", addedEscaped), zap.String("base_directory", repo.BaseDirectory))
-					continue
-				}
-
 				createdTime, err := time.Parse(time.RFC3339, commit.Timestamp)
 				if err != nil {
 					log.Warn("Ignored committed file, failed to parse commit timestamp.", zap.String("file", addedEscaped), zap.String("timestamp", common.EscapeForLogging(commit.Timestamp)), zap.Error(err))
 				}
 
-				// Ignore the schema file we auto generated to the repository.
-				if isSkipGeneratedSchemaFile(repo, addedEscaped) {
-					log.Debug("Ignored generated latest schema file.", zap.String("file", addedEscaped))
-					continue
-				}
-
 				vcsPushEvent := vcs.PushEvent{
 					VCSType:            repo.VCS.Type,
 					BaseDirectory:      repo.BaseDirectory,
@@ -119,116 +100,122 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 						Added:      addedEscaped,
 					},
 				}
-
-				// Create a WARNING project activity if committed file is ignored
-				var createIgnoredFileActivity = func(err error) {
-					log.Warn("Ignored committed file", zap.String("file", addedEscaped), zap.Error(err))
-					bytes, marshalErr := json.Marshal(api.ActivityProjectRepositoryPushPayload{
-						VCSPushEvent: vcsPushEvent,
-					})
-					if marshalErr != nil {
-						log.Warn("Failed to construct project activity payload to record ignored repository committed file", zap.Error(marshalErr))
-						return
-					}
-
-					activityCreate := &api.ActivityCreate{
-						CreatorID:   api.SystemBotID,
-						ContainerID: repo.ProjectID,
-						Type:        api.ActivityProjectRepositoryPush,
-						Level:       api.ActivityWarn,
-						Comment:     fmt.Sprintf("Ignored committed file %q, %s.", addedEscaped, err.Error()),
-						Payload:     string(bytes),
-					}
-					_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
-					if err != nil {
-						log.Warn("Failed to create project activity to record ignored repository committed file", zap.Error(err))
-					}
-				}
-
-				mi, err := db.ParseMigrationInfo(addedEscaped, filepath.Join(repo.BaseDirectory, repo.FilePathTemplate))
+				createdMessage, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent)
 				if err != nil {
-					createIgnoredFileActivity(err)
-					continue
+					return err
 				}
-
-				// Retrieve sql by reading the file content
-				content, err := vcs.Get(vcs.GitLabSelfHost, vcs.ProviderConfig{}).ReadFileContent(
-					ctx,
-					common.OauthContext{
-						ClientID:     repo.VCS.ApplicationID,
-						ClientSecret: repo.VCS.Secret,
-						AccessToken:  repo.AccessToken,
-						RefreshToken: repo.RefreshToken,
-						Refresher:    s.refreshToken(ctx, repo.ID),
-					},
-					repo.VCS.InstanceURL,
-					repo.ExternalID,
-					addedEscaped,
-					commit.ID,
-				)
-				if err != nil {
-					createIgnoredFileActivity(err)
-					continue
+				if createdMessage != "" {
+					createdMessageList = append(createdMessageList, createdMessage)
 				}
+			}
+		}
 
-				// Create schema update issue.
-				var createContext string
-				if repo.Project.TenantMode == api.TenantModeTenant {
-					if !s.feature(api.FeatureMultiTenancy) {
-						return echo.NewHTTPError(http.StatusForbidden, api.FeatureMultiTenancy.AccessErrorMessage())
-					}
-					createContext, err = s.createTenantSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, commit, addedEscaped, content)
-				} else {
-					createContext, err = s.createSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, commit, addedEscaped, content)
-				}
-				if err != nil {
-					createIgnoredFileActivity(err)
-					continue
-				}
+		if len(createdMessageList) == 0 {
+			msg := "Ignored push event. No applicable file found in the commit list."
+			log.Warn(msg,
+				zap.String("project", repo.Project.Name),
+			)
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+	})
 
-				issueType := api.IssueDatabaseSchemaUpdate
-				if mi.Type == db.Data {
-					issueType = api.IssueDatabaseDataUpdate
-				}
-				issueCreate := &api.IssueCreate{
-					ProjectID:     repo.ProjectID,
-					Name:          commit.Title,
-					Type:          issueType,
-					Description:   commit.Message,
-					AssigneeID:    api.SystemBotID,
-					CreateContext: createContext,
-				}
-				issue, err := s.createIssue(ctx, issueCreate, api.SystemBotID)
-				if err != nil {
-					errMsg := "Failed to create schema update issue"
-					if issueType == api.IssueDatabaseDataUpdate {
-						errMsg = "Failed to create data update issue"
-					}
-					return echo.NewHTTPError(http.StatusInternalServerError, errMsg).SetInternal(err)
-				}
+	g.POST("/github/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var b []byte
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read webhook request").SetInternal(err)
+		}
 
-				createdMessageList = append(createdMessageList, fmt.Sprintf("Created issue %q on adding %s", issue.Name, addedEscaped))
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
+		if err != nil {
+			return err
+		}
+
+		ok, err := validateGitHubWebhookSignature256(c.Request().Header.Get("X-Hub-Signature-256"), repo.WebhookSecretToken, b)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed webhook signature").SetInternal(err)
+		}
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "Webhook signature mismatch")
+		}
+
+		eventType := github.WebhookType(c.Request().Header.Get("X-GitHub-Event"))
+		switch eventType {
+		case github.WebhookPing:
+			// GitHub sends a ping event right after the webhook is created.
+			return c.String(http.StatusOK, "OK")
+		case github.WebhookPush:
+		default:
+			// This shouldn't happen as we only setup webhook to receive push event, just in case.
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push", eventType))
+		}
+
+		pushEvent := &github.WebhookPushEvent{}
+		if err := json.Unmarshal(b, pushEvent); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
+		}
+
+		if pushEvent.Repository.FullName != repo.ExternalID {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pushEvent.Repository.FullName, repo.ExternalID))
+		}
 
-				// Create a project activity after successfully creating the issue as the result of the push event
-				bytes, err := json.Marshal(api.ActivityProjectRepositoryPushPayload{
-					VCSPushEvent: vcsPushEvent,
-					IssueID:      issue.ID,
-					IssueName:    issue.Name,
-				})
+		// GitHub sends push events for tags and branch deletions as well, none of which carry migrations.
+		if !strings.HasPrefix(pushEvent.Ref, "refs/heads/") || pushEvent.Deleted {
+			log.Debug("Ignored push event, not a branch update.", zap.String("ref", common.EscapeForLogging(pushEvent.Ref)), zap.Bool("deleted", pushEvent.Deleted))
+			return c.String(http.StatusOK, "")
+		}
+
+		// Unlike GitLab, GitHub webhooks can't filter push events by branch, so we filter them here.
+		if repo.BranchFilter != "" && pushEvent.Ref != fmt.Sprintf("refs/heads/%s", repo.BranchFilter) {
+			log.Debug("Ignored push event, branch mismatch.", zap.String("ref", common.EscapeForLogging(pushEvent.Ref)), zap.String("branch_filter", repo.BranchFilter))
+			return c.String(http.StatusOK, "")
+		}
+
+		log.Debug("Processing github webhook push event...",
+			zap.String("project", repo.Project.Name),
+		)
+
+		createdMessageList := []string{}
+		for _, commit := range pushEvent.Commits {
+			// GitHub doesn't have a commit title, we use the first line of the commit message instead.
+			title := strings.SplitN(commit.Message, "\n", 2)[0]
+			log.Debug("Processing commit...",
+				zap.String("id", common.EscapeForLogging(commit.ID)),
+				zap.String("title", common.EscapeForLogging(title)),
+			)
+
+			for _, added := range commit.Added {
+				addedEscaped := common.EscapeForLogging(added)
+				createdTime, err := time.Parse(time.RFC3339, commit.Timestamp)
 				if err != nil {
-					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+					log.Warn("Ignored committed file, failed to parse commit timestamp.", zap.String("file", addedEscaped), zap.String("timestamp", common.EscapeForLogging(commit.Timestamp)), zap.Error(err))
 				}
 
-				activityCreate := &api.ActivityCreate{
-					CreatorID:   api.SystemBotID,
-					ContainerID: repo.ProjectID,
-					Type:        api.ActivityProjectRepositoryPush,
-					Level:       api.ActivityInfo,
-					Comment:     fmt.Sprintf("Created issue %q.", issue.Name),
-					Payload:     string(bytes),
+				vcsPushEvent := vcs.PushEvent{
+					VCSType:            repo.VCS.Type,
+					BaseDirectory:      repo.BaseDirectory,
+					Ref:                pushEvent.Ref,
+					RepositoryID:       pushEvent.Repository.FullName,
+					RepositoryURL:      pushEvent.Repository.HTMLURL,
+					RepositoryFullPath: pushEvent.Repository.FullName,
+					AuthorName:         pushEvent.Pusher.Name,
+					FileCommit: vcs.FileCommit{
+						ID:         commit.ID,
+						Title:      title,
+						Message:    commit.Message,
+						CreatedTs:  createdTime.Unix(),
+						URL:        commit.URL,
+						AuthorName: commit.Author.Name,
+						Added:      addedEscaped,
+					},
+				}
+				createdMessage, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent)
+				if err != nil {
+					return err
 				}
-				if _, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
-					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create project activity after creating issue from repository push event: %d", issue.ID)).SetInternal(err)
+				if createdMessage != "" {
+					createdMessageList = append(createdMessageList, createdMessage)
 				}
 			}
 		}
@@ -243,7 +230,176 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 	})
 }
 
-func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, commit gitlab.WebhookCommit, added string, statement string) (string, error) {
+// findWebhookRepository finds the repository with its VCS composed by the webhook endpoint ID.
+func (s *Server) findWebhookRepository(ctx context.Context, webhookEndpointID string) (*api.Repository, error) {
+	repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{WebhookEndpointID: &webhookEndpointID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to respond webhook event for endpoint: %v", webhookEndpointID)).SetInternal(err)
+	}
+	if repo == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Endpoint not found: %v", webhookEndpointID))
+	}
+
+	if repo.VCS == nil {
+		err := fmt.Errorf("VCS not found for ID: %v", repo.VCSID)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err).SetInternal(err)
+	}
+	return repo, nil
+}
+
+// validateGitHubWebhookSignature256 returns true if the signature from the "X-Hub-Signature-256" header
+// matches the HMAC-SHA256 digest of the payload using the webhook secret as the key.
+// See https://docs.github.com/en/developers/webhooks-and-events/webhooks/securing-your-webhooks
+func validateGitHubWebhookSignature256(signature, key string, body []byte) (bool, error) {
+	const prefix = "sha256="
+	if !strings.HasPrefix(signature, prefix) {
+		return false, nil
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false, err
+	}
+
+	m := hmac.New(sha256.New, []byte(key))
+	if _, err := m.Write(body); err != nil {
+		return false, err
+	}
+	return hmac.Equal(got, m.Sum(nil)), nil
+}
+
+// createIssueFromPushEvent creates a schema or data update issue for the file added by the push event.
+// It returns the message describing the created issue, or an empty message if the file is ignored.
+// The returned error is an echo HTTP error and should be returned to the webhook caller.
+func (s *Server) createIssueFromPushEvent(ctx context.Context, repo *api.Repository, vcsPushEvent vcs.PushEvent) (string, error) {
+	added := vcsPushEvent.FileCommit.Added
+	log.Debug("Processing added file...",
+		zap.String("file", added),
+	)
+
+	if !strings.HasPrefix(added, repo.BaseDirectory) {
+		log.Debug("Ignored committed file, not under base directory.", zap.String("file", added), zap.String("base_directory", repo.BaseDirectory))
+		return "", nil
+	}
+
+	// Ignore the schema file we auto generated to the repository.
+	if isSkipGeneratedSchemaFile(repo, added) {
+		log.Debug("Ignored generated latest schema file.", zap.String("file", added))
+		return "", nil
+	}
+
+	// Create a WARNING project activity if committed file is ignored
+	var createIgnoredFileActivity = func(err error) {
+		log.Warn("Ignored committed file", zap.String("file", added), zap.Error(err))
+		bytes, marshalErr := json.Marshal(api.ActivityProjectRepositoryPushPayload{
+			VCSPushEvent: vcsPushEvent,
+		})
+		if marshalErr != nil {
+			log.Warn("Failed to construct project activity payload to record ignored repository committed file", zap.Error(marshalErr))
+			return
+		}
+
+		activityCreate := &api.ActivityCreate{
+			CreatorID:   api.SystemBotID,
+			ContainerID: repo.ProjectID,
+			Type:        api.ActivityProjectRepositoryPush,
+			Level:       api.ActivityWarn,
+			Comment:     fmt.Sprintf("Ignored committed file %q, %s.", added, err.Error()),
+			Payload:     string(bytes),
+		}
+		_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
+		if err != nil {
+			log.Warn("Failed to create project activity to record ignored repository committed file", zap.Error(err))
+		}
+	}
+
+	mi, err := db.ParseMigrationInfo(added, filepath.Join(repo.BaseDirectory, repo.FilePathTemplate))
+	if err != nil {
+		createIgnoredFileActivity(err)
+		return "", nil
+	}
+
+	// Retrieve sql by reading the file content
+	content, err := vcs.Get(repo.VCS.Type, vcs.ProviderConfig{}).ReadFileContent(
+		ctx,
+		common.OauthContext{
+			ClientID:     repo.VCS.ApplicationID,
+			ClientSecret: repo.VCS.Secret,
+			AccessToken:  repo.AccessToken,
+			RefreshToken: repo.RefreshToken,
+			Refresher:    s.refreshToken(ctx, repo.ID),
+		},
+		repo.VCS.InstanceURL,
+		repo.ExternalID,
+		added,
+		vcsPushEvent.FileCommit.ID,
+	)
+	if err != nil {
+		createIgnoredFileActivity(err)
+		return "", nil
+	}
+
+	// Create schema update issue.
+	var createContext string
+	if repo.Project.TenantMode == api.TenantModeTenant {
+		if !s.feature(api.FeatureMultiTenancy) {
+			return "", echo.NewHTTPError(http.StatusForbidden, api.FeatureMultiTenancy.AccessErrorMessage())
+		}
+		createContext, err = s.createTenantSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, content)
+	} else {
+		createContext, err = s.createSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, added, content)
+	}
+	if err != nil {
+		createIgnoredFileActivity(err)
+		return "", nil
+	}
+
+	issueType := api.IssueDatabaseSchemaUpdate
+	if mi.Type == db.Data {
+		issueType = api.IssueDatabaseDataUpdate
+	}
+	issueCreate := &api.IssueCreate{
+		ProjectID:     repo.ProjectID,
+		Name:          vcsPushEvent.FileCommit.Title,
+		Type:          issueType,
+		Description:   vcsPushEvent.FileCommit.Message,
+		AssigneeID:    api.SystemBotID,
+		CreateContext: createContext,
+	}
+	issue, err := s.createIssue(ctx, issueCreate, api.SystemBotID)
+	if err != nil {
+		errMsg := "Failed to create schema update issue"
+		if issueType == api.IssueDatabaseDataUpdate {
+			errMsg = "Failed to create data update issue"
+		}
+		return "", echo.NewHTTPError(http.StatusInternalServerError, errMsg).SetInternal(err)
+	}
+
+	// Create a project activity after successfully creating the issue as the result of the push event
+	bytes, err := json.Marshal(api.ActivityProjectRepositoryPushPayload{
+		VCSPushEvent: vcsPushEvent,
+		IssueID:      issue.ID,
+		IssueName:    issue.Name,
+	})
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+	}
+
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   api.SystemBotID,
+		ContainerID: repo.ProjectID,
+		Type:        api.ActivityProjectRepositoryPush,
+		Level:       api.ActivityInfo,
+		Comment:     fmt.Sprintf("Created issue %q.", issue.Name),
+		Payload:     string(bytes),
+	}
+	if _, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create project activity after creating issue from repository push event: %d", issue.ID)).SetInternal(err)
+	}
+
+	return fmt.Sprintf("Created issue %q on adding %s", issue.Name, added), nil
+}
+
+func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, added string, statement string) (string, error) {
 	// Find matching database list
 	databaseFind := &api.DatabaseFind{
 		ProjectID: &repository.ProjectID,
@@ -318,7 +474,7 @@ func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Re
 	return string(createContext), nil
 }
 
-func (s *Server) createTenantSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, commit gitlab.WebhookCommit, added string, statement string) (string, error) {
+func (s *Server) createTenantSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, statement string) (string, error) {
 	// We don't take environment for tenant mode project because the databases needing schema update are determined by database name and deployment configuration.
 	if mi.Environment != "" {
 		return "", fmt.Errorf("environment isn't accepted in schema update for tenant mode project")
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGitHubWebhookSignature256(t *testing.T) {
+	// Example taken from https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries#testing-the-webhook-payload-validation
+	const (
+		key  = "It's a Secret to Everybody"
+		body = "Hello, World!"
+	)
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "valid signature",
+			signature: "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+			want:      true,
+		},
+		{
+			name:      "mismatched signature",
+			signature: "sha256=0000000000000000000000000000000000000000000000000000000000000000",
+			want:      false,
+		},
+		{
+			name:      "missing prefix",
+			signature: "757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+			want:      false,
+		},
+		{
+			name:      "malformed hex",
+			signature: "sha256=not-a-hex",
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := validateGitHubWebhookSignature256(test.signature, key, []byte(body))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
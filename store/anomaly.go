@@ -8,6 +8,7 @@ import (
 
 	"github.com/bytebase/bytebase/api"
 	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/metric"
 )
 
 // anomalyRaw is the store model for an Anomaly.
@@ -86,6 +87,40 @@ func (s *Store) FindAnomaly(ctx context.Context, find *api.AnomalyFind) ([]*api.
 	return anomalyList, nil
 }
 
+// CountAnomalyGroupByType counts the number of active anomalies and group by type.
+// Used by the metric collector.
+func (s *Store) CountAnomalyGroupByType(ctx context.Context) ([]*metric.AnomalyCountMetric, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	rows, err := tx.PTx.QueryContext(ctx, `
+		SELECT type, COUNT(*)
+		FROM anomaly
+		WHERE row_status = $1
+		GROUP BY type`,
+		api.Normal,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	var res []*metric.AnomalyCountMetric
+
+	for rows.Next() {
+		var metric metric.AnomalyCountMetric
+		if err := rows.Scan(&metric.Type, &metric.Count); err != nil {
+			return nil, FormatError(err)
+		}
+		res = append(res, &metric)
+	}
+
+	return res, nil
+}
+
 //
 // private functions
 //
@@ -0,0 +1,426 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// apiTokenRaw is the store model for an APIToken.
+// Fields have exactly the same meanings as APIToken.
+type apiTokenRaw struct {
+	ID int
+
+	// Standard fields
+	RowStatus api.RowStatus
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	PrincipalID int
+
+	// Domain specific fields
+	Name       string
+	Scope      api.APITokenScope
+	TokenHash  string
+	ExpiresTs  int64
+	LastUsedTs int64
+}
+
+// toAPIToken creates an instance of APIToken based on the apiTokenRaw.
+// This is intended to be called when we need to compose an APIToken relationship.
+func (raw *apiTokenRaw) toAPIToken() *api.APIToken {
+	return &api.APIToken{
+		ID: raw.ID,
+
+		// Standard fields
+		RowStatus: raw.RowStatus,
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		PrincipalID: raw.PrincipalID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		Scope:      raw.Scope,
+		TokenHash:  raw.TokenHash,
+		ExpiresTs:  raw.ExpiresTs,
+		LastUsedTs: raw.LastUsedTs,
+	}
+}
+
+// CreateAPIToken creates an instance of APIToken
+func (s *Store) CreateAPIToken(ctx context.Context, create *api.APITokenCreate) (*api.APIToken, error) {
+	// The api_token table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, &common.Error{Code: common.NotImplemented, Err: fmt.Errorf("API token isn't supported in release mode yet")}
+	}
+	apiTokenRaw, err := s.createAPITokenRaw(ctx, create)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create APIToken with name %q for principal %d, error[%w]", create.Name, create.PrincipalID, err)
+	}
+	apiToken, err := s.composeAPIToken(ctx, apiTokenRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose APIToken with ID[%d], error[%w]", apiTokenRaw.ID, err)
+	}
+	return apiToken, nil
+}
+
+// GetAPITokenByID gets an instance of APIToken
+func (s *Store) GetAPITokenByID(ctx context.Context, id int) (*api.APIToken, error) {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	find := &api.APITokenFind{ID: &id}
+	apiTokenRaw, err := s.getAPITokenRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get APIToken with ID[%d], error[%w]", id, err)
+	}
+	if apiTokenRaw == nil {
+		return nil, nil
+	}
+	apiToken, err := s.composeAPIToken(ctx, apiTokenRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose APIToken with ID[%d], error[%w]", apiTokenRaw.ID, err)
+	}
+	return apiToken, nil
+}
+
+// GetAPITokenByHash gets an instance of APIToken by the token hash.
+func (s *Store) GetAPITokenByHash(ctx context.Context, tokenHash string) (*api.APIToken, error) {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	find := &api.APITokenFind{TokenHash: &tokenHash}
+	apiTokenRaw, err := s.getAPITokenRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get APIToken by hash, error[%w]", err)
+	}
+	if apiTokenRaw == nil {
+		return nil, nil
+	}
+	apiToken, err := s.composeAPIToken(ctx, apiTokenRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose APIToken with ID[%d], error[%w]", apiTokenRaw.ID, err)
+	}
+	return apiToken, nil
+}
+
+// FindAPIToken finds a list of APIToken instances
+func (s *Store) FindAPIToken(ctx context.Context, find *api.APITokenFind) ([]*api.APIToken, error) {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	apiTokenRawList, err := s.findAPITokenRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find APIToken list with APITokenFind[%+v], error[%w]", find, err)
+	}
+	var apiTokenList []*api.APIToken
+	for _, raw := range apiTokenRawList {
+		apiToken, err := s.composeAPIToken(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose APIToken with ID[%d], error[%w]", raw.ID, err)
+		}
+		apiTokenList = append(apiTokenList, apiToken)
+	}
+	return apiTokenList, nil
+}
+
+// PatchAPIToken patches an instance of APIToken
+func (s *Store) PatchAPIToken(ctx context.Context, patch *api.APITokenPatch) (*api.APIToken, error) {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, &common.Error{Code: common.NotImplemented, Err: fmt.Errorf("API token isn't supported in release mode yet")}
+	}
+	apiTokenRaw, err := s.patchAPITokenRaw(ctx, patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch APIToken with APITokenPatch[%+v], error[%w]", patch, err)
+	}
+	apiToken, err := s.composeAPIToken(ctx, apiTokenRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose APIToken with ID[%d], error[%w]", apiTokenRaw.ID, err)
+	}
+	return apiToken, nil
+}
+
+//
+// private function
+//
+
+func (s *Store) composeAPIToken(ctx context.Context, raw *apiTokenRaw) (*api.APIToken, error) {
+	apiToken := raw.toAPIToken()
+
+	creator, err := s.GetPrincipalByID(ctx, apiToken.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	apiToken.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, apiToken.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	apiToken.Updater = updater
+
+	return apiToken, nil
+}
+
+// createAPITokenRaw creates a new API token.
+func (s *Store) createAPITokenRaw(ctx context.Context, create *api.APITokenCreate) (*apiTokenRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	apiToken, err := createAPITokenImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return apiToken, nil
+}
+
+// findAPITokenRaw retrieves a list of API tokens based on find.
+func (s *Store) findAPITokenRaw(ctx context.Context, find *api.APITokenFind) ([]*apiTokenRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findAPITokenImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// getAPITokenRaw retrieves a single API token based on find.
+// Returns ECONFLICT if finding more than 1 matching records.
+func (s *Store) getAPITokenRaw(ctx context.Context, find *api.APITokenFind) (*apiTokenRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	apiTokenRawList, err := findAPITokenImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiTokenRawList) == 0 {
+		return nil, nil
+	} else if len(apiTokenRawList) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: fmt.Errorf("found %d API tokens with filter %+v, expect 1", len(apiTokenRawList), find)}
+	}
+	return apiTokenRawList[0], nil
+}
+
+// patchAPITokenRaw updates an existing API token by ID.
+// Returns ENOTFOUND if API token does not exist.
+func (s *Store) patchAPITokenRaw(ctx context.Context, patch *api.APITokenPatch) (*apiTokenRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	apiToken, err := patchAPITokenImpl(ctx, tx.PTx, patch)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return apiToken, nil
+}
+
+// createAPITokenImpl creates a new API token.
+func createAPITokenImpl(ctx context.Context, tx *sql.Tx, create *api.APITokenCreate) (*apiTokenRaw, error) {
+	// Insert row into database.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO api_token (
+			creator_id,
+			updater_id,
+			principal_id,
+			name,
+			scope,
+			token_hash,
+			expires_ts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, principal_id, name, scope, token_hash, expires_ts, last_used_ts
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.PrincipalID,
+		create.Name,
+		create.Scope,
+		create.TokenHash,
+		create.ExpiresTs,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var apiTokenRaw apiTokenRaw
+	if err := row.Scan(
+		&apiTokenRaw.ID,
+		&apiTokenRaw.RowStatus,
+		&apiTokenRaw.CreatorID,
+		&apiTokenRaw.CreatedTs,
+		&apiTokenRaw.UpdaterID,
+		&apiTokenRaw.UpdatedTs,
+		&apiTokenRaw.PrincipalID,
+		&apiTokenRaw.Name,
+		&apiTokenRaw.Scope,
+		&apiTokenRaw.TokenHash,
+		&apiTokenRaw.ExpiresTs,
+		&apiTokenRaw.LastUsedTs,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return &apiTokenRaw, nil
+}
+
+func findAPITokenImpl(ctx context.Context, tx *sql.Tx, find *api.APITokenFind) ([]*apiTokenRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, fmt.Sprintf("row_status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PrincipalID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TokenHash; v != nil {
+		where, args = append(where, fmt.Sprintf("token_hash = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			row_status,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			principal_id,
+			name,
+			scope,
+			token_hash,
+			expires_ts,
+			last_used_ts
+		FROM api_token
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into apiTokenRawList.
+	var apiTokenRawList []*apiTokenRaw
+	for rows.Next() {
+		var apiToken apiTokenRaw
+		if err := rows.Scan(
+			&apiToken.ID,
+			&apiToken.RowStatus,
+			&apiToken.CreatorID,
+			&apiToken.CreatedTs,
+			&apiToken.UpdaterID,
+			&apiToken.UpdatedTs,
+			&apiToken.PrincipalID,
+			&apiToken.Name,
+			&apiToken.Scope,
+			&apiToken.TokenHash,
+			&apiToken.ExpiresTs,
+			&apiToken.LastUsedTs,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		apiTokenRawList = append(apiTokenRawList, &apiToken)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return apiTokenRawList, nil
+}
+
+// patchAPITokenImpl updates an API token by ID. Returns the new state of the API token after update.
+func patchAPITokenImpl(ctx context.Context, tx *sql.Tx, patch *api.APITokenPatch) (*apiTokenRaw, error) {
+	// Build UPDATE clause.
+	set, args := []string{"updater_id = $1"}, []interface{}{patch.UpdaterID}
+	if v := patch.RowStatus; v != nil {
+		set, args = append(set, fmt.Sprintf("row_status = $%d", len(args)+1)), append(args, api.RowStatus(*v))
+	}
+	if v := patch.LastUsedTs; v != nil {
+		set, args = append(set, fmt.Sprintf("last_used_ts = $%d", len(args)+1)), append(args, *v)
+	}
+
+	args = append(args, patch.ID)
+
+	// Execute update query with RETURNING.
+	row, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		UPDATE api_token
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, principal_id, name, scope, token_hash, expires_ts, last_used_ts
+	`, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	if row.Next() {
+		var apiTokenRaw apiTokenRaw
+		if err := row.Scan(
+			&apiTokenRaw.ID,
+			&apiTokenRaw.RowStatus,
+			&apiTokenRaw.CreatorID,
+			&apiTokenRaw.CreatedTs,
+			&apiTokenRaw.UpdaterID,
+			&apiTokenRaw.UpdatedTs,
+			&apiTokenRaw.PrincipalID,
+			&apiTokenRaw.Name,
+			&apiTokenRaw.Scope,
+			&apiTokenRaw.TokenHash,
+			&apiTokenRaw.ExpiresTs,
+			&apiTokenRaw.LastUsedTs,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+		return &apiTokenRaw, nil
+	}
+
+	return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("API token ID not found: %d", patch.ID)}
+}
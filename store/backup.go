@@ -17,6 +17,7 @@ type backupRaw struct {
 	ID int
 
 	// Standard fields
+	RowStatus api.RowStatus
 	CreatorID int
 	CreatedTs int64
 	UpdaterID int
@@ -45,6 +46,7 @@ func (raw *backupRaw) toBackup() *api.Backup {
 		ID: raw.ID,
 
 		// Standard fields
+		RowStatus: raw.RowStatus,
 		CreatorID: raw.CreatorID,
 		CreatedTs: raw.CreatedTs,
 		UpdaterID: raw.UpdaterID,
@@ -400,7 +402,7 @@ func (s *Store) createBackupImpl(ctx context.Context, tx *sql.Tx, create *api.Ba
 			path
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment
+		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment
 	`,
 		create.CreatorID,
 		create.CreatorID,
@@ -422,6 +424,7 @@ func (s *Store) createBackupImpl(ctx context.Context, tx *sql.Tx, create *api.Ba
 	var backupRaw backupRaw
 	if err := row.Scan(
 		&backupRaw.ID,
+		&backupRaw.RowStatus,
 		&backupRaw.CreatorID,
 		&backupRaw.CreatedTs,
 		&backupRaw.UpdaterID,
@@ -447,6 +450,9 @@ func (s *Store) findBackupImpl(ctx context.Context, tx *sql.Tx, find *api.Backup
 	if v := find.ID; v != nil {
 		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.RowStatus; v != nil {
+		where, args = append(where, fmt.Sprintf("row_status = $%d", len(args)+1)), append(args, *v)
+	}
 	if v := find.DatabaseID; v != nil {
 		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
 	}
@@ -460,6 +466,7 @@ func (s *Store) findBackupImpl(ctx context.Context, tx *sql.Tx, find *api.Backup
 	rows, err := tx.QueryContext(ctx, `
 		SELECT
 			id,
+			row_status,
 			creator_id,
 			created_ts,
 			updater_id,
@@ -489,6 +496,7 @@ func (s *Store) findBackupImpl(ctx context.Context, tx *sql.Tx, find *api.Backup
 		var payload []byte
 		if err := rows.Scan(
 			&backupRaw.ID,
+			&backupRaw.RowStatus,
 			&backupRaw.CreatorID,
 			&backupRaw.CreatedTs,
 			&backupRaw.UpdaterID,
@@ -523,13 +531,22 @@ func (s *Store) patchBackupImpl(ctx context.Context, tx *sql.Tx, patch *api.Back
 	// Build UPDATE clause.
 	set, args := []string{}, []interface{}{}
 	set, args = append(set, fmt.Sprintf("updater_id = $%d", len(args)+1)), append(args, patch.UpdaterID)
-	set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, patch.Status)
-	set, args = append(set, fmt.Sprintf("comment = $%d", len(args)+1)), append(args, patch.Comment)
-	if patch.Payload == "" {
-		patch.Payload = "{}"
+	if v := patch.RowStatus; v != nil {
+		set, args = append(set, fmt.Sprintf("row_status = $%d", len(args)+1)), append(args, api.RowStatus(*v))
+	}
+	if v := patch.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Comment; v != nil {
+		set, args = append(set, fmt.Sprintf("comment = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Payload; v != nil {
+		payload := *v
+		if payload == "" {
+			payload = "{}"
+		}
+		set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, payload)
 	}
-
-	set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, patch.Payload)
 	args = append(args, patch.ID)
 
 	// Execute update query with RETURNING.
@@ -537,7 +554,7 @@ func (s *Store) patchBackupImpl(ctx context.Context, tx *sql.Tx, patch *api.Back
 			UPDATE backup
 			SET `+strings.Join(set, ", ")+`
 			WHERE id = $%d
-			RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment, payload
+			RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment, payload
 		`, len(args)),
 		args...,
 	)
@@ -551,6 +568,7 @@ func (s *Store) patchBackupImpl(ctx context.Context, tx *sql.Tx, patch *api.Back
 		var payload []byte
 		if err := row.Scan(
 			&backupRaw.ID,
+			&backupRaw.RowStatus,
 			&backupRaw.CreatorID,
 			&backupRaw.CreatedTs,
 			&backupRaw.UpdaterID,
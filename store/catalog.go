@@ -35,12 +35,21 @@ func (c *Catalog) FindIndex(ctx context.Context, find *catalog.IndexFind) (*cata
 	if err != nil {
 		return nil, err
 	}
+	if table == nil {
+		return nil, nil
+	}
 
-	indexList, err := c.store.FindIndex(ctx, &api.IndexFind{
+	indexFind := &api.IndexFind{
 		DatabaseID: c.databaseID,
 		TableID:    &table.ID,
-		Name:       &find.IndexName,
-	})
+	}
+	if find.IndexName != "" {
+		indexFind.Name = &find.IndexName
+	}
+	if find.Primary {
+		indexFind.Primary = &find.Primary
+	}
+	indexList, err := c.store.FindIndex(ctx, indexFind)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/crypto"
+)
+
+// SetDataKey sets the data key encrypting the credentials at rest.
+// The credentials written afterwards are encrypted, and the encrypted credentials are decrypted transparently on read.
+func (s *Store) SetDataKey(dataKey []byte) error {
+	c, err := crypto.NewCipher(dataKey)
+	if err != nil {
+		return fmt.Errorf("invalid data key, error: %w", err)
+	}
+	s.credentialCipher = c
+	return nil
+}
+
+// EncryptCredentials encrypts the credentials stored in plaintext, which were written before the encryption was enabled.
+// It returns the number of the data sources encrypted.
+func (s *Store) EncryptCredentials(ctx context.Context) (int, error) {
+	if s.credentialCipher == nil {
+		return 0, fmt.Errorf("the data key isn't set")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	// The SSH columns only exist in the dev schema for now.
+	hasSSH := s.db.mode == common.ReleaseModeDev
+	sshColumns := ""
+	if hasSSH {
+		sshColumns = ", ssh_password, ssh_private_key"
+	}
+	rows, err := tx.PTx.QueryContext(ctx, `
+		SELECT id, password`+sshColumns+`
+		FROM data_source
+		FOR UPDATE`,
+	)
+	if err != nil {
+		return 0, FormatError(err)
+	}
+	defer rows.Close()
+
+	type credential struct {
+		id            int
+		password      string
+		sshPassword   string
+		sshPrivateKey string
+	}
+	var credentialList []*credential
+	for rows.Next() {
+		var c credential
+		dest := []interface{}{&c.id, &c.password}
+		if hasSSH {
+			dest = append(dest, &c.sshPassword, &c.sshPrivateKey)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, FormatError(err)
+		}
+		credentialList = append(credentialList, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, FormatError(err)
+	}
+	rows.Close()
+
+	count := 0
+	for _, c := range credentialList {
+		values := []*string{&c.password, &c.sshPassword, &c.sshPrivateKey}
+		changed := false
+		for _, v := range values {
+			if *v == "" || crypto.IsEncrypted(*v) {
+				continue
+			}
+			if *v, err = s.credentialCipher.Encrypt(*v); err != nil {
+				return 0, err
+			}
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if hasSSH {
+			_, err = tx.PTx.ExecContext(ctx, `UPDATE data_source SET password = $1, ssh_password = $2, ssh_private_key = $3 WHERE id = $4`, c.password, c.sshPassword, c.sshPrivateKey, c.id)
+		} else {
+			_, err = tx.PTx.ExecContext(ctx, `UPDATE data_source SET password = $1 WHERE id = $2`, c.password, c.id)
+		}
+		if err != nil {
+			return 0, FormatError(err)
+		}
+		count++
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return 0, FormatError(err)
+	}
+	return count, nil
+}
+
+// encryptCredential encrypts the credential if the data key is set.
+func (s *Store) encryptCredential(value string) (string, error) {
+	if s.credentialCipher == nil {
+		return value, nil
+	}
+	return s.credentialCipher.Encrypt(value)
+}
+
+// decryptCredential decrypts the credential if it's encrypted.
+func (s *Store) decryptCredential(value string) (string, error) {
+	if !crypto.IsEncrypted(value) {
+		return value, nil
+	}
+	if s.credentialCipher == nil {
+		return "", &common.Error{Code: common.Internal, Err: fmt.Errorf("the credential is encrypted but the master key isn't supplied")}
+	}
+	return s.credentialCipher.Decrypt(value)
+}
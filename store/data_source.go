@@ -30,6 +30,12 @@ type dataSourceRaw struct {
 	Type     api.DataSourceType
 	Username string
 	Password string
+	// SSH tunnel fields
+	SSHHost       string
+	SSHPort       string
+	SSHUser       string
+	SSHPassword   string
+	SSHPrivateKey string
 }
 
 // toDataSource creates an instance of DataSource based on the dataSourceRaw.
@@ -53,9 +59,51 @@ func (raw *dataSourceRaw) toDataSource() *api.DataSource {
 		Type:     raw.Type,
 		Username: raw.Username,
 		Password: raw.Password,
+		// SSH tunnel fields
+		SSHHost:       raw.SSHHost,
+		SSHPort:       raw.SSHPort,
+		SSHUser:       raw.SSHUser,
+		SSHPassword:   raw.SSHPassword,
+		SSHPrivateKey: raw.SSHPrivateKey,
 	}
 }
 
+// The SSH columns only exist in the dev schema for now.
+const dataSourceSSHColumns = "ssh_host, ssh_port, ssh_user, ssh_password, ssh_private_key"
+
+// scanDest returns the scan destinations of the data source columns in order, the SSH columns come last if hasSSH.
+func (raw *dataSourceRaw) scanDest(hasSSH bool) []interface{} {
+	dest := []interface{}{
+		&raw.ID,
+		&raw.CreatorID,
+		&raw.CreatedTs,
+		&raw.UpdaterID,
+		&raw.UpdatedTs,
+		&raw.InstanceID,
+		&raw.DatabaseID,
+		&raw.Name,
+		&raw.Type,
+		&raw.Username,
+		&raw.Password,
+	}
+	if hasSSH {
+		dest = append(dest, &raw.SSHHost, &raw.SSHPort, &raw.SSHUser, &raw.SSHPassword, &raw.SSHPrivateKey)
+	}
+	return dest
+}
+
+// decrypt decrypts the credentials of the data source in place.
+func (raw *dataSourceRaw) decrypt(s *Store) error {
+	for _, v := range []*string{&raw.Password, &raw.SSHPassword, &raw.SSHPrivateKey} {
+		plaintext, err := s.decryptCredential(*v)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt the credential of data source %d, error: %w", raw.ID, err)
+		}
+		*v = plaintext
+	}
+	return nil
+}
+
 // CreateDataSource creates an instance of DataSource.
 func (s *Store) CreateDataSource(ctx context.Context, create *api.DataSourceCreate) (*api.DataSource, error) {
 	dataSourceRaw, err := s.createDataSourceRaw(ctx, create)
@@ -228,29 +276,41 @@ func (s *Store) patchDataSourceRaw(ctx context.Context, patch *api.DataSourcePat
 
 // createDataSourceImpl creates a new dataSource.
 func (s *Store) createDataSourceImpl(ctx context.Context, tx *sql.Tx, create *api.DataSourceCreate) (*dataSourceRaw, error) {
+	hasSSH := s.db.mode == common.ReleaseModeDev
+	if !hasSSH && create.SSHHost != "" {
+		return nil, &common.Error{Code: common.NotImplemented, Err: fmt.Errorf("SSH tunnel isn't supported in release mode yet")}
+	}
+	password, err := s.encryptCredential(create.Password)
+	if err != nil {
+		return nil, err
+	}
+	columns := []string{"creator_id", "updater_id", "instance_id", "database_id", "name", "type", "username", "password"}
+	args := []interface{}{create.CreatorID, create.CreatorID, create.InstanceID, create.DatabaseID, create.Name, create.Type, create.Username, password}
+	returning := "id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password"
+	if hasSSH {
+		sshPassword, err := s.encryptCredential(create.SSHPassword)
+		if err != nil {
+			return nil, err
+		}
+		sshPrivateKey, err := s.encryptCredential(create.SSHPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, dataSourceSSHColumns)
+		args = append(args, create.SSHHost, create.SSHPort, create.SSHUser, sshPassword, sshPrivateKey)
+		returning += ", " + dataSourceSSHColumns
+	}
+	var placeholders []string
+	for i := range args {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+
 	// Insert row into dataSource.
 	row, err := tx.QueryContext(ctx, `
-		INSERT INTO data_source (
-			creator_id,
-			updater_id,
-			instance_id,
-			database_id,
-			name,
-			type,
-			username,
-			password
-		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password
-	`,
-		create.CreatorID,
-		create.CreatorID,
-		create.InstanceID,
-		create.DatabaseID,
-		create.Name,
-		create.Type,
-		create.Username,
-		create.Password,
+		INSERT INTO data_source (`+strings.Join(columns, ", ")+`)
+		VALUES (`+strings.Join(placeholders, ", ")+`)
+		RETURNING `+returning,
+		args...,
 	)
 
 	if err != nil {
@@ -260,26 +320,18 @@ func (s *Store) createDataSourceImpl(ctx context.Context, tx *sql.Tx, create *ap
 
 	row.Next()
 	var dataSourceRaw dataSourceRaw
-	if err := row.Scan(
-		&dataSourceRaw.ID,
-		&dataSourceRaw.CreatorID,
-		&dataSourceRaw.CreatedTs,
-		&dataSourceRaw.UpdaterID,
-		&dataSourceRaw.UpdatedTs,
-		&dataSourceRaw.InstanceID,
-		&dataSourceRaw.DatabaseID,
-		&dataSourceRaw.Name,
-		&dataSourceRaw.Type,
-		&dataSourceRaw.Username,
-		&dataSourceRaw.Password,
-	); err != nil {
+	if err := row.Scan(dataSourceRaw.scanDest(hasSSH)...); err != nil {
 		return nil, FormatError(err)
 	}
+	if err := dataSourceRaw.decrypt(s); err != nil {
+		return nil, err
+	}
 
 	return &dataSourceRaw, nil
 }
 
 func (s *Store) findDataSourceImpl(ctx context.Context, tx *sql.Tx, find *api.DataSourceFind) ([]*dataSourceRaw, error) {
+	hasSSH := s.db.mode == common.ReleaseModeDev
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if v := find.ID; v != nil {
@@ -294,6 +346,10 @@ func (s *Store) findDataSourceImpl(ctx context.Context, tx *sql.Tx, find *api.Da
 	if v := find.Type; v != nil {
 		where, args = append(where, fmt.Sprintf("type = $%d", len(args)+1)), append(args, api.DataSourceType(*v))
 	}
+	sshColumns := ""
+	if hasSSH {
+		sshColumns = ", " + dataSourceSSHColumns
+	}
 
 	rows, err := tx.QueryContext(ctx, `
 		SELECT
@@ -307,7 +363,7 @@ func (s *Store) findDataSourceImpl(ctx context.Context, tx *sql.Tx, find *api.Da
 			name,
 			type,
 			username,
-			password
+			password`+sshColumns+`
 		FROM data_source
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -321,21 +377,12 @@ func (s *Store) findDataSourceImpl(ctx context.Context, tx *sql.Tx, find *api.Da
 	var dataSourceRawList []*dataSourceRaw
 	for rows.Next() {
 		var dataSourceRaw dataSourceRaw
-		if err := rows.Scan(
-			&dataSourceRaw.ID,
-			&dataSourceRaw.CreatorID,
-			&dataSourceRaw.CreatedTs,
-			&dataSourceRaw.UpdaterID,
-			&dataSourceRaw.UpdatedTs,
-			&dataSourceRaw.InstanceID,
-			&dataSourceRaw.DatabaseID,
-			&dataSourceRaw.Name,
-			&dataSourceRaw.Type,
-			&dataSourceRaw.Username,
-			&dataSourceRaw.Password,
-		); err != nil {
+		if err := rows.Scan(dataSourceRaw.scanDest(hasSSH)...); err != nil {
 			return nil, FormatError(err)
 		}
+		if err := dataSourceRaw.decrypt(s); err != nil {
+			return nil, err
+		}
 
 		dataSourceRawList = append(dataSourceRawList, &dataSourceRaw)
 	}
@@ -354,7 +401,41 @@ func (s *Store) patchDataSourceImpl(ctx context.Context, tx *sql.Tx, patch *api.
 		set, args = append(set, fmt.Sprintf("username = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := patch.Password; v != nil {
-		set, args = append(set, fmt.Sprintf("password = $%d", len(args)+1)), append(args, *v)
+		password, err := s.encryptCredential(*v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("password = $%d", len(args)+1)), append(args, password)
+	}
+	hasSSH := s.db.mode == common.ReleaseModeDev
+	sshColumns := ""
+	if hasSSH {
+		sshColumns = ", " + dataSourceSSHColumns
+		if v := patch.SSHHost; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_host = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHPort; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_port = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHUser; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_user = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHPassword; v != nil {
+			sshPassword, err := s.encryptCredential(*v)
+			if err != nil {
+				return nil, err
+			}
+			set, args = append(set, fmt.Sprintf("ssh_password = $%d", len(args)+1)), append(args, sshPassword)
+		}
+		if v := patch.SSHPrivateKey; v != nil {
+			sshPrivateKey, err := s.encryptCredential(*v)
+			if err != nil {
+				return nil, err
+			}
+			set, args = append(set, fmt.Sprintf("ssh_private_key = $%d", len(args)+1)), append(args, sshPrivateKey)
+		}
+	} else if v := patch.SSHHost; v != nil && *v != "" {
+		return nil, &common.Error{Code: common.NotImplemented, Err: fmt.Errorf("SSH tunnel isn't supported in release mode yet")}
 	}
 
 	args = append(args, patch.ID)
@@ -364,7 +445,7 @@ func (s *Store) patchDataSourceImpl(ctx context.Context, tx *sql.Tx, patch *api.
 		UPDATE data_source
 		SET `+strings.Join(set, ", ")+`
 		WHERE id = $%d
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password`+sshColumns+`
 	`, len(args)),
 		args...,
 	)
@@ -375,21 +456,12 @@ func (s *Store) patchDataSourceImpl(ctx context.Context, tx *sql.Tx, patch *api.
 
 	if row.Next() {
 		var dataSourceRaw dataSourceRaw
-		if err := row.Scan(
-			&dataSourceRaw.ID,
-			&dataSourceRaw.CreatorID,
-			&dataSourceRaw.CreatedTs,
-			&dataSourceRaw.UpdaterID,
-			&dataSourceRaw.UpdatedTs,
-			&dataSourceRaw.InstanceID,
-			&dataSourceRaw.DatabaseID,
-			&dataSourceRaw.Name,
-			&dataSourceRaw.Type,
-			&dataSourceRaw.Username,
-			&dataSourceRaw.Password,
-		); err != nil {
+		if err := row.Scan(dataSourceRaw.scanDest(hasSSH)...); err != nil {
 			return nil, FormatError(err)
 		}
+		if err := dataSourceRaw.decrypt(s); err != nil {
+			return nil, err
+		}
 		return &dataSourceRaw, nil
 	}
 
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// CreateForeignKey creates a new foreign key.
+func (s *Store) CreateForeignKey(ctx context.Context, create *api.ForeignKeyCreate) (*api.ForeignKey, error) {
+	// The foreign_key table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	foreignKey, err := createForeignKeyImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return foreignKey, nil
+}
+
+// FindForeignKey retrieves a list of foreign keys based on find.
+func (s *Store) FindForeignKey(ctx context.Context, find *api.ForeignKeyFind) ([]*api.ForeignKey, error) {
+	// The foreign_key table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findForeignKeyImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createForeignKeyImpl creates a new foreign key.
+func createForeignKeyImpl(ctx context.Context, tx *sql.Tx, create *api.ForeignKeyCreate) (*api.ForeignKey, error) {
+	columnList, err := json.Marshal(create.ColumnList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal column list %v, error: %w", create.ColumnList, err)
+	}
+	referencedColumnList, err := json.Marshal(create.ReferencedColumnList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal referenced column list %v, error: %w", create.ReferencedColumnList, err)
+	}
+
+	// Insert row into foreign_key.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO foreign_key (
+			creator_id,
+			updater_id,
+			database_id,
+			table_id,
+			name,
+			column_list,
+			referenced_schema,
+			referenced_table,
+			referenced_column_list,
+			on_delete,
+			on_update
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, table_id, name, column_list, referenced_schema, referenced_table, referenced_column_list, on_delete, on_update
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.DatabaseID,
+		create.TableID,
+		create.Name,
+		string(columnList),
+		create.ReferencedSchema,
+		create.ReferencedTable,
+		string(referencedColumnList),
+		create.OnDelete,
+		create.OnUpdate,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	return scanForeignKey(row)
+}
+
+func findForeignKeyImpl(ctx context.Context, tx *sql.Tx, find *api.ForeignKeyFind) ([]*api.ForeignKey, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TableID; v != nil {
+		where, args = append(where, fmt.Sprintf("table_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			table_id,
+			name,
+			column_list,
+			referenced_schema,
+			referenced_table,
+			referenced_column_list,
+			on_delete,
+			on_update
+		FROM foreign_key
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, table_id, name ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into foreignKeyList.
+	var foreignKeyList []*api.ForeignKey
+	for rows.Next() {
+		foreignKey, err := scanForeignKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		foreignKeyList = append(foreignKeyList, foreignKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return foreignKeyList, nil
+}
+
+// scanForeignKey scans a foreign key from the current row, unmarshaling the JSONB column lists.
+func scanForeignKey(rows *sql.Rows) (*api.ForeignKey, error) {
+	var foreignKey api.ForeignKey
+	var columnList, referencedColumnList string
+	if err := rows.Scan(
+		&foreignKey.ID,
+		&foreignKey.CreatorID,
+		&foreignKey.CreatedTs,
+		&foreignKey.UpdaterID,
+		&foreignKey.UpdatedTs,
+		&foreignKey.DatabaseID,
+		&foreignKey.TableID,
+		&foreignKey.Name,
+		&columnList,
+		&foreignKey.ReferencedSchema,
+		&foreignKey.ReferencedTable,
+		&referencedColumnList,
+		&foreignKey.OnDelete,
+		&foreignKey.OnUpdate,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+	if err := json.Unmarshal([]byte(columnList), &foreignKey.ColumnList); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal column list %q, error: %w", columnList, err)
+	}
+	if err := json.Unmarshal([]byte(referencedColumnList), &foreignKey.ReferencedColumnList); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal referenced column list %q, error: %w", referencedColumnList, err)
+	}
+	return &foreignKey, nil
+}
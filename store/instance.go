@@ -295,6 +295,12 @@ func (s *Store) createInstanceRaw(ctx context.Context, create *api.InstanceCreat
 		Type:       api.Admin,
 		Username:   create.Username,
 		Password:   create.Password,
+		// SSH tunnel fields
+		SSHHost:       create.SSHHost,
+		SSHPort:       create.SSHPort,
+		SSHUser:       create.SSHUser,
+		SSHPassword:   create.SSHPassword,
+		SSHPrivateKey: create.SSHPrivateKey,
 	}
 	if err := s.createDataSourceRawTx(ctx, tx.PTx, adminDataSourceCreate); err != nil {
 		return nil, err
@@ -0,0 +1 @@
+ALTER TABLE idx ADD "primary" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,29 @@
+ALTER TABLE principal DROP CONSTRAINT principal_type_check;
+ALTER TABLE principal ADD CONSTRAINT principal_type_check CHECK (type IN ('END_USER', 'SYSTEM_BOT', 'SERVICE_ACCOUNT'));
+
+CREATE TABLE api_token (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL CHECK (scope IN ('READ_ONLY', 'READ_WRITE')),
+    token_hash TEXT NOT NULL,
+    expires_ts BIGINT NOT NULL DEFAULT 0,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_api_token_unique_token_hash ON api_token(token_hash);
+
+CREATE INDEX idx_api_token_principal_id ON api_token(principal_id);
+
+ALTER SEQUENCE api_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_api_token_updated_ts
+BEFORE
+UPDATE
+    ON api_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
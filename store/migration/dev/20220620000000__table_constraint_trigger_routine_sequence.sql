@@ -0,0 +1,146 @@
+-- foreign_key stores the foreign key for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE foreign_key (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    column_list JSONB NOT NULL DEFAULT '[]',
+    referenced_schema TEXT NOT NULL,
+    referenced_table TEXT NOT NULL,
+    referenced_column_list JSONB NOT NULL DEFAULT '[]',
+    on_delete TEXT NOT NULL,
+    on_update TEXT NOT NULL
+);
+
+CREATE INDEX idx_foreign_key_database_id_table_id ON foreign_key(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_foreign_key_unique_table_id_name ON foreign_key(table_id, name);
+
+ALTER SEQUENCE foreign_key_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_foreign_key_updated_ts
+BEFORE
+UPDATE
+    ON foreign_key FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- check_constraint stores the check constraint for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE check_constraint (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    expression TEXT NOT NULL
+);
+
+CREATE INDEX idx_check_constraint_database_id_table_id ON check_constraint(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_check_constraint_unique_table_id_name ON check_constraint(table_id, name);
+
+ALTER SEQUENCE check_constraint_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_check_constraint_updated_ts
+BEFORE
+UPDATE
+    ON check_constraint FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- table_trigger stores the trigger for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE table_trigger (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    timing TEXT NOT NULL,
+    event TEXT NOT NULL,
+    body TEXT NOT NULL
+);
+
+CREATE INDEX idx_table_trigger_database_id_table_id ON table_trigger(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_table_trigger_unique_table_id_name ON table_trigger(table_id, name);
+
+ALTER SEQUENCE table_trigger_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_table_trigger_updated_ts
+BEFORE
+UPDATE
+    ON table_trigger FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- routine stores the stored procedure and function for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE routine (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('PROCEDURE', 'FUNCTION')),
+    arguments TEXT NOT NULL,
+    return_type TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_routine_database_id ON routine(database_id);
+
+ALTER SEQUENCE routine_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_routine_updated_ts
+BEFORE
+UPDATE
+    ON routine FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_sequence stores the sequence for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_sequence (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    data_type TEXT NOT NULL,
+    start_value BIGINT NOT NULL,
+    min_value BIGINT NOT NULL,
+    max_value BIGINT NOT NULL,
+    increment BIGINT NOT NULL,
+    cycle BOOLEAN NOT NULL
+);
+
+CREATE INDEX idx_db_sequence_database_id ON db_sequence(database_id);
+
+CREATE UNIQUE INDEX idx_db_sequence_unique_database_id_name ON db_sequence(database_id, name);
+
+ALTER SEQUENCE db_sequence_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_sequence_updated_ts
+BEFORE
+UPDATE
+    ON db_sequence FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,5 @@
+ALTER TABLE data_source ADD ssh_host TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD ssh_port TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD ssh_user TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD ssh_password TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD ssh_private_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,24 @@
+-- task approval table stores the approvals of the tasks for the multi-step approval workflow
+CREATE TABLE task_approval (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    task_id INTEGER NOT NULL REFERENCES task (id),
+    stage_id INTEGER NOT NULL REFERENCES stage (id),
+    step INTEGER NOT NULL CHECK (step >= 0)
+);
+
+-- Each principal approves a task at most once.
+CREATE UNIQUE INDEX idx_task_approval_unique_task_id_creator_id ON task_approval(task_id, creator_id);
+
+CREATE INDEX idx_task_approval_stage_id ON task_approval(stage_id);
+
+ALTER SEQUENCE task_approval_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_task_approval_updated_ts
+BEFORE
+UPDATE
+    ON task_approval FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -18,7 +18,7 @@ CREATE TABLE principal (
     created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     updater_id INTEGER NOT NULL REFERENCES principal (id),
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
-    type TEXT NOT NULL CHECK (type IN ('END_USER', 'SYSTEM_BOT')),
+    type TEXT NOT NULL CHECK (type IN ('END_USER', 'SYSTEM_BOT', 'SERVICE_ACCOUNT')),
     name TEXT NOT NULL,
     email TEXT NOT NULL,
     password_hash TEXT NOT NULL
@@ -270,7 +270,7 @@ CREATE TABLE instance (
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     environment_id INTEGER NOT NULL REFERENCES environment (id),
     name TEXT NOT NULL,
-    engine TEXT NOT NULL CHECK (engine IN ('MYSQL', 'POSTGRES', 'TIDB', 'CLICKHOUSE', 'SNOWFLAKE', 'SQLITE')),
+    engine TEXT NOT NULL CHECK (engine IN ('MYSQL', 'POSTGRES', 'TIDB', 'CLICKHOUSE', 'SNOWFLAKE', 'SQLITE', 'DUCKDB', 'MARIADB')),
     engine_version TEXT NOT NULL DEFAULT '',
     host TEXT NOT NULL,
     port TEXT NOT NULL,
@@ -425,7 +425,8 @@ CREATE TABLE idx (
     type TEXT NOT NULL,
     "unique" BOOLEAN NOT NULL,
     visible BOOLEAN NOT NULL,
-    comment TEXT NOT NULL
+    comment TEXT NOT NULL,
+    "primary" BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 CREATE INDEX idx_idx_database_id_table_id ON idx(database_id, table_id);
@@ -440,6 +441,153 @@ UPDATE
     ON idx FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- foreign_key stores the foreign key for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE foreign_key (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    column_list JSONB NOT NULL DEFAULT '[]',
+    referenced_schema TEXT NOT NULL,
+    referenced_table TEXT NOT NULL,
+    referenced_column_list JSONB NOT NULL DEFAULT '[]',
+    on_delete TEXT NOT NULL,
+    on_update TEXT NOT NULL
+);
+
+CREATE INDEX idx_foreign_key_database_id_table_id ON foreign_key(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_foreign_key_unique_table_id_name ON foreign_key(table_id, name);
+
+ALTER SEQUENCE foreign_key_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_foreign_key_updated_ts
+BEFORE
+UPDATE
+    ON foreign_key FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- check_constraint stores the check constraint for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE check_constraint (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    expression TEXT NOT NULL
+);
+
+CREATE INDEX idx_check_constraint_database_id_table_id ON check_constraint(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_check_constraint_unique_table_id_name ON check_constraint(table_id, name);
+
+ALTER SEQUENCE check_constraint_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_check_constraint_updated_ts
+BEFORE
+UPDATE
+    ON check_constraint FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- table_trigger stores the trigger for a particular table.
+-- data is synced periodically from the instance.
+CREATE TABLE table_trigger (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    timing TEXT NOT NULL,
+    event TEXT NOT NULL,
+    body TEXT NOT NULL
+);
+
+CREATE INDEX idx_table_trigger_database_id_table_id ON table_trigger(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_table_trigger_unique_table_id_name ON table_trigger(table_id, name);
+
+ALTER SEQUENCE table_trigger_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_table_trigger_updated_ts
+BEFORE
+UPDATE
+    ON table_trigger FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- routine stores the stored procedure and function for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE routine (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('PROCEDURE', 'FUNCTION')),
+    arguments TEXT NOT NULL,
+    return_type TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_routine_database_id ON routine(database_id);
+
+ALTER SEQUENCE routine_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_routine_updated_ts
+BEFORE
+UPDATE
+    ON routine FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_sequence stores the sequence for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_sequence (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    data_type TEXT NOT NULL,
+    start_value BIGINT NOT NULL,
+    min_value BIGINT NOT NULL,
+    max_value BIGINT NOT NULL,
+    increment BIGINT NOT NULL,
+    cycle BOOLEAN NOT NULL
+);
+
+CREATE INDEX idx_db_sequence_database_id ON db_sequence(database_id);
+
+CREATE UNIQUE INDEX idx_db_sequence_unique_database_id_name ON db_sequence(database_id, name);
+
+ALTER SEQUENCE db_sequence_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_sequence_updated_ts
+BEFORE
+UPDATE
+    ON db_sequence FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- db_extension stores the extensions for a particular database.
 -- data is synced periodically from the instance.
 CREATE TABLE db_extension (
@@ -511,7 +659,12 @@ CREATE TABLE data_source (
     password TEXT NOT NULL,
     ssl_key TEXT NOT NULL DEFAULT '',
     ssl_cert TEXT NOT NULL DEFAULT '',
-    ssl_ca TEXT NOT NULL DEFAULT ''
+    ssl_ca TEXT NOT NULL DEFAULT '',
+    ssh_host TEXT NOT NULL DEFAULT '',
+    ssh_port TEXT NOT NULL DEFAULT '',
+    ssh_user TEXT NOT NULL DEFAULT '',
+    ssh_password TEXT NOT NULL DEFAULT '',
+    ssh_private_key TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_data_source_instance_id ON data_source(instance_id);
@@ -744,6 +897,31 @@ UPDATE
     ON task_check_run FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- task approval table stores the approvals of the tasks for the multi-step approval workflow
+CREATE TABLE task_approval (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    task_id INTEGER NOT NULL REFERENCES task (id),
+    stage_id INTEGER NOT NULL REFERENCES stage (id),
+    step INTEGER NOT NULL CHECK (step >= 0)
+);
+
+-- Each principal approves a task at most once.
+CREATE UNIQUE INDEX idx_task_approval_unique_task_id_creator_id ON task_approval(task_id, creator_id);
+
+CREATE INDEX idx_task_approval_stage_id ON task_approval(stage_id);
+
+ALTER SEQUENCE task_approval_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_task_approval_updated_ts
+BEFORE
+UPDATE
+    ON task_approval FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- Pipeline related END
 -----------------------
 -- issue
@@ -1120,3 +1298,33 @@ CREATE TABLE sheet_organizer (
 CREATE UNIQUE INDEX idx_sheet_organizer_unique_sheet_id_principal_id ON sheet_organizer(sheet_id, principal_id);
 
 CREATE INDEX idx_sheet_organizer_principal_id ON sheet_organizer(principal_id);
+
+-- api_token stores the long-lived API tokens of the principals for automation.
+-- Only the hash of the token is stored, and the token is only returned once when it's created.
+CREATE TABLE api_token (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL CHECK (scope IN ('READ_ONLY', 'READ_WRITE')),
+    token_hash TEXT NOT NULL,
+    -- expires_ts is 0 if the token never expires.
+    expires_ts BIGINT NOT NULL DEFAULT 0,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_api_token_unique_token_hash ON api_token(token_hash);
+
+CREATE INDEX idx_api_token_principal_id ON api_token(principal_id);
+
+ALTER SEQUENCE api_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_api_token_updated_ts
+BEFORE
+UPDATE
+    ON api_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
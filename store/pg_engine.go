@@ -718,6 +718,8 @@ func FormatError(err error) error {
 			return common.Errorf(common.Conflict, fmt.Errorf("backup name already exists"))
 		case strings.Contains(err.Error(), "idx_backup_setting_unique_database_id"):
 			return common.Errorf(common.Conflict, fmt.Errorf("database id already exists"))
+		case strings.Contains(err.Error(), "idx_task_approval_unique_task_id_creator_id"):
+			return common.Errorf(common.Conflict, fmt.Errorf("task has already been approved by the principal"))
 		case strings.Contains(err.Error(), "idx_bookmark_unique_creator_id_link"):
 			return common.Errorf(common.Conflict, fmt.Errorf("bookmark already exists"))
 		case strings.Contains(err.Error(), "idx_repository_unique_project_id"):
@@ -164,6 +164,71 @@ func (s *Store) GetBackupPlanPolicyByEnvID(ctx context.Context, environmentID in
 	return api.UnmarshalBackupPlanPolicy(policy.Payload)
 }
 
+// GetBackupStoragePolicyByEnvID will get the backup storage policy for an environment.
+func (s *Store) GetBackupStoragePolicyByEnvID(ctx context.Context, environmentID int) (*api.BackupStoragePolicy, error) {
+	pType := api.PolicyTypeBackupStorage
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalBackupStoragePolicy(policy.Payload)
+}
+
+// GetAffectedRowLimitPolicyByEnvID will get the affected row limit policy for an environment.
+func (s *Store) GetAffectedRowLimitPolicyByEnvID(ctx context.Context, environmentID int) (*api.AffectedRowLimitPolicy, error) {
+	pType := api.PolicyTypeAffectedRowLimit
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalAffectedRowLimitPolicy(policy.Payload)
+}
+
+// GetMaintenanceWindowPolicyByEnvID will get the maintenance window policy for an environment.
+func (s *Store) GetMaintenanceWindowPolicyByEnvID(ctx context.Context, environmentID int) (*api.MaintenanceWindowPolicy, error) {
+	pType := api.PolicyTypeMaintenanceWindow
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalMaintenanceWindowPolicy(policy.Payload)
+}
+
+// GetTaskConcurrencyPolicyByEnvID will get the task concurrency policy for an environment.
+func (s *Store) GetTaskConcurrencyPolicyByEnvID(ctx context.Context, environmentID int) (*api.TaskConcurrencyPolicy, error) {
+	pType := api.PolicyTypeTaskConcurrency
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalTaskConcurrencyPolicy(policy.Payload)
+}
+
+// GetTaskRetryPolicyByEnvID will get the task retry policy for an environment.
+func (s *Store) GetTaskRetryPolicyByEnvID(ctx context.Context, environmentID int) (*api.TaskRetryPolicy, error) {
+	pType := api.PolicyTypeTaskRetry
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalTaskRetryPolicy(policy.Payload)
+}
+
 // GetPipelineApprovalPolicy will get the pipeline approval policy for an environment.
 func (s *Store) GetPipelineApprovalPolicy(ctx context.Context, environmentID int) (*api.PipelineApprovalPolicy, error) {
 	pType := api.PolicyTypePipelineApproval
@@ -0,0 +1,316 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// routineRaw is the store model for a Routine.
+// Fields have exactly the same meanings as Routine.
+type routineRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	Type       string
+	Arguments  string
+	ReturnType string
+	Definition string
+	Comment    string
+}
+
+// toRoutine creates an instance of Routine based on the routineRaw.
+// This is intended to be called when we need to compose a Routine relationship.
+func (raw *routineRaw) toRoutine() *api.Routine {
+	return &api.Routine{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		DatabaseID: raw.DatabaseID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		Type:       raw.Type,
+		Arguments:  raw.Arguments,
+		ReturnType: raw.ReturnType,
+		Definition: raw.Definition,
+		Comment:    raw.Comment,
+	}
+}
+
+// CreateRoutine creates an instance of Routine
+func (s *Store) CreateRoutine(ctx context.Context, create *api.RoutineCreate) (*api.Routine, error) {
+	// The routine table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	routineRaw, err := s.createRoutineRaw(ctx, create)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Routine with RoutineCreate[%+v], error[%w]", create, err)
+	}
+	routine, err := s.composeRoutine(ctx, routineRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose Routine with routineRaw[%+v], error[%w]", routineRaw, err)
+	}
+	return routine, nil
+}
+
+// FindRoutine finds a list of Routine instances
+func (s *Store) FindRoutine(ctx context.Context, find *api.RoutineFind) ([]*api.Routine, error) {
+	// The routine table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	routineRawList, err := s.findRoutineRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Routine list with RoutineFind[%+v], error[%w]", find, err)
+	}
+	var routineList []*api.Routine
+	for _, raw := range routineRawList {
+		routine, err := s.composeRoutine(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose Routine with routineRaw[%+v], error[%w]", raw, err)
+		}
+		routineList = append(routineList, routine)
+	}
+	return routineList, nil
+}
+
+// DeleteRoutine deletes the routines of a database.
+func (s *Store) DeleteRoutine(ctx context.Context, delete *api.RoutineDelete) error {
+	// The routine table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if err := deleteRoutineImpl(ctx, tx.PTx, delete); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+//
+// private functions
+//
+
+func (s *Store) composeRoutine(ctx context.Context, raw *routineRaw) (*api.Routine, error) {
+	routine := raw.toRoutine()
+
+	creator, err := s.GetPrincipalByID(ctx, routine.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	routine.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, routine.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	routine.Updater = updater
+
+	database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &routine.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	routine.Database = database
+
+	return routine, nil
+}
+
+// createRoutineRaw creates a new routine.
+func (s *Store) createRoutineRaw(ctx context.Context, create *api.RoutineCreate) (*routineRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	routine, err := createRoutineImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return routine, nil
+}
+
+// findRoutineRaw retrieves a list of routines based on find.
+func (s *Store) findRoutineRaw(ctx context.Context, find *api.RoutineFind) ([]*routineRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findRoutineImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createRoutineImpl creates a new routine.
+func createRoutineImpl(ctx context.Context, tx *sql.Tx, create *api.RoutineCreate) (*routineRaw, error) {
+	// Insert row into routine.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO routine (
+			creator_id,
+			updater_id,
+			database_id,
+			name,
+			type,
+			arguments,
+			return_type,
+			definition,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, type, arguments, return_type, definition, comment
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.DatabaseID,
+		create.Name,
+		create.Type,
+		create.Arguments,
+		create.ReturnType,
+		create.Definition,
+		create.Comment,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var routineRaw routineRaw
+	if err := row.Scan(
+		&routineRaw.ID,
+		&routineRaw.CreatorID,
+		&routineRaw.CreatedTs,
+		&routineRaw.UpdaterID,
+		&routineRaw.UpdatedTs,
+		&routineRaw.DatabaseID,
+		&routineRaw.Name,
+		&routineRaw.Type,
+		&routineRaw.Arguments,
+		&routineRaw.ReturnType,
+		&routineRaw.Definition,
+		&routineRaw.Comment,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return &routineRaw, nil
+}
+
+func findRoutineImpl(ctx context.Context, tx *sql.Tx, find *api.RoutineFind) ([]*routineRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			type,
+			arguments,
+			return_type,
+			definition,
+			comment
+		FROM routine
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, name ASC, arguments ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into routineRawList.
+	var routineRawList []*routineRaw
+	for rows.Next() {
+		var routineRaw routineRaw
+		if err := rows.Scan(
+			&routineRaw.ID,
+			&routineRaw.CreatorID,
+			&routineRaw.CreatedTs,
+			&routineRaw.UpdaterID,
+			&routineRaw.UpdatedTs,
+			&routineRaw.DatabaseID,
+			&routineRaw.Name,
+			&routineRaw.Type,
+			&routineRaw.Arguments,
+			&routineRaw.ReturnType,
+			&routineRaw.Definition,
+			&routineRaw.Comment,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		routineRawList = append(routineRawList, &routineRaw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return routineRawList, nil
+}
+
+// deleteRoutineImpl permanently deletes routines from a database.
+func deleteRoutineImpl(ctx context.Context, tx *sql.Tx, delete *api.RoutineDelete) error {
+	// Remove row from database.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM routine WHERE database_id = $1`, delete.DatabaseID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
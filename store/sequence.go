@@ -0,0 +1,323 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// sequenceRaw is the store model for a Sequence.
+// Fields have exactly the same meanings as Sequence.
+type sequenceRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+}
+
+// toSequence creates an instance of Sequence based on the sequenceRaw.
+// This is intended to be called when we need to compose a Sequence relationship.
+func (raw *sequenceRaw) toSequence() *api.Sequence {
+	return &api.Sequence{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		DatabaseID: raw.DatabaseID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		DataType:   raw.DataType,
+		StartValue: raw.StartValue,
+		MinValue:   raw.MinValue,
+		MaxValue:   raw.MaxValue,
+		Increment:  raw.Increment,
+		Cycle:      raw.Cycle,
+	}
+}
+
+// CreateSequence creates an instance of Sequence
+func (s *Store) CreateSequence(ctx context.Context, create *api.SequenceCreate) (*api.Sequence, error) {
+	// The db_sequence table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	sequenceRaw, err := s.createSequenceRaw(ctx, create)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sequence with SequenceCreate[%+v], error[%w]", create, err)
+	}
+	sequence, err := s.composeSequence(ctx, sequenceRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose Sequence with sequenceRaw[%+v], error[%w]", sequenceRaw, err)
+	}
+	return sequence, nil
+}
+
+// FindSequence finds a list of Sequence instances
+func (s *Store) FindSequence(ctx context.Context, find *api.SequenceFind) ([]*api.Sequence, error) {
+	// The db_sequence table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	sequenceRawList, err := s.findSequenceRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Sequence list with SequenceFind[%+v], error[%w]", find, err)
+	}
+	var sequenceList []*api.Sequence
+	for _, raw := range sequenceRawList {
+		sequence, err := s.composeSequence(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose Sequence with sequenceRaw[%+v], error[%w]", raw, err)
+		}
+		sequenceList = append(sequenceList, sequence)
+	}
+	return sequenceList, nil
+}
+
+// DeleteSequence deletes the sequences of a database.
+func (s *Store) DeleteSequence(ctx context.Context, delete *api.SequenceDelete) error {
+	// The db_sequence table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if err := deleteSequenceImpl(ctx, tx.PTx, delete); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+//
+// private functions
+//
+
+func (s *Store) composeSequence(ctx context.Context, raw *sequenceRaw) (*api.Sequence, error) {
+	sequence := raw.toSequence()
+
+	creator, err := s.GetPrincipalByID(ctx, sequence.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, sequence.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	sequence.Updater = updater
+
+	database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &sequence.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	sequence.Database = database
+
+	return sequence, nil
+}
+
+// createSequenceRaw creates a new sequence.
+func (s *Store) createSequenceRaw(ctx context.Context, create *api.SequenceCreate) (*sequenceRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	sequence, err := createSequenceImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return sequence, nil
+}
+
+// findSequenceRaw retrieves a list of sequences based on find.
+func (s *Store) findSequenceRaw(ctx context.Context, find *api.SequenceFind) ([]*sequenceRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findSequenceImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createSequenceImpl creates a new sequence.
+func createSequenceImpl(ctx context.Context, tx *sql.Tx, create *api.SequenceCreate) (*sequenceRaw, error) {
+	// Insert row into db_sequence.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO db_sequence (
+			creator_id,
+			updater_id,
+			database_id,
+			name,
+			data_type,
+			start_value,
+			min_value,
+			max_value,
+			increment,
+			cycle
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, data_type, start_value, min_value, max_value, increment, cycle
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.DatabaseID,
+		create.Name,
+		create.DataType,
+		create.StartValue,
+		create.MinValue,
+		create.MaxValue,
+		create.Increment,
+		create.Cycle,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var sequenceRaw sequenceRaw
+	if err := row.Scan(
+		&sequenceRaw.ID,
+		&sequenceRaw.CreatorID,
+		&sequenceRaw.CreatedTs,
+		&sequenceRaw.UpdaterID,
+		&sequenceRaw.UpdatedTs,
+		&sequenceRaw.DatabaseID,
+		&sequenceRaw.Name,
+		&sequenceRaw.DataType,
+		&sequenceRaw.StartValue,
+		&sequenceRaw.MinValue,
+		&sequenceRaw.MaxValue,
+		&sequenceRaw.Increment,
+		&sequenceRaw.Cycle,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return &sequenceRaw, nil
+}
+
+func findSequenceImpl(ctx context.Context, tx *sql.Tx, find *api.SequenceFind) ([]*sequenceRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			data_type,
+			start_value,
+			min_value,
+			max_value,
+			increment,
+			cycle
+		FROM db_sequence
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, name ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into sequenceRawList.
+	var sequenceRawList []*sequenceRaw
+	for rows.Next() {
+		var sequenceRaw sequenceRaw
+		if err := rows.Scan(
+			&sequenceRaw.ID,
+			&sequenceRaw.CreatorID,
+			&sequenceRaw.CreatedTs,
+			&sequenceRaw.UpdaterID,
+			&sequenceRaw.UpdatedTs,
+			&sequenceRaw.DatabaseID,
+			&sequenceRaw.Name,
+			&sequenceRaw.DataType,
+			&sequenceRaw.StartValue,
+			&sequenceRaw.MinValue,
+			&sequenceRaw.MaxValue,
+			&sequenceRaw.Increment,
+			&sequenceRaw.Cycle,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		sequenceRawList = append(sequenceRawList, &sequenceRaw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return sequenceRawList, nil
+}
+
+// deleteSequenceImpl permanently deletes sequences from a database.
+func deleteSequenceImpl(ctx context.Context, tx *sql.Tx, delete *api.SequenceDelete) error {
+	// Remove row from database.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM db_sequence WHERE database_id = $1`, delete.DatabaseID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
@@ -2,12 +2,15 @@ package store
 
 import (
 	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/crypto"
 )
 
 // Store provides database access to all raw objects
 type Store struct {
 	db    *DB
 	cache api.CacheService
+	// credentialCipher encrypts the credentials such as data source passwords, nil if the credentials are stored in plaintext.
+	credentialCipher *crypto.Cipher
 }
 
 // New creates a new instance of Store
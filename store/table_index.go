@@ -68,47 +68,14 @@ func (s *Store) GetIndex(ctx context.Context, find *api.IndexFind) (*api.Index,
 	return list[0], nil
 }
 
-// createIndexImpl creates a new index.
-func (s *Store) createIndexImpl(ctx context.Context, tx *sql.Tx, create *api.IndexCreate) (*api.Index, error) {
-	// Insert row into index.
-	row, err := tx.QueryContext(ctx, `
-		INSERT INTO idx (
-			creator_id,
-			updater_id,
-			database_id,
-			table_id,
-			name,
-			expression,
-			position,
-			type,
-			"unique",
-			visible,
-			comment
-		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, table_id, name, expression, position, type, "unique", visible, comment
-	`,
-		create.CreatorID,
-		create.CreatorID,
-		create.DatabaseID,
-		create.TableID,
-		create.Name,
-		create.Expression,
-		create.Position,
-		create.Type,
-		create.Unique,
-		create.Visible,
-		create.Comment,
-	)
+const indexColumns = `id, creator_id, created_ts, updater_id, updated_ts, database_id, table_id, name, expression, position, type, "unique", visible, comment`
 
-	if err != nil {
-		return nil, FormatError(err)
-	}
-	defer row.Close()
+// The "primary" column only exists in the dev schema for now.
+const indexPrimaryColumn = `"primary"`
 
-	row.Next()
-	var index api.Index
-	if err := row.Scan(
+// indexScanDest returns the scan destinations of the index columns in order, the "primary" column comes last if hasPrimary.
+func indexScanDest(index *api.Index, hasPrimary bool) []interface{} {
+	dest := []interface{}{
 		&index.ID,
 		&index.CreatorID,
 		&index.CreatedTs,
@@ -123,7 +90,45 @@ func (s *Store) createIndexImpl(ctx context.Context, tx *sql.Tx, create *api.Ind
 		&index.Unique,
 		&index.Visible,
 		&index.Comment,
-	); err != nil {
+	}
+	if hasPrimary {
+		dest = append(dest, &index.Primary)
+	}
+	return dest
+}
+
+// createIndexImpl creates a new index.
+func (s *Store) createIndexImpl(ctx context.Context, tx *sql.Tx, create *api.IndexCreate) (*api.Index, error) {
+	hasPrimary := s.db.mode == common.ReleaseModeDev
+	columns := []string{"creator_id", "updater_id", "database_id", "table_id", "name", "expression", "position", "type", `"unique"`, "visible", "comment"}
+	args := []interface{}{create.CreatorID, create.CreatorID, create.DatabaseID, create.TableID, create.Name, create.Expression, create.Position, create.Type, create.Unique, create.Visible, create.Comment}
+	returning := indexColumns
+	if hasPrimary {
+		columns = append(columns, indexPrimaryColumn)
+		args = append(args, create.Primary)
+		returning += ", " + indexPrimaryColumn
+	}
+	var placeholders []string
+	for i := range args {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+
+	// Insert row into index.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO idx (`+strings.Join(columns, ", ")+`)
+		VALUES (`+strings.Join(placeholders, ", ")+`)
+		RETURNING `+returning,
+		args...,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var index api.Index
+	if err := row.Scan(indexScanDest(&index, hasPrimary)...); err != nil {
 		return nil, FormatError(err)
 	}
 
@@ -131,6 +136,7 @@ func (s *Store) createIndexImpl(ctx context.Context, tx *sql.Tx, create *api.Ind
 }
 
 func (s *Store) findIndexImpl(ctx context.Context, tx *sql.Tx, find *api.IndexFind) ([]*api.Index, error) {
+	hasPrimary := s.db.mode == common.ReleaseModeDev
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if v := find.ID; v != nil {
@@ -148,23 +154,21 @@ func (s *Store) findIndexImpl(ctx context.Context, tx *sql.Tx, find *api.IndexFi
 	if v := find.Expression; v != nil {
 		where, args = append(where, fmt.Sprintf("expression = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.Primary; v != nil {
+		if hasPrimary {
+			where, args = append(where, fmt.Sprintf(`"primary" = $%d`, len(args)+1)), append(args, *v)
+		} else if *v {
+			// No index is known to be primary without the column.
+			where = append(where, "FALSE")
+		}
+	}
+	columns := indexColumns
+	if hasPrimary {
+		columns += ", " + indexPrimaryColumn
+	}
 
 	rows, err := tx.QueryContext(ctx, `
-		SELECT
-			id,
-			creator_id,
-			created_ts,
-			updater_id,
-			updated_ts,
-			database_id,
-			table_id,
-			name,
-			expression,
-			position,
-			type,
-			"unique",
-			visible,
-			comment
+		SELECT `+columns+`
 		FROM idx
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY database_id, table_id, CASE name WHEN 'PRIMARY' THEN 1 ELSE 2 END, name ASC, position ASC`,
@@ -179,22 +183,7 @@ func (s *Store) findIndexImpl(ctx context.Context, tx *sql.Tx, find *api.IndexFi
 	var indexList []*api.Index
 	for rows.Next() {
 		var index api.Index
-		if err := rows.Scan(
-			&index.ID,
-			&index.CreatorID,
-			&index.CreatedTs,
-			&index.UpdaterID,
-			&index.UpdatedTs,
-			&index.DatabaseID,
-			&index.TableID,
-			&index.Name,
-			&index.Expression,
-			&index.Position,
-			&index.Type,
-			&index.Unique,
-			&index.Visible,
-			&index.Comment,
-		); err != nil {
+		if err := rows.Scan(indexScanDest(&index, hasPrimary)...); err != nil {
 			return nil, FormatError(err)
 		}
 
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// taskApprovalRaw is the store model for a TaskApproval.
+// Fields have exactly the same meanings as TaskApproval.
+type taskApprovalRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	TaskID  int
+	StageID int
+
+	// Domain specific fields
+	Step int
+}
+
+// toTaskApproval creates an instance of TaskApproval based on the taskApprovalRaw.
+// This is intended to be called when we need to compose a TaskApproval relationship.
+func (raw *taskApprovalRaw) toTaskApproval() *api.TaskApproval {
+	return &api.TaskApproval{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		TaskID:  raw.TaskID,
+		StageID: raw.StageID,
+
+		// Domain specific fields
+		Step: raw.Step,
+	}
+}
+
+// CreateTaskApproval creates an instance of TaskApproval
+func (s *Store) CreateTaskApproval(ctx context.Context, create *api.TaskApprovalCreate) (*api.TaskApproval, error) {
+	// The task_approval table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, &common.Error{Code: common.NotImplemented, Err: fmt.Errorf("approval steps aren't supported in release mode yet")}
+	}
+	taskApprovalRaw, err := s.createTaskApprovalRaw(ctx, create)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TaskApproval with TaskApprovalCreate[%+v], error[%w]", create, err)
+	}
+	taskApproval, err := s.composeTaskApproval(ctx, taskApprovalRaw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose TaskApproval with taskApprovalRaw[%+v], error[%w]", taskApprovalRaw, err)
+	}
+	return taskApproval, nil
+}
+
+// FindTaskApproval finds a list of TaskApproval instances
+func (s *Store) FindTaskApproval(ctx context.Context, find *api.TaskApprovalFind) ([]*api.TaskApproval, error) {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	taskApprovalRawList, err := s.findTaskApprovalRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find TaskApproval list with TaskApprovalFind[%+v], error[%w]", find, err)
+	}
+	var taskApprovalList []*api.TaskApproval
+	for _, raw := range taskApprovalRawList {
+		taskApproval, err := s.composeTaskApproval(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose TaskApproval with taskApprovalRaw[%+v], error[%w]", raw, err)
+		}
+		taskApprovalList = append(taskApprovalList, taskApproval)
+	}
+	return taskApprovalList, nil
+}
+
+// DeleteTaskApproval deletes the approvals of a task.
+func (s *Store) DeleteTaskApproval(ctx context.Context, delete *api.TaskApprovalDelete) error {
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if _, err := tx.PTx.ExecContext(ctx, `DELETE FROM task_approval WHERE task_id = $1`, delete.TaskID); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
+
+//
+// private functions
+//
+
+func (s *Store) composeTaskApproval(ctx context.Context, raw *taskApprovalRaw) (*api.TaskApproval, error) {
+	taskApproval := raw.toTaskApproval()
+
+	creator, err := s.GetPrincipalByID(ctx, taskApproval.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	taskApproval.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, taskApproval.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	taskApproval.Updater = updater
+
+	return taskApproval, nil
+}
+
+// createTaskApprovalRaw creates a new task approval.
+func (s *Store) createTaskApprovalRaw(ctx context.Context, create *api.TaskApprovalCreate) (*taskApprovalRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	taskApproval, err := createTaskApprovalImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return taskApproval, nil
+}
+
+// findTaskApprovalRaw retrieves a list of task approvals based on find.
+func (s *Store) findTaskApprovalRaw(ctx context.Context, find *api.TaskApprovalFind) ([]*taskApprovalRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findTaskApprovalImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createTaskApprovalImpl creates a new task approval.
+func createTaskApprovalImpl(ctx context.Context, tx *sql.Tx, create *api.TaskApprovalCreate) (*taskApprovalRaw, error) {
+	// Insert row into database.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO task_approval (
+			creator_id,
+			updater_id,
+			task_id,
+			stage_id,
+			step
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, task_id, stage_id, step
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.TaskID,
+		create.StageID,
+		create.Step,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var taskApprovalRaw taskApprovalRaw
+	if err := row.Scan(
+		&taskApprovalRaw.ID,
+		&taskApprovalRaw.CreatorID,
+		&taskApprovalRaw.CreatedTs,
+		&taskApprovalRaw.UpdaterID,
+		&taskApprovalRaw.UpdatedTs,
+		&taskApprovalRaw.TaskID,
+		&taskApprovalRaw.StageID,
+		&taskApprovalRaw.Step,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return &taskApprovalRaw, nil
+}
+
+func findTaskApprovalImpl(ctx context.Context, tx *sql.Tx, find *api.TaskApprovalFind) ([]*taskApprovalRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TaskID; v != nil {
+		where, args = append(where, fmt.Sprintf("task_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.StageID; v != nil {
+		where, args = append(where, fmt.Sprintf("stage_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			task_id,
+			stage_id,
+			step
+		FROM task_approval
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into taskApprovalRawList.
+	var taskApprovalRawList []*taskApprovalRaw
+	for rows.Next() {
+		var taskApproval taskApprovalRaw
+		if err := rows.Scan(
+			&taskApproval.ID,
+			&taskApproval.CreatorID,
+			&taskApproval.CreatedTs,
+			&taskApproval.UpdaterID,
+			&taskApproval.UpdatedTs,
+			&taskApproval.TaskID,
+			&taskApproval.StageID,
+			&taskApproval.Step,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		taskApprovalRawList = append(taskApprovalRawList, &taskApproval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return taskApprovalRawList, nil
+}
@@ -0,0 +1,176 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+)
+
+// CreateTrigger creates a new table trigger.
+func (s *Store) CreateTrigger(ctx context.Context, create *api.TriggerCreate) (*api.Trigger, error) {
+	// The table_trigger table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	trigger, err := createTriggerImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return trigger, nil
+}
+
+// FindTrigger retrieves a list of table triggers based on find.
+func (s *Store) FindTrigger(ctx context.Context, find *api.TriggerFind) ([]*api.Trigger, error) {
+	// The table_trigger table only exists in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findTriggerImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createTriggerImpl creates a new table trigger.
+func createTriggerImpl(ctx context.Context, tx *sql.Tx, create *api.TriggerCreate) (*api.Trigger, error) {
+	// Insert row into table_trigger.
+	row, err := tx.QueryContext(ctx, `
+		INSERT INTO table_trigger (
+			creator_id,
+			updater_id,
+			database_id,
+			table_id,
+			name,
+			timing,
+			event,
+			body
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, table_id, name, timing, event, body
+	`,
+		create.CreatorID,
+		create.CreatorID,
+		create.DatabaseID,
+		create.TableID,
+		create.Name,
+		create.Timing,
+		create.Event,
+		create.Body,
+	)
+
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer row.Close()
+
+	row.Next()
+	var trigger api.Trigger
+	if err := row.Scan(
+		&trigger.ID,
+		&trigger.CreatorID,
+		&trigger.CreatedTs,
+		&trigger.UpdaterID,
+		&trigger.UpdatedTs,
+		&trigger.DatabaseID,
+		&trigger.TableID,
+		&trigger.Name,
+		&trigger.Timing,
+		&trigger.Event,
+		&trigger.Body,
+	); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return &trigger, nil
+}
+
+func findTriggerImpl(ctx context.Context, tx *sql.Tx, find *api.TriggerFind) ([]*api.Trigger, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TableID; v != nil {
+		where, args = append(where, fmt.Sprintf("table_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			table_id,
+			name,
+			timing,
+			event,
+			body
+		FROM table_trigger
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, table_id, name ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into triggerList.
+	var triggerList []*api.Trigger
+	for rows.Next() {
+		var trigger api.Trigger
+		if err := rows.Scan(
+			&trigger.ID,
+			&trigger.CreatorID,
+			&trigger.CreatedTs,
+			&trigger.UpdaterID,
+			&trigger.UpdatedTs,
+			&trigger.DatabaseID,
+			&trigger.TableID,
+			&trigger.Name,
+			&trigger.Timing,
+			&trigger.Event,
+			&trigger.Body,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		triggerList = append(triggerList, &trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return triggerList, nil
+}
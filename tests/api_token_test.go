@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestAPIToken(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	err := ctl.StartServer(ctx, dataDir, getTestPort(t.Name()))
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+
+	// The demo owner.
+	principalID := 101
+	readOnlyToken, err := ctl.createAPIToken(principalID, api.APITokenCreate{Name: "read-only", Scope: api.APITokenScopeReadOnly})
+	a.NoError(err)
+	a.True(strings.HasPrefix(readOnlyToken.Token, api.APITokenPrefix))
+	readWriteToken, err := ctl.createAPIToken(principalID, api.APITokenCreate{Name: "read-write", Scope: api.APITokenScopeReadWrite})
+	a.NoError(err)
+
+	// The API tokens authenticate the requests.
+	code, err := ctl.requestWithAPIToken(http.MethodGet, "/project", readOnlyToken.Token, nil)
+	a.NoError(err)
+	a.Equal(http.StatusOK, code)
+	code, err = ctl.requestWithAPIToken(http.MethodGet, "/project", "bbp_invalid", nil)
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+
+	// The READ_ONLY token only allows the GET requests.
+	projectCreate := `{"data":{"type":"projectCreate","attributes":{"name":"API token","key":"APT","tenantMode":"DISABLED","roleProvider":"BYTEBASE"}}}`
+	code, err = ctl.requestWithAPIToken(http.MethodPost, "/project", readOnlyToken.Token, strings.NewReader(projectCreate))
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+	code, err = ctl.requestWithAPIToken(http.MethodPost, "/project", readWriteToken.Token, strings.NewReader(projectCreate))
+	a.NoError(err)
+	a.Equal(http.StatusOK, code)
+
+	// The API tokens can't mint new tokens.
+	tokenCreate := `{"data":{"type":"apiTokenCreate","attributes":{"name":"minted","scope":"READ_WRITE"}}}`
+	code, err = ctl.requestWithAPIToken(http.MethodPost, "/principal/101/token", readWriteToken.Token, strings.NewReader(tokenCreate))
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+
+	// The revoked token is rejected.
+	err = ctl.revokeAPIToken(principalID, readWriteToken.ID)
+	a.NoError(err)
+	code, err = ctl.requestWithAPIToken(http.MethodGet, "/project", readWriteToken.Token, nil)
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+	code, err = ctl.requestWithAPIToken(http.MethodGet, "/project", readOnlyToken.Token, nil)
+	a.NoError(err)
+	a.Equal(http.StatusOK, code)
+}
@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/tests/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupS3Storage(t *testing.T) {
+	const (
+		bucket          = "bytebase-backup"
+		prefix          = "prod"
+		accessKeyID     = "test-access-key-id"
+		secretAccessKey = "test-secret-access-key"
+	)
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	port := getTestPort(t.Name())
+	err := ctl.StartServer(ctx, dataDir, port)
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+
+	err = ctl.setLicense()
+	a.NoError(err)
+
+	// Start the S3-compatible storage.
+	s3, err := startS3(port+1, accessKeyID)
+	a.NoError(err)
+	defer s3.Close()
+	s3.CreateBucket(bucket)
+
+	environments, err := ctl.getEnvironments()
+	a.NoError(err)
+	prodEnvironment, err := findEnvironment(environments, "Prod")
+	a.NoError(err)
+
+	// Store the backups of Prod environment in S3.
+	policyPayload, err := json.Marshal(api.BackupStoragePolicy{
+		StorageBackend: api.BackupStorageBackendS3,
+		S3: &api.BackupStorageS3Config{
+			Endpoint:        fmt.Sprintf("http://localhost:%d", port+1),
+			Bucket:          bucket,
+			Prefix:          prefix,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			UsePathStyle:    true,
+		},
+	})
+	a.NoError(err)
+	payload := string(policyPayload)
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		EnvironmentID: prodEnvironment.ID,
+		Type:          api.PolicyTypeBackupStorage,
+		Payload:       &payload,
+	})
+	a.NoError(err)
+
+	// Create a project.
+	project, err := ctl.createProject(api.ProjectCreate{
+		Name: "Test Backup S3 Storage Project",
+		Key:  "TestBackupS3Storage",
+	})
+	a.NoError(err)
+
+	// Provision an instance.
+	instanceRootDir := t.TempDir()
+	instanceName := "testInstance1"
+	instanceDir, err := ctl.provisionSQLiteInstance(instanceRootDir, instanceName)
+	a.NoError(err)
+
+	instance, err := ctl.addInstance(api.InstanceCreate{
+		EnvironmentID: prodEnvironment.ID,
+		Name:          instanceName,
+		Engine:        db.SQLite,
+		Host:          instanceDir,
+	})
+	a.NoError(err)
+
+	// Create a database with some data.
+	databaseName := "testBackupS3Storage"
+	err = ctl.createDatabase(project, instance, databaseName, nil /* labelMap */)
+	a.NoError(err)
+	databases, err := ctl.getDatabases(api.DatabaseFind{
+		ProjectID: &project.ID,
+	})
+	a.NoError(err)
+	a.Equal(1, len(databases))
+	database := databases[0]
+
+	for _, statement := range []string{migrationStatement, dataUpdateStatement} {
+		createContext, err := json.Marshal(&api.UpdateSchemaContext{
+			MigrationType: db.Migrate,
+			DetailList: []*api.UpdateSchemaDetail{
+				{
+					DatabaseID: database.ID,
+					Statement:  statement,
+				},
+			},
+		})
+		a.NoError(err)
+		issue, err := ctl.createIssue(api.IssueCreate{
+			ProjectID:   project.ID,
+			Name:        fmt.Sprintf("update database %q", databaseName),
+			Type:        api.IssueDatabaseSchemaUpdate,
+			Description: fmt.Sprintf("This updates database %q.", databaseName),
+			// Assign to self.
+			AssigneeID:    project.Creator.ID,
+			CreateContext: string(createContext),
+		})
+		a.NoError(err)
+		status, err := ctl.waitIssuePipeline(issue.ID)
+		a.NoError(err)
+		a.Equal(api.TaskDone, status)
+	}
+
+	// Create a manual backup, which is stored in S3 regardless of the requested storage backend.
+	backup, err := ctl.createBackup(api.BackupCreate{
+		DatabaseID:     database.ID,
+		Name:           "name",
+		Type:           api.BackupTypeManual,
+		StorageBackend: api.BackupStorageBackendLocal,
+	})
+	a.NoError(err)
+	a.Equal(api.BackupStorageBackendS3, backup.StorageBackend)
+	err = ctl.waitBackup(backup.DatabaseID, backup.ID)
+	a.NoError(err)
+
+	backupContent, ok := s3.GetObject(bucket, path.Join(prefix, backup.Path))
+	a.True(ok)
+	a.Equal(backupDump, string(backupContent))
+	_, err = os.Stat(path.Join(dataDir, backup.Path))
+	a.True(os.IsNotExist(err))
+
+	// Restore the backup from S3 to a new database.
+	cloneDatabaseName := "testClone"
+	err = ctl.cloneDatabaseFromBackup(project, instance, cloneDatabaseName, backup, nil /* labelMap */)
+	a.NoError(err)
+
+	result, err := ctl.query(instance, cloneDatabaseName, bookDataQuery)
+	a.NoError(err)
+	a.Equal(bookDataSQLResult, result)
+}
+
+func startS3(port int, accessKeyID string) (*fake.S3, error) {
+	s3 := fake.NewS3(port, accessKeyID)
+	errChan := make(chan error, 1)
+	go func() {
+		if err := s3.Run(); err != nil {
+			errChan <- fmt.Errorf("failed to run s3 server, error: %w", err)
+		}
+	}()
+	if err := waitForEchoStart(s3.Echo, errChan); err != nil {
+		return nil, fmt.Errorf("failed to wait for s3 to start, error: %w", err)
+	}
+	return s3, nil
+}
@@ -0,0 +1,355 @@
+package fake
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/bytebase/bytebase/plugin/vcs/github"
+)
+
+// GitHub is a fake implementation of GitHub.
+type GitHub struct {
+	port int
+	Echo *echo.Echo
+
+	client *http.Client
+
+	nextWebhookID int
+	repositories  map[string]*repositoryData
+}
+
+type repositoryData struct {
+	// webhooks is a map that the webhook ID is the key and the webhook is the value.
+	webhooks map[int]*github.WebhookCreateOrUpdate
+	// files is a map that the full file path is the key and the file content is the value.
+	files map[string]string
+}
+
+// NewGitHub creates a fake GitHub.
+func NewGitHub(port int) *GitHub {
+	e := echo.New()
+	// Middleware
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	gh := &GitHub{
+		port:          port,
+		Echo:          e,
+		client:        &http.Client{},
+		nextWebhookID: 20211030,
+		repositories:  map[string]*repositoryData{},
+	}
+
+	// Routes
+	// The GitHub provider serves the REST API under "/api/v3" for instance URLs other than github.com.
+	repositoryGroup := e.Group("/api/v3")
+	repositoryGroup.POST("/repos/:owner/:repo/hooks", gh.createRepositoryHook)
+	repositoryGroup.PATCH("/repos/:owner/:repo/hooks/:hookID", gh.patchRepositoryHook)
+	repositoryGroup.DELETE("/repos/:owner/:repo/hooks/:hookID", gh.deleteRepositoryHook)
+	repositoryGroup.GET("/repos/:owner/:repo/git/commits/:commitID", gh.getFakeCommit)
+	repositoryGroup.GET("/repos/:owner/:repo/commits", gh.listFakeCommits)
+	repositoryGroup.GET("/repos/:owner/:repo/contents/*", gh.readRepositoryFile)
+	repositoryGroup.PUT("/repos/:owner/:repo/contents/*", gh.putRepositoryFile)
+
+	return gh
+}
+
+// Run runs a GitHub server.
+func (gh *GitHub) Run() error {
+	return gh.Echo.Start(fmt.Sprintf(":%d", gh.port))
+}
+
+// Close close a GitHub server.
+func (gh *GitHub) Close() error {
+	return gh.Echo.Close()
+}
+
+// CreateRepository creates a GitHub repository with the full name, e.g. "octocat/Hello-World".
+func (gh *GitHub) CreateRepository(fullName string) {
+	gh.repositories[fullName] = &repositoryData{
+		webhooks: map[int]*github.WebhookCreateOrUpdate{},
+		files:    map[string]string{},
+	}
+}
+
+// createRepositoryHook creates a repository webhook.
+func (gh *GitHub) createRepositoryHook(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	c.Logger().Info("create webhook for repository %q", fullName)
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return fmt.Errorf("failed to read create repository hook request body, error %w", err)
+	}
+	webhookCreate := &github.WebhookCreateOrUpdate{}
+	if err := json.Unmarshal(b, webhookCreate); err != nil {
+		return fmt.Errorf("failed to unmarshal create repository hook request body, error %w", err)
+	}
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return fmt.Errorf("github repository %q doesn't exist", fullName)
+	}
+	rd.webhooks[gh.nextWebhookID] = webhookCreate
+
+	c.Response().WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(c.Response().Writer).Encode(&github.WebhookInfo{
+		ID: gh.nextWebhookID,
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal WebhookInfo response").SetInternal(err)
+	}
+	gh.nextWebhookID++
+
+	return nil
+}
+
+// patchRepositoryHook patches a repository webhook.
+func (gh *GitHub) patchRepositoryHook(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+	hookID, err := strconv.Atoi(c.Param("hookID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid webhook ID %q", c.Param("hookID")))
+	}
+	if _, ok := rd.webhooks[hookID]; !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("webhook %d not found", hookID))
+	}
+
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to read patch repository hook request body, error %v", err))
+	}
+	webhookPatch := &github.WebhookCreateOrUpdate{}
+	if err := json.Unmarshal(b, webhookPatch); err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("failed to unmarshal patch repository hook request body, error %v", err))
+	}
+	rd.webhooks[hookID] = webhookPatch
+
+	buf, err := json.Marshal(&github.WebhookInfo{
+		ID: hookID,
+	})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal WebhookInfo, error %v", err))
+	}
+	return c.String(http.StatusOK, string(buf))
+}
+
+// deleteRepositoryHook deletes a repository webhook.
+func (gh *GitHub) deleteRepositoryHook(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+	hookID, err := strconv.Atoi(c.Param("hookID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid webhook ID %q", c.Param("hookID")))
+	}
+	if _, ok := rd.webhooks[hookID]; !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("webhook %d not found", hookID))
+	}
+	delete(rd.webhooks, hookID)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// readRepositoryFile reads a repository file with its content encoded in base64.
+func (gh *GitHub) readRepositoryFile(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	filePath := c.Param("*")
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+
+	content, ok := rd.files[filePath]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("file %q not found", filePath))
+	}
+
+	buf, err := json.Marshal(&github.File{
+		Encoding: "base64",
+		Size:     int64(len(content)),
+		Name:     filepath.Base(filePath),
+		Path:     filePath,
+		Content:  base64.StdEncoding.EncodeToString([]byte(content)),
+		SHA:      "fake_github_file_sha",
+	})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal File, error %v", err))
+	}
+
+	return c.String(http.StatusOK, string(buf))
+}
+
+// putRepositoryFile creates or updates a repository file.
+func (gh *GitHub) putRepositoryFile(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	filePath := c.Param("*")
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to read put repository file request body, error %v", err))
+	}
+	fileCommit := &github.FileCommit{}
+	if err := json.Unmarshal(b, fileCommit); err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("failed to unmarshal put repository file request body, error %v", err))
+	}
+
+	// GitHub requires the blob SHA of the file being replaced.
+	_, exists := rd.files[filePath]
+	if exists && fileCommit.SHA != "fake_github_file_sha" {
+		return c.String(http.StatusConflict, fmt.Sprintf("file %q does not match sha %q", filePath, fileCommit.SHA))
+	}
+	if !exists && fileCommit.SHA != "" {
+		return c.String(http.StatusNotFound, fmt.Sprintf("file %q not found", filePath))
+	}
+
+	content, err := base64.StdEncoding.DecodeString(fileCommit.Content)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("failed to decode file content, error %v", err))
+	}
+	// Save file.
+	rd.files[filePath] = string(content)
+
+	if exists {
+		return c.String(http.StatusOK, "{}")
+	}
+	return c.String(http.StatusCreated, "{}")
+}
+
+// listFakeCommits lists a fake commit for the file path.
+func (gh *GitHub) listFakeCommits(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+
+	commits := []github.Commit{}
+	if _, ok := rd.files[c.QueryParam("path")]; ok {
+		commit := github.Commit{
+			SHA: "fake_github_commit_id",
+		}
+		commit.Author.Name = "fake_github_bot"
+		commit.Author.Date = time.Now()
+		commits = append(commits, commit)
+	}
+	buf, err := json.Marshal(commits)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal commits, error %v", err))
+	}
+
+	return c.String(http.StatusOK, string(buf))
+}
+
+// getFakeCommit get a fake commit data
+func (gh *GitHub) getFakeCommit(c echo.Context) error {
+	fullName := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	if _, ok := gh.repositories[fullName]; !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("github repository %q doesn't exist", fullName))
+	}
+
+	commit := github.Commit{
+		SHA: "fake_github_commit_id",
+	}
+	commit.Author.Name = "fake_github_bot"
+	commit.Author.Date = time.Now()
+	buf, err := json.Marshal(commit)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal commit, error %v", err))
+	}
+
+	return c.String(http.StatusOK, string(buf))
+}
+
+// SendCommits sends commits to webhooks.
+func (gh *GitHub) SendCommits(fullName string, webhookPushEvent *github.WebhookPushEvent) error {
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return fmt.Errorf("github repository %q doesn't exist", fullName)
+	}
+
+	// Trigger webhooks.
+	for _, webhook := range rd.webhooks {
+		// Send post request.
+		buf, err := json.Marshal(webhookPushEvent)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhookPushEvent, error %w", err)
+		}
+		req, err := http.NewRequest("POST", webhook.Config.URL, strings.NewReader(string(buf)))
+		if err != nil {
+			return fmt.Errorf("fail to create a new POST request(%q), error: %w", webhook.Config.URL, err)
+		}
+		m := hmac.New(sha256.New, []byte(webhook.Config.Secret))
+		if _, err := m.Write(buf); err != nil {
+			return fmt.Errorf("failed to sign webhookPushEvent, error %w", err)
+		}
+		req.Header.Set("X-GitHub-Event", string(github.WebhookPush))
+		req.Header.Set("X-Hub-Signature-256", fmt.Sprintf("sha256=%s", hex.EncodeToString(m.Sum(nil))))
+		resp, err := gh.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("fail to send a POST request(%q), error: %w", webhook.Config.URL, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read http response body, error: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("http response error code %v body %q", resp.StatusCode, string(body))
+		}
+		gh.Echo.Logger.Infof("SendCommits response body %s\n", body)
+	}
+
+	return nil
+}
+
+// AddFiles add files to repository.
+func (gh *GitHub) AddFiles(fullName string, files map[string]string) error {
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return fmt.Errorf("github repository %q doesn't exist", fullName)
+	}
+
+	// Save files
+	for path, content := range files {
+		rd.files[path] = content
+	}
+	return nil
+}
+
+// GetFiles get files from repository.
+func (gh *GitHub) GetFiles(fullName string, filePaths ...string) (map[string]string, error) {
+	rd, ok := gh.repositories[fullName]
+	if !ok {
+		return nil, fmt.Errorf("github repository %q doesn't exist", fullName)
+	}
+
+	// Get files
+	files := make(map[string]string, len(filePaths))
+	for _, path := range filePaths {
+		if content, ok := rd.files[path]; ok {
+			files[path] = content
+		}
+	}
+	return files, nil
+}
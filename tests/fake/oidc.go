@@ -0,0 +1,151 @@
+package fake
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const oidcKeyID = "fake-oidc-key"
+
+// OIDC is a fake implementation of an OpenID Connect identity provider.
+type OIDC struct {
+	port int
+	Echo *echo.Echo
+
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu sync.Mutex
+	// codes is a map that the authorization code is the key and the claims of the ID token issued for the code is the value.
+	codes map[string]map[string]interface{}
+}
+
+// NewOIDC creates a fake OpenID Connect identity provider.
+// The issuer is derived from the host of the requests, so that it also works behind httptest servers.
+func NewOIDC(port int, clientID, clientSecret string) (*OIDC, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate RSA key, error: %w", err)
+	}
+
+	e := echo.New()
+	// Middleware
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	o := &OIDC{
+		port:         port,
+		Echo:         e,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]map[string]interface{}{},
+	}
+
+	// Routes
+	e.GET("/.well-known/openid-configuration", o.getDiscoveryDocument)
+	e.GET("/jwks", o.getKeySet)
+	e.POST("/token", o.exchangeToken)
+
+	return o, nil
+}
+
+// Run runs an OpenID Connect identity provider server.
+func (o *OIDC) Run() error {
+	return o.Echo.Start(fmt.Sprintf(":%d", o.port))
+}
+
+// Close close an OpenID Connect identity provider server.
+func (o *OIDC) Close() error {
+	return o.Echo.Close()
+}
+
+// AddAuthorizationCode registers an authorization code, which can be exchanged once for an ID token with the claims.
+// The standard claims iss, aud, iat and exp are filled in if they're absent.
+func (o *OIDC) AddAuthorizationCode(code string, claims map[string]interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.codes[code] = claims
+}
+
+func issuer(c echo.Context) string {
+	return fmt.Sprintf("http://%s", c.Request().Host)
+}
+
+func (o *OIDC) getDiscoveryDocument(c echo.Context) error {
+	iss := issuer(c)
+	return c.JSON(http.StatusOK, map[string]string{
+		"issuer":                 iss,
+		"authorization_endpoint": iss + "/authorize",
+		"token_endpoint":         iss + "/token",
+		"jwks_uri":               iss + "/jwks",
+	})
+}
+
+func (o *OIDC) getKeySet(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": oidcKeyID,
+				"kty": "RSA",
+				"use": "sig",
+				"alg": jwt.SigningMethodRS256.Name,
+				"n":   base64.RawURLEncoding.EncodeToString(o.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(o.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (o *OIDC) exchangeToken(c echo.Context) error {
+	if c.FormValue("grant_type") != "authorization_code" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+	}
+	if c.FormValue("client_id") != o.clientID || c.FormValue("client_secret") != o.clientSecret {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+	}
+
+	o.mu.Lock()
+	code := c.FormValue("code")
+	codeClaims, ok := o.codes[code]
+	delete(o.codes, code)
+	o.mu.Unlock()
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": issuer(c),
+		"aud": o.clientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range codeClaims {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = oidcKeyID
+	idToken, err := token.SignedString(o.key)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to sign ID token").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"access_token": "fake-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
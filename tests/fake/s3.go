@@ -0,0 +1,142 @@
+package fake
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// S3 is a fake implementation of an S3-compatible object storage such as MinIO.
+// It only supports path-style requests for putting (in a single part), getting and deleting objects,
+// and doesn't verify the request signature except the access key ID.
+type S3 struct {
+	port        int
+	accessKeyID string
+	Echo        *echo.Echo
+
+	mu sync.Mutex
+	// buckets is a map from the bucket name to its objects, which is a map from the object key to the content.
+	buckets map[string]map[string][]byte
+}
+
+// NewS3 creates a fake S3 accepting the requests signed with the access key ID.
+func NewS3(port int, accessKeyID string) *S3 {
+	e := echo.New()
+	// Middleware
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	s := &S3{
+		port:        port,
+		accessKeyID: accessKeyID,
+		Echo:        e,
+		buckets:     map[string]map[string][]byte{},
+	}
+
+	// Routes
+	e.PUT("/:bucket/*", s.putObject)
+	e.GET("/:bucket/*", s.getObject)
+	e.DELETE("/:bucket/*", s.deleteObject)
+
+	return s
+}
+
+// Run runs an S3 server.
+func (s *S3) Run() error {
+	return s.Echo.Start(fmt.Sprintf(":%d", s.port))
+}
+
+// Close close an S3 server.
+func (s *S3) Close() error {
+	return s.Echo.Close()
+}
+
+// CreateBucket creates an S3 bucket.
+func (s *S3) CreateBucket(bucket string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets[bucket] = map[string][]byte{}
+}
+
+// GetObject returns the content of the object, and whether it exists.
+func (s *S3) GetObject(bucket, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects, ok := s.buckets[bucket]
+	if !ok {
+		return nil, false
+	}
+	content, ok := objects[key]
+	return content, ok
+}
+
+// putObject puts an object.
+func (s *S3) putObject(c echo.Context) error {
+	if !s.authorized(c) {
+		return errorResponse(c, http.StatusForbidden, "InvalidAccessKeyId", "the access key ID doesn't exist")
+	}
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return errorResponse(c, http.StatusInternalServerError, "InternalError", fmt.Sprintf("failed to read put object request body, error %v", err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects, ok := s.buckets[c.Param("bucket")]
+	if !ok {
+		return errorResponse(c, http.StatusNotFound, "NoSuchBucket", fmt.Sprintf("bucket %q doesn't exist", c.Param("bucket")))
+	}
+	objects[c.Param("*")] = b
+
+	return c.NoContent(http.StatusOK)
+}
+
+// getObject gets an object.
+func (s *S3) getObject(c echo.Context) error {
+	if !s.authorized(c) {
+		return errorResponse(c, http.StatusForbidden, "InvalidAccessKeyId", "the access key ID doesn't exist")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects, ok := s.buckets[c.Param("bucket")]
+	if !ok {
+		return errorResponse(c, http.StatusNotFound, "NoSuchBucket", fmt.Sprintf("bucket %q doesn't exist", c.Param("bucket")))
+	}
+	content, ok := objects[c.Param("*")]
+	if !ok {
+		return errorResponse(c, http.StatusNotFound, "NoSuchKey", fmt.Sprintf("object %q doesn't exist", c.Param("*")))
+	}
+	return c.Blob(http.StatusOK, "application/octet-stream", content)
+}
+
+// deleteObject deletes an object. Like S3, deleting an object which doesn't exist succeeds.
+func (s *S3) deleteObject(c echo.Context) error {
+	if !s.authorized(c) {
+		return errorResponse(c, http.StatusForbidden, "InvalidAccessKeyId", "the access key ID doesn't exist")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects, ok := s.buckets[c.Param("bucket")]
+	if !ok {
+		return errorResponse(c, http.StatusNotFound, "NoSuchBucket", fmt.Sprintf("bucket %q doesn't exist", c.Param("bucket")))
+	}
+	delete(objects, c.Param("*"))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// authorized returns true if the request is signed with the access key ID.
+func (s *S3) authorized(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get("Authorization"), fmt.Sprintf("Credential=%s/", s.accessKeyID))
+}
+
+// errorResponse writes an S3 error response.
+func errorResponse(c echo.Context, status int, code, message string) error {
+	return c.XMLBlob(status, []byte(fmt.Sprintf("<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)))
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/tests/fake"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	err := ctl.StartServer(ctx, dataDir, getTestPort(t.Name()))
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+	err = ctl.setLicense()
+	a.NoError(err)
+
+	// Set up the fake identity provider.
+	idp, err := fake.NewOIDC(0 /* port */, "bytebase", "bytebase-secret")
+	a.NoError(err)
+	idpServer := httptest.NewServer(idp.Echo)
+	defer idpServer.Close()
+
+	// The OpenID Connect auth provider is disabled by default.
+	_, err = ctl.loginOIDC(api.OIDCLogin{Code: "code"})
+	a.Error(err)
+
+	// The invalid config is rejected.
+	_, err = ctl.patchSetting(api.SettingPatch{
+		Name:  api.SettingAuthOIDC,
+		Value: `{"discoveryUrl":"http://localhost","clientId":"bytebase","groupRoleMapping":{"dba":"ADMIN"}}`,
+	})
+	a.Error(err)
+
+	config, err := json.Marshal(&api.OIDCConfig{
+		DiscoveryURL: idpServer.URL,
+		ClientID:     "bytebase",
+		ClientSecret: "bytebase-secret",
+		Scopes:       []string{"openid", "email", "profile", "groups"},
+		GroupClaim:   "groups",
+		GroupRoleMapping: map[string]api.Role{
+			"dba":     api.DBA,
+			"db-team": api.Developer,
+		},
+	})
+	a.NoError(err)
+	_, err = ctl.patchSetting(api.SettingPatch{
+		Name:  api.SettingAuthOIDC,
+		Value: string(config),
+	})
+	a.NoError(err)
+
+	// The user is provisioned with the highest mapped role on the first login.
+	idp.AddAuthorizationCode("alice-code", map[string]interface{}{
+		"sub":    "alice",
+		"email":  "alice@example.com",
+		"name":   "Alice",
+		"groups": []string{"db-team", "dba", "marketing"},
+	})
+	alice, err := ctl.loginOIDC(api.OIDCLogin{Code: "alice-code"})
+	a.NoError(err)
+	a.Equal("alice@example.com", alice.Email)
+	a.Equal("Alice", alice.Name)
+	alice, err = ctl.getPrincipal(alice.ID)
+	a.NoError(err)
+	a.Equal(api.DBA, alice.Role)
+
+	// The user logs in as the provisioned principal afterwards.
+	idp.AddAuthorizationCode("alice-code-2", map[string]interface{}{
+		"sub":    "alice",
+		"email":  "alice@example.com",
+		"name":   "Alice",
+		"groups": []string{"db-team", "dba", "marketing"},
+	})
+	principal, err := ctl.loginOIDC(api.OIDCLogin{Code: "alice-code-2"})
+	a.NoError(err)
+	a.Equal(alice.ID, principal.ID)
+
+	// The user is provisioned as Developer if none of the groups is mapped.
+	idp.AddAuthorizationCode("bob-code", map[string]interface{}{
+		"sub":   "bob",
+		"email": "bob@example.com",
+	})
+	bob, err := ctl.loginOIDC(api.OIDCLogin{Code: "bob-code"})
+	a.NoError(err)
+	a.Equal("bob@example.com", bob.Name)
+	bob, err = ctl.getPrincipal(bob.ID)
+	a.NoError(err)
+	a.Equal(api.Developer, bob.Role)
+
+	// The unknown authorization code is rejected.
+	_, err = ctl.loginOIDC(api.OIDCLogin{Code: "unknown-code"})
+	a.Error(err)
+}
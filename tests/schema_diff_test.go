@@ -0,0 +1,116 @@
+//go:build mysql
+// +build mysql
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/resources/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseSchemaDiff(t *testing.T) {
+	const (
+		sourceDatabaseName = "testSchemaDiffSource"
+		targetDatabaseName = "testSchemaDiffTarget"
+		sourceStatement    = `
+	CREATE TABLE book (
+		id INT PRIMARY KEY AUTO_INCREMENT,
+		name VARCHAR(64)
+	);
+	`
+		targetStatement = `
+	CREATE TABLE book (
+		id INT PRIMARY KEY AUTO_INCREMENT,
+		name VARCHAR(128) NOT NULL,
+		updated_ts TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	);
+	`
+		wantStatement = "ALTER TABLE `book` MODIFY COLUMN `name` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL;\n" +
+			"ALTER TABLE `book` ADD COLUMN `updated_ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP AFTER `name`;"
+	)
+
+	port := getTestPort(t.Name())
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	err := ctl.StartServer(ctx, dataDir, port)
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+
+	_, stopInstance := mysql.SetupTestInstance(t, port+1)
+	defer stopInstance()
+
+	mysqlDB, err := connectTestMySQL(port+1, "")
+	a.NoError(err)
+	defer mysqlDB.Close()
+
+	project, err := ctl.createProject(api.ProjectCreate{
+		Name: "Test Schema Diff Project",
+		Key:  "TestDatabaseSchemaDiff",
+	})
+	a.NoError(err)
+
+	environments, err := ctl.getEnvironments()
+	a.NoError(err)
+	prodEnvironment, err := findEnvironment(environments, "Prod")
+	a.NoError(err)
+
+	instance, err := ctl.addInstance(api.InstanceCreate{
+		EnvironmentID: prodEnvironment.ID,
+		Name:          "mysqlInstance",
+		Engine:        db.MySQL,
+		Host:          "127.0.0.1",
+		Port:          strconv.Itoa(port + 1),
+		Username:      "root",
+	})
+	a.NoError(err)
+
+	err = ctl.createDatabase(project, instance, sourceDatabaseName, nil)
+	a.NoError(err)
+	err = ctl.createDatabase(project, instance, targetDatabaseName, nil)
+	a.NoError(err)
+
+	_, err = mysqlDB.Exec(fmt.Sprintf("USE `%s`; %s", sourceDatabaseName, sourceStatement))
+	a.NoError(err)
+	_, err = mysqlDB.Exec(fmt.Sprintf("USE `%s`; %s", targetDatabaseName, targetStatement))
+	a.NoError(err)
+
+	databases, err := ctl.getDatabases(api.DatabaseFind{
+		ProjectID: &project.ID,
+	})
+	a.NoError(err)
+	a.Equal(2, len(databases))
+	var sourceDatabase, targetDatabase *api.Database
+	for _, database := range databases {
+		switch database.Name {
+		case sourceDatabaseName:
+			sourceDatabase = database
+		case targetDatabaseName:
+			targetDatabase = database
+		}
+	}
+	a.NotNil(sourceDatabase)
+	a.NotNil(targetDatabase)
+
+	schemaDiff, err := ctl.getDatabaseSchemaDiff(sourceDatabase.ID, targetDatabase.ID)
+	a.NoError(err)
+	a.Equal(sourceDatabase.ID, schemaDiff.SourceDatabaseID)
+	a.Equal(targetDatabase.ID, schemaDiff.TargetDatabaseID)
+	a.Equal(wantStatement, schemaDiff.Statement)
+
+	// Identical schemas have an empty diff.
+	schemaDiff, err = ctl.getDatabaseSchemaDiff(targetDatabase.ID, targetDatabase.ID)
+	a.NoError(err)
+	a.Equal("", schemaDiff.Statement)
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresSchemaReview(t *testing.T) {
+	type test struct {
+		statement string
+		result    []api.TaskCheckResult
+	}
+
+	var (
+		databaseName = "testpostgresschemareview"
+		tests        = []test{
+			{
+				statement: "CREATE TABLE book(" +
+					"id INT PRIMARY KEY," +
+					"name TEXT NOT NULL," +
+					"creator_id INT NOT NULL," +
+					"created_ts TIMESTAMP NOT NULL," +
+					"updater_id INT NOT NULL," +
+					"updated_ts TIMESTAMP NOT NULL" +
+					")",
+				result: []api.TaskCheckResult{
+					{
+						Status:  api.TaskCheckStatusSuccess,
+						Code:    common.Ok,
+						Title:   "OK",
+						Content: "",
+					},
+				},
+			},
+			{
+				statement: "CREATE TABLE book(id);",
+				result: []api.TaskCheckResult{
+					{
+						Status:  api.TaskCheckStatusError,
+						Code:    common.DbStatementSyntaxError,
+						Title:   advisor.SyntaxErrorTitle,
+						Content: "syntax error at or near \";\"",
+					},
+				},
+			},
+			{
+				statement: "CREATE TABLE \"bookTable\"(id INT NOT NULL, name TEXT NOT NULL)",
+				result: []api.TaskCheckResult{
+					{
+						Status:  api.TaskCheckStatusWarn,
+						Code:    common.NamingTableConventionMismatch,
+						Title:   "naming.table",
+						Content: "`bookTable` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					},
+					{
+						Status:  api.TaskCheckStatusError,
+						Code:    common.TableNoPK,
+						Title:   "table.require-pk",
+						Content: "Table `public.bookTable` requires PRIMARY KEY",
+					},
+					{
+						Status:  api.TaskCheckStatusWarn,
+						Code:    common.NoRequiredColumn,
+						Title:   "column.required",
+						Content: "Table `public.bookTable` requires columns: created_ts, creator_id, updated_ts, updater_id",
+					},
+				},
+			},
+			{
+				statement: "DELETE FROM book",
+				result: []api.TaskCheckResult{
+					{
+						Status:  api.TaskCheckStatusError,
+						Code:    common.StatementNoWhere,
+						Title:   "statement.where.require",
+						Content: "\"DELETE FROM book\" requires WHERE clause",
+					},
+				},
+			},
+			{
+				statement: "DROP TABLE book",
+				result: []api.TaskCheckResult{
+					{
+						Status:  api.TaskCheckStatusWarn,
+						Code:    common.CompatibilityDropTable,
+						Title:   "schema.backward-compatibility",
+						Content: "\"DROP TABLE book\" may cause incompatibility with the existing data and code",
+					},
+				},
+			},
+		}
+	)
+
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	port := getTestPort(t.Name())
+	err := ctl.StartServer(ctx, dataDir, port)
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+
+	// Create a Postgres instance.
+	pgPort := port + 1
+	externalPg, err := newFakeExternalPg(t.TempDir(), pgPort)
+	a.NoError(err)
+	defer func() {
+		if err := externalPg.Destroy(); err != nil {
+			fmt.Printf("cannot destroy postgres instance, error: %s", err.Error())
+		}
+	}()
+
+	// Create a project.
+	project, err := ctl.createProject(api.ProjectCreate{
+		Name: "Test Postgres Schema Review Project",
+		Key:  "TestPostgresSchemaReview",
+	})
+	a.NoError(err)
+
+	environments, err := ctl.getEnvironments()
+	a.NoError(err)
+	prodEnvironment, err := findEnvironment(environments, "Prod")
+	a.NoError(err)
+
+	err = ctl.setLicense()
+	a.NoError(err)
+
+	policyPayload, err := prodTemplateSchemaReviewPolicy()
+	a.NoError(err)
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		EnvironmentID: prodEnvironment.ID,
+		Type:          api.PolicyTypeSchemaReview,
+		Payload:       &policyPayload,
+	})
+	a.NoError(err)
+
+	instance, err := ctl.addInstance(api.InstanceCreate{
+		EnvironmentID: prodEnvironment.ID,
+		Name:          "pgInstance",
+		Engine:        db.Postgres,
+		Host:          common.GetPostgresSocketDir(),
+		Port:          strconv.Itoa(pgPort),
+		Username:      externalPg.pgUser,
+	})
+	a.NoError(err)
+
+	err = ctl.createDatabase(project, instance, databaseName, nil)
+	a.NoError(err)
+
+	databases, err := ctl.getDatabases(api.DatabaseFind{
+		ProjectID: &project.ID,
+	})
+	a.NoError(err)
+	a.Equal(1, len(databases))
+	database := databases[0]
+	a.Equal(instance.ID, database.Instance.ID)
+
+	for _, t := range tests {
+		result := createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, t.statement)
+		a.Equal(t.result, result, t.statement)
+	}
+}
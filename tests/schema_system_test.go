@@ -6,7 +6,6 @@ package tests
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -342,31 +341,3 @@ func TestSchemaSystem(t *testing.T) {
 	result = createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[0])
 	a.Equal(noSchemaReviewPolicy, result)
 }
-
-func createIssueAndReturnSchemaReviewResult(a *require.Assertions, ctl *controller, databaseID int, projectID int, assigneeID int, statement string) []api.TaskCheckResult {
-	createContext, err := json.Marshal(&api.UpdateSchemaContext{
-		MigrationType: db.Migrate,
-		DetailList: []*api.UpdateSchemaDetail{
-			{
-				DatabaseID: databaseID,
-				Statement:  statement,
-			},
-		},
-	})
-	a.NoError(err)
-
-	issue, err := ctl.createIssue(api.IssueCreate{
-		ProjectID:     projectID,
-		Name:          "update schema for database",
-		Type:          api.IssueDatabaseSchemaUpdate,
-		Description:   "This updates the schema of database",
-		AssigneeID:    assigneeID,
-		CreateContext: string(createContext),
-	})
-	a.NoError(err)
-
-	result, err := ctl.getSchemaReviewResult(issue.ID)
-	a.NoError(err)
-
-	return result
-}
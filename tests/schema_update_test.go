@@ -11,7 +11,9 @@ import (
 	"github.com/bytebase/bytebase/api"
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/plugin/vcs"
+	"github.com/bytebase/bytebase/plugin/vcs/github"
 	"github.com/bytebase/bytebase/plugin/vcs/gitlab"
+	"github.com/bytebase/bytebase/tests/fake"
 	"github.com/stretchr/testify/require"
 )
 
@@ -484,3 +486,195 @@ func TestVCS(t *testing.T) {
 	a.Equal(histories[0].Version, "ver2")
 	a.Equal(histories[1].Version, "ver1")
 }
+
+func TestGitHubVCS(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	port := getTestPort(t.Name())
+	err := ctl.StartServer(ctx, dataDir, port)
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+	err = ctl.setLicense()
+	a.NoError(err)
+
+	// Start a fake GitHub on the port next to the fake GitLab.
+	gitHubURL := fmt.Sprintf("http://localhost:%d", port+3)
+	gitHub, err := startGitHub(port + 3)
+	a.NoError(err)
+	defer gitHub.Close()
+
+	// Create a VCS.
+	vcs, err := ctl.createVCS(api.VCSCreate{
+		Name:          "TestGitHubVCS",
+		Type:          vcs.GitHubCom,
+		InstanceURL:   gitHubURL,
+		APIURL:        fmt.Sprintf("%s/api/v3", gitHubURL),
+		ApplicationID: "testApplicationID",
+		Secret:        "testApplicationSecret",
+	})
+	a.NoError(err)
+
+	// Create a project.
+	project, err := ctl.createProject(api.ProjectCreate{
+		Name: "Test GitHub VCS Project",
+		Key:  "TestGitHubVCSSchemaUpdate",
+	})
+	a.NoError(err)
+
+	// Create a repository.
+	repositoryFullName := "octocat/schemaUpdate"
+	repositoryURL := fmt.Sprintf("%s/%s", gitHubURL, repositoryFullName)
+	gitHub.CreateRepository(repositoryFullName)
+	_, err = ctl.createRepository(api.RepositoryCreate{
+		VCSID:              vcs.ID,
+		ProjectID:          project.ID,
+		Name:               "Test Repository",
+		FullPath:           repositoryFullName,
+		WebURL:             repositoryURL,
+		BranchFilter:       "feature/foo",
+		BaseDirectory:      "bbtest",
+		FilePathTemplate:   "{{ENV_NAME}}/{{DB_NAME}}__{{VERSION}}__{{TYPE}}__{{DESCRIPTION}}.sql",
+		SchemaPathTemplate: "{{ENV_NAME}}/.{{DB_NAME}}__LATEST.sql",
+		ExternalID:         repositoryFullName,
+		AccessToken:        "accessToken1",
+		ExpiresTs:          0,
+		RefreshToken:       "refreshToken1",
+	})
+	a.NoError(err)
+
+	// Provision an instance.
+	instanceRootDir := t.TempDir()
+	instanceName := "testInstance1"
+	instanceDir, err := ctl.provisionSQLiteInstance(instanceRootDir, instanceName)
+	a.NoError(err)
+
+	environments, err := ctl.getEnvironments()
+	a.NoError(err)
+	prodEnvironment, err := findEnvironment(environments, "Prod")
+	a.NoError(err)
+
+	// Add an instance.
+	instance, err := ctl.addInstance(api.InstanceCreate{
+		EnvironmentID: prodEnvironment.ID,
+		Name:          instanceName,
+		Engine:        db.SQLite,
+		Host:          instanceDir,
+	})
+	a.NoError(err)
+
+	// Create an issue that creates a database.
+	databaseName := "testGitHubVCSSchemaUpdate"
+	err = ctl.createDatabase(project, instance, databaseName, nil /* labelMap */)
+	a.NoError(err)
+
+	// Pushing a tag doesn't create any issue.
+	gitFile := "bbtest/Prod/testGitHubVCSSchemaUpdate__ver1__migrate__create_a_test_table.sql"
+	err = gitHub.AddFiles(repositoryFullName, map[string]string{gitFile: migrationStatement})
+	a.NoError(err)
+	pushEvent := newGitHubPushEvent("refs/tags/v1", repositoryFullName, repositoryURL, gitFile)
+	err = gitHub.SendCommits(repositoryFullName, pushEvent)
+	a.NoError(err)
+	openStatus := []api.IssueStatus{api.IssueOpen}
+	issues, err := ctl.getIssues(api.IssueFind{ProjectID: &project.ID, StatusList: &openStatus})
+	a.NoError(err)
+	a.Equal(len(issues), 0)
+
+	// Simulate Git commits for schema update.
+	pushEvent = newGitHubPushEvent("refs/heads/feature/foo", repositoryFullName, repositoryURL, gitFile)
+	err = gitHub.SendCommits(repositoryFullName, pushEvent)
+	a.NoError(err)
+
+	// Get schema update issue.
+	issues, err = ctl.getIssues(api.IssueFind{ProjectID: &project.ID, StatusList: &openStatus})
+	a.NoError(err)
+	a.Equal(len(issues), 1)
+	issue := issues[0]
+	status, err := ctl.waitIssuePipeline(issue.ID)
+	a.NoError(err)
+	a.Equal(status, api.TaskDone)
+	_, err = ctl.patchIssueStatus(api.IssueStatusPatch{
+		ID:     issue.ID,
+		Status: api.IssueDone,
+	})
+	a.NoError(err)
+
+	// Query schema.
+	result, err := ctl.query(instance, databaseName, bookTableQuery)
+	a.NoError(err)
+	a.Equal(bookSchemaSQLResult, result)
+
+	// Simulate Git commits for data update, which overwrites the latest schema file.
+	gitFile = "bbtest/Prod/testGitHubVCSSchemaUpdate__ver2__data__insert_data.sql"
+	err = gitHub.AddFiles(repositoryFullName, map[string]string{gitFile: dataUpdateStatement})
+	a.NoError(err)
+	pushEvent = newGitHubPushEvent("refs/heads/feature/foo", repositoryFullName, repositoryURL, gitFile)
+	err = gitHub.SendCommits(repositoryFullName, pushEvent)
+	a.NoError(err)
+
+	// Get data update issue.
+	issues, err = ctl.getIssues(api.IssueFind{ProjectID: &project.ID, StatusList: &openStatus})
+	a.NoError(err)
+	a.Equal(len(issues), 1)
+	issue = issues[0]
+	status, err = ctl.waitIssuePipeline(issue.ID)
+	a.NoError(err)
+	a.Equal(status, api.TaskDone)
+	_, err = ctl.patchIssueStatus(api.IssueStatusPatch{
+		ID:     issue.ID,
+		Status: api.IssueDone,
+	})
+	a.NoError(err)
+
+	// Get migration history.
+	histories, err := ctl.getInstanceMigrationHistory(db.MigrationHistoryFind{ID: &instance.ID})
+	a.NoError(err)
+	a.Equal(len(histories), 3)
+	a.Equal(histories[0].Version, "ver2")
+	a.Equal(histories[1].Version, "ver1")
+
+	// Check the latest schema file written back to the repository.
+	latestSchemaFile := fmt.Sprintf("bbtest/Prod/.%s__LATEST.sql", databaseName)
+	files, err := gitHub.GetFiles(repositoryFullName, latestSchemaFile)
+	a.NoError(err)
+	a.Equal(dumpedSchema, files[latestSchemaFile])
+}
+
+// newGitHubPushEvent returns a GitHub push event which adds the file.
+func newGitHubPushEvent(ref, repositoryFullName, repositoryURL, addedFile string) *github.WebhookPushEvent {
+	return &github.WebhookPushEvent{
+		Ref: ref,
+		Repository: github.WebhookRepository{
+			FullName: repositoryFullName,
+			HTMLURL:  repositoryURL,
+		},
+		Commits: []github.WebhookCommit{
+			{
+				ID:        "fake_github_commit_id",
+				Timestamp: "2021-01-13T13:14:00Z",
+				Added: []string{
+					addedFile,
+				},
+			},
+		},
+	}
+}
+
+// startGitHub starts a fake GitHub on the port.
+func startGitHub(port int) (*fake.GitHub, error) {
+	gh := fake.NewGitHub(port)
+	errChan := make(chan error, 1)
+	go func() {
+		if err := gh.Run(); err != nil {
+			errChan <- fmt.Errorf("failed to run github server, error: %w", err)
+		}
+	}()
+	if err := waitForEchoStart(gh.Echo, errChan); err != nil {
+		return nil, fmt.Errorf("failed to wait for github to start, error: %w", err)
+	}
+	return gh, nil
+}
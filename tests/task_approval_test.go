@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestTaskApprovalSelfApproval(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	dataDir := t.TempDir()
+	err := ctl.StartServer(ctx, dataDir, getTestPort(t.Name()))
+	a.NoError(err)
+	defer ctl.Close(ctx)
+	err = ctl.Login()
+	a.NoError(err)
+	err = ctl.setLicense()
+	a.NoError(err)
+
+	project, err := ctl.createProject(api.ProjectCreate{
+		Name: "Test Project",
+		Key:  "TestTaskApproval",
+	})
+	a.NoError(err)
+
+	instanceRootDir := t.TempDir()
+	instanceName := "testInstance1"
+	instanceDir, err := ctl.provisionSQLiteInstance(instanceRootDir, instanceName)
+	a.NoError(err)
+	environments, err := ctl.getEnvironments()
+	a.NoError(err)
+	prodEnvironment, err := findEnvironment(environments, "Prod")
+	a.NoError(err)
+	instance, err := ctl.addInstance(api.InstanceCreate{
+		EnvironmentID: prodEnvironment.ID,
+		Name:          instanceName,
+		Engine:        db.SQLite,
+		Host:          instanceDir,
+	})
+	a.NoError(err)
+
+	// Without the approval steps, the issue creator approves the tasks as the assignee.
+	databaseName := "testTaskApproval"
+	err = ctl.createDatabase(project, instance, databaseName, nil /* labelMap */)
+	a.NoError(err)
+	databases, err := ctl.getDatabases(api.DatabaseFind{
+		ProjectID: &project.ID,
+	})
+	a.NoError(err)
+	a.Equal(1, len(databases))
+	database := databases[0]
+
+	// With the approval steps, the issue creator can't approve the tasks even as the assignee.
+	policy, err := api.PipelineApprovalPolicy{
+		Value: api.PipelineApprovalValueManualAlways,
+		StepList: []*api.ApprovalStep{
+			{Type: api.ApprovalStepTypeWorkspaceRole, Role: string(api.Owner), ApprovalCount: 1},
+		},
+	}.String()
+	a.NoError(err)
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		EnvironmentID: prodEnvironment.ID,
+		Type:          api.PolicyTypePipelineApproval,
+		Payload:       &policy,
+	})
+	a.NoError(err)
+
+	createContext, err := json.Marshal(&api.UpdateSchemaContext{
+		MigrationType: db.Migrate,
+		DetailList: []*api.UpdateSchemaDetail{
+			{
+				DatabaseID: database.ID,
+				Statement:  migrationStatement,
+			},
+		},
+	})
+	a.NoError(err)
+	issue, err := ctl.createIssue(api.IssueCreate{
+		ProjectID: project.ID,
+		Name:      "update schema with approval steps",
+		Type:      api.IssueDatabaseSchemaUpdate,
+		// Assign to self.
+		AssigneeID:    project.Creator.ID,
+		CreateContext: string(createContext),
+	})
+	a.NoError(err)
+	task := issue.Pipeline.StageList[0].TaskList[0]
+	a.Equal(api.TaskPendingApproval, task.Status)
+	_, err = ctl.patchTaskStatus(api.TaskStatusPatch{
+		ID:     task.ID,
+		Status: api.TaskPending,
+	}, issue.Pipeline.ID)
+	a.Error(err)
+	a.Contains(err.Error(), "the issue creator can't approve")
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/bytebase/bytebase/server"
 	"github.com/bytebase/bytebase/tests/fake"
 	"github.com/google/jsonapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -133,6 +135,13 @@ func getTestPort(testName string) int {
 		"TestCheckServerVersionAndBinlogForPITR",
 
 		"TestSchemaSystem",
+		"TestDatabaseSchemaDiff",
+		"TestPostgresSchemaReview",
+		"TestGitHubVCS",
+		"TestBackupS3Storage",
+		"TestOIDCLogin",
+		"TestAPIToken",
+		"TestTaskApprovalSelfApproval",
 	}
 	port := 1234
 	for _, name := range tests {
@@ -241,16 +250,21 @@ func waitForServerStart(s *server.Server, errChan <-chan error) error {
 }
 
 func waitForGitLabStart(g *fake.GitLab, errChan <-chan error) error {
+	return waitForEchoStart(g.Echo, errChan)
+}
+
+// waitForEchoStart waits for the echo server of a fake VCS to start.
+func waitForEchoStart(e *echo.Echo, errChan <-chan error) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			if g.Echo == nil {
+			if e == nil {
 				continue
 			}
-			addr := g.Echo.ListenerAddr()
+			addr := e.ListenerAddr()
 			if addr != nil && strings.Contains(addr.String(), ":") {
 				return nil // was started
 			}
@@ -648,6 +662,22 @@ func (ctl *controller) createIssue(issueCreate api.IssueCreate) (*api.Issue, err
 	return issue, nil
 }
 
+// getDatabaseSchemaDiff gets the schema diff from the source database to the target database.
+func (ctl *controller) getDatabaseSchemaDiff(sourceDatabaseID, targetDatabaseID int) (*api.DatabaseSchemaDiff, error) {
+	body, err := ctl.get(fmt.Sprintf("/database/%d/diff", sourceDatabaseID), map[string]string{
+		"target": strconv.Itoa(targetDatabaseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schemaDiff := new(api.DatabaseSchemaDiff)
+	if err = jsonapi.UnmarshalPayload(body, schemaDiff); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal get database schema diff response, error %w", err)
+	}
+	return schemaDiff, nil
+}
+
 // getIssue gets the issue with given ID.
 func (ctl *controller) getIssue(id int) (*api.Issue, error) {
 	body, err := ctl.get(fmt.Sprintf("/issue/%d", id), nil)
@@ -964,13 +994,19 @@ func (ctl *controller) createDatabase(project *api.Project, instance *api.Instan
 		return err
 	}
 
-	createContext, err := json.Marshal(&api.CreateDatabaseContext{
+	createDatabaseContext := &api.CreateDatabaseContext{
 		InstanceID:   instance.ID,
 		DatabaseName: databaseName,
 		Labels:       labels,
 		CharacterSet: "utf8mb4",
 		Collation:    "utf8mb4_general_ci",
-	})
+	}
+	if instance.Engine == db.Postgres {
+		createDatabaseContext.CharacterSet = "UTF8"
+		createDatabaseContext.Collation = ""
+		createDatabaseContext.Owner = instance.Username
+	}
+	createContext, err := json.Marshal(createDatabaseContext)
 	if err != nil {
 		return fmt.Errorf("failed to construct database creation issue CreateContext payload, error: %w", err)
 	}
@@ -1406,6 +1442,35 @@ func (ctl *controller) getSchemaReviewResult(id int) ([]api.TaskCheckResult, err
 	return nil, nil
 }
 
+// createIssueAndReturnSchemaReviewResult creates a schema update issue for the statement and returns its schema review result.
+func createIssueAndReturnSchemaReviewResult(a *require.Assertions, ctl *controller, databaseID int, projectID int, assigneeID int, statement string) []api.TaskCheckResult {
+	createContext, err := json.Marshal(&api.UpdateSchemaContext{
+		MigrationType: db.Migrate,
+		DetailList: []*api.UpdateSchemaDetail{
+			{
+				DatabaseID: databaseID,
+				Statement:  statement,
+			},
+		},
+	})
+	a.NoError(err)
+
+	issue, err := ctl.createIssue(api.IssueCreate{
+		ProjectID:     projectID,
+		Name:          "update schema for database",
+		Type:          api.IssueDatabaseSchemaUpdate,
+		Description:   "This updates the schema of database",
+		AssigneeID:    assigneeID,
+		CreateContext: string(createContext),
+	})
+	a.NoError(err)
+
+	result, err := ctl.getSchemaReviewResult(issue.ID)
+	a.NoError(err)
+
+	return result
+}
+
 // setDefaultSchemaReviewRulePayload sets the default payload for this rule.
 func setDefaultSchemaReviewRulePayload(ruleTp advisor.SchemaReviewRuleType) (string, error) {
 	var payload []byte
@@ -1530,3 +1595,99 @@ func prodTemplateSchemaReviewPolicy() (string, error) {
 	}
 	return string(s), nil
 }
+
+// patchSetting patches the setting.
+func (ctl *controller) patchSetting(settingPatch api.SettingPatch) (*api.Setting, error) {
+	buf := new(bytes.Buffer)
+	if err := jsonapi.MarshalPayload(buf, &settingPatch); err != nil {
+		return nil, fmt.Errorf("failed to marshal settingPatch, error: %w", err)
+	}
+
+	body, err := ctl.patch(fmt.Sprintf("/setting/%s", settingPatch.Name), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := new(api.Setting)
+	if err = jsonapi.UnmarshalPayload(body, setting); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal setting response, error: %w", err)
+	}
+	return setting, nil
+}
+
+// loginOIDC logins via OpenID Connect with the authorization code.
+func (ctl *controller) loginOIDC(oidcLogin api.OIDCLogin) (*api.Principal, error) {
+	buf := new(bytes.Buffer)
+	if err := jsonapi.MarshalPayload(buf, &oidcLogin); err != nil {
+		return nil, fmt.Errorf("failed to marshal oidcLogin, error: %w", err)
+	}
+
+	body, err := ctl.post("/auth/login/OIDC", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := new(api.Principal)
+	if err = jsonapi.UnmarshalPayload(body, principal); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal principal response, error: %w", err)
+	}
+	return principal, nil
+}
+
+// getPrincipal gets the principal by ID.
+func (ctl *controller) getPrincipal(id int) (*api.Principal, error) {
+	body, err := ctl.get(fmt.Sprintf("/principal/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := new(api.Principal)
+	if err = jsonapi.UnmarshalPayload(body, principal); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal principal response, error: %w", err)
+	}
+	return principal, nil
+}
+
+// createAPIToken creates an API token for the principal.
+func (ctl *controller) createAPIToken(principalID int, apiTokenCreate api.APITokenCreate) (*api.APIToken, error) {
+	buf := new(bytes.Buffer)
+	if err := jsonapi.MarshalPayload(buf, &apiTokenCreate); err != nil {
+		return nil, fmt.Errorf("failed to marshal apiTokenCreate, error: %w", err)
+	}
+
+	body, err := ctl.post(fmt.Sprintf("/principal/%d/token", principalID), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken := new(api.APIToken)
+	if err = jsonapi.UnmarshalPayload(body, apiToken); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal API token response, error: %w", err)
+	}
+	return apiToken, nil
+}
+
+// revokeAPIToken revokes the API token of the principal.
+func (ctl *controller) revokeAPIToken(principalID, tokenID int) error {
+	body, err := ctl.delete(fmt.Sprintf("/principal/%d/token/%d", principalID, tokenID), nil)
+	if err != nil {
+		return err
+	}
+	return body.Close()
+}
+
+// requestWithAPIToken sends a client request authenticated by the API token instead of the cookie, and returns the response status code.
+func (ctl *controller) requestWithAPIToken(method, shortURL, token string, body io.Reader) (int, error) {
+	url := fmt.Sprintf("%s%s", ctl.apiURL, shortURL)
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return 0, fmt.Errorf("fail to create a new %s request(%q), error: %w", method, url, err)
+	}
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	resp, err := ctl.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("fail to send a %s request(%q), error: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}